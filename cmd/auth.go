@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -11,6 +15,8 @@ import (
 
 	"github.com/presstronic/recontronic-cli-client/pkg/client"
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
 )
@@ -20,8 +26,9 @@ var authCmd = &cobra.Command{
 	Short: "Authentication and API key management",
 	Long: `Manage user authentication and API keys for the Recontronic platform.
 
-Commands include user registration, login, viewing current user info,
-and creating, listing, and revoking API keys.`,
+Commands include user registration, login and logout, viewing current user
+info, switching between accounts, and creating, listing, and revoking API
+keys.`,
 }
 
 var authRegisterCmd = &cobra.Command{
@@ -40,10 +47,57 @@ var authLoginCmd = &cobra.Command{
 	Long: `Authenticate with the Recontronic platform and receive an API key.
 
 The API key will be saved to your configuration file (~/.recon-cli/config.yaml)
-and used automatically for all subsequent commands.`,
+and used automatically for all subsequent commands.
+
+With --sso the login goes through your organization's identity provider
+instead of a password: the CLI prints a URL and a code to enter there, in a
+browser on any device, and waits until the login is approved. Use it where
+password login is disabled in favor of SSO.
+
+With --profile the account is saved under a name, e.g., work, personal, or
+a self-hosted instance, and becomes the active one; 'recon-cli auth switch'
+changes between saved accounts without logging in again.
+
+Examples:
+  recon-cli auth login
+  recon-cli auth login --sso
+  recon-cli auth login --profile work --sso
+  recon-cli auth login --profile selfhosted --server https://recon.internal.example.com --grpc-server recon.internal.example.com:9090`,
+	Args: cobra.NoArgs,
 	RunE: runAuthLogin,
 }
 
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the API key of the active account",
+	Long: `Remove the API key of the active account from the configuration, and
+from its profile. The profile keeps its server settings, so
+'recon-cli auth login' logs back in to the same instance.
+
+The key stays valid on the server; revoke it with 'recon-cli auth keys
+revoke' if it may have leaked.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogout,
+}
+
+var authSwitchCmd = &cobra.Command{
+	Use:   "switch [profile]",
+	Short: "Switch to another saved account",
+	Long: `Make a saved account (profile) the active one: its server, gRPC
+server, and API key are used by every following command. Without a
+profile, the saved profiles are listed.
+
+Accounts are saved with 'recon-cli auth login --profile <name>'. An
+account logged in without a profile can be named with --create.
+
+Examples:
+  recon-cli auth switch
+  recon-cli auth switch personal
+  recon-cli auth switch work --create`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAuthSwitch,
+}
+
 var authWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Display current authenticated user information",
@@ -68,7 +122,16 @@ var authKeysCreateCmd = &cobra.Command{
 	Short: "Create a new API key",
 	Long: `Create a new API key for your account.
 
-You can optionally specify a name and expiration date for the key.`,
+You can optionally specify a name and expiration date for the key.
+
+With --scopes the key can only do what the scopes grant, e.g., a CI key
+that reads results and starts scans. A scope is <access>:<resource> with
+access read or write (write includes read). Without --scopes the key has
+full access to your account.
+
+Examples:
+  recon-cli auth keys create --name laptop
+  recon-cli auth keys create --name ci --expires-in 90d --scopes read:results,write:scans`,
 	RunE: runAuthKeysCreate,
 }
 
@@ -77,7 +140,13 @@ var authKeysListCmd = &cobra.Command{
 	Short: "List all API keys",
 	Long: `List all API keys associated with your account.
 
-Shows key ID, name, prefix, expiration, last used time, and status.`,
+Shows key ID, name, prefix, expiration, last used time, and status.
+Every page of keys is fetched unless --page or --limit is given.
+
+Examples:
+  recon-cli auth keys list
+  recon-cli auth keys list --limit 10
+  recon-cli auth keys list --page 2`,
 	RunE: runAuthKeysList,
 }
 
@@ -92,14 +161,24 @@ The key will be immediately deactivated and cannot be used for authentication.`,
 }
 
 var (
-	keyName      string
-	keyExpiresIn string
-	forceRevoke  bool
+	keyName         string
+	keyExpiresIn    string
+	keyScopes       []string
+	forceRevoke     bool
+	keysLimit       int
+	keysPage        int
+	loginProfile    string
+	loginServer     string
+	loginGRPCServer string
+	loginSSO        bool
+	switchCreate    bool
 )
 
 func init() {
 	authCmd.AddCommand(authRegisterCmd)
 	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authSwitchCmd)
 	authCmd.AddCommand(authWhoamiCmd)
 	authCmd.AddCommand(authKeysCmd)
 
@@ -107,8 +186,19 @@ func init() {
 	authKeysCmd.AddCommand(authKeysListCmd)
 	authKeysCmd.AddCommand(authKeysRevokeCmd)
 
+	authLoginCmd.Flags().BoolVar(&loginSSO, "sso", false, "Log in through your organization's identity provider (OAuth2 device flow)")
+	authLoginCmd.Flags().StringVar(&loginProfile, "profile", "", "Save the account under this profile name and make it active")
+	authLoginCmd.Flags().StringVar(&loginServer, "server", "", "Server URL to log in to (default: the profile's, or the configured server)")
+	authLoginCmd.Flags().StringVar(&loginGRPCServer, "grpc-server", "", "gRPC server address of the account (default: the profile's, or the configured one)")
+
+	authSwitchCmd.Flags().BoolVar(&switchCreate, "create", false, "Save the active account as a new profile with this name")
+
 	authKeysCreateCmd.Flags().StringVarP(&keyName, "name", "n", "", "Name for the API key")
 	authKeysCreateCmd.Flags().StringVar(&keyExpiresIn, "expires-in", "", "Expiration duration (e.g., 90d, 1y)")
+	authKeysCreateCmd.Flags().StringSliceVar(&keyScopes, "scopes", nil, "Limit the key to these scopes (e.g., read:results,write:scans)")
+
+	authKeysListCmd.Flags().IntVar(&keysLimit, "limit", 0, "Show at most this many keys (0 = all)")
+	authKeysListCmd.Flags().IntVar(&keysPage, "page", 0, fmt.Sprintf("Show only this page of %d keys", client.DefaultPerPage))
 
 	authKeysRevokeCmd.Flags().BoolVarP(&forceRevoke, "force", "f", false, "Skip confirmation prompt")
 }
@@ -145,9 +235,9 @@ func runAuthRegister(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid password: %w", err)
 	}
 
-	restClient := client.NewRestClient(cfg.Server, "", cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
+	restClient, err := newRestClient("")
+	if err != nil {
+		return err
 	}
 
 	user, err := restClient.Register(ctx, username, email, password)
@@ -170,32 +260,65 @@ func runAuthRegister(cmd *cobra.Command, args []string) error {
 func runAuthLogin(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	fmt.Println("Login to Recontronic")
-
-	username, err := ui.ReadInput("Username: ")
-	if err != nil {
-		return fmt.Errorf("failed to read username: %w", err)
+	// The account logs in to its profile's server unless told otherwise
+	server, grpcServer := cfg.Server, ""
+	if loginProfile != "" {
+		if err := config.ValidateProfileName(loginProfile); err != nil {
+			return err
+		}
+		if profile, ok := cfg.Profiles[loginProfile]; ok && loginProfile != cfg.Profile {
+			server, grpcServer = profile.Server, profile.GRPCServer
+		}
+	}
+	if loginServer != "" {
+		server = loginServer
 	}
+	if loginGRPCServer != "" {
+		grpcServer = loginGRPCServer
+	}
+	cfg.Server = server
+
+	fmt.Printf("Login to Recontronic (%s)\n", server)
 
-	password, err := ui.ReadPassword("Password: ")
+	restClient, err := newRestClient("")
 	if err != nil {
-		return fmt.Errorf("failed to read password: %w", err)
+		return err
 	}
 
-	restClient := client.NewRestClient(cfg.Server, "", cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
-	}
+	var loginResp *models.LoginResponse
+	if loginSSO {
+		loginResp, err = ssoLogin(ctx, restClient)
+		if err != nil {
+			return err
+		}
+	} else {
+		username, err := ui.ReadInput("Username: ")
+		if err != nil {
+			return fmt.Errorf("failed to read username: %w", err)
+		}
 
-	loginResp, err := restClient.Login(ctx, username, password)
-	if err != nil {
-		if client.IsAuthError(err) {
-			return fmt.Errorf("login failed: invalid username or password")
+		password, err := ui.ReadPassword("Password: ")
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+
+		loginResp, err = restClient.Login(ctx, username, password)
+		if err != nil {
+			if client.IsAuthError(err) {
+				return fmt.Errorf("login failed: invalid username or password")
+			}
+			return fmt.Errorf("login failed: %w", err)
 		}
-		return fmt.Errorf("login failed: %w", err)
 	}
 
-	if err := config.SaveAPIKey(loginResp.APIKey); err != nil {
+	err = config.SaveLogin(config.Login{
+		Profile:    loginProfile,
+		Server:     server,
+		GRPCServer: grpcServer,
+		APIKey:     loginResp.APIKey,
+		Username:   loginResp.User.Username,
+	})
+	if err != nil {
 		fmt.Println("\n✓ Login successful!")
 		fmt.Printf("\nYour API key: %s\n", loginResp.APIKey)
 		fmt.Println("\n⚠️  WARNING: Failed to save API key to config file")
@@ -212,11 +335,159 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 	fmt.Println("\n⚠️  IMPORTANT: Save this key securely!")
 	fmt.Printf("   It has been saved to: %s\n", configPath)
 	fmt.Println("   This key will not be shown again.")
+	if loginProfile != "" {
+		fmt.Printf("\nSaved as profile '%s', now the active account.\n", loginProfile)
+	}
 	fmt.Println("\nYou're now authenticated and ready to use the CLI.")
 
 	return nil
 }
 
+// ssoLogin logs in through the organization's identity provider with the
+// OAuth2 device flow: the user approves the login in a browser, on any
+// device, while the CLI waits
+func ssoLogin(ctx context.Context, restClient *client.RestClient) (*models.LoginResponse, error) {
+	code, err := restClient.StartDeviceLogin(ctx)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return nil, fmt.Errorf("the server does not support SSO login")
+		}
+		return nil, err
+	}
+
+	fmt.Println("\nTo sign in with your organization's SSO, open:")
+	fmt.Printf("\n  %s\n", code.VerificationURI)
+	fmt.Printf("\nand enter the code:\n\n  %s\n", code.UserCode)
+	if code.VerificationURIComplete != "" {
+		fmt.Printf("\nOr open this link, with the code filled in:\n\n  %s\n", code.VerificationURIComplete)
+	}
+	fmt.Println("\nWaiting for the login to be approved (Ctrl+C to cancel)...")
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	loginResp, err := restClient.WaitDeviceLogin(ctx, code)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, fmt.Errorf("login cancelled")
+		}
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+	return loginResp, nil
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	if cfg.APIKey == "" && !cfg.APIKeyLocked() {
+		fmt.Println("Not logged in")
+		return nil
+	}
+
+	if err := config.Logout(); err != nil {
+		return fmt.Errorf("failed to log out: %w", err)
+	}
+
+	if cfg.Profile != "" {
+		fmt.Printf("✓ Logged out of profile '%s' (%s)\n", cfg.Profile, cfg.Server)
+	} else {
+		fmt.Printf("✓ Logged out of %s\n", cfg.Server)
+	}
+	fmt.Println("The API key stays valid on the server until revoked ('recon-cli auth keys revoke').")
+	return nil
+}
+
+func runAuthSwitch(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		if switchCreate {
+			return fmt.Errorf("--create needs the name of the new profile")
+		}
+		return listProfiles()
+	}
+	name := args[0]
+
+	if switchCreate {
+		if err := config.NameProfile(name); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Saved the active account as profile '%s'\n", name)
+		return nil
+	}
+
+	return switchProfile(name)
+}
+
+// switchProfile makes the named profile the active account
+func switchProfile(name string) error {
+	if name == cfg.Profile {
+		fmt.Printf("Profile '%s' is already active\n", name)
+		return nil
+	}
+	switched, err := config.SwitchProfile(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Switched to profile '%s' (%s)\n", name, switched.Server)
+	if switched.APIKey == "" && !switched.APIKeyLocked() {
+		fmt.Printf("Not logged in on this profile; run 'recon-cli auth login --profile %s'\n", name)
+	}
+	return nil
+}
+
+// listProfiles prints the saved accounts, marking the active one
+func listProfiles() error {
+	names := cfg.ProfileNames()
+	if structuredOutput() {
+		return renderOutput(profileSummaries(names))
+	}
+	if len(names) == 0 {
+		fmt.Println("No saved profiles")
+		fmt.Println("\nSave an account with 'recon-cli auth login --profile <name>', name the")
+		fmt.Println("active one with 'recon-cli auth switch <name> --create', or add an")
+		fmt.Println("environment with 'recon-cli config profile create <name> --server <url>'.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tPROFILE\tSERVER\tUSER\tSTATUS")
+	for _, profile := range profileSummaries(names) {
+		marker, status := "", "logged in"
+		if profile.Active {
+			marker = "*"
+		}
+		if !profile.LoggedIn {
+			status = "logged out"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, profile.Name, profile.Server, valueOrDash(profile.Username), status)
+	}
+	return w.Flush()
+}
+
+// profileSummary is a saved account as 'auth switch' lists it
+type profileSummary struct {
+	Name     string `json:"name"`
+	Server   string `json:"server"`
+	Username string `json:"username,omitempty"`
+	Active   bool   `json:"active"`
+	LoggedIn bool   `json:"logged_in"`
+}
+
+// profileSummaries describes the named profiles, with the active one's
+// current credentials
+func profileSummaries(names []string) []profileSummary {
+	summaries := make([]profileSummary, 0, len(names))
+	for _, name := range names {
+		profile := cfg.Profiles[name]
+		summary := profileSummary{Name: name, Server: profile.Server, Username: profile.Username, LoggedIn: profile.APIKey != ""}
+		if name == cfg.Profile {
+			// The active credentials may have changed since they were saved
+			summary.Active = true
+			summary.Server = cfg.Server
+			summary.LoggedIn = cfg.APIKey != "" || cfg.APIKeyLocked()
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
 func runAuthWhoami(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
@@ -224,9 +495,9 @@ func runAuthWhoami(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not authenticated: please run 'recon-cli auth login' first")
 	}
 
-	restClient := client.NewRestClient(cfg.Server, cfg.APIKey, cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
+	restClient, err := newRestClient(cfg.APIKey)
+	if err != nil {
+		return err
 	}
 
 	user, err := restClient.GetCurrentUser(ctx)
@@ -242,6 +513,19 @@ func runAuthWhoami(cmd *cobra.Command, args []string) error {
 		keyPrefix = cfg.APIKey[:8] + "..."
 	}
 
+	if structuredOutput() {
+		return renderOutput(struct {
+			Profile string `json:"profile,omitempty"`
+			Server  string `json:"server"`
+			*models.User
+			APIKeyPrefix string `json:"api_key_prefix"`
+		}{cfg.Profile, cfg.Server, user, keyPrefix})
+	}
+
+	if cfg.Profile != "" {
+		fmt.Printf("Profile:      %s\n", cfg.Profile)
+	}
+	fmt.Printf("Server:       %s\n", cfg.Server)
 	fmt.Printf("Username:     %s\n", user.Username)
 	fmt.Printf("Email:        %s\n", user.Email)
 	fmt.Printf("Account ID:   %d\n", user.ID)
@@ -269,19 +553,34 @@ func runAuthKeysCreate(cmd *cobra.Command, args []string) error {
 		expiresAt = &expiry
 	}
 
-	restClient := client.NewRestClient(cfg.Server, cfg.APIKey, cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
+	scopes, err := parseScopes(keyScopes)
+	if err != nil {
+		return err
+	}
+
+	restClient, err := newRestClient(cfg.APIKey)
+	if err != nil {
+		return err
 	}
 
-	apiKey, err := restClient.CreateAPIKey(ctx, keyName, expiresAt)
+	apiKey, err := restClient.CreateAPIKey(ctx, keyName, expiresAt, scopes)
 	if err != nil {
 		if client.IsAuthError(err) {
 			return fmt.Errorf("authentication failed: please run 'recon-cli auth login' first")
 		}
+		if client.IsValidationError(err) && len(scopes) > 0 {
+			return fmt.Errorf("failed to create API key (check the scopes): %w", err)
+		}
 		return fmt.Errorf("failed to create API key: %w", err)
 	}
 
+	if structuredOutput() {
+		if len(scopes) > 0 && len(apiKey.Scopes) == 0 {
+			fmt.Fprintln(os.Stderr, "Warning: the server did not confirm the scopes; the key may have full access")
+		}
+		return renderOutput(apiKey)
+	}
+
 	fmt.Println("✓ New API key created!")
 	fmt.Printf("\nAPI Key: %s\n", apiKey.PlainKey)
 	if apiKey.Name != "" {
@@ -293,6 +592,10 @@ func runAuthKeysCreate(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Println("Expires: Never")
 	}
+	fmt.Printf("Scopes:  %s\n", formatScopes(apiKey.Scopes))
+	if len(scopes) > 0 && len(apiKey.Scopes) == 0 {
+		fmt.Println("\nWarning: the server did not confirm the scopes; the key may have full access")
+	}
 	fmt.Println("\n⚠️  Save this key! It won't be shown again.")
 
 	return nil
@@ -305,12 +608,15 @@ func runAuthKeysList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not authenticated: please run 'recon-cli auth login' first")
 	}
 
-	restClient := client.NewRestClient(cfg.Server, cfg.APIKey, cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
+	restClient, err := newRestClient(cfg.APIKey)
+	if err != nil {
+		return err
 	}
 
-	response, err := restClient.ListAPIKeys(ctx)
+	if keysLimit < 0 || keysPage < 0 {
+		return fmt.Errorf("--limit and --page must not be negative")
+	}
+	response, err := restClient.ListAPIKeys(ctx, client.ListOptions{Page: keysPage, Limit: keysLimit})
 	if err != nil {
 		if client.IsAuthError(err) {
 			return fmt.Errorf("authentication failed: please run 'recon-cli auth login' first")
@@ -318,14 +624,20 @@ func runAuthKeysList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list API keys: %w", err)
 	}
 
+	if structuredOutput() {
+		if response.APIKeys == nil {
+			response.APIKeys = []models.APIKey{}
+		}
+		return renderOutput(response)
+	}
 	if len(response.APIKeys) == 0 {
 		fmt.Println("No API keys found.")
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tLAST USED\tEXPIRES\tSTATUS")
-	fmt.Fprintln(w, "──\t────\t──────\t─────────\t───────\t──────")
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tLAST USED\tEXPIRES\tSTATUS")
+	fmt.Fprintln(w, "──\t────\t──────\t──────\t─────────\t───────\t──────")
 
 	for _, key := range response.APIKeys {
 		name := key.Name
@@ -345,12 +657,16 @@ func runAuthKeysList(cmd *cobra.Command, args []string) error {
 
 		status := formatStatus(key.IsActive)
 
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
-			key.ID, name, key.KeyPrefix, lastUsed, expires, status)
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, name, key.KeyPrefix, formatScopes(key.Scopes), lastUsed, expires, status)
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d API key(s)\n", response.Total)
+	if len(response.APIKeys) < response.Total {
+		fmt.Printf("\nShowing %d of %d API key(s)\n", len(response.APIKeys), response.Total)
+	} else {
+		fmt.Printf("\nTotal: %d API key(s)\n", response.Total)
+	}
 
 	return nil
 }
@@ -378,9 +694,9 @@ func runAuthKeysRevoke(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	restClient := client.NewRestClient(cfg.Server, cfg.APIKey, cfg.Timeout)
-	if debug {
-		restClient.SetDebug(true)
+	restClient, err := newRestClient(cfg.APIKey)
+	if err != nil {
+		return err
 	}
 
 	err = restClient.RevokeAPIKey(ctx, keyID)
@@ -399,6 +715,37 @@ func runAuthKeysRevoke(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// scopePattern matches API key scopes: <access>:<resource>
+var scopePattern = regexp.MustCompile(`^(read|write):[a-z][a-z_]*$`)
+
+// parseScopes validates and normalizes the --scopes values, dropping
+// duplicates
+func parseScopes(values []string) ([]string, error) {
+	var scopes []string
+	for _, value := range values {
+		scope := strings.ToLower(strings.TrimSpace(value))
+		if scope == "" {
+			continue
+		}
+		if !scopePattern.MatchString(scope) {
+			return nil, fmt.Errorf("invalid scope %q (expected read:<resource> or write:<resource>, e.g., read:results)", value)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// formatScopes formats the scopes of an API key; a key without scopes has
+// full access
+func formatScopes(scopes []string) string {
+	if len(scopes) == 0 {
+		return "all"
+	}
+	return strings.Join(scopes, ",")
+}
+
 func formatStatus(isActive bool) string {
 	if isActive {
 		return "Active"
@@ -491,3 +838,44 @@ func parseDuration(s string) (time.Duration, error) {
 		return time.ParseDuration(s)
 	}
 }
+
+// newRestClient returns a client for the configured server, with the
+// configured retries, proxy, connection, and TLS settings, logging at the
+// configured level, and tracing to --trace-file
+func newRestClient(apiKey string) (*client.RestClient, error) {
+	restClient := client.NewRestClient(cfg.Server, apiKey, cfg.Timeout)
+	policy := client.DefaultRetryPolicy()
+	policy.MaxRetries = cfg.MaxRetries
+	restClient.SetRetryPolicy(policy)
+	proxyURL, err := recon.ParseProxy(cfg.API.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api.proxy: %w", err)
+	}
+	err = restClient.SetTransport(client.TransportOptions{
+		Proxy:           proxyURL,
+		MaxIdleConns:    cfg.API.MaxIdleConns,
+		IdleConnTimeout: cfg.API.IdleTimeout,
+		KeepAlive:       cfg.API.KeepAlive,
+		TLS:             serverTLSOptions(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+	restClient.SetLogger(apiLogger())
+	trace, err := apiTracer()
+	if err != nil {
+		return nil, err
+	}
+	restClient.SetTracer(trace)
+	return restClient, nil
+}
+
+// serverTLSOptions returns the configured TLS settings for the server
+func serverTLSOptions() client.TLSOptions {
+	return client.TLSOptions{
+		CAFile:             cfg.TLS.CAFile,
+		ClientCert:         cfg.TLS.ClientCert,
+		ClientKey:          cfg.TLS.ClientKey,
+		InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+	}
+}
@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+// Thresholds of 'config doctor' warnings
+const (
+	doctorTimeout        = 5 * time.Second
+	doctorKeyExpiryAlert = 14 * 24 * time.Hour
+	doctorResultsAlert   = 1 << 30 // Results directory size, in bytes
+)
+
+// doctorTools are the external tools recon commands use when installed,
+// with what for; the first is needed for 'recon subdomain'
+var doctorTools = []struct {
+	name    string
+	purpose string
+}{
+	{"subfinder", "recon subdomain"},
+	{"amass", "recon subdomain"},
+	{"assetfinder", "recon subdomain"},
+	{"nuclei", "recon nuclei"},
+	{"ffuf", "recon dirs"},
+	{"gobuster", "recon dirs"},
+	{"gau", "recon urls"},
+	{"whois", "recon whois fallback"},
+	{"wkhtmltopdf", "PDF reports"},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file for mistakes",
+	Long: `Check the configuration file: that it parses, that every key is known
+(typos are ignored silently otherwise), that values are valid as 'config set'
+would check them, and that settings don't conflict, e.g., a client
+certificate without its key.
+
+Errors are settings the CLI can't use; warnings are settings that work,
+perhaps not as intended. The command fails when there are errors.
+
+Examples:
+  recon-cli config validate
+  recon-cli config validate --config ./ci-config.yaml`,
+	Args: cobra.NoArgs,
+	// The file is read by the command, which reports why it doesn't load
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE:              runConfigValidate,
+}
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Troubleshoot the CLI setup",
+	Long: `Check everything the CLI depends on and suggest fixes: the config
+directory's permissions, the configuration file, the server's reachability,
+the API key and its expiry, the vault, secrets in the current git
+repository, the installed recon tools, and the size of the stored results.
+
+The command fails when a check does; warnings don't fail it.
+
+Examples:
+  recon-cli config doctor`,
+	Args:              cobra.NoArgs,
+	PersistentPreRunE: loadDoctorConfig,
+	RunE:              runConfigDoctor,
+}
+
+// doctorLoadErr is why the configuration didn't load for 'config doctor',
+// which then checks with the defaults
+var doctorLoadErr error
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configDoctorCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	issues, err := config.Validate(cfgFile)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("✓ Configuration is valid")
+		return nil
+	}
+
+	errorCount := printConfigIssues(issues)
+	if errorCount > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("configuration has %d error(s)", errorCount)
+	}
+	return nil
+}
+
+// printConfigIssues prints the issues Validate found, returning the
+// number of errors
+func printConfigIssues(issues []config.Issue) int {
+	errorCount := 0
+	for _, issue := range issues {
+		icon := "!"
+		if !issue.Warning {
+			icon = "✗"
+			errorCount++
+		}
+		key := issue.Key
+		if key == "" {
+			key = "config file"
+		}
+		fmt.Printf("%s %s: %s\n", icon, key, issue.Message)
+		if issue.Fix != "" {
+			fmt.Printf("    Fix: %s\n", issue.Fix)
+		}
+	}
+
+	fmt.Printf("\n%d error(s), %d warning(s)\n", errorCount, len(issues)-errorCount)
+	return errorCount
+}
+
+// doctorCheck is the outcome of one 'config doctor' check
+type doctorCheck struct {
+	name   string
+	status string // ok, warn, fail, or skip
+	detail string
+	fixes  []string
+}
+
+// loadDoctorConfig loads the configuration like the root command, falling
+// back to the defaults so a broken file can be diagnosed
+func loadDoctorConfig(cmd *cobra.Command, args []string) error {
+	config.SetProfileOverride(profileName)
+	applySettingFlags(cmd.Root())
+	cfg, doctorLoadErr = config.Load(cfgFile)
+	if doctorLoadErr != nil {
+		cfg = config.DefaultConfig()
+		return nil
+	}
+	if err := applyOutputFlag(); err != nil {
+		return err
+	}
+	return applyStorageConfig(cfg)
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	if doctorLoadErr != nil {
+		fmt.Printf("Warning: the configuration doesn't load, checking with the defaults: %v\n\n", doctorLoadErr)
+	}
+
+	server := checkDoctorServer()
+	checks := []doctorCheck{
+		checkDoctorConfigDir(),
+		checkDoctorConfigFile(),
+		server,
+		checkDoctorAPIKey(server.status == "ok"),
+		checkDoctorVault(),
+		checkDoctorSecrets(),
+		checkDoctorTools(),
+		checkDoctorResults(),
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	failed := 0
+	for _, check := range checks {
+		icon := "✓"
+		switch check.status {
+		case "warn":
+			icon = "!"
+		case "fail":
+			icon = "✗"
+			failed++
+		case "skip":
+			icon = "-"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\n", icon, check.name, check.detail)
+	}
+	w.Flush()
+
+	var fixes []string
+	for _, check := range checks {
+		for _, fix := range check.fixes {
+			fixes = append(fixes, fmt.Sprintf("%s: %s", check.name, fix))
+		}
+	}
+	if len(fixes) > 0 {
+		fmt.Println("\nSuggested fixes:")
+		for _, fix := range fixes {
+			fmt.Printf("  - %s\n", fix)
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	if len(fixes) == 0 {
+		fmt.Println("\n✓ Everything looks good")
+	}
+	return nil
+}
+
+// checkDoctorConfigDir checks that the config directory and files are
+// private, since they hold the API key
+func checkDoctorConfigDir() doctorCheck {
+	check := doctorCheck{name: "Config directory"}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		check.status, check.detail = "skip", fmt.Sprintf("%s not created yet", dir)
+		check.fixes = []string{"create it with 'recon-cli config init'"}
+		return check
+	}
+
+	check.status, check.detail = "ok", fmt.Sprintf("%s (%04o)", dir, info.Mode().Perm())
+	insecure, err := config.CheckPermissions(cfgFile)
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	if len(insecure) > 0 {
+		check.status = "fail"
+		for _, path := range insecure {
+			check.detail += fmt.Sprintf(", %s is %04o", filepath.Base(path.Path), path.Mode)
+		}
+		check.fixes = []string{"make them private with 'recon-cli config fix-permissions'"}
+	}
+	return check
+}
+
+// checkDoctorSecrets checks that no secrets are in the git repository of
+// the working directory
+func checkDoctorSecrets() doctorCheck {
+	check := doctorCheck{name: "Secrets"}
+	root, exposures, err := scanRepository()
+	switch {
+	case err != nil:
+		check.status, check.detail = "skip", err.Error()
+	case root == "":
+		check.status, check.detail = "ok", "not in a git repository"
+	case len(exposures) == 0:
+		check.status, check.detail = "ok", fmt.Sprintf("none found in %s", root)
+	default:
+		check.status = "fail"
+		var found []string
+		for _, exposure := range exposures {
+			found = append(found, fmt.Sprintf("%s in %s", exposure.Secret, exposure.File))
+		}
+		check.detail = strings.Join(found, ", ")
+		check.fixes = []string{"remove them and rotate the secrets, since commits keep them; for an API key, 'recon-cli auth keys create' a new one and 'recon-cli auth keys revoke' the old one"}
+	}
+	return check
+}
+
+// checkDoctorConfigFile validates the configuration file
+func checkDoctorConfigFile() doctorCheck {
+	check := doctorCheck{name: "Config file"}
+	issues, err := config.Validate(cfgFile)
+	if err != nil {
+		check.status, check.detail = "skip", err.Error()
+		return check
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if !issue.Warning {
+			errorCount++
+		}
+	}
+	switch {
+	case errorCount > 0:
+		check.status = "fail"
+	case len(issues) > 0:
+		check.status = "warn"
+	default:
+		check.status, check.detail = "ok", "valid"
+		return check
+	}
+	check.detail = fmt.Sprintf("%d error(s), %d warning(s)", errorCount, len(issues)-errorCount)
+	check.fixes = []string{"run 'recon-cli config validate' for the details and fixes"}
+	return check
+}
+
+// checkDoctorServer checks that the server's REST API answers
+func checkDoctorServer() doctorCheck {
+	check := doctorCheck{name: "Server"}
+	restClient, err := newRestClient("")
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	restClient.SetRetryPolicy(client.RetryPolicy{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	start := time.Now()
+	health, err := restClient.Health(ctx)
+	if err != nil {
+		check.status, check.detail = "fail", fmt.Sprintf("%s unreachable: %v", cfg.Server, err)
+		check.fixes = []string{"check the server URL ('recon-cli config set server <url>') and your network; 'recon server status' details the REST and gRPC endpoints"}
+		return check
+	}
+
+	check.status = "ok"
+	check.detail = fmt.Sprintf("%s (%s, %s)", cfg.Server, valueOrDash(health.Status), formatLatency(time.Since(start)))
+	if health.Status != "ok" {
+		check.status = "warn"
+		check.fixes = []string{"the server reports problems; 'recon server status' shows its features and version"}
+	}
+	return check
+}
+
+// checkDoctorAPIKey checks that the API key is valid and not about to expire
+func checkDoctorAPIKey(serverUp bool) doctorCheck {
+	check := doctorCheck{name: "API key"}
+	switch {
+	case cfg.APIKeyLocked():
+		check.status, check.detail = "skip", "encrypted by the locked vault"
+		check.fixes = []string{"unlock it with 'recon vault unlock'"}
+		return check
+	case cfg.APIKey == "":
+		check.status, check.detail = "warn", "not logged in"
+		check.fixes = []string{"log in with 'recon-cli auth login'"}
+		return check
+	case !serverUp:
+		check.status, check.detail = "skip", "not checked, the server is unreachable"
+		return check
+	}
+
+	restClient, err := newRestClient(cfg.APIKey)
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	restClient.SetRetryPolicy(client.RetryPolicy{})
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	user, err := restClient.GetCurrentUser(ctx)
+	if err != nil {
+		check.status = "fail"
+		if client.IsAuthError(err) {
+			check.detail = "invalid, expired, or revoked"
+			check.fixes = []string{"log in with 'recon-cli auth login'"}
+		} else {
+			check.detail = err.Error()
+		}
+		return check
+	}
+	check.status, check.detail = "ok", fmt.Sprintf("valid (%s)", user.Username)
+
+	// A scoped key may not list keys; its expiry is then unknown
+	keys, err := restClient.ListAPIKeys(ctx, client.ListOptions{})
+	if err != nil {
+		return check
+	}
+	for _, key := range keys.APIKeys {
+		if key.KeyPrefix == "" || !strings.HasPrefix(cfg.APIKey, key.KeyPrefix) || key.ExpiresAt == nil {
+			continue
+		}
+		if remaining := time.Until(*key.ExpiresAt); remaining < doctorKeyExpiryAlert {
+			check.status = "warn"
+			check.detail += fmt.Sprintf(", expires in %d day(s)", int(remaining.Hours()/24))
+			check.fixes = []string{"create a new key with 'recon-cli auth keys create --expires-in 90d' and set it with 'recon-cli config set api-key <key>', or run 'recon-cli auth login'"}
+		}
+		break
+	}
+	return check
+}
+
+// checkDoctorVault reports whether secrets are encrypted at rest
+func checkDoctorVault() doctorCheck {
+	check := doctorCheck{name: "Vault"}
+	status, err := config.GetVaultStatus()
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	switch {
+	case !status.Enabled && cfg.APIKey != "":
+		check.status, check.detail = "warn", "not enabled; the API key and results are stored unencrypted"
+		check.fixes = []string{"encrypt them with 'recon vault lock'"}
+	case !status.Enabled:
+		check.status, check.detail = "ok", "not enabled"
+	case status.Unlocked:
+		check.status, check.detail = "ok", "enabled, unlocked"
+	default:
+		check.status, check.detail = "ok", "enabled, locked"
+	}
+	return check
+}
+
+// checkDoctorTools reports the installed recon tools
+func checkDoctorTools() doctorCheck {
+	check := doctorCheck{name: "Tools"}
+	var installed, missing []string
+	for _, tool := range doctorTools {
+		if recon.IsToolAvailable(tool.name) {
+			installed = append(installed, tool.name)
+		} else {
+			missing = append(missing, fmt.Sprintf("%s (%s)", tool.name, tool.purpose))
+		}
+	}
+
+	check.status = "ok"
+	check.detail = fmt.Sprintf("installed: %s", valueOrDash(strings.Join(installed, ", ")))
+	if len(missing) > 0 {
+		check.detail += fmt.Sprintf("; missing: %s", strings.Join(missing, ", "))
+	}
+	if !recon.IsToolAvailable(doctorTools[0].name) {
+		check.status = "warn"
+		check.fixes = []string{"install subfinder for 'recon subdomain' with 'go install github.com/projectdiscovery/subfinder/v2/cmd/subfinder@latest'"}
+	}
+	return check
+}
+
+// checkDoctorResults checks the size of the stored results
+func checkDoctorResults() doctorCheck {
+	check := doctorCheck{name: "Results"}
+	dir, err := recon.GetResultsDir()
+	if err != nil {
+		check.status, check.detail = "fail", err.Error()
+		return check
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		check.status, check.detail = "ok", "none stored yet"
+		return check
+	}
+
+	var size int64
+	domains := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			domains++
+		}
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+
+	check.status = "ok"
+	check.detail = fmt.Sprintf("%s in %d domain(s), %s", recon.FormatFileSize(size), domains, dir)
+	if size > doctorResultsAlert {
+		check.status = "warn"
+		if cfg.Retention.MaxAge == "" {
+			check.fixes = append(check.fixes, "delete old results after each scan with 'recon-cli config set retention.max-age 30d'")
+		}
+		if !cfg.Compress {
+			check.fixes = append(check.fixes, "compress stored results with 'recon results compact' and 'recon-cli config set compress-results true'")
+		}
+		if len(check.fixes) == 0 {
+			check.fixes = []string{"archive the results of finished programs with 'recon results archive <domain>'"}
+		}
+	}
+	return check
+}
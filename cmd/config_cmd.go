@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -22,15 +30,81 @@ var configSetCmd = &cobra.Command{
 Available keys:
   server         - Server URL (e.g., http://localhost:8080)
   grpc-server    - gRPC server address (e.g., localhost:9090)
+  grpc-tls       - TLS for the gRPC server (auto, on, off; auto skips TLS on loopback)
+  grpc-ca-cert   - PEM CA bundle for the gRPC server only (overrides tls.ca-file)
   api-key        - API key for authentication
   timeout        - Request timeout (e.g., 30s, 1m)
+  max-retries    - Retries of failed API requests, with backoff (0-10)
   output-format  - Output format (table, json, yaml)
-  log-level      - Log level (debug, info, warn, error)`,
+  log-level      - Log level (debug, info, warn, error)
+  proxy          - Proxy URL for recon traffic (e.g., http://127.0.0.1:8080, socks5://127.0.0.1:9050)
+  doh            - DNS-over-HTTPS endpoint for recon DNS lookups (e.g., https://cloudflare-dns.com/dns-query)
+  github-token   - GitHub token for 'recon leaks' code search
+  gitlab-token   - GitLab token for 'recon leaks' code search
+  webhook-secret - HMAC key signing 'recon results export --webhook' requests
+  wordlists.dirs - Default wordlist for 'recon dirs', a path or 'recon assets' name
+  retention.max-age   - Delete stored results older than this after each scan (e.g., 30d)
+  retention.keep-last - Always keep this many of the newest results per tool (e.g., 5)
+  api.proxy           - Proxy for API traffic to the server (default: HTTPS_PROXY); 'proxy' covers recon traffic
+  api.max-idle-conns  - Idle API connections kept open (e.g., 10)
+  api.idle-timeout    - Close idle API connections after this long (e.g., 90s)
+  api.keepalive       - TCP keep-alive interval of API connections (e.g., 30s)
+  tls.ca-file         - PEM CA bundle to verify the server with (REST and gRPC)
+  tls.client-cert     - PEM client certificate for mutual-TLS gateways
+  tls.client-key      - PEM key of the client certificate
+  tls.insecure-skip-verify - Skip server certificate verification (true, false; testing only)
+  compress-results    - Save recon results gzip-compressed as .json.gz (true, false)
+  auto-sync           - Push subdomain and DNS results to the server after each scan (true, false)
+  notify.slack        - Slack incoming webhook URL for 'recon notify' and --notify
+  notify.discord      - Discord webhook URL for 'recon notify' and --notify
+  defectdojo.url        - DefectDojo URL for 'recon results push'
+  defectdojo.api-key    - DefectDojo API v2 key
+  defectdojo.engagement - Default DefectDojo engagement ID
+  faraday.url           - Faraday URL for 'recon results push'
+  faraday.token         - Faraday API token
+  faraday.workspace     - Default Faraday workspace
+
+Recon defaults per target, applied by 'recon verify', 'recon dirs', and
+'recon nuclei' unless the flag is given. Domain settings cover subdomains;
+program settings cover the program's domains and are overridden by domain
+ones. An empty value removes a setting.
+  domains.<domain>.verify.concurrency - Parallel probes (e.g., 3)
+  domains.<domain>.verify.timeout     - Timeout per probe in seconds
+  domains.<domain>.verify.host-delay  - Delay between requests to the same host (e.g., 500ms)
+  domains.<domain>.dirs.threads       - Concurrent requests per host
+  domains.<domain>.rate-limit         - Maximum request rate (e.g., 5/s)
+  domains.<domain>.headers            - Add a header sent with probes ('Name: value'; 'Name:' removes it)
+  domains.<domain>.exclude            - Out-of-scope hosts (e.g., logout.example.com,*.corp.example.com)
+  programs.<name>.domains             - Domains of a program (e.g., example.com,example.net)
+  programs.<name>.<setting>           - Any of the domain settings above, for the program's domains
+
+Flag defaults, applied to the flags not given on the command line (per-target
+settings win over them). The command path leaves out recon-cli and recon; an
+empty value removes a default.
+  defaults.<command>.<flag>           - e.g., defaults.verify.concurrency 20, defaults.results.export.format csv
+
+Every key above but the per-target ones can be overridden for a run, without
+changing the file, by a RECON_ environment variable named after it (dots and
+dashes as underscores, e.g., RECON_TLS_CA_FILE), or by a global --set- flag
+(e.g., --set-tls-ca-file). Secrets and tokens have no flag, to keep them out
+of shell history. Flags win over environment variables, which win over the file.
+
+Examples:
+  recon-cli config set timeout 1m
+  recon-cli config set domains.example.com.verify.concurrency 3
+  recon-cli config set domains.example.com.headers "X-Bug-Bounty: researcher"
+  recon-cli config set programs.acme.domains acme.com,acme.io
+  recon-cli config set defaults.dns.types A,AAAA,CNAME`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := args[1]
 
+		if strings.HasPrefix(key, "defaults.") && value != "" {
+			if err := checkFlagDefault(key, value); err != nil {
+				return err
+			}
+		}
 		if err := config.Set(key, value); err != nil {
 			return err
 		}
@@ -59,10 +133,8 @@ var configGetCmd = &cobra.Command{
 		}
 
 		// Mask sensitive values
-		if (key == "api-key" || key == "api_key") && value != "" {
-			if len(value) > 12 {
-				value = value[:8] + "..." + value[len(value)-4:]
-			}
+		if config.IsSecretKey(key) {
+			value = maskSecret(value)
 		}
 
 		fmt.Printf("%s: %s\n", key, value)
@@ -73,7 +145,14 @@ var configGetCmd = &cobra.Command{
 var configListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all configuration values",
-	Long:  `Display all current configuration settings.`,
+	Long: `Display all current configuration settings, including those overridden
+for this run by flags or RECON_ environment variables.
+
+Settings that aren't secrets can be overridden with a global flag named
+--set- followed by the key, with dots and underscores as dashes, e.g.,
+--set-server, --set-timeout 1m, or --set-retention-max-age 30d. These
+flags are left out of each command's help. Secrets are environment-only to
+keep them out of shell history.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load("")
 		if err != nil {
@@ -83,24 +162,103 @@ var configListCmd = &cobra.Command{
 		fmt.Println("Configuration:")
 		fmt.Printf("  server:         %s\n", cfg.Server)
 		fmt.Printf("  grpc-server:    %s\n", cfg.GRPCServer)
+		fmt.Printf("  grpc-tls:       %s\n", cfg.GRPCTLS)
+		if cfg.GRPCCACert != "" {
+			fmt.Printf("  grpc-ca-cert:   %s\n", cfg.GRPCCACert)
+		}
 
-		// Mask API key
-		apiKey := cfg.APIKey
-		if apiKey != "" && len(apiKey) > 12 {
-			apiKey = apiKey[:8] + "..." + apiKey[len(apiKey)-4:]
-		} else if apiKey == "" {
-			apiKey = "(not set)"
+		fmt.Printf("  api-key:        %s\n", formatSecret(cfg.APIKey))
+		if cfg.Profile != "" {
+			fmt.Printf("  profile:        %s (of %d; 'recon-cli auth switch' lists them)\n", cfg.Profile, len(cfg.Profiles))
 		}
-		fmt.Printf("  api-key:        %s\n", apiKey)
 
 		fmt.Printf("  timeout:        %s\n", cfg.Timeout)
+		fmt.Printf("  max-retries:    %d\n", cfg.MaxRetries)
 		fmt.Printf("  output-format:  %s\n", cfg.OutputFormat)
 		fmt.Printf("  log-level:      %s\n", cfg.LogLevel)
 
+		proxy := cfg.Proxy
+		if proxy == "" {
+			proxy = "(not set)"
+		}
+		fmt.Printf("  proxy:          %s\n", proxy)
+
+		doh := cfg.DoH
+		if doh == "" {
+			doh = "(not set)"
+		}
+		fmt.Printf("  doh:            %s\n", doh)
+		fmt.Printf("  github-token:   %s\n", formatSecret(cfg.GitHubToken))
+		fmt.Printf("  gitlab-token:   %s\n", formatSecret(cfg.GitLabToken))
+		fmt.Printf("  webhook-secret: %s\n", formatSecret(cfg.WebhookSecret))
+		for _, name := range sortedKeys(cfg.Wordlists) {
+			fmt.Printf("  %-15s %s\n", "wordlists."+name+":", cfg.Wordlists[name])
+		}
+		if cfg.Retention.MaxAge != "" {
+			fmt.Printf("  retention.max-age:   %s\n", cfg.Retention.MaxAge)
+		}
+		if cfg.Retention.KeepLast > 0 {
+			fmt.Printf("  retention.keep-last: %d\n", cfg.Retention.KeepLast)
+		}
+		if cfg.API.Proxy != "" {
+			fmt.Printf("  api.proxy:           %s\n", cfg.API.Proxy)
+		}
+		if cfg.API.MaxIdleConns > 0 {
+			fmt.Printf("  api.max-idle-conns:  %d\n", cfg.API.MaxIdleConns)
+		}
+		if cfg.API.IdleTimeout > 0 {
+			fmt.Printf("  api.idle-timeout:    %s\n", cfg.API.IdleTimeout)
+		}
+		if cfg.API.KeepAlive > 0 {
+			fmt.Printf("  api.keepalive:       %s\n", cfg.API.KeepAlive)
+		}
+		if cfg.TLS.CAFile != "" {
+			fmt.Printf("  tls.ca-file:         %s\n", cfg.TLS.CAFile)
+		}
+		if cfg.TLS.ClientCert != "" {
+			fmt.Printf("  tls.client-cert:     %s\n", cfg.TLS.ClientCert)
+			fmt.Printf("  tls.client-key:      %s\n", valueOrDash(cfg.TLS.ClientKey))
+		}
+		if cfg.TLS.InsecureSkipVerify {
+			fmt.Printf("  tls.insecure-skip-verify: true\n")
+		}
+		fmt.Printf("  compress-results:    %t\n", cfg.Compress)
+		fmt.Printf("  auto-sync:           %t\n", cfg.AutoSync)
+		if cfg.Notify.Slack != "" {
+			fmt.Printf("  notify.slack:        %s\n", formatSecret(cfg.Notify.Slack))
+		}
+		if cfg.Notify.Discord != "" {
+			fmt.Printf("  notify.discord:      %s\n", formatSecret(cfg.Notify.Discord))
+		}
+		if cfg.DefectDojo.URL != "" {
+			fmt.Printf("  defectdojo.url:        %s\n", cfg.DefectDojo.URL)
+			fmt.Printf("  defectdojo.api-key:    %s\n", formatSecret(cfg.DefectDojo.APIKey))
+			fmt.Printf("  defectdojo.engagement: %s\n", valueOrDash(cfg.DefectDojo.Engagement))
+		}
+		if cfg.Faraday.URL != "" {
+			fmt.Printf("  faraday.url:           %s\n", cfg.Faraday.URL)
+			fmt.Printf("  faraday.token:         %s\n", formatSecret(cfg.Faraday.Token))
+			fmt.Printf("  faraday.workspace:     %s\n", valueOrDash(cfg.Faraday.Workspace))
+		}
+		for _, target := range cfg.Targets {
+			printTargetConfig(target)
+		}
+		defaults := cfg.FlagDefaults()
+		for _, key := range sortedKeys(defaults) {
+			fmt.Printf("  defaults.%s: %s\n", key, defaults[key])
+		}
+
 		// Show config file location
 		configPath, _ := config.GetConfigPath()
 		fmt.Printf("\nConfig file: %s\n", configPath)
 
+		if overrides := cfg.Overrides(); len(overrides) > 0 {
+			fmt.Println("Overridden for this run:")
+			for _, key := range sortedKeys(overrides) {
+				fmt.Printf("  %s (%s)\n", key, overrides[key])
+			}
+		}
+
 		return nil
 	},
 }
@@ -156,7 +314,206 @@ func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configEditCmd)
 
 	// Flags for init command
 	configInitCmd.Flags().Bool("force", false, "overwrite existing configuration")
 }
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Remove a configuration value, e.g., to clear the API key or a token,
+resetting it to its default. 'config set --help' lists the keys.
+
+A per-target key removes that setting; domains.<domain> or programs.<name>
+removes all settings of the target.
+
+Examples:
+  recon-cli config unset api-key
+  recon-cli config unset retention.max-age
+  recon-cli config unset domains.example.com.rate-limit
+  recon-cli config unset programs.acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if err := config.Unset(key); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Configuration updated: %s unset\n", key)
+		if value, err := config.Get(key); err == nil && value != "" && value != "0" && value != "false" && value != "0s" {
+			fmt.Printf("  Default: %s\n", value)
+		}
+		return nil
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the configuration file in your editor",
+	Long: `Open the configuration file in $VISUAL or $EDITOR (default: vi).
+
+The changes are checked like 'config validate' does before they replace the
+file. When they have errors, you can edit again or discard them; warnings
+are shown but accepted.
+
+Examples:
+  recon-cli config edit
+  EDITOR="code --wait" recon-cli config edit`,
+	Args: cobra.NoArgs,
+	// The file is checked by the command, so a broken one can be fixed
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE:              runConfigEdit,
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	path := cfgFile
+	if path == "" {
+		var err error
+		if path, err = config.GetConfigPath(); err != nil {
+			return err
+		}
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no config file at %s; create one with 'recon-cli config init'", path)
+		}
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// Edit a private copy next to the file, replacing it once it's valid
+	temp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(temp.Name())
+	_, err = temp.Write(original)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	for {
+		if err := runEditor(temp.Name()); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(temp.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read edited config: %w", err)
+		}
+		if bytes.Equal(edited, original) {
+			fmt.Println("No changes")
+			return nil
+		}
+
+		issues, err := config.Validate(temp.Name())
+		if err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			break
+		}
+		errorCount := printConfigIssues(issues)
+		if errorCount == 0 {
+			break
+		}
+		again, err := ui.Confirm("Edit again?")
+		if err != nil {
+			return err
+		}
+		if !again {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("changes discarded: the configuration has %d error(s)", errorCount)
+		}
+	}
+
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := config.SecureConfigFile(path); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Configuration saved: %s\n", path)
+	return nil
+}
+
+// runEditor opens a file in $VISUAL, $EDITOR, or vi, which may be given
+// with arguments (e.g., "code --wait")
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+
+	editorCmd := exec.Command(fields[0], append(fields[1:], path)...)
+	editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", fields[0], err)
+	}
+	return nil
+}
+
+// maskSecret shows only the start and end of long secrets
+func maskSecret(value string) string {
+	if len(value) > 12 {
+		return value[:8] + "..." + value[len(value)-4:]
+	}
+	return value
+}
+
+// formatSecret masks a secret for display, noting when it is unset
+func formatSecret(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return maskSecret(value)
+}
+
+// sortedKeys returns the keys of m in alphabetical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// printTargetConfig prints the recon defaults of a domain or program as
+// config keys
+func printTargetConfig(target config.TargetConfig) {
+	name := target.Name()
+	if len(target.Domains) > 0 {
+		fmt.Printf("  %s.domains: %s\n", name, strings.Join(target.Domains, ","))
+	}
+	if target.Verify.Concurrency > 0 {
+		fmt.Printf("  %s.verify.concurrency: %d\n", name, target.Verify.Concurrency)
+	}
+	if target.Verify.Timeout > 0 {
+		fmt.Printf("  %s.verify.timeout: %d\n", name, target.Verify.Timeout)
+	}
+	if target.Verify.HostDelay > 0 {
+		fmt.Printf("  %s.verify.host-delay: %s\n", name, target.Verify.HostDelay)
+	}
+	if target.Dirs.Threads > 0 {
+		fmt.Printf("  %s.dirs.threads: %d\n", name, target.Dirs.Threads)
+	}
+	if target.RateLimit != "" {
+		fmt.Printf("  %s.rate-limit: %s\n", name, target.RateLimit)
+	}
+	for _, header := range target.Headers {
+		fmt.Printf("  %s.headers: %s\n", name, header)
+	}
+	if len(target.Exclude) > 0 {
+		fmt.Printf("  %s.exclude: %s\n", name, strings.Join(target.Exclude, ","))
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage configuration profiles for multiple environments",
+	Long: `Manage configuration profiles: named sets of server, gRPC server, TLS
+settings, output format, and API key, e.g., for a local dev server and
+production, all stored in the config file.
+
+Switch the active profile with 'config profile use', or use one for a
+single command with the global --profile flag. Profiles are the same as
+the accounts of 'recon-cli auth login --profile' and 'recon-cli auth switch'.
+
+Available subcommands:
+  create - Create a profile for another environment
+  use    - Make a profile the active one
+  list   - List the profiles`,
+}
+
+var configProfileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a profile for another environment",
+	Long: `Create a profile for another environment. Settings not given are copied
+from the active profile, without its API key; log in to the new profile
+with 'recon-cli auth login --profile <name>'.
+
+When the active settings aren't a profile yet, they are saved as profile
+'default' first, so they can be switched back to.
+
+Examples:
+  recon-cli config profile create dev --server http://localhost:8080 --grpc-server localhost:9090
+  recon-cli config profile create staging --server https://staging.recon.example.com --use
+  recon-cli --profile staging recon server status`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigProfileCreate,
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the active one",
+	Long: `Make a profile the active one for all following commands.
+
+Examples:
+  recon-cli config profile use dev`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return switchProfile(args[0])
+	},
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles",
+	Long: `List the profiles, marking the active one with '*'.
+
+Examples:
+  recon-cli config profile list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listProfiles()
+	},
+}
+
+var (
+	profileServer       string
+	profileGRPCServer   string
+	profileGRPCTLS      string
+	profileOutputFormat string
+	profileUse          bool
+)
+
+func init() {
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileCreateCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+
+	configProfileCreateCmd.Flags().StringVar(&profileServer, "server", "", "Server URL of the environment")
+	configProfileCreateCmd.Flags().StringVar(&profileGRPCServer, "grpc-server", "", "gRPC server address of the environment")
+	configProfileCreateCmd.Flags().StringVar(&profileGRPCTLS, "grpc-tls", "", "TLS for the gRPC server (auto, on, off)")
+	configProfileCreateCmd.Flags().StringVar(&profileOutputFormat, "output-format", "", "Default output format (table, json, yaml)")
+	configProfileCreateCmd.Flags().BoolVar(&profileUse, "use", false, "Make the new profile the active one")
+}
+
+func runConfigProfileCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	saved, err := config.CreateProfile(name, config.Profile{
+		Server:       profileServer,
+		GRPCServer:   profileGRPCServer,
+		GRPCTLS:      profileGRPCTLS,
+		OutputFormat: profileOutputFormat,
+	})
+	if err != nil {
+		return err
+	}
+
+	if saved != "" {
+		fmt.Printf("✓ Saved the active settings as profile '%s'\n", saved)
+		cfg.Profile = saved
+	}
+	fmt.Printf("✓ Created profile '%s'\n", name)
+
+	if profileUse {
+		return switchProfile(name)
+	}
+	fmt.Printf("\nUse it with 'recon-cli config profile use %s', or for one command with --profile %s\n", name, name)
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// repositoryScanTimeout bounds the scan of the current git repository for
+// secrets, so it never holds up a command for long
+const repositoryScanTimeout = 2 * time.Second
+
+// securityChecked is set once the config's security was checked, so the
+// warnings show once per process, e.g., in interactive mode
+var securityChecked bool
+
+var configFixPermissionsCmd = &cobra.Command{
+	Use:   "fix-permissions",
+	Short: "Make the configuration files private",
+	Long: `Restrict the config directory to its owner (0700) and the files in it,
+the config file, backups, and vault files, to owner read/write (0600), since
+they hold your API key and tokens.
+
+Examples:
+  recon-cli config fix-permissions`,
+	Args: cobra.NoArgs,
+	RunE: runConfigFixPermissions,
+}
+
+func init() {
+	configCmd.AddCommand(configFixPermissionsCmd)
+}
+
+func runConfigFixPermissions(cmd *cobra.Command, args []string) error {
+	fixed, err := config.FixPermissions(cfgFile)
+	for _, path := range fixed {
+		fmt.Printf("✓ %s: %04o -> %04o\n", path.Path, path.Mode, path.Want)
+	}
+	if err != nil {
+		return err
+	}
+	if len(fixed) == 0 {
+		fmt.Println("✓ Configuration files are already private")
+	}
+	return nil
+}
+
+// warnConfigSecurity warns when the configuration files are accessible by
+// other users or its secrets are in files of the current git repository
+func warnConfigSecurity(cmd *cobra.Command) {
+	if securityChecked || cmd == configFixPermissionsCmd {
+		return
+	}
+	securityChecked = true
+
+	insecure, _ := config.CheckPermissions(cfgFile)
+	for _, path := range insecure {
+		fmt.Fprintf(os.Stderr, "Warning: %s is accessible by other users (%04o) and holds your secrets\n", path.Path, path.Mode)
+	}
+	if len(insecure) > 0 {
+		fmt.Fprintln(os.Stderr, "  Fix: recon-cli config fix-permissions")
+	}
+
+	root, exposures, _ := scanRepository()
+	if len(exposures) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: secrets found in the git repository %s:\n", root)
+	for _, exposure := range exposures {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", exposure.File, exposure.Secret)
+	}
+	fmt.Fprintln(os.Stderr, "  Fix: remove them and rotate the secrets, since commits keep them; for an API key, 'recon-cli auth keys create' a new one and 'recon-cli auth keys revoke' the old one")
+}
+
+// scanRepository scans the git repository of the working directory for
+// the configured secrets and Recontronic API keys
+func scanRepository() (string, []config.Exposure, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), repositoryScanTimeout)
+	defer cancel()
+	return cfg.ScanRepository(ctx, dir)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the configuration file to stdout",
+	Long: `Write the configuration file to stdout as YAML, e.g., to share
+team-standard settings such as per-target rate limits and exclusions.
+
+--redact-secrets leaves out the API key, tokens, webhook URLs, and the API
+keys of profiles, so the file can be shared; importing it keeps the
+importer's own secrets.
+
+Examples:
+  recon-cli config export --redact-secrets > config.shared.yaml
+  recon-cli config export > config.backup.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import settings from a configuration file",
+	Long: `Import the settings of a configuration file, e.g., one shared with
+'config export --redact-secrets'. The file is checked like 'config validate'
+does first, and is not imported when it has errors.
+
+Without --merge, the file replaces the configuration, keeping the secrets
+it doesn't set. With --merge, its settings are added to the current ones;
+per-target settings replace those of the same domain or program. The
+previous file is kept as config.yaml.bak.
+
+Examples:
+  recon-cli config import config.shared.yaml --merge
+  recon-cli config import config.backup.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigImport,
+}
+
+var (
+	exportRedactSecrets bool
+	importMerge         bool
+)
+
+func init() {
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+
+	configExportCmd.Flags().BoolVar(&exportRedactSecrets, "redact-secrets", false, "Leave out API keys, tokens, and webhook URLs")
+	configImportCmd.Flags().BoolVar(&importMerge, "merge", false, "Add the settings to the current ones instead of replacing them")
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	if err := config.Export(cfgFile, os.Stdout, exportRedactSecrets); err != nil {
+		return err
+	}
+	if !exportRedactSecrets {
+		fmt.Fprintln(os.Stderr, "Warning: the export includes secrets; use --redact-secrets to share it")
+	}
+	return nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	issues, err := config.Validate(path)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		if errorCount := printConfigIssues(issues); errorCount > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("not imported: %s has %d error(s)", path, errorCount)
+		}
+		fmt.Println()
+	}
+
+	if secrets, err := config.FileSecrets(path); err == nil && len(secrets) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s contains secrets (%s); delete it or keep it private, and share exports made with --redact-secrets\n\n",
+			path, strings.Join(secrets, ", "))
+	}
+
+	changed, err := config.Import(cfgFile, path, importMerge)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		fmt.Printf("✓ Imported %s: no settings changed\n", path)
+		return nil
+	}
+
+	fmt.Printf("✓ Imported %s: %d setting(s) changed\n", path, len(changed))
+	for _, key := range changed {
+		fmt.Printf("  %s\n", key)
+	}
+	configPath := cfgFile
+	if configPath == "" {
+		configPath, _ = config.GetConfigPath()
+	}
+	if _, err := os.Stat(configPath + ".bak"); err == nil {
+		fmt.Printf("\nPrevious configuration: %s.bak\n", configPath)
+	}
+	return nil
+}
@@ -21,7 +21,7 @@ func init() {
 }
 
 func runDashboard(cmd *cobra.Command, args []string) error {
-	if err := ui.DisplayDashboard(cfg); err != nil {
+	if err := ui.DisplayDashboard(cfg, probeServer); err != nil {
 		return fmt.Errorf("failed to display dashboard: %w", err)
 	}
 	return nil
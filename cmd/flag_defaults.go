@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// applyFlagDefaults gives the flags of a command not set on the command
+// line the defaults configured for them, e.g., defaults.verify.concurrency.
+// The flags aren't marked as changed, so per-target settings still win.
+func applyFlagDefaults(cmd *cobra.Command) error {
+	prefix := flagDefaultsCommand(cmd) + "."
+	for key, value := range cfg.FlagDefaults() {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok || strings.Contains(name, ".") {
+			continue
+		}
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring defaults.%s: '%s' has no --%s flag\n", key, cmd.CommandPath(), name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid defaults.%s in config: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// flagDefaultsCommand returns the command path flag defaults are kept
+// under: without recon-cli and recon, joined by dots (e.g., results.export)
+func flagDefaultsCommand(cmd *cobra.Command) string {
+	path := strings.Fields(cmd.CommandPath())[1:]
+	if len(path) > 0 && path[0] == "recon" {
+		path = path[1:]
+	}
+	return strings.Join(path, ".")
+}
+
+// checkFlagDefault checks that a defaults.<command>.<flag> key names a flag
+// of a command and that the value suits it, for 'config set'
+func checkFlagDefault(key, value string) error {
+	parts := strings.Split(strings.ToLower(strings.TrimPrefix(key, "defaults.")), ".")
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid key %s (expected defaults.<command>.<flag>, e.g., defaults.verify.concurrency)", key)
+	}
+	commandPath, name := parts[:len(parts)-1], parts[len(parts)-1]
+	if commandPath[0] == "recon" && len(commandPath) > 1 {
+		return fmt.Errorf("leave out recon: defaults.%s", strings.Join(parts[1:], "."))
+	}
+
+	command := findSubcommand(rootCmd, commandPath)
+	if command == nil {
+		command = findSubcommand(reconCmd, commandPath)
+	}
+	if command == nil {
+		return fmt.Errorf("unknown command in %s: %s", key, strings.Join(commandPath, " "))
+	}
+	if rootCmd.PersistentFlags().Lookup(name) != nil {
+		return fmt.Errorf("--%s is a global flag; set its config key instead (see 'recon-cli config set --help')", name)
+	}
+	flag := command.Flags().Lookup(name)
+	if flag == nil {
+		flag = command.InheritedFlags().Lookup(name)
+	}
+	if flag == nil {
+		return fmt.Errorf("'%s' has no --%s flag", command.CommandPath(), name)
+	}
+	if err := checkFlagValue(flag, value); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return nil
+}
+
+// findSubcommand returns the subcommand of parent at a path of names, or nil
+func findSubcommand(parent *cobra.Command, path []string) *cobra.Command {
+	command := parent
+	for _, name := range path {
+		var next *cobra.Command
+		for _, child := range command.Commands() {
+			if child.Name() == name || child.HasAlias(name) {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		command = next
+	}
+	return command
+}
+
+// checkFlagValue parses a value as a flag of the same type would
+func checkFlagValue(flag *pflag.Flag, value string) error {
+	check := pflag.NewFlagSet("defaults", pflag.ContinueOnError)
+	switch flag.Value.Type() {
+	case "bool":
+		check.Bool(flag.Name, false, "")
+	case "int":
+		check.Int(flag.Name, 0, "")
+	case "int32":
+		check.Int32(flag.Name, 0, "")
+	case "float64":
+		check.Float64(flag.Name, 0, "")
+	case "duration":
+		check.Duration(flag.Name, 0, "")
+	case "intSlice":
+		check.IntSlice(flag.Name, nil, "")
+	case "stringToInt":
+		check.StringToInt(flag.Name, nil, "")
+	default:
+		return nil
+	}
+	return check.Set(flag.Name, value)
+}
@@ -15,7 +15,7 @@ import (
 // startInteractiveMode starts the interactive REPL session
 func startInteractiveMode() error {
 	// Display dashboard on startup
-	if err := ui.DisplayDashboard(cfg); err != nil {
+	if err := ui.DisplayDashboard(cfg, probeServer); err != nil {
 		// Fallback to simple welcome message if dashboard fails
 		fmt.Println("Recontronic CLI - Interactive Mode")
 		fmt.Println("Type 'help' for available commands, 'exit' or 'quit' to leave")
@@ -67,7 +67,7 @@ func startInteractiveMode() error {
 
 		// Handle dashboard refresh
 		if line == "dash" || line == "dashboard" || line == "refresh" {
-			if err := ui.DisplayDashboard(cfg); err != nil {
+			if err := ui.DisplayDashboard(cfg, probeServer); err != nil {
 				fmt.Fprintf(os.Stderr, "Error displaying dashboard: %v\n", err)
 			}
 			continue
@@ -120,37 +120,51 @@ The CLI provides tools for:
 - Bug bounty program management
 - Reconnaissance scan control and monitoring
 - Security anomaly tracking and review
-- Real-time dashboards and statistics`,
+- Real-time dashboards and statistics
+- Sharing programs and findings with your team`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load configuration
 			var err error
+			config.SetProfileOverride(profileName)
+			applySettingFlags(cmd.Root())
 			cfg, err = config.Load(cfgFile)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
 			// Override with command-line flags if provided
-			if output != "" {
-				cfg.OutputFormat = output
+			if err := applyOutputFlag(); err != nil {
+				return err
 			}
 			if debug {
 				cfg.LogLevel = "debug"
 			}
 
-			return nil
+			warnConfigSecurity(cmd)
+			if err := applyFlagDefaults(cmd); err != nil {
+				return err
+			}
+
+			return applyStorageConfig(cfg)
 		},
 	}
 
 	// Add persistent flags
 	cmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.recon-cli/config.yaml)")
 	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
+	cmd.PersistentFlags().StringVar(&profileName, "profile", "", "use this profile for the command, without switching to it")
+	cmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "append transcripts of API requests and responses (secrets redacted) to this file")
 	cmd.PersistentFlags().StringVarP(&output, "output", "o", "", "output format (table|json|yaml)")
+	addSettingFlags(cmd.PersistentFlags())
 
 	// Add all subcommands
 	cmd.AddCommand(authCmd)
 	cmd.AddCommand(versionCmd)
 	cmd.AddCommand(configCmd)
 	cmd.AddCommand(reconCmd)
+	cmd.AddCommand(scanCmd)
+	cmd.AddCommand(teamCmd)
+	cmd.AddCommand(programCmd)
 	cmd.AddCommand(dashboardCmd)
 
 	return cmd
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+)
+
+// traceFile is the --trace-file path API transcripts are appended to
+var traceFile string
+
+var (
+	tracerOnce sync.Once
+	tracer     *client.Tracer
+	tracerErr  error
+)
+
+// apiLogger returns the logger of API clients: text records on stderr at
+// the configured log level (debug with --debug)
+func apiLogger() *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		level = slog.LevelInfo
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
+// apiTracer returns the tracer writing to --trace-file, opened on first
+// use; nil without the flag
+func apiTracer() (*client.Tracer, error) {
+	if traceFile == "" {
+		return nil, nil
+	}
+	tracerOnce.Do(func() {
+		file, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			tracerErr = fmt.Errorf("failed to open trace file: %w", err)
+			return
+		}
+		tracer = client.NewTracer(file)
+	})
+	return tracer, tracerErr
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+)
+
+// stdout is the process's standard output, where JSON and YAML output goes
+// while statusToStderr points os.Stdout at stderr
+var stdout = os.Stdout
+
+// applyOutputFlag checks --output and gives it precedence over the
+// output_format setting
+func applyOutputFlag() error {
+	if output == "" {
+		return nil
+	}
+	if !ui.ValidOutputFormat(output) {
+		return fmt.Errorf("invalid --output: %s (must be: table, json, or yaml)", output)
+	}
+	cfg.OutputFormat = output
+	return nil
+}
+
+// structuredOutput reports whether --output or the output_format setting
+// asks for JSON or YAML
+func structuredOutput() bool {
+	return cfg != nil && ui.IsStructured(cfg.OutputFormat)
+}
+
+// statusToStderr points os.Stdout at stderr for JSON and YAML output, so
+// the progress and status messages of a command don't mix with the data
+// it renders to stdout. The returned function restores os.Stdout.
+func statusToStderr() func() {
+	if !structuredOutput() {
+		return func() {}
+	}
+	os.Stdout = os.Stderr
+	return func() { os.Stdout = stdout }
+}
+
+// renderOutput writes data to stdout as JSON or YAML, following --output
+func renderOutput(data interface{}) error {
+	return ui.Render(stdout, cfg.OutputFormat, data)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// programPermissions are the access levels a program can be shared with
+var programPermissions = []string{"read", "write"}
+
+var programCmd = &cobra.Command{
+	Use:   "program",
+	Short: "Manage your bug bounty programs on the Recontronic server",
+	Long: `Manage the bug bounty programs the Recontronic server runs continuous
+reconnaissance for.
+
+Available subcommands:
+  share - Share a program and its findings with a team member`,
+}
+
+var programShareCmd = &cobra.Command{
+	Use:   "share <program>",
+	Short: "Share a program and its findings with a team member",
+	Long: `Share a program (by name or ID), with its scan results and anomalies,
+with a member of your team ('recon-cli team list').
+
+Permissions:
+  read  - See the program and its findings (default)
+  write - Also change its scope and schedule, and review anomalies
+
+Sharing again with another --permission changes it.
+
+Examples:
+  recon-cli program share acme --with alice
+  recon-cli program share acme --with bob@example.com --permission write`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProgramShare,
+}
+
+var (
+	programShareWith       string
+	programSharePermission string
+)
+
+func init() {
+	rootCmd.AddCommand(programCmd)
+	programCmd.AddCommand(programShareCmd)
+
+	programShareCmd.Flags().StringVar(&programShareWith, "with", "", "Username or email of the team member to share with")
+	programShareCmd.Flags().StringVar(&programSharePermission, "permission", "read", "Access to grant: read, write")
+	programShareCmd.MarkFlagRequired("with")
+}
+
+func runProgramShare(cmd *cobra.Command, args []string) error {
+	program := args[0]
+	user := strings.TrimSpace(programShareWith)
+	if user == "" {
+		return fmt.Errorf("--with is required")
+	}
+	permission := strings.ToLower(programSharePermission)
+	if !containsFold(programPermissions, permission) {
+		return fmt.Errorf("invalid --permission %q (%s)", programSharePermission, strings.Join(programPermissions, ", "))
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	req := models.ProgramShareRequest{User: user, Permission: permission}
+	share, err := restClient.ShareProgram(context.Background(), program, req)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("program %s or user %s not found", program, user)
+		}
+		if client.IsValidationError(err) {
+			return fmt.Errorf("cannot share %s with %s (are they on your team? 'recon-cli team list'): %w", program, user, err)
+		}
+		return err
+	}
+
+	name := share.ProgramName
+	if name == "" {
+		name = program
+	}
+	username := share.Username
+	if username == "" {
+		username = user
+	}
+	fmt.Printf("✓ Shared %s with %s (%s)\n", name, username, valueOrDash(share.Permission))
+	return nil
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/notify"
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
@@ -18,7 +22,26 @@ Available subcommands:
   subdomain - Find subdomains using multiple sources
   verify    - Verify which subdomains are alive
   dns       - Enumerate DNS records
-  whois     - Lookup WHOIS information`,
+  whois     - Lookup WHOIS information
+  urls      - Collect historical URLs
+  params    - Build parameter wordlists from collected URLs
+  dirs      - Brute-force directories and files on web hosts
+  nuclei    - Run nuclei templates against alive hosts
+  leaks     - Search GitHub and GitLab for leaked secrets
+  reverseip - Find co-hosted domains on the target's IPs
+  import    - Import subdomains from external tool output
+  fingerprints - Manage subdomain takeover fingerprints
+  assets    - Download curated wordlists and fingerprint sets
+  results   - Manage stored results
+  vault     - Encrypt stored results and the API key at rest
+  notify    - Configure Slack and Discord notifications
+  sync      - Sync local results to the Recontronic server
+  events    - Show the platform's event feed (--follow for live)
+  server    - Check the server's endpoints and the API key
+  diff      - Compare two subdomain scans
+  analyze   - Analyze stored results
+  inventory - Summarize assets across every domain
+  report    - Generate a full report combining every module`,
 }
 
 var reconSubdomainCmd = &cobra.Command{
@@ -32,21 +55,39 @@ Available sources:
   - assetfinder (if installed - future)
   - crt.sh (built-in - future)
 
-The tool will automatically detect which sources are available and use them all.`,
+The tool will automatically detect which sources are available and use them all.
+
+Traffic to API-based sources can be routed through a proxy with --proxy or
+the 'proxy' config setting.
+
+With --notify, the new subdomains since the previous scan are posted to the
+channels set up with 'recon notify'.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runReconSubdomain,
 }
 
 var (
-	subdomainSources []string
+	subdomainSources   []string
+	subdomainNotify    bool
+	reconProxy         string
+	reconResolvers     []string
+	reconResolversFile string
+	reconDoH           string
 )
 
 func init() {
 	rootCmd.AddCommand(reconCmd)
 	reconCmd.AddCommand(reconSubdomainCmd)
 
+	// Flags shared by all recon commands
+	reconCmd.PersistentFlags().StringSliceVar(&reconResolvers, "resolvers", []string{}, "DNS resolvers to rotate through (e.g., 1.1.1.1,8.8.8.8)")
+	reconCmd.PersistentFlags().StringVar(&reconResolversFile, "resolvers-file", "", "File with one DNS resolver per line")
+	reconCmd.PersistentFlags().StringVar(&reconDoH, "doh", "", "Resolve DNS over HTTPS via this endpoint (e.g., https://cloudflare-dns.com/dns-query)")
+	reconCmd.PersistentFlags().StringVar(&reconProxy, "proxy", "", "Proxy URL for recon traffic (e.g., http://127.0.0.1:8080, socks5://127.0.0.1:9050)")
+
 	// Flags for subdomain command
 	reconSubdomainCmd.Flags().StringSliceVar(&subdomainSources, "sources", []string{}, "Specific sources to use (comma-separated)")
+	reconSubdomainCmd.Flags().BoolVar(&subdomainNotify, "notify", false, "Post new subdomains to the channels set up with 'recon notify'")
 }
 
 func runReconSubdomain(cmd *cobra.Command, args []string) error {
@@ -57,20 +98,29 @@ func runReconSubdomain(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid domain: %w", err)
 	}
 
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	defer statusToStderr()()
 	fmt.Printf("Finding subdomains for %s\n", domain)
 	fmt.Println("Mode: Passive reconnaissance (safe, no active scanning)")
+	if proxyURL != nil {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
 
 	// Detect available sources (in order of speed/reliability)
 	var sources []recon.SubdomainSource
 
 	// crt.sh - always available (API-based)
-	crtshSource := &recon.CrtShSource{}
+	crtshSource := &recon.CrtShSource{Proxy: proxyURL}
 	if crtshSource.IsAvailable() {
 		sources = append(sources, crtshSource)
 	}
 
 	// subfinder - fast and comprehensive
-	subfinderSource := &recon.SubfinderSource{}
+	subfinderSource := &recon.SubfinderSource{Proxy: proxyURL}
 	if subfinderSource.IsAvailable() {
 		sources = append(sources, subfinderSource)
 	}
@@ -115,6 +165,9 @@ func runReconSubdomain(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\nTotal unique: %d subdomains\n", results.TotalUnique)
 	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
 
+	// The previous scan, to report new subdomains with --notify
+	previous, _ := recon.GetLatestSubdomainResult(domain)
+
 	// Save results
 	filePath, err := recon.SaveResults(domain, "subdomains", results, recon.FormatJSON)
 	if err != nil {
@@ -157,7 +210,96 @@ func runReconSubdomain(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Warning: failed to log activity: %v\n", err)
 	}
 
+	if subdomainNotify {
+		summary := notify.Summary{
+			Domain:        domain,
+			Event:         "Subdomain enumeration",
+			Note:          fmt.Sprintf("%d subdomains", results.TotalUnique),
+			TakeoverRisks: notifyTakeoverRisks(domain),
+		}
+		if previous != nil {
+			summary.NewHosts = recon.DiffSubdomainResults(previous, results).New
+		} else {
+			for _, sub := range results.Subdomains {
+				summary.NewHosts = append(summary.NewHosts, sub.Name)
+			}
+		}
+		fmt.Println()
+		sendNotification(summary)
+	}
+
+	if structuredOutput() {
+		return renderOutput(results)
+	}
 	fmt.Println("\nNext: Run 'recon verify", domain, "' to check which subdomains are alive")
 
 	return nil
 }
+
+// resolveReconProxy returns the proxy from --proxy, falling back to the
+// configured proxy setting
+func resolveReconProxy() (*url.URL, error) {
+	proxy := reconProxy
+	if proxy == "" && cfg != nil {
+		proxy = cfg.Proxy
+	}
+	return recon.ParseProxy(proxy)
+}
+
+// reconTargetSettings resolves the configured defaults of a domain or host
+// (the domains.* and programs.* config keys), noting where they come from
+func reconTargetSettings(host string) config.TargetConfig {
+	if cfg == nil {
+		return config.TargetConfig{}
+	}
+	settings, applied := cfg.TargetSettings(host)
+	if len(applied) > 0 {
+		fmt.Printf("Target settings: %s\n", strings.Join(applied, ", "))
+	}
+	return settings
+}
+
+// resolveReconResolvers builds a resolver pool from --doh (or the 'doh'
+// config setting), --resolvers, and --resolvers-file. It returns nil when
+// none is set so the system resolver is used.
+func resolveReconResolvers() (*recon.ResolverPool, error) {
+	if reconDoH != "" && (len(reconResolvers) > 0 || reconResolversFile != "") {
+		return nil, fmt.Errorf("--doh cannot be combined with --resolvers or --resolvers-file")
+	}
+
+	doh := reconDoH
+	if doh == "" && len(reconResolvers) == 0 && reconResolversFile == "" && cfg != nil {
+		doh = cfg.DoH
+	}
+	if doh != "" {
+		proxyURL, err := resolveReconProxy()
+		if err != nil {
+			return nil, err
+		}
+		return recon.NewDoHResolverPool(doh, proxyURL)
+	}
+
+	servers := append([]string{}, reconResolvers...)
+	if reconResolversFile != "" {
+		fileServers, err := recon.LoadResolversFile(reconResolversFile)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, fileServers...)
+	}
+
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	return recon.NewResolverPool(servers)
+}
+
+// printResolverStats reports resolvers that failed during a run
+func printResolverStats(pool *recon.ResolverPool) {
+	for _, stats := range pool.Stats() {
+		if stats.Failures > 0 {
+			fmt.Printf("Warning: resolver %s failed %d/%d queries\n", stats.Server, stats.Failures, stats.Queries)
+		}
+	}
+}
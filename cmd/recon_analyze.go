@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconAnalyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze stored reconnaissance results",
+	Long: `Analyze stored reconnaissance results to help prioritize targets.
+
+Available subcommands:
+  cluster - Group alive hosts serving identical or near-identical content
+  jarm    - Group HTTPS hosts by JARM TLS fingerprint`,
+}
+
+var reconAnalyzeClusterCmd = &cobra.Command{
+	Use:   "cluster <domain>",
+	Short: "Group alive hosts by response content",
+	Long: `Group alive hosts that serve identical or near-identical content.
+
+Hosts are grouped by a hash of their normalized response body (hostnames,
+digits, case, and whitespace removed), so 200 copies of the same default
+page collapse into one cluster and unique applications stand out.
+
+Requires verification data from 'recon verify <domain>'.
+
+Examples:
+  recon analyze cluster example.com
+  recon analyze cluster example.com --min-size 5
+  recon analyze cluster example.com --unique`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconAnalyzeCluster,
+}
+
+var reconAnalyzeJARMCmd = &cobra.Command{
+	Use:   "jarm <domain>",
+	Short: "Group HTTPS hosts by JARM fingerprint",
+	Long: `Group HTTPS hosts by JARM TLS fingerprint.
+
+Hosts sharing a JARM fingerprint run the same TLS stack and configuration,
+which often reveals shared infrastructure behind different hostnames.
+Fingerprints matching known C2 frameworks or products are flagged.
+
+Requires verification data from 'recon verify <domain> --jarm'.
+
+Examples:
+  recon analyze jarm example.com
+  recon analyze jarm example.com --min-size 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconAnalyzeJARM,
+}
+
+var (
+	clusterMinSize int
+	clusterUnique  bool
+	clusterSample  int
+)
+
+func init() {
+	reconCmd.AddCommand(reconAnalyzeCmd)
+	reconAnalyzeCmd.AddCommand(reconAnalyzeClusterCmd)
+
+	reconAnalyzeClusterCmd.Flags().IntVar(&clusterMinSize, "min-size", 1, "Only show clusters with at least this many hosts")
+	reconAnalyzeClusterCmd.Flags().BoolVar(&clusterUnique, "unique", false, "Only list hosts whose content is unique")
+	reconAnalyzeClusterCmd.Flags().IntVar(&clusterSample, "sample", 5, "Number of hosts to show per cluster")
+
+	reconAnalyzeCmd.AddCommand(reconAnalyzeJARMCmd)
+	reconAnalyzeJARMCmd.Flags().IntVar(&clusterMinSize, "min-size", 1, "Only show clusters with at least this many hosts")
+	reconAnalyzeJARMCmd.Flags().IntVar(&clusterSample, "sample", 5, "Number of hosts to show per cluster")
+}
+
+func runReconAnalyzeCluster(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	result, err := recon.GetLatestSubdomainResult(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load results for %s: %w", domain, err)
+	}
+
+	clusters := recon.ClusterByContent(result.Subdomains)
+	if len(clusters) == 0 {
+		fmt.Printf("No alive hosts found for %s\n", domain)
+		fmt.Printf("\nNext: Run 'recon verify %s' to check which subdomains are alive\n", domain)
+		return nil
+	}
+
+	aliveCount := 0
+	for _, cluster := range clusters {
+		aliveCount += len(cluster.Subdomains)
+	}
+
+	fmt.Printf("Content clusters for %s\n", domain)
+	fmt.Printf("%d alive hosts in %d distinct clusters\n\n", aliveCount, len(clusters))
+
+	if clusterUnique {
+		fmt.Println("Hosts with unique content:")
+		for _, cluster := range clusters {
+			if len(cluster.Subdomains) == 1 {
+				fmt.Printf("  %s%s\n", cluster.Subdomains[0].Name, formatClusterLabel(cluster))
+			}
+		}
+		return nil
+	}
+
+	shown := 0
+	for i, cluster := range clusters {
+		if len(cluster.Subdomains) < clusterMinSize {
+			continue
+		}
+		shown++
+
+		match := "near-identical"
+		if cluster.Identical {
+			match = "identical"
+		}
+		if len(cluster.Subdomains) == 1 {
+			match = "unique"
+		}
+
+		fmt.Printf("Cluster %d: %d host(s), %s%s\n", i+1, len(cluster.Subdomains), match, formatClusterLabel(cluster))
+		for j, sub := range cluster.Subdomains {
+			if clusterSample > 0 && j >= clusterSample {
+				fmt.Printf("    ... and %d more\n", len(cluster.Subdomains)-clusterSample)
+				break
+			}
+			fmt.Printf("    %s\n", sub.Name)
+		}
+		fmt.Println()
+	}
+
+	if shown == 0 {
+		fmt.Printf("No clusters with at least %d hosts\n", clusterMinSize)
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "cluster",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d hosts in %d clusters", aliveCount, len(clusters)),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
+
+func runReconAnalyzeJARM(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	result, err := recon.GetLatestSubdomainResult(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load results for %s: %w", domain, err)
+	}
+
+	clusters := recon.ClusterByJARM(result.Subdomains)
+	if len(clusters) == 0 {
+		fmt.Printf("No JARM fingerprints found for %s\n", domain)
+		fmt.Printf("\nNext: Run 'recon verify %s --jarm' to fingerprint HTTPS hosts\n", domain)
+		return nil
+	}
+
+	hostCount := 0
+	for _, cluster := range clusters {
+		hostCount += len(cluster.Subdomains)
+	}
+
+	fmt.Printf("JARM clusters for %s\n", domain)
+	fmt.Printf("%d HTTPS hosts with %d distinct fingerprints\n\n", hostCount, len(clusters))
+
+	shown := 0
+	for _, cluster := range clusters {
+		if len(cluster.Subdomains) < clusterMinSize {
+			continue
+		}
+		shown++
+
+		match := ""
+		if cluster.Match != "" {
+			match = fmt.Sprintf(" ⚠️  %s", cluster.Match)
+		}
+
+		fmt.Printf("%s: %d host(s)%s\n", cluster.JARM, len(cluster.Subdomains), match)
+		for j, sub := range cluster.Subdomains {
+			if clusterSample > 0 && j >= clusterSample {
+				fmt.Printf("    ... and %d more\n", len(cluster.Subdomains)-clusterSample)
+				break
+			}
+			fmt.Printf("    %s\n", sub.Name)
+		}
+		fmt.Println()
+	}
+
+	if shown == 0 {
+		fmt.Printf("No clusters with at least %d hosts\n", clusterMinSize)
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "jarm",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d hosts, %d fingerprints", hostCount, len(clusters)),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
+
+func formatClusterLabel(cluster recon.ContentCluster) string {
+	label := ""
+	if cluster.StatusCode != 0 {
+		label = fmt.Sprintf(" [%d]", cluster.StatusCode)
+	}
+	if cluster.Title != "" {
+		title := cluster.Title
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+		label += " - " + title
+	}
+	return label
+}
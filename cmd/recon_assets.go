@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconAssetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Manage downloaded wordlists and fingerprint sets",
+	Long: `Manage the curated wordlists and fingerprint sets recon modules use,
+kept in ~/.recon-cli/assets/ with the version and SHA-256 checksum of each
+download recorded in assets.json.
+
+Wordlists are referenced by name in the wordlists settings, e.g.:
+  recon-cli recon assets download seclists-top110k
+  recon-cli config set wordlists.subdomains seclists-top110k
+
+Downloading can-i-take-over-xyz also replaces the takeover fingerprints
+'recon dns' uses, like 'recon fingerprints update'.
+
+Available subcommands:
+  list     - Show the available assets and those downloaded
+  download - Download assets by name
+  update   - Download the downloaded assets again`,
+}
+
+var reconAssetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the available assets and those downloaded",
+	Long: `Show every asset in the catalog with its version, and whether it is
+downloaded. Downloaded files are checked against their recorded checksum:
+'modified' files were changed or truncated, and 'outdated' ones were
+downloaded from an older version than the catalog's.`,
+	Args: cobra.NoArgs,
+	RunE: runReconAssetsList,
+}
+
+var reconAssetsDownloadCmd = &cobra.Command{
+	Use:   "download <name>...",
+	Short: "Download assets by name",
+	Long: `Download assets from the catalog into ~/.recon-cli/assets/. Assets
+already downloaded at the catalog's version and unmodified are skipped
+unless --force is given.
+
+Traffic can be routed through a proxy with --proxy or the 'proxy' config
+setting.
+
+Examples:
+  recon assets download seclists-top110k
+  recon assets download seclists-common can-i-take-over-xyz`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReconAssetsDownload,
+}
+
+var reconAssetsUpdateCmd = &cobra.Command{
+	Use:   "update [name]...",
+	Short: "Download the downloaded assets again",
+	Long: `Download every downloaded asset, or the named ones, again at the
+catalog's version, picking up upstream changes to assets that follow a
+branch and repairing modified files. Assets whose download fails keep
+their previous file.
+
+Examples:
+  recon assets update
+  recon assets update can-i-take-over-xyz`,
+	RunE: runReconAssetsUpdate,
+}
+
+var assetsForce bool
+
+func init() {
+	reconCmd.AddCommand(reconAssetsCmd)
+	reconAssetsCmd.AddCommand(reconAssetsListCmd)
+	reconAssetsCmd.AddCommand(reconAssetsDownloadCmd)
+	reconAssetsCmd.AddCommand(reconAssetsUpdateCmd)
+
+	reconAssetsDownloadCmd.Flags().BoolVar(&assetsForce, "force", false, "Download assets that are already up to date")
+}
+
+func runReconAssetsList(cmd *cobra.Command, args []string) error {
+	statuses, err := recon.AssetStatuses()
+	if err != nil {
+		return err
+	}
+
+	dir, _ := recon.GetAssetsDir()
+	fmt.Printf("Assets in %s\n\n", dir)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tKIND\tPURPOSE\tVERSION\tSTATUS\tENTRIES")
+	for _, status := range statuses {
+		entries := "-"
+		if status.Installed != nil {
+			entries = fmt.Sprintf("%d", status.Installed.Entries)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", status.Name, status.Kind, status.Purpose, status.Version, assetState(status), entries)
+	}
+	w.Flush()
+	return nil
+}
+
+// assetState describes the downloaded copy of an asset for 'assets list'
+func assetState(status recon.AssetStatus) string {
+	switch {
+	case status.Installed == nil:
+		return "not downloaded"
+	case status.Modified:
+		return "modified"
+	case status.Outdated:
+		return "outdated (" + status.Installed.Version + ")"
+	default:
+		return "downloaded " + status.Installed.DownloadedAt.Local().Format("2006-01-02")
+	}
+}
+
+func runReconAssetsDownload(cmd *cobra.Command, args []string) error {
+	for _, name := range args {
+		if _, ok := recon.FindAsset(name); !ok {
+			return fmt.Errorf("unknown asset: %s (see 'recon-cli recon assets list')", name)
+		}
+	}
+
+	statuses, err := recon.AssetStatuses()
+	if err != nil {
+		return err
+	}
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, name := range args {
+		for _, status := range statuses {
+			if status.Name != name {
+				continue
+			}
+			if status.Installed != nil && !status.Modified && !status.Outdated && !assetsForce {
+				fmt.Printf("✓ %s %s is up to date\n", name, status.Version)
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return downloadAssets(cmd, names, proxyURL)
+}
+
+func runReconAssetsUpdate(cmd *cobra.Command, args []string) error {
+	installed, err := recon.LoadInstalledAssets()
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		for _, asset := range recon.Assets() {
+			if _, ok := installed[asset.Name]; ok {
+				names = append(names, asset.Name)
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println("No assets downloaded yet")
+			fmt.Println("  Download: recon-cli recon assets download <name> (see 'recon-cli recon assets list')")
+			return nil
+		}
+	}
+	for _, name := range names {
+		if _, ok := installed[name]; !ok {
+			return fmt.Errorf("%s is not downloaded (run 'recon-cli recon assets download %s')", name, name)
+		}
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+	return downloadAssets(cmd, names, proxyURL)
+}
+
+// downloadAssets downloads assets, reporting each; a failed download
+// doesn't stop the others
+func downloadAssets(cmd *cobra.Command, names []string, proxyURL *url.URL) error {
+	statuses, err := recon.AssetStatuses()
+	if err != nil {
+		return err
+	}
+	previous := make(map[string]recon.AssetStatus)
+	for _, status := range statuses {
+		if status.Installed != nil {
+			previous[status.Name] = status
+		}
+	}
+	dir, err := recon.GetAssetsDir()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, name := range names {
+		asset, _ := recon.FindAsset(name)
+		fmt.Printf("Downloading %s %s...\n", asset.Name, asset.Version)
+		entry, err := recon.DownloadAsset(name, proxyURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			failed++
+			continue
+		}
+
+		unit := "words"
+		if asset.Kind == recon.AssetFingerprints {
+			unit = "fingerprints"
+		}
+		change := ""
+		if status, ok := previous[name]; ok {
+			switch {
+			case status.Installed.SHA256 != entry.SHA256:
+				change = " (updated)"
+			case status.Modified:
+				change = " (restored)"
+			default:
+				change = " (unchanged)"
+			}
+		}
+		fmt.Printf("✓ Saved %d %s to %s%s\n", entry.Entries, unit, filepath.Join(dir, entry.File), change)
+		fmt.Printf("  SHA-256: %s\n", entry.SHA256)
+		if _, ok := previous[name]; !ok && asset.Kind == recon.AssetWordlist {
+			fmt.Printf("  Use it: recon-cli config set wordlists.%s %s\n", asset.Purpose, asset.Name)
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d asset(s) failed to download", failed, len(names))
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/notify"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconDiffCmd = &cobra.Command{
+	Use:   "diff <domain>",
+	Short: "Compare two subdomain scans",
+	Long: `Compare two stored subdomain result files for a domain and report new,
+removed, newly-alive, and newly-dead hosts.
+
+By default the two most recent scans are compared. --from and --to select
+the latest scan at or before the given time, written as a result file
+timestamp (20060102_150405), a date (2006-01-02, meaning the end of that
+day), or a date and time (2006-01-02T15:04). Run 'recon results list
+<domain>' to see stored scans.
+
+With --notify, new and newly-alive hosts are also posted to the channels set
+up with 'recon notify'.
+
+Examples:
+  recon diff example.com
+  recon diff example.com --from 2025-01-01
+  recon diff example.com --from 20250101_090000 --to 20250108_090000
+  recon diff example.com --format markdown > changes.md
+  recon diff example.com --notify`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconDiff,
+}
+
+var (
+	diffFrom   string
+	diffTo     string
+	diffFormat string
+	diffNotify bool
+)
+
+func init() {
+	reconDiffCmd.Flags().StringVar(&diffFrom, "from", "", "Older scan to compare (default: second most recent)")
+	reconDiffCmd.Flags().StringVar(&diffTo, "to", "", "Newer scan to compare (default: most recent)")
+	reconDiffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format (text, json, markdown)")
+	reconDiffCmd.Flags().BoolVar(&diffNotify, "notify", false, "Post new and newly alive hosts to the channels set up with 'recon notify'")
+	reconCmd.AddCommand(reconDiffCmd)
+}
+
+func runReconDiff(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	switch diffFormat {
+	case "text", "json", "markdown", "md":
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: text, json, markdown)", diffFormat)
+	}
+
+	results, err := recon.ListResultsForDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	// Subdomain scans, newest first
+	var scans []recon.ResultInfo
+	for _, result := range results {
+		if result.ToolName == "subdomains" {
+			scans = append(scans, result)
+		}
+	}
+	if len(scans) < 2 {
+		return fmt.Errorf("at least two subdomain scans are needed to compare (found %d for %s)", len(scans), domain)
+	}
+
+	to := scans[0]
+	if diffTo != "" {
+		if to, err = findScan(scans, diffTo); err != nil {
+			return err
+		}
+	}
+
+	var from recon.ResultInfo
+	if diffFrom != "" {
+		if from, err = findScan(scans, diffFrom); err != nil {
+			return err
+		}
+	} else {
+		// The scan preceding --to
+		found := false
+		for _, scan := range scans {
+			if scan.Timestamp.Before(to.Timestamp) {
+				from, found = scan, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no scan found before %s", to.Timestamp.Format("2006-01-02 15:04:05"))
+		}
+	}
+
+	if !from.Timestamp.Before(to.Timestamp) {
+		return fmt.Errorf("--from scan (%s) must be older than --to scan (%s)",
+			from.Timestamp.Format("2006-01-02 15:04:05"), to.Timestamp.Format("2006-01-02 15:04:05"))
+	}
+
+	fromResult, err := recon.LoadSubdomainResult(domain, from.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to load scan %s: %w", from.Timestamp.Format("20060102_150405"), err)
+	}
+	toResult, err := recon.LoadSubdomainResult(domain, to.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to load scan %s: %w", to.Timestamp.Format("20060102_150405"), err)
+	}
+
+	diff := recon.DiffSubdomainResults(fromResult, toResult)
+	diff.Domain = domain
+	diff.From = from.Timestamp
+	diff.To = to.Timestamp
+
+	switch diffFormat {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	case "markdown", "md":
+		fmt.Print(formatScanDiffMarkdown(diff))
+	default:
+		displayScanDiff(diff)
+	}
+
+	if diffNotify {
+		summary := notify.Summary{
+			Domain:        domain,
+			Event:         "Scan changes",
+			Note:          fmt.Sprintf("%s to %s", diff.From.Format("2006-01-02 15:04"), diff.To.Format("2006-01-02 15:04")),
+			NewHosts:      diff.New,
+			NewlyAlive:    diff.NewlyAlive,
+			TakeoverRisks: notifyTakeoverRisks(domain),
+		}
+		// Keep JSON and Markdown output clean for redirection
+		if diffFormat == "text" {
+			fmt.Println()
+			sendNotification(summary)
+		} else if _, err := notify.Send(notifyWebhooks(), summary.Text()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findScan returns the latest scan at or before the time described by value
+func findScan(scans []recon.ResultInfo, value string) (recon.ResultInfo, error) {
+	at, err := parseScanTime(value)
+	if err != nil {
+		return recon.ResultInfo{}, err
+	}
+
+	for _, scan := range scans {
+		if !scan.Timestamp.After(at) {
+			return scan, nil
+		}
+	}
+	return recon.ResultInfo{}, fmt.Errorf("no scan found at or before %s", value)
+}
+
+// parseScanTime parses a result file timestamp, a date (end of day), or a
+// date and time
+func parseScanTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102_150405", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid scan time %q (use 20060102_150405, 2006-01-02, or 2006-01-02T15:04)", value)
+}
+
+func displayScanDiff(diff *recon.ScanDiff) {
+	fmt.Printf("Changes for %s\n", diff.Domain)
+	fmt.Printf("From: %s\n", diff.From.Format("2006-01-02 15:04:05"))
+	fmt.Printf("To:   %s\n", diff.To.Format("2006-01-02 15:04:05"))
+
+	fmt.Println("\nSummary:")
+	fmt.Printf("  New: %d\n", len(diff.New))
+	fmt.Printf("  Removed: %d\n", len(diff.Removed))
+	fmt.Printf("  Newly alive: %d\n", len(diff.NewlyAlive))
+	fmt.Printf("  Newly dead: %d\n", len(diff.NewlyDead))
+
+	sections := []struct {
+		title string
+		names []string
+	}{
+		{"🆕 New", diff.New},
+		{"🗑️  Removed", diff.Removed},
+		{"✅ Newly alive", diff.NewlyAlive},
+		{"💀 Newly dead", diff.NewlyDead},
+	}
+	for _, section := range sections {
+		if len(section.names) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s (%d):\n", section.title, len(section.names))
+		for _, name := range section.names {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	if len(diff.New)+len(diff.Removed)+len(diff.NewlyAlive)+len(diff.NewlyDead) == 0 {
+		fmt.Println("\nNo changes between these scans")
+	}
+}
+
+func formatScanDiffMarkdown(diff *recon.ScanDiff) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Changes for %s\n\n", diff.Domain)
+	fmt.Fprintf(&b, "**From:** %s  \n", diff.From.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "**To:** %s\n\n", diff.To.Format("2006-01-02 15:04:05"))
+
+	b.WriteString("| Change | Count |\n")
+	b.WriteString("|--------|-------|\n")
+	fmt.Fprintf(&b, "| New | %d |\n", len(diff.New))
+	fmt.Fprintf(&b, "| Removed | %d |\n", len(diff.Removed))
+	fmt.Fprintf(&b, "| Newly alive | %d |\n", len(diff.NewlyAlive))
+	fmt.Fprintf(&b, "| Newly dead | %d |\n", len(diff.NewlyDead))
+
+	sections := []struct {
+		title string
+		names []string
+	}{
+		{"New", diff.New},
+		{"Removed", diff.Removed},
+		{"Newly Alive", diff.NewlyAlive},
+		{"Newly Dead", diff.NewlyDead},
+	}
+	for _, section := range sections {
+		if len(section.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		for _, name := range section.names {
+			fmt.Fprintf(&b, "- `%s`\n", name)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconDirsCmd = &cobra.Command{
+	Use:   "dirs <url|domain>",
+	Short: "Brute-force directories and files on web hosts",
+	Long: `Brute-force directories and files on a single URL, or on every alive host of
+a domain when given a domain (requires 'recon verify <domain>' first).
+
+Uses ffuf or gobuster when installed, falling back to a built-in worker pool.
+Responses are kept when their status matches --match-status (default: ffuf's
+200-299,301,302,307,401,403,405,500) and dropped by --filter-status and
+--filter-size. Each target is also calibrated with a random path so catch-all
+"not found" pages are filtered automatically.
+
+The wordlist is taken from --wordlist, then the 'wordlists.dirs' config
+setting, then common SecLists/dirb install locations, then dirs wordlists
+downloaded with 'recon assets'. Either can be a path or an asset name:
+  recon-cli config set wordlists.dirs /path/to/wordlist.txt
+  recon-cli config set wordlists.dirs seclists-raft-medium-dirs
+
+Results are saved per host to ~/.recon-cli/results/<domain>/dirs_<timestamp>.json
+
+Examples:
+  recon dirs example.com
+  recon dirs https://app.example.com --extensions php,bak
+  recon dirs example.com --tool native --threads 10 --rate-limit 20/s
+  recon dirs example.com --filter-status 403 --filter-size 0`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconDirs,
+}
+
+var (
+	dirsWordlist     string
+	dirsTool         string
+	dirsExtensions   []string
+	dirsThreads      int
+	dirsRateLimit    string
+	dirsTimeout      time.Duration
+	dirsMatchStatus  []int
+	dirsFilterStatus []int
+	dirsFilterSize   []int
+)
+
+func init() {
+	reconCmd.AddCommand(reconDirsCmd)
+
+	reconDirsCmd.Flags().StringVarP(&dirsWordlist, "wordlist", "w", "", "Wordlist path or asset name (default: wordlists.dirs config setting)")
+	reconDirsCmd.Flags().StringVar(&dirsTool, "tool", "", "Tool to use (ffuf, gobuster, native; default: first installed)")
+	reconDirsCmd.Flags().StringSliceVarP(&dirsExtensions, "extensions", "e", []string{}, "Extensions to append to every word (e.g., php,bak)")
+	reconDirsCmd.Flags().IntVar(&dirsThreads, "threads", 20, "Concurrent requests per target")
+	reconDirsCmd.Flags().StringVar(&dirsRateLimit, "rate-limit", "", "Maximum request rate per target (e.g., 20/s, 600/m)")
+	reconDirsCmd.Flags().DurationVar(&dirsTimeout, "timeout", 10*time.Second, "Timeout per request")
+	reconDirsCmd.Flags().IntSliceVar(&dirsMatchStatus, "match-status", []int{}, "Status codes to keep (default: 200-299,301,302,307,401,403,405,500)")
+	reconDirsCmd.Flags().IntSliceVar(&dirsFilterStatus, "filter-status", []int{}, "Status codes to drop")
+	reconDirsCmd.Flags().IntSliceVar(&dirsFilterSize, "filter-size", []int{}, "Response sizes to drop")
+}
+
+func runReconDirs(cmd *cobra.Command, args []string) error {
+	// Accept either a URL or a domain
+	var domain string
+	var targets []string
+	if strings.Contains(args[0], "://") {
+		parsed, err := url.Parse(args[0])
+		if err != nil || parsed.Hostname() == "" {
+			return fmt.Errorf("invalid URL: %s", args[0])
+		}
+		domain = parsed.Hostname()
+		targets = []string{strings.TrimSuffix(parsed.String(), "/")}
+	} else {
+		domain = args[0]
+		if err := recon.ValidateDomain(domain); err != nil {
+			return fmt.Errorf("invalid domain: %w", err)
+		}
+
+		result, err := recon.GetLatestSubdomainResult(domain)
+		if err != nil {
+			return fmt.Errorf("failed to load results for %s: %w", domain, err)
+		}
+		targets = recon.AliveURLs(result.Subdomains)
+		if len(targets) == 0 {
+			fmt.Printf("No alive hosts found for %s\n", domain)
+			fmt.Printf("\nNext: Run 'recon verify %s' to check which subdomains are alive\n", domain)
+			return nil
+		}
+	}
+
+	// Configured defaults of the target apply unless the flag is given
+	target := reconTargetSettings(domain)
+	if target.Dirs.Threads > 0 && !cmd.Flags().Changed("threads") {
+		dirsThreads = target.Dirs.Threads
+	}
+	if target.RateLimit != "" && !cmd.Flags().Changed("rate-limit") {
+		dirsRateLimit = target.RateLimit
+	}
+	if targets = excludeTargetURLs(targets, target); len(targets) == 0 {
+		fmt.Printf("No in-scope hosts to brute-force for %s\n", domain)
+		return nil
+	}
+
+	rateLimit, err := recon.ParseRateLimit(dirsRateLimit)
+	if err != nil {
+		return err
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	configured := dirsWordlist
+	if configured == "" && cfg != nil {
+		configured = cfg.Wordlists["dirs"]
+	}
+	wordlist, err := recon.ResolveDirWordlist(configured)
+	if err != nil {
+		return err
+	}
+
+	tool, err := recon.SelectDirTool(dirsTool)
+	if err != nil {
+		return err
+	}
+
+	options := recon.DirsOptions{
+		Tool:         tool,
+		Wordlist:     wordlist,
+		Extensions:   dirsExtensions,
+		Threads:      dirsThreads,
+		RateLimit:    rateLimit,
+		Timeout:      dirsTimeout,
+		MatchStatus:  dirsMatchStatus,
+		FilterStatus: dirsFilterStatus,
+		Proxy:        proxyURL,
+	}
+	for _, size := range dirsFilterSize {
+		options.FilterSize = append(options.FilterSize, int64(size))
+	}
+
+	fmt.Printf("Brute-forcing %d targets for %s\n", len(targets), domain)
+	fmt.Printf("Tool: %s\n", tool)
+	fmt.Printf("Wordlist: %s\n", wordlist)
+	if rateLimit > 0 {
+		fmt.Printf("Rate limit: %s\n", formatRateLimit(rateLimit))
+	}
+	if proxyURL != nil {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
+	fmt.Println()
+
+	startTime := time.Now()
+	results, err := recon.BruteForceDirs(domain, targets, options)
+	if err != nil {
+		return fmt.Errorf("directory brute-force failed: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveResults(domain, "dirs", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+
+	total, filtered := 0, 0
+	for _, host := range results.Hosts {
+		total += len(host.Entries)
+		filtered += host.Filtered
+	}
+
+	fmt.Printf("\nPaths found: %d (%d filtered as noise)\n", total, filtered)
+	for _, status := range recon.TopCounts(results.Summary, 0) {
+		fmt.Printf("  %-6s %d\n", status+":", results.Summary[status])
+	}
+	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
+	fmt.Printf("Saved to: %s\n", filePath)
+
+	// Highlight paths that are accessible rather than redirects or denials
+	var lines []string
+	for _, host := range results.Hosts {
+		for _, entry := range host.Entries {
+			if entry.StatusCode >= 200 && entry.StatusCode < 300 {
+				lines = append(lines, fmt.Sprintf("%s [%d, %s]", entry.URL, entry.StatusCode, recon.FormatFileSize(entry.Size)))
+			}
+		}
+	}
+	if len(lines) > 0 {
+		fmt.Println("\nKey Findings:")
+		fmt.Printf("  📂 Accessible paths: %d\n", len(lines))
+		printFindingLines(lines)
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "dirs",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d paths on %d hosts", total, len(results.Hosts)),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
+
+// excludeTargetURLs drops the URLs of hosts excluded by a target's settings
+func excludeTargetURLs(urls []string, target config.TargetConfig) []string {
+	if len(target.Exclude) == 0 {
+		return urls
+	}
+	var inScope []string
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err == nil && target.ExcludesHost(parsed.Hostname()) {
+			continue
+		}
+		inScope = append(inScope, u)
+	}
+	if skipped := len(urls) - len(inScope); skipped > 0 {
+		fmt.Printf("Skipping %d out-of-scope hosts (exclude: %s)\n", skipped, strings.Join(target.Exclude, ", "))
+	}
+	return inScope
+}
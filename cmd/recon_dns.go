@@ -17,8 +17,19 @@ var (
 	dnsAliveOnly     bool
 	dnsRecordTypes   string
 	dnsConcurrency   int
+	dnsTypeWorkers   map[string]int
 	dnsTimeout       time.Duration
 	dnsCheckTakeover bool
+	dnsCheckDangling bool
+	dnsGeoIP         bool
+	dnsCheckDNSSEC   bool
+	dnsCheckEmail    bool
+	dnsDiff          bool
+	dnsSelection     recon.ResultSelection
+	sweepDomain      string
+	sweepMinIPs      int
+	sweepConcurrency int
+	sweepTimeout     time.Duration
 )
 
 var reconDNSCmd = &cobra.Command{
@@ -31,11 +42,46 @@ var reconDNSCmd = &cobra.Command{
   - MX records (mail servers)
   - TXT records (SPF, DMARC, verification records)
   - NS records (name servers)
+  - CAA records (permitted certificate authorities)
+  - SOA records (zone authority, at zone apexes)
+  - PTR records (reverse names for resolved IPs)
+  - SRV records for common services (opt-in: --types ...,SRV)
 
 This command also:
-  - Identifies cloud providers (AWS, Azure, GCP, Cloudflare, Akamai)
-  - Detects potential subdomain takeover opportunities
+  - Identifies cloud providers (AWS, Azure, GCP, Cloudflare, Akamai) from
+    CNAME and NS patterns and from the published IP ranges containing each
+    A/AAAA record (refresh with 'recon fingerprints update')
+  - Detects potential subdomain takeover opportunities, scored higher when
+    the CNAME target no longer resolves or the zone is not DNSSEC-signed;
+    services come from ~/.recon-cli/fingerprints/takeover.json (refresh with
+    'recon fingerprints update')
+  - Flags dangling records: A/AAAA records on cloud IPs (AWS, Azure, GCP,
+    Linode) with nothing listening on 80/443, and NS delegations to
+    nameservers that refuse the zone or sit under unregistered domains
+  - Reports DNSSEC status per subdomain (secure, signed, insecure, bogus);
+    validation status requires a validating resolver such as 1.1.1.1
+  - Grades email security (A-F) for the domain and mail-receiving subdomains
+    from SPF (all qualifier, 10-lookup limit), DMARC policy, and DKIM keys
+    published under common selectors
   - Maps subdomains to IP addresses for port scanning
+  - Optionally annotates each IP with country, ASN, and hosting type via
+    ip-api.com (--geoip; view with 'recon results view --group-by')
+
+Each record type is queried by its own pool of --concurrency workers
+(override per type with --type-concurrency), and answers are cached for the
+run so shared CNAME targets, IPs, and nameservers are queried once.
+
+Queries go directly to a single nameserver (the system resolver, one from
+--resolvers, or a DNS-over-HTTPS endpoint from --doh), and each answer is
+stored with its TTL, class, and the server that answered. Point --resolvers
+at an authoritative or internal nameserver (IP or hostname) to compare
+split-horizon views between scans.
+
+With --diff, the new results are compared to the previous scan and added or
+removed IPs, CNAME re-points, NS changes, and new takeover risks are listed.
+
+Subdomains come from the latest subdomain results; --file or --timestamp
+queries an older scan instead.
 
 Results are automatically saved to ~/.recon-cli/results/<domain>/dns_<timestamp>.json
 
@@ -44,18 +90,61 @@ Examples:
   recon dns example.com --alive-only
   recon dns example.com --types A,AAAA,MX
   recon dns example.com --check-takeover
-  recon dns example.com --concurrency 20 --timeout 10s`,
+  recon dns example.com --geoip
+  recon dns example.com --diff
+  recon dns example.com --concurrency 20 --timeout 10s
+  recon dns example.com --resolvers 1.1.1.1,8.8.8.8
+  recon dns example.com --resolvers-file resolvers.txt
+  recon dns example.com --resolvers ns1.example.com
+  recon dns example.com --doh https://cloudflare-dns.com/dns-query
+  recon dns example.com --timestamp 2025-01-31`,
 	Args: cobra.ExactArgs(1),
 	RunE: runReconDNS,
 }
 
+var reconDNSSweepCmd = &cobra.Command{
+	Use:   "sweep <cidr|domain>",
+	Short: "PTR-scan netblocks for unlisted hostnames",
+	Long: `Reverse-resolve every address in a netblock and report hostnames under
+the target domain that passive sources did not find.
+
+Given a domain, the /24 blocks holding at least --min-ips of the addresses
+in the latest DNS results are swept. Given a CIDR (at most a /16) or IP,
+that block is swept and --domain sets the target domain.
+
+In-scope names are merged into the subdomain results with source 'rdns', so
+'recon verify' and 'recon dns' pick them up.
+
+Results are saved to ~/.recon-cli/results/<domain>/rdns_<timestamp>.json
+
+Examples:
+  recon dns sweep example.com
+  recon dns sweep example.com --min-ips 2
+  recon dns sweep 203.0.113.0/24 --domain example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconDNSSweep,
+}
+
 func init() {
 	reconDNSCmd.Flags().BoolVar(&dnsAliveOnly, "alive-only", true, "Only query DNS for alive subdomains")
-	reconDNSCmd.Flags().StringVar(&dnsRecordTypes, "types", "A,AAAA,CNAME,MX,TXT,NS", "DNS record types to query (comma-separated)")
-	reconDNSCmd.Flags().IntVar(&dnsConcurrency, "concurrency", 10, "Number of concurrent DNS queries")
+	reconDNSCmd.Flags().StringVar(&dnsRecordTypes, "types", strings.Join(recon.DefaultDNSRecordTypes, ","), "DNS record types to query (comma-separated; also SRV)")
+	reconDNSCmd.Flags().IntVar(&dnsConcurrency, "concurrency", 10, "Number of concurrent DNS queries per record type")
+	reconDNSCmd.Flags().StringToIntVar(&dnsTypeWorkers, "type-concurrency", map[string]int{}, "Concurrent queries for specific record types (e.g., TXT=5,SRV=2)")
 	reconDNSCmd.Flags().DurationVar(&dnsTimeout, "timeout", 5*time.Second, "Timeout per DNS query")
 	reconDNSCmd.Flags().BoolVar(&dnsCheckTakeover, "check-takeover", true, "Check for subdomain takeover opportunities")
+	reconDNSCmd.Flags().BoolVar(&dnsCheckDangling, "check-dangling", true, "Check for A/AAAA records on released cloud IPs and dangling NS delegations")
+	reconDNSCmd.Flags().BoolVar(&dnsCheckEmail, "email", true, "Grade SPF, DMARC, and DKIM for the domain and mail-receiving subdomains")
+	reconDNSCmd.Flags().BoolVar(&dnsCheckDNSSEC, "dnssec", true, "Check DNSSEC signing and validation status")
+	reconDNSCmd.Flags().BoolVar(&dnsDiff, "diff", false, "Report changes since the previous DNS results")
+	reconDNSCmd.Flags().BoolVar(&dnsGeoIP, "geoip", false, "Annotate IPs with country, ASN, and hosting type (ip-api.com)")
+	addResultSelectionFlags(reconDNSCmd, &dnsSelection, "subdomain scan")
 	reconCmd.AddCommand(reconDNSCmd)
+
+	reconDNSSweepCmd.Flags().StringVar(&sweepDomain, "domain", "", "Target domain when sweeping a CIDR")
+	reconDNSSweepCmd.Flags().IntVar(&sweepMinIPs, "min-ips", 3, "Sweep /24 blocks holding at least this many resolved IPs")
+	reconDNSSweepCmd.Flags().IntVar(&sweepConcurrency, "concurrency", 20, "Number of concurrent PTR lookups")
+	reconDNSSweepCmd.Flags().DurationVar(&sweepTimeout, "timeout", 3*time.Second, "Timeout per PTR lookup")
+	reconDNSCmd.AddCommand(reconDNSSweepCmd)
 }
 
 func runReconDNS(cmd *cobra.Command, args []string) error {
@@ -66,6 +155,7 @@ func runReconDNS(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid domain: %w", err)
 	}
 
+	defer statusToStderr()()
 	fmt.Printf("Enumerating DNS records for %s\n", domain)
 	fmt.Println("Mode: Passive DNS enumeration")
 
@@ -75,13 +165,31 @@ func runReconDNS(cmd *cobra.Command, args []string) error {
 		recordTypes[i] = strings.TrimSpace(strings.ToUpper(rt))
 	}
 
+	resolvers, err := resolveReconResolvers()
+	if err != nil {
+		return err
+	}
+
 	// Setup options
 	options := recon.DNSEnumerationOptions{
-		AliveOnly:     dnsAliveOnly,
-		RecordTypes:   recordTypes,
-		Concurrency:   dnsConcurrency,
-		Timeout:       dnsTimeout,
-		CheckTakeover: dnsCheckTakeover,
+		AliveOnly:       dnsAliveOnly,
+		RecordTypes:     recordTypes,
+		Concurrency:     dnsConcurrency,
+		TypeConcurrency: normalizeTypeConcurrency(dnsTypeWorkers),
+		Timeout:         dnsTimeout,
+		CheckTakeover:   dnsCheckTakeover,
+		CheckDangling:   dnsCheckDangling,
+		CheckDNSSEC:     dnsCheckDNSSEC,
+		CheckEmail:      dnsCheckEmail,
+		Resolvers:       resolvers,
+	}
+
+	if !dnsSelection.IsLatest() {
+		subdomains, err := recon.GetSubdomainResult(domain, dnsSelection)
+		if err != nil {
+			return fmt.Errorf("failed to load subdomain results: %w", err)
+		}
+		options.Subdomains = subdomains
 	}
 
 	ctx := context.Background()
@@ -112,8 +220,34 @@ func runReconDNS(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("DNS enumeration failed: %w", err)
 	}
 
+	printResolverStats(resolvers)
+
+	// Enrich IPs with geolocation and ownership
+	if dnsGeoIP {
+		proxyURL, err := resolveReconProxy()
+		if err != nil {
+			return err
+		}
+		ips := results.UniqueIPs()
+		fmt.Printf("Enriching %d IPs via ip-api.com...\n", len(ips))
+		ipInfo, err := recon.EnrichIPs(ips, proxyURL)
+		if err != nil {
+			fmt.Printf("Warning: GeoIP enrichment incomplete: %v\n", err)
+		}
+		results.IPInfo = ipInfo
+	}
+
 	duration := time.Since(startTime)
 
+	// Load the previous scan before it is superseded
+	var previous *recon.DNSResults
+	if dnsDiff {
+		previous, err = recon.LoadDNSResults(domain)
+		if err != nil {
+			fmt.Println("No previous DNS results to compare against")
+		}
+	}
+
 	// Save results
 	if err := recon.SaveDNSResults(domain, results); err != nil {
 		fmt.Printf("Warning: Failed to save results: %v\n", err)
@@ -127,8 +261,16 @@ func runReconDNS(cmd *cobra.Command, args []string) error {
 	// Display key findings
 	displayKeyFindings(results)
 
+	// Display changes since the previous scan
+	if previous != nil {
+		displayDNSChanges(recon.DiffDNSResults(previous, results), previous.EnumeratedAt)
+	}
+
 	// Log activity
 	activityResult := fmt.Sprintf("%d IPs, %d CNAMEs", results.Summary.UniqueIPs, results.Summary.TotalCNAME)
+	if results.Summary.DanglingRisks > 0 {
+		activityResult += fmt.Sprintf(", %d dangling", results.Summary.DanglingRisks)
+	}
 	if results.Summary.TakeoverRisks > 0 {
 		activityResult += fmt.Sprintf(", %d takeover risks", results.Summary.TakeoverRisks)
 	}
@@ -141,9 +283,143 @@ func runReconDNS(cmd *cobra.Command, args []string) error {
 		Result:    activityResult,
 	})
 
+	if structuredOutput() {
+		return renderOutput(results)
+	}
+	return nil
+}
+
+func runReconDNSSweep(cmd *cobra.Command, args []string) error {
+	target := args[0]
+
+	var domain string
+	var blocks []string
+	if recon.IsIPQuery(target) {
+		if sweepDomain == "" {
+			return fmt.Errorf("--domain is required when sweeping a CIDR")
+		}
+		domain = sweepDomain
+		blocks = []string{target}
+	} else {
+		domain = target
+		dnsResults, err := recon.LoadDNSResults(domain)
+		if err != nil {
+			return fmt.Errorf("failed to load DNS results for %s: %w\nRun 'recon dns %s' first", domain, err, domain)
+		}
+		blocks = recon.SweepCandidateBlocks(dnsResults, sweepMinIPs)
+		if len(blocks) == 0 {
+			fmt.Printf("No /24 blocks hold %d or more resolved IPs for %s\n", sweepMinIPs, domain)
+			return nil
+		}
+	}
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	resolvers, err := resolveReconResolvers()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sweeping reverse DNS for %s\n", domain)
+	fmt.Printf("Blocks: %s\n\n", strings.Join(blocks, ", "))
+
+	startTime := time.Now()
+	results, err := recon.SweepBlocks(context.Background(), domain, blocks, recon.SweepOptions{
+		Concurrency: sweepConcurrency,
+		Timeout:     sweepTimeout,
+		Resolvers:   resolvers,
+	})
+	if err != nil {
+		return fmt.Errorf("reverse DNS sweep failed: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	printResolverStats(resolvers)
+
+	if err := recon.SaveSweepResults(domain, results); err != nil {
+		fmt.Printf("Warning: Failed to save results: %v\n", err)
+	} else {
+		fmt.Printf("✓ Results saved to ~/.recon-cli/results/%s/\n", domain)
+	}
+
+	fmt.Println("\nSummary:")
+	fmt.Printf("  IPs scanned: %d\n", results.ScannedIPs)
+	fmt.Printf("  IPs with PTR records: %d\n", len(results.Hosts))
+	fmt.Printf("  In-scope hostnames: %d\n", len(results.InScope))
+	fmt.Printf("  New subdomains: %d\n", len(results.NewSubdomains))
+	if results.Errors > 0 {
+		fmt.Printf("  Failed lookups: %d\n", results.Errors)
+	}
+	fmt.Printf("  Duration: %s\n", duration.Round(time.Second))
+
+	if len(results.NewSubdomains) > 0 {
+		fmt.Println("\nKey Findings:")
+		fmt.Printf("  🆕 Subdomains not found by passive sources: %d\n", len(results.NewSubdomains))
+		printFindingLines(results.NewSubdomains)
+	}
+
+	if len(results.InScope) > 0 {
+		added, err := recon.MergeSubdomains(domain, "rdns", results.InScope)
+		if err != nil {
+			fmt.Printf("Warning: failed to merge into subdomain results: %v\n", err)
+		} else {
+			fmt.Printf("\n✓ Merged into subdomain results (%d added, source: rdns)\n", added)
+		}
+	}
+
+	ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "dns sweep",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d IPs, %d new subdomains", results.ScannedIPs, len(results.NewSubdomains)),
+	})
+
+	if len(results.NewSubdomains) > 0 {
+		fmt.Println("\nNext: Run 'recon verify", domain, "' to check the new subdomains")
+	}
+
 	return nil
 }
 
+// normalizeTypeConcurrency upper-cases the record types in --type-concurrency
+func normalizeTypeConcurrency(workers map[string]int) map[string]int {
+	normalized := make(map[string]int, len(workers))
+	for recordType, n := range workers {
+		normalized[strings.ToUpper(strings.TrimSpace(recordType))] = n
+	}
+	return normalized
+}
+
+func displayDNSChanges(changes []recon.DNSChange, since time.Time) {
+	if len(changes) == 0 {
+		fmt.Printf("\nNo DNS changes since %s\n", since.Format("2006-01-02 15:04"))
+		return
+	}
+
+	fmt.Printf("\nDNS changes since %s: %d\n", since.Format("2006-01-02 15:04"), len(changes))
+	for _, change := range changes {
+		switch {
+		case change.Field == "takeover":
+			fmt.Printf("  ⚠️  %s new takeover risk: %s\n", change.Subdomain, change.After)
+		case change.Field == "ip" && change.Before == "":
+			fmt.Printf("  + %s ip %s\n", change.Subdomain, change.After)
+		case change.Field == "ip":
+			fmt.Printf("  - %s ip %s\n", change.Subdomain, change.Before)
+		default:
+			before, after := change.Before, change.After
+			if before == "" {
+				before = "(none)"
+			}
+			if after == "" {
+				after = "(none)"
+			}
+			fmt.Printf("  ~ %s %s: %s → %s\n", change.Subdomain, change.Field, before, after)
+		}
+	}
+}
+
 func displayDNSSummary(results *recon.DNSResults, duration time.Duration) {
 	fmt.Println("\nSummary:")
 	fmt.Printf("  Subdomains queried: %d\n", results.TotalQueried)
@@ -153,7 +429,12 @@ func displayDNSSummary(results *recon.DNSResults, duration time.Duration) {
 	fmt.Printf("  MX records: %d\n", results.Summary.TotalMX)
 	fmt.Printf("  TXT records: %d\n", results.Summary.TotalTXT)
 	fmt.Printf("  NS records: %d\n", results.Summary.TotalNS)
+	fmt.Printf("  CAA records: %d\n", results.Summary.TotalCAA)
+	fmt.Printf("  SOA records: %d\n", results.Summary.TotalSOA)
+	fmt.Printf("  SRV records: %d\n", results.Summary.TotalSRV)
+	fmt.Printf("  PTR records: %d\n", results.Summary.TotalPTR)
 	fmt.Printf("  Unique IPs: %d\n", results.Summary.UniqueIPs)
+	fmt.Printf("  DNS queries: %d (%d answered from cache)\n", results.Summary.Queries, results.Summary.CacheHits)
 	fmt.Printf("  Duration: %s\n", duration.Round(time.Second))
 }
 
@@ -168,7 +449,7 @@ func displayKeyFindings(results *recon.DNSResults) {
 		count := 0
 		for _, record := range results.Records {
 			if record.TakeoverRisk && count < 5 {
-				fmt.Printf("      - %s → %s\n", record.Subdomain, record.TakeoverReason)
+				fmt.Printf("      - %s → %s [score %d/10]\n", record.Subdomain, record.TakeoverReason, record.TakeoverScore)
 				count++
 			}
 		}
@@ -179,11 +460,66 @@ func displayKeyFindings(results *recon.DNSResults) {
 		fmt.Println("  ✓ No obvious subdomain takeover risks detected")
 	}
 
+	// Dangling A/AAAA/NS records
+	if results.Summary.DanglingRisks > 0 {
+		fmt.Printf("  ⚠️  Dangling DNS records: %d subdomains\n", results.Summary.DanglingRisks)
+		var lines []string
+		for _, record := range results.Records {
+			for _, dangling := range record.Dangling {
+				lines = append(lines, fmt.Sprintf("%s %s %s → %s", record.Subdomain, dangling.Type, dangling.Value, dangling.Reason))
+			}
+		}
+		printFindingLines(lines)
+	}
+
+	// DNSSEC coverage
+	if results.Summary.DNSSECSigned+results.Summary.DNSSECUnsigned+results.Summary.DNSSECBogus > 0 {
+		fmt.Printf("  🔏 DNSSEC: %d signed, %d unsigned", results.Summary.DNSSECSigned, results.Summary.DNSSECUnsigned)
+		if results.Summary.DNSSECBogus > 0 {
+			fmt.Printf(", %d failing validation ⚠️", results.Summary.DNSSECBogus)
+		}
+		fmt.Println()
+	}
+
+	// Short TTLs hint at failover, load balancing, or imminent changes
+	lowTTL := 0
+	for _, record := range results.Records {
+		for _, rr := range record.Records {
+			if rr.TTL < 300 {
+				lowTTL++
+			}
+		}
+	}
+	if lowTTL > 0 {
+		fmt.Printf("  ⏱  Records with TTL under 5 minutes: %d\n", lowTTL)
+	}
+
 	// Cloud providers
 	if len(results.Summary.CloudProviders) > 0 {
 		fmt.Printf("  ☁️  Cloud providers detected: %s\n", strings.Join(results.Summary.CloudProviders, ", "))
 	}
 
+	// IP locations and owners
+	if len(results.IPInfo) > 0 {
+		countries := make(map[string]int)
+		providers := make(map[string]int)
+		hosting := 0
+		for _, info := range results.IPInfo {
+			if info.Country != "" {
+				countries[info.Country]++
+			}
+			if info.ASNOrg != "" {
+				providers[info.ASNOrg]++
+			}
+			if info.HostingType == recon.HostingTypeHosting {
+				hosting++
+			}
+		}
+		fmt.Printf("  🌍 IP locations: %s\n", formatTopCounts(countries, 5))
+		fmt.Printf("      Providers: %s\n", formatTopCounts(providers, 5))
+		fmt.Printf("      Hosting/datacenter IPs: %d of %d\n", hosting, len(results.IPInfo))
+	}
+
 	// Mail servers
 	if results.Summary.TotalMX > 0 {
 		fmt.Printf("  📧 Mail servers found: %d MX records\n", results.Summary.TotalMX)
@@ -209,33 +545,26 @@ func displayKeyFindings(results *recon.DNSResults) {
 		}
 	}
 
-	// Security records
-	hasSecurityRecords := false
-	hasSPF := false
-	hasDMARC := false
-	hasDKIM := false
-
-	for _, record := range results.Records {
-		for _, txt := range record.TXT {
-			txtLower := strings.ToLower(txt)
-			if strings.HasPrefix(txtLower, "v=spf1") {
-				hasSPF = true
-				hasSecurityRecords = true
-			}
-			if strings.HasPrefix(txtLower, "v=dmarc1") {
-				hasDMARC = true
-				hasSecurityRecords = true
-			}
-			if strings.Contains(txtLower, "dkim") {
-				hasDKIM = true
-				hasSecurityRecords = true
-			}
+	// Email security grades
+	for _, email := range results.Email {
+		var parts []string
+		if email.SPF != nil {
+			parts = append(parts, fmt.Sprintf("SPF %s, %d lookups", valueOrDash(email.SPF.All), email.SPF.Lookups))
+		} else {
+			parts = append(parts, "no SPF")
 		}
-	}
-
-	if hasSecurityRecords {
-		fmt.Printf("  🔒 Security records: SPF (%v), DMARC (%v), DKIM (%v)\n",
-			formatBool(hasSPF), formatBool(hasDMARC), formatBool(hasDKIM))
+		if email.DMARC != nil {
+			parts = append(parts, "DMARC "+valueOrDash(email.DMARC.Policy))
+		} else {
+			parts = append(parts, "no DMARC")
+		}
+		if len(email.DKIM) > 0 {
+			parts = append(parts, "DKIM "+strings.Join(email.DKIM, ","))
+		} else {
+			parts = append(parts, "no DKIM")
+		}
+		fmt.Printf("  🔒 Email security %s: grade %s (%s)\n", email.Domain, email.Grade, strings.Join(parts, "; "))
+		printFindingLines(email.Issues)
 	}
 
 	// Sample records
@@ -281,9 +610,21 @@ func displayKeyFindings(results *recon.DNSResults) {
 	}
 }
 
-func formatBool(b bool) string {
-	if b {
-		return "yes"
+// formatTopCounts renders the most common keys as "key (count)" pairs
+func formatTopCounts(counts map[string]int, limit int) string {
+	var parts []string
+	for _, key := range recon.TopCounts(counts, limit) {
+		parts = append(parts, fmt.Sprintf("%s (%d)", key, counts[key]))
+	}
+	if len(counts) > limit {
+		parts = append(parts, fmt.Sprintf("+%d more", len(counts)-limit))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
 	}
-	return "no"
+	return value
 }
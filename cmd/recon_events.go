@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/presstronic/recontronic-cli-client/pkg/notify"
+	"github.com/spf13/cobra"
+)
+
+// eventTypes maps the --type values to the server's event types
+var eventTypes = map[string]string{
+	"anomaly": "anomaly",
+	"scan":    "scan_completed",
+	"asset":   "asset",
+}
+
+// maxEventBackoff caps the wait between reconnects of 'recon events --follow'
+const maxEventBackoff = 30 * time.Second
+
+var reconEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the platform's event feed",
+	Long: `Show recent events of the Recontronic platform's continuous recon: new
+anomalies, completed scans, and newly discovered assets.
+
+With --follow the feed stays open and new events scroll in as the server
+sends them (Server-Sent Events). Dropped connections are re-established
+without missing events. --desktop shows each new event as a desktop
+notification, and --notify posts it to the channels set up with
+'recon notify'.
+
+Event types (--type, repeatable):
+  anomaly - Detected anomalies
+  scan    - Completed scans
+  asset   - Newly discovered assets
+
+Examples:
+  recon events
+  recon events --follow
+  recon events -f --type anomaly --min-priority 70 --desktop
+  recon events -f --type scan --notify`,
+	Args: cobra.NoArgs,
+	RunE: runReconEvents,
+}
+
+var (
+	eventsFollow      bool
+	eventsTypes       []string
+	eventsLimit       int
+	eventsMinPriority float64
+	eventsDesktop     bool
+	eventsNotify      bool
+)
+
+func init() {
+	reconCmd.AddCommand(reconEventsCmd)
+
+	reconEventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep the feed open and show new events as they happen")
+	reconEventsCmd.Flags().StringSliceVar(&eventsTypes, "type", nil, "Only show events of this type: anomaly, scan, asset (repeatable)")
+	reconEventsCmd.Flags().IntVar(&eventsLimit, "limit", 20, "Number of recent events to show first (0 = none)")
+	reconEventsCmd.Flags().Float64Var(&eventsMinPriority, "min-priority", 0, "Only show anomalies with at least this priority score")
+	reconEventsCmd.Flags().BoolVar(&eventsDesktop, "desktop", false, "Show new events as desktop notifications (with --follow)")
+	reconEventsCmd.Flags().BoolVar(&eventsNotify, "notify", false, "Post new events to the channels set up with 'recon notify' (with --follow)")
+}
+
+func runReconEvents(cmd *cobra.Command, args []string) error {
+	if (eventsDesktop || eventsNotify) && !eventsFollow {
+		return fmt.Errorf("--desktop and --notify require --follow")
+	}
+	if eventsLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	var types []string
+	for _, value := range eventsTypes {
+		serverType, ok := eventTypes[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("invalid event type: %s (must be: anomaly, scan, asset)", value)
+		}
+		types = append(types, serverType)
+	}
+	if eventsNotify && len(notifyWebhooks()) == 0 {
+		return fmt.Errorf("--notify set but no channels configured\nRun 'recon notify set slack <webhook-url>' first")
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	lastEventID := ""
+	if eventsLimit > 0 || !eventsFollow {
+		listing, err := restClient.ListEvents(ctx, types, eventsLimit)
+		if err != nil {
+			return eventsError(err)
+		}
+		shown := 0
+		for _, event := range listing.Events {
+			lastEventID = event.ID
+			if showEvent(event) {
+				printEvent(event)
+				shown++
+			}
+		}
+		if shown == 0 && !eventsFollow {
+			fmt.Println("No recent events")
+		}
+	}
+	if !eventsFollow {
+		return nil
+	}
+
+	fmt.Printf("Following events from %s (Ctrl+C to stop)\n", cfg.Server)
+	backoff := time.Second
+	for {
+		lastEventID, err = restClient.StreamEvents(ctx, types, lastEventID, func(event models.Event) error {
+			backoff = time.Second
+			if showEvent(event) {
+				printEvent(event)
+				alertEvent(event)
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			fmt.Println("\nStopped following events")
+			return nil
+		}
+		if client.IsAuthError(err) || client.IsNotFoundError(err) {
+			return eventsError(err)
+		}
+
+		reason := "stream closed by the server"
+		if err != nil {
+			reason = err.Error()
+		}
+		fmt.Printf("Warning: %s; reconnecting in %s\n", reason, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			fmt.Println("\nStopped following events")
+			return nil
+		}
+		backoff = min(backoff*2, maxEventBackoff)
+	}
+}
+
+// eventsError explains an error of the event endpoints
+func eventsError(err error) error {
+	if client.IsAuthError(err) {
+		return fmt.Errorf("authentication failed: your API key may be invalid or expired\nPlease run 'recon-cli auth login' to get a new key")
+	}
+	if client.IsNotFoundError(err) {
+		return fmt.Errorf("the server at %s has no event feed", cfg.Server)
+	}
+	return err
+}
+
+// showEvent applies the filters the server doesn't
+func showEvent(event models.Event) bool {
+	return event.Type != "anomaly" || event.Priority >= eventsMinPriority
+}
+
+// eventLabel names an event type in the feed
+func eventLabel(eventType string) string {
+	switch eventType {
+	case "scan_completed":
+		return "SCAN"
+	case "":
+		return "EVENT"
+	default:
+		return strings.ToUpper(eventType)
+	}
+}
+
+// eventText formats an event without its timestamp
+func eventText(event models.Event) string {
+	text := event.Message
+	scope := event.ProgramName
+	if scope == "" {
+		scope = event.Domain
+	}
+	if scope != "" {
+		text = fmt.Sprintf("[%s] %s", scope, text)
+	}
+	if event.Type == "anomaly" && event.Priority > 0 {
+		text += fmt.Sprintf(" (priority %.0f)", event.Priority)
+	}
+	return text
+}
+
+// printEvent writes one line of the feed
+func printEvent(event models.Event) {
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	fmt.Printf("%s  %-8s %s\n", timestamp.Local().Format("2006-01-02 15:04:05"), eventLabel(event.Type), eventText(event))
+}
+
+// alertEvent sends the --desktop and --notify notifications of a new event.
+// Failures only warn, so the feed keeps running.
+func alertEvent(event models.Event) {
+	if eventsDesktop {
+		if err := notify.Desktop("recon-cli: "+eventLabel(event.Type), eventText(event)); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+	if eventsNotify {
+		if _, err := notify.Send(notifyWebhooks(), eventLabel(event.Type)+": "+eventText(event)); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconFingerprintsCmd = &cobra.Command{
+	Use:   "fingerprints",
+	Short: "Manage takeover fingerprints and cloud IP ranges",
+	Long: `Manage the takeover fingerprints and cloud IP ranges used by 'recon dns'.
+
+Fingerprints are stored in ~/.recon-cli/fingerprints/takeover.json, which is
+created from the built-in defaults on first use and can be edited by hand.
+Each entry lists the CNAME patterns of a service and whether it is
+Vulnerable, an Edge case, or Not vulnerable; services marked Not vulnerable
+are not reported as takeover risks.
+
+Cloud IP ranges attribute A/AAAA records to AWS, GCP, Azure, or Cloudflare.
+Built-in ranges cover the largest blocks; 'update' downloads each provider's
+full published list to ~/.recon-cli/fingerprints/cloud-ranges.json.
+
+Available subcommands:
+  list   - Show the fingerprints and cloud ranges in use
+  update - Download the latest can-i-take-over-xyz dataset and cloud ranges`,
+}
+
+var reconFingerprintsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the takeover fingerprints and cloud ranges in use",
+	Args:  cobra.NoArgs,
+	RunE:  runReconFingerprintsList,
+}
+
+var reconFingerprintsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Refresh takeover fingerprints and cloud IP ranges",
+	Long: `Download fingerprints.json from the can-i-take-over-xyz project and
+replace ~/.recon-cli/fingerprints/takeover.json with it, then download the
+published IP ranges of AWS, GCP, Azure, and Cloudflare. Providers whose feed
+fails keep their previous ranges.
+
+Traffic can be routed through a proxy with --proxy or the 'proxy' config
+setting.`,
+	Args: cobra.NoArgs,
+	RunE: runReconFingerprintsUpdate,
+}
+
+func init() {
+	reconCmd.AddCommand(reconFingerprintsCmd)
+	reconFingerprintsCmd.AddCommand(reconFingerprintsListCmd)
+	reconFingerprintsCmd.AddCommand(reconFingerprintsUpdateCmd)
+}
+
+func runReconFingerprintsList(cmd *cobra.Command, args []string) error {
+	fingerprints, err := recon.LoadTakeoverFingerprints()
+	if err != nil {
+		return err
+	}
+
+	path, _ := recon.GetFingerprintsPath()
+	fmt.Printf("Takeover fingerprints (%d) from %s\n\n", len(fingerprints), path)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  SERVICE\tSTATUS\tCNAME")
+	for _, fingerprint := range fingerprints {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", fingerprint.Service, fingerprint.Status, strings.Join(fingerprint.CNAME, ", "))
+	}
+	w.Flush()
+
+	ranges, err := recon.LoadCloudRanges()
+	if err != nil {
+		return err
+	}
+
+	source := "built-in defaults"
+	if !ranges.UpdatedAt.IsZero() {
+		source = "updated " + ranges.UpdatedAt.Format("2006-01-02")
+	}
+	fmt.Printf("\nCloud IP ranges (%s)\n\n", source)
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  PROVIDER\tRANGES")
+	for _, provider := range sortedRangeProviders(ranges) {
+		fmt.Fprintf(w, "  %s\t%d\n", provider, len(ranges.Providers[provider]))
+	}
+	w.Flush()
+
+	return nil
+}
+
+// sortedRangeProviders returns the providers in ranges alphabetically
+func sortedRangeProviders(ranges *recon.CloudRanges) []string {
+	providers := make([]string, 0, len(ranges.Providers))
+	for provider := range ranges.Providers {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+func runReconFingerprintsUpdate(cmd *cobra.Command, args []string) error {
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Downloading can-i-take-over-xyz fingerprints...")
+	fingerprints, err := recon.UpdateTakeoverFingerprints(proxyURL)
+	if err != nil {
+		return fmt.Errorf("failed to update fingerprints: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, fingerprint := range fingerprints {
+		counts[fingerprint.Status]++
+	}
+
+	path, _ := recon.GetFingerprintsPath()
+	fmt.Printf("✓ Saved %d fingerprints to %s\n", len(fingerprints), path)
+	fmt.Printf("  %s: %d, %s: %d, %s: %d\n",
+		recon.TakeoverVulnerable, counts[recon.TakeoverVulnerable],
+		recon.TakeoverEdgeCase, counts[recon.TakeoverEdgeCase],
+		recon.TakeoverNotVulnerable, counts[recon.TakeoverNotVulnerable])
+
+	fmt.Println("\nDownloading cloud IP ranges...")
+	ranges, err := recon.UpdateCloudRanges(proxyURL)
+	if ranges == nil {
+		return fmt.Errorf("failed to update cloud ranges: %w", err)
+	}
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	rangesPath, _ := recon.GetCloudRangesPath()
+	fmt.Printf("✓ Saved cloud ranges to %s\n", rangesPath)
+	for _, provider := range sortedRangeProviders(ranges) {
+		fmt.Printf("  %s: %d\n", provider, len(ranges.Providers[provider]))
+	}
+
+	return nil
+}
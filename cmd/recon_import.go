@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconImportCmd = &cobra.Command{
+	Use:   "import <domain>",
+	Short: "Import subdomains from external tool output",
+	Long: `Merge hosts gathered outside the CLI into the stored subdomain results
+for a domain. Each imported subdomain is credited to --source, and the merged
+results are saved as a new scan so 'recon verify', 'recon results', and
+'recon diff' pick them up.
+
+Supported formats (detected from the file when --format is omitted):
+  text      - One hostname or URL per line
+  subfinder - subfinder -json output
+  amass     - amass -json output
+  nmap      - nmap -oX output (hostnames with their addresses)
+  httpx     - httpx -json output; probed hosts are marked alive
+  burp      - Burp Suite XML export of target/site map items
+
+Hosts outside the domain are skipped.
+
+Examples:
+  recon import example.com --file subs.txt --source manual
+  recon import example.com --file subfinder.json --format subfinder
+  recon import example.com --file httpx.jsonl
+  recon import example.com --file scan.xml --format nmap`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconImport,
+}
+
+var (
+	importFile   string
+	importFormat string
+	importSource string
+)
+
+func init() {
+	reconImportCmd.Flags().StringVar(&importFile, "file", "", "File to import (required)")
+	reconImportCmd.Flags().StringVar(&importFormat, "format", "", "Input format ("+strings.Join(recon.ImportFormats, ", ")+"; default: detect)")
+	reconImportCmd.Flags().StringVar(&importSource, "source", "", "Source to credit imported subdomains to (default: the format, or manual for text)")
+	reconImportCmd.MarkFlagRequired("file")
+	reconCmd.AddCommand(reconImportCmd)
+}
+
+func runReconImport(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	parsed, err := recon.ParseImportFile(importFile, importFormat, domain)
+	if err != nil {
+		return err
+	}
+
+	source := importSource
+	if source == "" {
+		source = parsed.Format
+		if parsed.Format == recon.ImportText {
+			source = "manual"
+		}
+	}
+	if strings.ContainsAny(source, " ,") {
+		return fmt.Errorf("invalid source name: %q", source)
+	}
+
+	fmt.Printf("Importing %s into %s\n", importFile, domain)
+	fmt.Printf("Format: %s\n", parsed.Format)
+	fmt.Printf("Source: %s\n\n", source)
+
+	if len(parsed.Records) == 0 {
+		if parsed.OutOfScope > 0 {
+			return fmt.Errorf("no hosts in scope for %s (%d out-of-scope hosts skipped)", domain, parsed.OutOfScope)
+		}
+		return fmt.Errorf("no hosts found in %s", importFile)
+	}
+
+	summary, err := recon.ImportSubdomains(domain, source, parsed.Records)
+	if err != nil {
+		return fmt.Errorf("failed to import results: %w", err)
+	}
+
+	fmt.Println("Results:")
+	fmt.Printf("  Hosts read: %d\n", summary.Parsed)
+	fmt.Printf("  New subdomains: %d\n", summary.Added)
+	fmt.Printf("  Existing subdomains credited to %s: %d\n", source, summary.Updated)
+	if summary.Probed > 0 {
+		fmt.Printf("  Marked alive from probe data: %d\n", summary.Probed)
+	}
+	if parsed.OutOfScope > 0 {
+		fmt.Printf("  Out of scope (skipped): %d\n", parsed.OutOfScope)
+	}
+	fmt.Printf("\nSaved to: %s\n", summary.FilePath)
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "import",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d new from %s", summary.Added, source),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	if summary.Added > 0 {
+		fmt.Println("\nNext: Run 'recon verify", domain, "' to check which subdomains are alive")
+	}
+
+	return nil
+}
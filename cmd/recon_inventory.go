@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/export"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Summarize assets across every domain",
+	Long: `Aggregate the latest subdomain and DNS results of every domain into one
+portfolio-wide inventory: assets and alive hosts per domain, technologies
+seen on alive hosts (from Server and X-Powered-By headers and favicons),
+and cloud providers.
+
+With --export, the full inventory is written to the exports directory
+(~/.recon-cli/exports/) or --output:
+  csv      - One row per asset across all domains
+  json     - Domain summaries, breakdowns, and every asset
+  markdown - A report with summary tables and the asset list
+
+Examples:
+  recon inventory
+  recon inventory --top 20
+  recon inventory --json
+  recon inventory --export csv --alive-only
+  recon inventory --export markdown --output ~/reports/inventory.md`,
+	Args: cobra.NoArgs,
+	RunE: runReconInventory,
+}
+
+var (
+	inventoryTop       int
+	inventoryJSON      bool
+	inventoryExport    string
+	inventoryOutput    string
+	inventoryAliveOnly bool
+)
+
+func init() {
+	reconCmd.AddCommand(reconInventoryCmd)
+
+	reconInventoryCmd.Flags().IntVar(&inventoryTop, "top", 10, "Number of technologies and cloud providers to show")
+	reconInventoryCmd.Flags().BoolVar(&inventoryJSON, "json", false, "Output the inventory as JSON")
+	reconInventoryCmd.Flags().StringVar(&inventoryExport, "export", "", "Export the inventory (csv, json, markdown)")
+	reconInventoryCmd.Flags().StringVar(&inventoryOutput, "output", "", "Export file path (default: ~/.recon-cli/exports/inventory.<ext>)")
+	reconInventoryCmd.Flags().BoolVar(&inventoryAliveOnly, "alive-only", false, "Export only alive assets")
+}
+
+func runReconInventory(cmd *cobra.Command, args []string) error {
+	if inventoryAliveOnly && inventoryExport == "" {
+		return fmt.Errorf("--alive-only applies to --export")
+	}
+
+	inventory, err := recon.BuildInventory()
+	if err != nil {
+		return fmt.Errorf("failed to build inventory: %w", err)
+	}
+
+	if inventoryExport != "" {
+		return exportInventory(inventory)
+	}
+
+	if inventoryJSON {
+		data, err := json.MarshalIndent(inventory, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(inventory.Domains) == 0 {
+		fmt.Println("No subdomain results found.")
+		fmt.Println("\nRun 'recon subdomain <domain>' to start collecting data.")
+		return nil
+	}
+
+	fmt.Printf("Asset Inventory (%d domains)\n\n", len(inventory.Domains))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tASSETS\tALIVE\tTAKEOVER\tCLOUD\tLAST SCAN")
+	fmt.Fprintln(w, "──────\t──────\t─────\t────────\t─────\t─────────")
+	for _, domain := range inventory.Domains {
+		alive := "-"
+		if domain.Verified > 0 {
+			alive = fmt.Sprintf("%d", domain.Alive)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\t%s\n",
+			domain.Domain,
+			domain.Assets,
+			alive,
+			domain.TakeoverRisks,
+			valueOrDash(strings.Join(domain.CloudProviders, ", ")),
+			formatTimeAgo(domain.LastScan),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %d assets, %d verified, %d alive\n", inventory.TotalAssets, inventory.VerifiedAssets, inventory.AliveAssets)
+
+	if len(inventory.Technologies) > 0 {
+		fmt.Println("\nTechnologies (alive assets):")
+		printInventoryCounts(inventory.Technologies)
+	}
+
+	if len(inventory.CloudProviders) > 0 {
+		fmt.Println("\nCloud providers (assets):")
+		printInventoryCounts(inventory.CloudProviders)
+	}
+
+	if inventory.VerifiedAssets < inventory.TotalAssets {
+		fmt.Printf("\n%d assets are unverified; run 'recon verify <domain>' for alive hosts and technologies.\n",
+			inventory.TotalAssets-inventory.VerifiedAssets)
+	}
+
+	return nil
+}
+
+// printInventoryCounts prints the --top most common keys with their counts
+func printInventoryCounts(counts map[string]int) {
+	for _, key := range recon.TopCounts(counts, inventoryTop) {
+		fmt.Printf("  %-30s %d\n", key, counts[key])
+	}
+	if inventoryTop > 0 && len(counts) > inventoryTop {
+		fmt.Printf("  ... and %d more\n", len(counts)-inventoryTop)
+	}
+}
+
+func exportInventory(inventory *recon.Inventory) error {
+	var format export.ExportFormat
+	switch strings.ToLower(inventoryExport) {
+	case "csv":
+		format = export.FormatCSV
+	case "json":
+		format = export.FormatJSON
+	case "markdown", "md":
+		format = export.FormatMarkdown
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: csv, json, markdown)", inventoryExport)
+	}
+
+	outputPath := inventoryOutput
+	if outputPath == "" {
+		exportsDir, err := export.GetExportsDir()
+		if err != nil {
+			return fmt.Errorf("failed to get exports directory: %w", err)
+		}
+		extension := string(format)
+		if format == export.FormatMarkdown {
+			extension = "md"
+		}
+		outputPath = filepath.Join(exportsDir, "inventory."+extension)
+	} else if strings.HasPrefix(outputPath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		outputPath = filepath.Join(homeDir, outputPath[2:])
+	}
+
+	filePath, err := export.ExportInventory(inventory, export.ExportOptions{
+		QueryOptions: recon.QueryOptions{AliveOnly: inventoryAliveOnly},
+		Format:       format,
+		OutputPath:   outputPath,
+	})
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fmt.Printf("✓ Exported inventory of %d domains to %s\n", len(inventory.Domains), strings.ToUpper(string(format)))
+	fmt.Printf("File: %s\n", filePath)
+	fmt.Printf("Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+	if inventoryAliveOnly {
+		fmt.Println("Filters: alive only")
+	}
+
+	return nil
+}
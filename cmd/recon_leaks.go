@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconLeaksCmd = &cobra.Command{
+	Use:   "leaks <domain>",
+	Short: "Search GitHub and GitLab for leaked secrets and assets",
+	Long: `Search GitHub and GitLab code for the target domain combined with common
+secret terms (passwords, tokens, API keys, .env files).
+
+Hits are deduplicated and scanned for known credential formats and internal
+hostnames under the domain. Results are saved with repository, file, line,
+and surrounding context to
+~/.recon-cli/results/<domain>/leaks_<timestamp>.json
+
+Tokens are read from config (or the GITHUB_TOKEN / GITLAB_TOKEN environment
+variables):
+  recon-cli config set github-token <token>
+  recon-cli config set gitlab-token <token>
+
+Examples:
+  recon leaks example.com
+  recon leaks example.com --platforms github
+  recon leaks example.com --gitlab-url https://gitlab.example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconLeaks,
+}
+
+var (
+	leaksPlatforms []string
+	leaksGitLabURL string
+	leaksDelay     time.Duration
+)
+
+func init() {
+	reconCmd.AddCommand(reconLeaksCmd)
+
+	reconLeaksCmd.Flags().StringSliceVar(&leaksPlatforms, "platforms", []string{"github", "gitlab"}, "Platforms to search (github, gitlab)")
+	reconLeaksCmd.Flags().StringVar(&leaksGitLabURL, "gitlab-url", "https://gitlab.com", "GitLab instance URL")
+	reconLeaksCmd.Flags().DurationVar(&leaksDelay, "delay", 7*time.Second, "Delay between search queries (GitHub allows 10 code searches per minute)")
+}
+
+func runReconLeaks(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	options := recon.LeakSearchOptions{
+		GitLabURL: leaksGitLabURL,
+		Proxy:     proxyURL,
+		Delay:     leaksDelay,
+	}
+	if containsFold(leaksPlatforms, "github") {
+		options.GitHubToken = os.Getenv("GITHUB_TOKEN")
+		if cfg != nil && cfg.GitHubToken != "" {
+			options.GitHubToken = cfg.GitHubToken
+		}
+	}
+	if containsFold(leaksPlatforms, "gitlab") {
+		options.GitLabToken = os.Getenv("GITLAB_TOKEN")
+		if cfg != nil && cfg.GitLabToken != "" {
+			options.GitLabToken = cfg.GitLabToken
+		}
+	}
+
+	if options.GitHubToken == "" && options.GitLabToken == "" {
+		return fmt.Errorf("no token configured for %s\nRun 'recon-cli config set github-token <token>' or 'recon-cli config set gitlab-token <token>'",
+			strings.Join(leaksPlatforms, " or "))
+	}
+
+	fmt.Printf("Searching for leaks mentioning %s\n", domain)
+	fmt.Println("Mode: Passive reconnaissance (public code search)")
+	if proxyURL != nil {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
+	fmt.Println()
+
+	startTime := time.Now()
+	results, err := recon.SearchLeaks(domain, options)
+	if err != nil {
+		return fmt.Errorf("leak search failed: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveResults(domain, "leaks", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+
+	withSecrets := 0
+	for _, leak := range results.Leaks {
+		if len(leak.Matches) > 0 {
+			withSecrets++
+		}
+	}
+
+	fmt.Printf("\nTotal unique hits: %d (%d with likely secrets)\n", len(results.Leaks), withSecrets)
+	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
+	fmt.Printf("Saved to: %s\n", filePath)
+
+	if withSecrets > 0 {
+		fmt.Println("\nKey Findings:")
+		fmt.Printf("  ⚠️  Hits with likely secrets: %d\n", withSecrets)
+		var lines []string
+		for _, leak := range results.Leaks {
+			if len(leak.Matches) == 0 {
+				continue
+			}
+			location := leak.File
+			if leak.Line > 0 {
+				location = fmt.Sprintf("%s:%d", leak.File, leak.Line)
+			}
+			lines = append(lines, fmt.Sprintf("%s %s/%s (%s)", leak.Platform, leak.Repository, location, strings.Join(leak.Matches, ", ")))
+		}
+		printFindingLines(lines)
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "leaks",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d hits, %d with secrets", len(results.Leaks), withSecrets),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/notify"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Configure Slack and Discord notifications",
+	Long: `Configure the Slack and Discord channels that recon commands run with
+--notify post summaries to: new hosts, newly alive hosts, and takeover risks.
+Summaries go to every configured channel.
+
+Create an incoming webhook in Slack (Apps > Incoming Webhooks) or Discord
+(Channel settings > Integrations > Webhooks) and add its URL here.
+
+Available subcommands:
+  set    - Set the webhook URL of a channel
+  remove - Remove a channel's webhook URL
+  status - Show the configured channels
+  test   - Post a test message to every configured channel
+
+Examples:
+  recon notify set slack https://hooks.slack.com/services/T000/B000/XXXX
+  recon notify set discord https://discord.com/api/webhooks/123/abc
+  recon notify test
+  recon subdomain example.com --notify
+  recon verify example.com --notify
+  recon diff example.com --notify`,
+}
+
+var reconNotifySetCmd = &cobra.Command{
+	Use:   "set <slack|discord> <webhook-url>",
+	Short: "Set the webhook URL of a channel",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runReconNotifySet,
+}
+
+var reconNotifyRemoveCmd = &cobra.Command{
+	Use:   "remove <slack|discord>",
+	Short: "Remove a channel's webhook URL",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReconNotifyRemove,
+}
+
+var reconNotifyStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the configured channels",
+	Args:  cobra.NoArgs,
+	RunE:  runReconNotifyStatus,
+}
+
+var reconNotifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Post a test message to every configured channel",
+	Args:  cobra.NoArgs,
+	RunE:  runReconNotifyTest,
+}
+
+func init() {
+	reconCmd.AddCommand(reconNotifyCmd)
+	reconNotifyCmd.AddCommand(reconNotifySetCmd)
+	reconNotifyCmd.AddCommand(reconNotifyRemoveCmd)
+	reconNotifyCmd.AddCommand(reconNotifyStatusCmd)
+	reconNotifyCmd.AddCommand(reconNotifyTestCmd)
+}
+
+// parseNotifyChannel validates a channel name
+func parseNotifyChannel(value string) (string, error) {
+	channel := strings.ToLower(value)
+	if !containsFold(notify.Channels, channel) {
+		return "", fmt.Errorf("invalid channel: %s (must be: %s)", value, strings.Join(notify.Channels, ", "))
+	}
+	return channel, nil
+}
+
+func runReconNotifySet(cmd *cobra.Command, args []string) error {
+	channel, err := parseNotifyChannel(args[0])
+	if err != nil {
+		return err
+	}
+	if err := notify.ValidateWebhookURL(args[1]); err != nil {
+		return err
+	}
+	if err := config.Set("notify."+channel, args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %s notifications enabled\n", channel)
+	fmt.Println("Run 'recon notify test' to post a test message")
+	return nil
+}
+
+func runReconNotifyRemove(cmd *cobra.Command, args []string) error {
+	channel, err := parseNotifyChannel(args[0])
+	if err != nil {
+		return err
+	}
+	if err := config.Set("notify."+channel, ""); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %s notifications removed\n", channel)
+	return nil
+}
+
+func runReconNotifyStatus(cmd *cobra.Command, args []string) error {
+	webhooks := notifyWebhooks()
+
+	fmt.Println("Notification channels:")
+	for _, channel := range notify.Channels {
+		fmt.Printf("  %-8s %s\n", channel+":", formatSecret(webhooks[channel]))
+	}
+	return nil
+}
+
+func runReconNotifyTest(cmd *cobra.Command, args []string) error {
+	webhooks := notifyWebhooks()
+	if len(webhooks) == 0 {
+		return fmt.Errorf("no notification channels configured\nRun 'recon notify set slack <webhook-url>' first")
+	}
+
+	sent, err := notify.Send(webhooks, "Test message from recon-cli: notifications are working")
+	for _, channel := range sent {
+		fmt.Printf("✓ Posted to %s\n", channel)
+	}
+	return err
+}
+
+// notifyWebhooks returns the configured webhook URL of each channel
+func notifyWebhooks() map[string]string {
+	if cfg == nil {
+		return map[string]string{}
+	}
+	return cfg.Notify.Webhooks()
+}
+
+// sendNotification posts a summary for a --notify flag. Failures are
+// reported as warnings, since the run itself succeeded.
+func sendNotification(summary notify.Summary) {
+	webhooks := notifyWebhooks()
+	if len(webhooks) == 0 {
+		fmt.Println("Warning: --notify set but no channels configured; run 'recon notify set slack <webhook-url>'")
+		return
+	}
+
+	sent, err := notify.Send(webhooks, summary.Text())
+	if len(sent) > 0 {
+		fmt.Printf("✓ Notified %s\n", strings.Join(sent, ", "))
+	}
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// notifyTakeoverRisks returns the subdomains flagged as takeover risks in
+// the latest DNS results of a domain, if any
+func notifyTakeoverRisks(domain string) []string {
+	dnsResults, err := recon.LoadDNSResults(domain)
+	if err != nil {
+		return nil
+	}
+
+	var risks []string
+	for _, info := range recon.QueryDNSRecords(dnsResults, recon.DNSQueryOptions{TakeoverOnly: true}) {
+		risks = append(risks, info.Subdomain)
+	}
+	return risks
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconNucleiCmd = &cobra.Command{
+	Use:   "nuclei <domain>",
+	Short: "Run nuclei templates against alive hosts",
+	Long: `Run nuclei templates against the alive hosts of a domain.
+
+Alive URLs are taken from the latest verification results. Templates tagged
+dos, intrusive, fuzz, or bruteforce are always excluded to keep the scan
+low-impact. Narrow the scan further with --severity and --tags.
+
+Requires nuclei to be installed and verification data from
+'recon verify <domain>'.
+
+Results are saved to ~/.recon-cli/results/<domain>/nuclei_<timestamp>.json
+
+Examples:
+  recon nuclei example.com
+  recon nuclei example.com --severity low,medium --tags exposures,misconfig
+  recon nuclei example.com --severity high,critical --rate-limit 50`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconNuclei,
+}
+
+var (
+	nucleiSeverities []string
+	nucleiTags       []string
+	nucleiRateLimit  int
+	nucleiTimeout    time.Duration
+)
+
+func init() {
+	reconCmd.AddCommand(reconNucleiCmd)
+
+	reconNucleiCmd.Flags().StringSliceVar(&nucleiSeverities, "severity", []string{}, "Template severities to run (critical, high, medium, low, info)")
+	reconNucleiCmd.Flags().StringSliceVar(&nucleiTags, "tags", []string{}, "Template tags to run (e.g., exposures,misconfig)")
+	reconNucleiCmd.Flags().IntVar(&nucleiRateLimit, "rate-limit", 0, "Maximum requests per second (0 = nuclei default)")
+	reconNucleiCmd.Flags().DurationVar(&nucleiTimeout, "timeout", 60*time.Minute, "Overall scan timeout")
+}
+
+func runReconNuclei(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	severities, err := recon.ParseNucleiSeverities(nucleiSeverities)
+	if err != nil {
+		return err
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	if !recon.IsToolAvailable("nuclei") {
+		return fmt.Errorf("nuclei is not installed (see https://github.com/projectdiscovery/nuclei)")
+	}
+
+	result, err := recon.GetLatestSubdomainResult(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load results for %s: %w", domain, err)
+	}
+
+	targets := recon.AliveURLs(result.Subdomains)
+	if len(targets) == 0 {
+		fmt.Printf("No alive hosts found for %s\n", domain)
+		fmt.Printf("\nNext: Run 'recon verify %s' to check which subdomains are alive\n", domain)
+		return nil
+	}
+
+	// Configured defaults of the target apply unless the flag is given
+	target := reconTargetSettings(domain)
+	if target.RateLimit != "" && !cmd.Flags().Changed("rate-limit") {
+		perSecond, err := recon.ParseRateLimit(target.RateLimit)
+		if err != nil {
+			return fmt.Errorf("%w (target settings)", err)
+		}
+		// nuclei takes whole requests per second
+		nucleiRateLimit = max(1, int(perSecond))
+	}
+	if targets = excludeTargetURLs(targets, target); len(targets) == 0 {
+		fmt.Printf("No in-scope hosts to scan for %s\n", domain)
+		return nil
+	}
+
+	fmt.Printf("Running nuclei against %d alive URLs for %s\n", len(targets), domain)
+	if len(severities) > 0 {
+		fmt.Printf("Severity: %s\n", strings.Join(severities, ", "))
+	}
+	if len(nucleiTags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(nucleiTags, ", "))
+	}
+	fmt.Println()
+
+	options := recon.NucleiOptions{
+		Severities: severities,
+		Tags:       nucleiTags,
+		RateLimit:  nucleiRateLimit,
+		Timeout:    nucleiTimeout,
+	}
+	if proxyURL != nil {
+		options.Proxy = proxyURL.String()
+	}
+
+	startTime := time.Now()
+	results, err := recon.RunNuclei(domain, targets, options)
+	if err != nil {
+		return err
+	}
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveResults(domain, "nuclei", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+
+	fmt.Println("Scan Complete!")
+	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
+
+	fmt.Printf("Findings: %d\n", len(results.Findings))
+	for _, severity := range recon.NucleiSeverities {
+		if count := results.Summary[severity]; count > 0 {
+			fmt.Printf("  %-10s %d\n", severity+":", count)
+		}
+	}
+	fmt.Printf("\nSaved to: %s\n", filePath)
+
+	// Show the most severe findings first
+	if len(results.Findings) > 0 {
+		fmt.Println("\nTop findings:")
+		shown := 0
+		for _, severity := range recon.NucleiSeverities {
+			for _, finding := range results.Findings {
+				if finding.Severity != severity {
+					continue
+				}
+				if shown >= 10 {
+					break
+				}
+				fmt.Printf("  [%s] %s - %s\n", finding.Severity, finding.Name, finding.MatchedAt)
+				shown++
+			}
+		}
+		if len(results.Findings) > shown {
+			fmt.Printf("  ... and %d more (see JSON results)\n", len(results.Findings)-shown)
+		}
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "nuclei",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d findings", len(results.Findings)),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconParamsCmd = &cobra.Command{
+	Use:   "params <domain>",
+	Short: "Build parameter wordlists from collected URLs",
+	Long: `Mine query parameter names from the latest URL collection and the
+JavaScript files it contains, and aggregate them into per-host wordlists.
+
+JavaScript files are fetched and scanned for endpoints with query strings and
+URLSearchParams calls (disable with --no-js).
+
+Requires URL data from 'recon urls <domain>'.
+
+Results are saved to ~/.recon-cli/results/<domain>/params_<timestamp>.json
+and the wordlist (one name per line, most frequent first) to
+~/.recon-cli/results/<domain>/params_<timestamp>.txt for use with:
+  arjun -u https://app.example.com/search -w params.txt
+  ffuf -u 'https://app.example.com/search?FUZZ=1' -w params.txt:FUZZ
+
+When the vault is enabled the stored wordlist is encrypted; use --output to
+also write a plain copy for other tools.
+
+Examples:
+  recon params example.com
+  recon params example.com --host app.example.com --output params.txt
+  recon params example.com --no-js`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconParams,
+}
+
+var (
+	paramsNoJS   bool
+	paramsMaxJS  int
+	paramsHost   string
+	paramsOutput string
+)
+
+func init() {
+	reconCmd.AddCommand(reconParamsCmd)
+
+	reconParamsCmd.Flags().BoolVar(&paramsNoJS, "no-js", false, "Skip fetching JavaScript files")
+	reconParamsCmd.Flags().IntVar(&paramsMaxJS, "max-js", 50, "Maximum JavaScript files to fetch")
+	reconParamsCmd.Flags().StringVar(&paramsHost, "host", "", "Export the wordlist for a single host")
+	reconParamsCmd.Flags().StringVarP(&paramsOutput, "output", "o", "", "Also write the wordlist to this file")
+}
+
+func runReconParams(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	var urlResults recon.URLResults
+	if err := recon.LoadLatestResult(domain, "urls", &urlResults); err != nil {
+		return fmt.Errorf("failed to load URL results for %s: %w\nRun 'recon urls %s' first", domain, err, domain)
+	}
+
+	fmt.Printf("Mining parameters from %d URLs for %s\n", len(urlResults.URLs), domain)
+	if !paramsNoJS && proxyURL != nil {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
+	fmt.Println()
+
+	options := recon.ParamMiningOptions{
+		ScanJS:     !paramsNoJS,
+		MaxJSFiles: paramsMaxJS,
+		Proxy:      proxyURL,
+	}
+
+	startTime := time.Now()
+	results, err := recon.MineParameters(&urlResults, options)
+	if err != nil {
+		return fmt.Errorf("parameter mining failed: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	if paramsHost != "" {
+		if _, ok := results.Hosts[strings.ToLower(paramsHost)]; !ok {
+			return fmt.Errorf("no parameters found for host %s", paramsHost)
+		}
+	}
+	wordlist := results.Wordlist(paramsHost)
+
+	filePath, err := recon.SaveResults(domain, "params", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+	wordlistPath, err := recon.SaveResults(domain, "params", wordlist, recon.FormatText)
+	if err != nil {
+		return fmt.Errorf("failed to save wordlist: %w", err)
+	}
+	if paramsOutput != "" {
+		if err := os.WriteFile(paramsOutput, []byte(wordlist), 0600); err != nil {
+			return fmt.Errorf("failed to write wordlist: %w", err)
+		}
+	}
+
+	fmt.Printf("Unique parameters: %d across %d hosts\n", len(results.Parameters), len(results.Hosts))
+	if options.ScanJS {
+		fmt.Printf("JavaScript files scanned: %d (%d failed)\n", results.JSFilesScanned, results.JSFilesFailed)
+	}
+	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
+	fmt.Printf("Saved to: %s\n", filePath)
+	fmt.Printf("Wordlist: %s\n", wordlistPath)
+	if paramsOutput != "" {
+		fmt.Printf("Wordlist also written to: %s\n", paramsOutput)
+	}
+
+	if len(results.Hosts) > 0 {
+		fmt.Println("\nParameters per host:")
+		hostCounts := make(map[string]int)
+		for host, names := range results.Hosts {
+			hostCounts[host] = len(names)
+		}
+		for _, host := range recon.TopCounts(hostCounts, 10) {
+			names := results.Hosts[host]
+			sample := names
+			if len(sample) > 5 {
+				sample = sample[:5]
+			}
+			fmt.Printf("  %-40s %d (%s)\n", host, len(names), strings.Join(sample, ", "))
+		}
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "params",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d parameters across %d hosts", len(results.Parameters), len(results.Hosts)),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/export"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconReportCmd = &cobra.Command{
+	Use:   "report <domain>",
+	Short: "Generate a full report combining every module",
+	Long: `Merge the latest subdomain, verification, DNS, WHOIS, port, and findings
+data of a domain into a single narrative report: an executive summary,
+the methodology behind the data, the asset inventory, and notable findings
+(takeover risks, dangling DNS, misconfigurations, nuclei matches, and
+anomalies) ordered by severity.
+
+Formats:
+  markdown - Markdown report (default)
+  html     - Standalone HTML report with inline CSS
+  pdf      - The HTML report printed to PDF (requires wkhtmltopdf or
+             Chrome/Chromium)
+
+Reports are rendered from Go templates. To customize them, save the
+built-in template and edit it:
+  recon report --print-template markdown > ~/.recon-cli/templates/report.md.tmpl
+  recon report --print-template html > ~/.recon-cli/templates/report.html.tmpl
+
+Templates in ~/.recon-cli/templates/ replace the built-in ones (the HTML
+template is also used for PDF); --template uses a template file for one run.
+
+Examples:
+  recon report example.com
+  recon report example.com --format html
+  recon report example.com --format pdf --output ~/reports/example.pdf
+  recon report example.com --template client.md.tmpl`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if reportPrintTemplate != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runReconReport,
+}
+
+var (
+	reportFormat        string
+	reportOutput        string
+	reportTemplate      string
+	reportPrintTemplate string
+)
+
+func init() {
+	reconCmd.AddCommand(reconReportCmd)
+
+	reconReportCmd.Flags().StringVarP(&reportFormat, "format", "f", "markdown", "Report format (markdown, html, pdf)")
+	reconReportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Report file path (default: ~/.recon-cli/exports/<domain>_report.<ext>)")
+	reconReportCmd.Flags().StringVar(&reportTemplate, "template", "", "Render with this template file instead of the default")
+	reconReportCmd.Flags().StringVar(&reportPrintTemplate, "print-template", "", "Print the built-in template of a format (markdown, html) and exit")
+}
+
+// parseReportFormat validates a report format
+func parseReportFormat(value string) (export.ExportFormat, error) {
+	switch strings.ToLower(value) {
+	case "markdown", "md":
+		return export.FormatMarkdown, nil
+	case "html":
+		return export.FormatHTML, nil
+	case "pdf":
+		return export.FormatPDF, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (supported: markdown, html, pdf)", value)
+	}
+}
+
+func runReconReport(cmd *cobra.Command, args []string) error {
+	if reportPrintTemplate != "" {
+		format, err := parseReportFormat(reportPrintTemplate)
+		if err != nil {
+			return err
+		}
+		text, err := export.DefaultReportTemplate(format)
+		if err != nil {
+			return err
+		}
+		fmt.Print(text)
+		return nil
+	}
+
+	domain := args[0]
+	format, err := parseReportFormat(reportFormat)
+	if err != nil {
+		return err
+	}
+
+	report, err := recon.BuildReport(domain)
+	if err != nil {
+		return fmt.Errorf("%w\nRun 'recon subdomain %s' first", err, domain)
+	}
+
+	outputPath := reportOutput
+	if outputPath == "" {
+		exportsDir, err := export.GetExportsDir()
+		if err != nil {
+			return fmt.Errorf("failed to get exports directory: %w", err)
+		}
+		extension := string(format)
+		if format == export.FormatMarkdown {
+			extension = "md"
+		}
+		outputPath = filepath.Join(exportsDir, fmt.Sprintf("%s_report.%s", domain, extension))
+	} else if strings.HasPrefix(outputPath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		outputPath = filepath.Join(homeDir, outputPath[2:])
+	}
+
+	filePath, err := export.ExportReport(report, export.ExportOptions{
+		Format:     format,
+		OutputPath: outputPath,
+	}, reportTemplate)
+	if err != nil {
+		return fmt.Errorf("report failed: %w", err)
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fmt.Printf("✓ Generated %s report for %s\n", strings.ToUpper(string(format)), domain)
+	fmt.Printf("File: %s\n", filePath)
+	fmt.Printf("Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+
+	var modules []string
+	for _, step := range report.Methodology {
+		modules = append(modules, step.Module)
+	}
+	fmt.Printf("Modules: %s\n", strings.Join(modules, ", "))
+	if len(report.Findings) > 0 {
+		fmt.Printf("Findings: %d\n", len(report.Findings))
+	}
+
+	return nil
+}
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
 	"github.com/presstronic/recontronic-cli-client/pkg/export"
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 	"github.com/presstronic/recontronic-cli-client/pkg/ui"
@@ -19,9 +25,18 @@ var reconResultsCmd = &cobra.Command{
 	Long: `View, filter, and manage stored reconnaissance results.
 
 Available subcommands:
-  list   - List all stored results
-  view   - View specific result details
-  export - Export results to various formats`,
+  list    - List all stored results
+  view    - View the latest results of a tool
+  export  - Export results to various formats
+  history - Show every observation of one subdomain across scans
+  delete  - Delete stored results by age, count, or all at once
+  compact - Gzip-compress stored result files
+  archive - Bundle a domain's results and exports into a tarball
+  restore - Restore a domain's dataset from an archive
+  tag     - Add or remove tags on a subdomain
+  note    - Set or show the notes of a subdomain
+  import-annotations - Sync tags and notes edited in a spreadsheet back
+  push    - Push findings into DefectDojo or Faraday`,
 }
 
 var reconResultsListCmd = &cobra.Command{
@@ -36,38 +51,346 @@ If a domain is specified, only results for that domain will be shown.`,
 
 var reconResultsViewCmd = &cobra.Command{
 	Use:   "view <domain>",
-	Short: "View subdomain results for a domain",
-	Long: `View the most recent subdomain results for a domain.
+	Short: "View the latest results for a domain",
+	Long: `View the most recent results of one tool for a domain.
+
+Tools (--tool, default subdomains) and their filters:
+  subdomains - --alive-only, --dead-only, --status, --source, --missing-header,
+               --favicon-hash, --final-url, --cross-domain, --tech,
+               --title-contains, --cloud, --takeover-risk, --ip-cidr,
+               --with-paths, --group-by (--cloud and --takeover-risk match
+               against the latest DNS results)
+  dns        - --type, --takeover, --dangling, --cloud
+  whois      - --raw
+  ports      - Web ports probed by verify (--ports); --port, --alive-only,
+               --status
+  urls       - --host, --ext, --param, --contains
+
+--limit applies to every tool. --file or --timestamp views an older result
+of the tool instead of the latest.
 
-Supports filtering options to narrow down results.`,
+Examples:
+  recon results view example.com --alive-only
+  recon results view example.com --missing-header hsts
+  recon results view example.com --missing-header csp
+  recon results view example.com --favicon-hash 116323821
+  recon results view example.com --cross-domain
+  recon results view example.com --final-url login
+  recon results view example.com --tech wordpress --title-contains login
+  recon results view example.com --cloud aws --ip-cidr 52.0.0.0/8
+  recon results view example.com --alive-only --with-paths
+  recon results view example.com --group-by country
+  recon results view example.com --group-by provider
+  recon results view example.com --tool dns --type CNAME
+  recon results view example.com --tool dns --takeover
+  recon results view example.com --tool whois
+  recon results view example.com --tool ports --port 8443 --alive-only
+  recon results view example.com --tool urls --ext js --host api.example.com
+  recon results view example.com --timestamp 2025-01-31
+  recon results view example.com --tool dns --file dns_20250131_140512.json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runReconResultsView,
 }
 
 var reconResultsExportCmd = &cobra.Command{
-	Use:   "export <domain>",
-	Short: "Export subdomain results to various formats",
-	Long: `Export the most recent subdomain results for a domain to various formats.
+	Use:   "export [domain]",
+	Short: "Export subdomain or WHOIS results to various formats",
+	Long: `Export the most recent subdomain results for a domain to various formats,
+or an older scan chosen with --file or --timestamp. With --tool whois, the
+WHOIS results are exported instead (csv, json, or markdown); the raw WHOIS
+response, stored in a *_raw.txt sidecar, is included only with --include-raw.
+
+Subdomains can be filtered with --alive-only, --dead-only, --status, --source,
+--tech, --title-contains, --ip-cidr, and, using the latest DNS results,
+--cloud and --takeover-risk.
 
 Supported formats:
   csv      - Comma-separated values (Excel-compatible)
   json     - JSON format (for tool integration)
   markdown - Markdown format (for reports)
+             (--group-by status, tech, source, or cloud splits the
+             table into per-group sections with counts; --toc adds a
+             table of contents and --collapsible folds each table into a
+             <details> block, for GitHub and Obsidian notes)
+  html     - Standalone HTML report with summary charts, a sortable and
+             filterable subdomain table, and takeover findings from the
+             latest DNS results (for sending to clients)
+  txt      - One hostname per line, for piping into other tools
+  urls     - One base URL (scheme://host) per alive web host, for ffuf,
+             nuclei, aquatone, and other HTTP tools
+  burp     - Burp Suite project scope (Project options > Load): an include
+             rule per web port of each alive subdomain and an exclude rule
+             per --exclude host
+  aquatone - host:port per web port of each alive subdomain, for
+             cat list.txt | aquatone
+  eyewitness - URL per web port of each alive subdomain, for
+               EyeWitness -f list.txt or gowitness file -f list.txt
+               (--format gowitness is the same list)
+  stix     - STIX 2.1 bundle for threat-intel platforms: domain-name,
+             ipv4-addr, ipv6-addr, and x509-certificate objects linked by
+             resolves-to and related-to relationships, from verification
+             and the latest DNS results
+  iocs     - The same indicators as a flat CSV (Type, Value, Subdomain,
+             Relationship)
+  template - Rendered with your own Go template (--template), which gets
+             the full results of --tool (subdomains, dns, whois, ipwhois,
+             urls, or nuclei) as its dot; the export's extension is taken
+             from the template name (wiki.md.tmpl)
+  nmap-targets - Deduplicated IP, hostname, and /24 lists from the latest
+                 DNS results, one target per line for nmap -iL, naabu -list,
+                 or masscan -iL (writes <name>.txt, <name>_hosts.txt, and
+                 <name>_cidrs.txt)
+
+With --webhook <url>, the (filtered) results of --tool are POSTed as JSON to
+the URL instead, e.g., to feed n8n or an asset database. When a secret is
+set, each request carries an X-Recon-Signature: sha256=<hex> header holding
+the HMAC-SHA256 of the body:
+  recon-cli config set webhook-secret <secret>   (or RECON_WEBHOOK_SECRET)
+
+Without --output, exports are written to ~/.recon-cli/exports/ with a
+timestamp in the name (example.com_subdomains_20250131_140512.csv), so
+earlier exports are kept. --latest-symlink also points
+<domain>_<name>_latest.<ext> at the new file, giving scripts a stable path.
+Use --output - to write the export to stdout instead of a file, e.g., to pipe
+it into another tool; nmap-targets then writes the IPs followed by the
+hostnames as one list.
+
+With --all-domains instead of a domain, the latest results of every domain
+that has --tool results are exported in one run, with the same format,
+filters, and timestamp.
 
 Examples:
   recon results export tesla.com --format csv
   recon results export basecamp.com --format markdown --alive-only
-  recon results export example.com --format json --output /path/to/file.json`,
-	Args: cobra.ExactArgs(1),
+  recon results export example.com --format markdown --group-by tech --toc --collapsible
+  recon results export example.com --format json --output /path/to/file.json
+  recon results export example.com --format html
+  recon results export example.com --format nmap-targets --alive-only
+  recon results export example.com --format csv --tech wordpress --cloud aws
+  recon results export example.com --format txt --takeover-risk -o -
+  recon results export example.com --alive-only --webhook https://n8n.example.com/webhook/recon
+  recon results export example.com --format urls --alive-only -o - | nuclei
+  recon results export example.com --format txt -o - | httpx
+  recon results export example.com --format burp --exclude '*.corp.example.com'
+  recon results export example.com --format aquatone -o - | aquatone
+  recon results export example.com --format eyewitness -o urls.txt
+  recon results export example.com --format stix --alive-only
+  recon results export example.com --format csv --timestamp 2025-01-31
+  recon results export example.com --format txt --alive-only --latest-symlink
+  recon results export --all-domains --format csv
+  recon results export example.com --tool whois --format json --include-raw
+  recon results export example.com --format template --template wiki.md.tmpl
+  recon results export example.com --tool dns --format template --template zone.txt.tmpl`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if exportAllDomains {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runReconResultsExport,
 }
 
+var reconResultsHistoryCmd = &cobra.Command{
+	Use:   "history <domain> <subdomain>",
+	Short: "Show one subdomain's history across all stored scans",
+	Long: `Show every observation of a single subdomain across the stored subdomain
+and DNS results for a domain: when it first appeared, its verification
+status over time, IP and CNAME changes, and which sources have reported it.
+
+Rows marked * changed since the previous scan of the same kind.
+
+Examples:
+  recon results history example.com api.example.com
+  recon results history example.com api.example.com --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runReconResultsHistory,
+}
+
+var reconResultsDeleteCmd = &cobra.Command{
+	Use:   "delete <domain>",
+	Short: "Delete stored results for a domain",
+	Long: `Delete stored result files for a domain. Exactly one of --older-than,
+--keep-last, or --all selects what is removed; --keep-last counts each tool
+(subdomains, dns, dirs, ...) separately.
+
+Results can also be pruned automatically after every scan with the
+retention config settings:
+  recon-cli config set retention.max-age 30d
+  recon-cli config set retention.keep-last 5
+
+Examples:
+  recon results delete example.com --older-than 30d
+  recon results delete example.com --keep-last 5
+  recon results delete example.com --all --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconResultsDelete,
+}
+
+var reconResultsCompactCmd = &cobra.Command{
+	Use:   "compact [domain]",
+	Short: "Gzip-compress stored result files",
+	Long: `Compress stored JSON result files to .json.gz, for one domain or for
+every domain. Compressed results are read transparently by every command.
+
+New results can be saved compressed with:
+  recon-cli config set compress-results true
+
+Examples:
+  recon results compact
+  recon results compact example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReconResultsCompact,
+}
+
+var reconResultsArchiveCmd = &cobra.Command{
+	Use:   "archive <domain>",
+	Short: "Bundle a domain's results and exports into a tarball",
+	Long: `Bundle a domain's complete dataset into a .tar.gz archive: every stored
+result file with its raw output sidecars, and the domain's exports. Hand the
+archive to a teammate or copy it to another machine and unpack it there with
+'recon results restore'.
+
+Results sealed by the vault stay sealed in the archive and can only be read
+with the same vault key.
+
+Examples:
+  recon results archive example.com
+  recon results archive example.com -o ~/handoff/example.com.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconResultsArchive,
+}
+
+var reconResultsRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore a domain's dataset from an archive",
+	Long: `Unpack an archive written by 'recon results archive' into the results and
+exports directories. Nothing is restored when a file in the archive already
+exists, unless --force is given to overwrite it.
+
+Examples:
+  recon results restore example.com.tar.gz
+  recon results restore example.com.tar.gz --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconResultsRestore,
+}
+
+var reconResultsTagCmd = &cobra.Command{
+	Use:   "tag <domain> <subdomain>",
+	Short: "Add or remove tags on a subdomain",
+	Long: `Add or remove tags on a subdomain of the latest results to keep track of
+triage, e.g., interesting, login-page, or reviewed. Without --add or
+--remove the subdomain's tags are shown.
+
+Tags and notes are kept in the .annotations file of the domain's results
+directory, so they carry over to new scans of the subdomain. They are shown
+and filterable (--tag) in 'results view' and included in the CSV, JSON, and
+template exports.
+
+Examples:
+  recon results tag example.com admin.example.com --add interesting,login-page
+  recon results tag example.com admin.example.com --remove login-page
+  recon results view example.com --tag interesting`,
+	Args: cobra.ExactArgs(2),
+	RunE: runReconResultsTag,
+}
+
+var reconResultsNoteCmd = &cobra.Command{
+	Use:   "note <domain> <subdomain> [text]",
+	Short: "Set or show the notes of a subdomain",
+	Long: `Set the notes of a subdomain of the latest results, replacing any
+previous notes, or show them when no text is given. Notes are kept with
+the tags in the domain's annotations (see 'results tag').
+
+Examples:
+  recon results note example.com staging.example.com "default creds worked on staging"
+  recon results note example.com staging.example.com
+  recon results note example.com staging.example.com --clear`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runReconResultsNote,
+}
+
+var reconResultsImportAnnotationsCmd = &cobra.Command{
+	Use:   "import-annotations <domain>",
+	Short: "Sync tags and notes from a spreadsheet into the results",
+	Long: `Read subdomain tags and notes from a CSV file and store them in the
+domain's annotations, so triage done in a spreadsheet (marking assets
+reviewed or interesting) flows back into the CLI's dataset.
+
+The CSV needs a header row with a Subdomain column and a Tags or Notes
+column; the CSV export has all three. Tags are separated by ; or , and an
+empty cell clears the stored value. Tags and notes appear in the CSV, JSON,
+and template exports.
+
+Examples:
+  recon results export example.com --format csv -o triage.csv
+  # edit the Tags and Notes columns, save as CSV
+  recon results import-annotations example.com --csv triage.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconResultsImportAnnotations,
+}
+
+var reconResultsPushCmd = &cobra.Command{
+	Use:   "push <domain>",
+	Short: "Push findings into DefectDojo or Faraday",
+	Long: `Push the findings of a domain into a vulnerability management platform:
+takeover risks, dangling DNS records, misconfigurations from verify --checks,
+nuclei hits, and anomalies, gathered as in 'recon report'. Severities map to
+the platform's (info becomes Info in DefectDojo and informational in
+Faraday) and each finding's detail becomes its evidence.
+
+Targets (--to):
+  defectdojo - Imported into an engagement as a Generic Findings Import scan
+               through /api/v2/import-scan/
+  faraday    - Created in a workspace through the bulk_create API, grouped
+               by host
+
+Credentials come from config:
+  recon-cli config set defectdojo.url https://dojo.example.com
+  recon-cli config set defectdojo.api-key <key>
+  recon-cli config set defectdojo.engagement 12
+  recon-cli config set faraday.url https://faraday.example.com
+  recon-cli config set faraday.token <token>
+  recon-cli config set faraday.workspace bugbounty
+
+Examples:
+  recon results push example.com --to defectdojo
+  recon results push example.com --to defectdojo --engagement 14
+  recon results push example.com --to faraday --workspace example
+  recon results push example.com --to faraday --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconResultsPush,
+}
+
 var (
-	viewAliveOnly  bool
-	viewDeadOnly   bool
-	viewStatusCode int
-	viewSource     string
-	viewLimit      int
+	viewAliveOnly     bool
+	viewDeadOnly      bool
+	viewStatusCode    int
+	viewSource        string
+	viewMissingHeader string
+	viewFaviconHash   int32
+	viewFinalURL      string
+	viewCrossDomain   bool
+	viewLimit         int
+	historyJSON       bool
+	viewWithPaths     bool
+	viewGroupBy       string
+	viewTool          string
+	viewRecordType    string
+	viewTakeover      bool
+	viewDangling      bool
+	viewCloud         string
+	viewRaw           bool
+	viewPort          int
+	viewHost          string
+	viewExtension     string
+	viewParam         string
+	viewContains      string
+	viewTech          string
+	viewTitleContains string
+	viewTakeoverRisk  bool
+	viewIPCIDR        string
+	viewTag           string
+	viewSelection     recon.ResultSelection
 
 	exportFormat     string
 	exportAliveOnly  bool
@@ -75,6 +398,44 @@ var (
 	exportStatusCode int
 	exportSource     string
 	exportOutput     string
+	exportStatus     io.Writer = os.Stdout // Status messages of export; silenced for --output -
+	exportPiped      bool                  // --output -: the export is written to a temporary file and copied to stdout
+	exportTool       string
+	exportIncludeRaw bool
+	exportTemplate   string
+	exportExclude    []string
+	exportTech       string
+	exportTitle      string
+	exportCloud      string
+	exportTakeover   bool
+	exportIPCIDR     string
+	exportTag        string
+	exportWebhook    string
+	exportLatest     bool
+	exportGroupBy    string
+	exportTOC        bool
+	exportCollapse   bool
+	exportAllDomains bool
+	exportStamp      string // Timestamp of auto-generated export names, shared by one run
+	exportSelection  recon.ResultSelection
+
+	deleteOlderThan string
+	deleteKeepLast  int
+	deleteAll       bool
+	deleteYes       bool
+
+	archiveOutput string
+	restoreForce  bool
+
+	annotationsCSV string
+	tagAdd         []string
+	tagRemove      []string
+	noteClear      bool
+
+	pushTarget     string
+	pushEngagement string
+	pushWorkspace  string
+	pushDryRun     bool
 )
 
 func init() {
@@ -82,21 +443,104 @@ func init() {
 	reconResultsCmd.AddCommand(reconResultsListCmd)
 	reconResultsCmd.AddCommand(reconResultsViewCmd)
 	reconResultsCmd.AddCommand(reconResultsExportCmd)
+	reconResultsCmd.AddCommand(reconResultsHistoryCmd)
+	reconResultsCmd.AddCommand(reconResultsDeleteCmd)
+	reconResultsCmd.AddCommand(reconResultsCompactCmd)
+	reconResultsCmd.AddCommand(reconResultsArchiveCmd)
+	reconResultsCmd.AddCommand(reconResultsRestoreCmd)
+	reconResultsCmd.AddCommand(reconResultsTagCmd)
+	reconResultsCmd.AddCommand(reconResultsNoteCmd)
+	reconResultsCmd.AddCommand(reconResultsImportAnnotationsCmd)
+	reconResultsCmd.AddCommand(reconResultsPushCmd)
+
+	reconResultsHistoryCmd.Flags().BoolVar(&historyJSON, "json", false, "Output the timeline as JSON")
+
+	// Flags for delete command
+	reconResultsDeleteCmd.Flags().StringVar(&deleteOlderThan, "older-than", "", "Delete results older than this (e.g., 30d, 12h)")
+	reconResultsDeleteCmd.Flags().IntVar(&deleteKeepLast, "keep-last", 0, "Keep only the newest N results of each tool")
+	reconResultsDeleteCmd.Flags().BoolVar(&deleteAll, "all", false, "Delete every stored result for the domain")
+	reconResultsDeleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip the confirmation prompt")
+	reconResultsDeleteCmd.MarkFlagsMutuallyExclusive("older-than", "keep-last", "all")
+	reconResultsDeleteCmd.MarkFlagsOneRequired("older-than", "keep-last", "all")
 
 	// Flags for view command
 	reconResultsViewCmd.Flags().BoolVar(&viewAliveOnly, "alive-only", false, "Show only alive subdomains")
 	reconResultsViewCmd.Flags().BoolVar(&viewDeadOnly, "dead-only", false, "Show only dead subdomains")
 	reconResultsViewCmd.Flags().IntVar(&viewStatusCode, "status", 0, "Filter by HTTP status code")
 	reconResultsViewCmd.Flags().StringVar(&viewSource, "source", "", "Filter by discovery source")
+	reconResultsViewCmd.Flags().StringVar(&viewMissingHeader, "missing-header", "", "Show alive hosts missing a header (server, x-powered-by, csp, hsts, x-frame-options, x-content-type-options)")
+	reconResultsViewCmd.Flags().Int32Var(&viewFaviconHash, "favicon-hash", 0, "Filter by favicon hash (Shodan http.favicon.hash)")
+	reconResultsViewCmd.Flags().StringVar(&viewFinalURL, "final-url", "", "Filter by final URL after redirects (substring match)")
+	reconResultsViewCmd.Flags().BoolVar(&viewCrossDomain, "cross-domain", false, "Show only hosts that redirect to another domain")
+	reconResultsViewCmd.Flags().StringVar(&viewTech, "tech", "", "Show only hosts running a technology (e.g., wordpress)")
+	reconResultsViewCmd.Flags().StringVar(&viewTitleContains, "title-contains", "", "Show only hosts whose page title contains this text")
+	reconResultsViewCmd.Flags().BoolVar(&viewTakeoverRisk, "takeover-risk", false, "Show only subdomains flagged as takeover risks (needs DNS results)")
+	reconResultsViewCmd.Flags().StringVar(&viewIPCIDR, "ip-cidr", "", "Show only subdomains with an IP in this range (e.g., 10.0.0.0/8)")
+	reconResultsViewCmd.Flags().StringVar(&viewTag, "tag", "", "Show only subdomains with this tag")
+	reconResultsViewCmd.Flags().BoolVar(&viewWithPaths, "with-paths", false, "Show robots.txt disallowed paths and sitemap URL counts")
+	reconResultsViewCmd.Flags().StringVar(&viewGroupBy, "group-by", "", "Group subdomains by IP location or owner (country, provider)")
 	reconResultsViewCmd.Flags().IntVarP(&viewLimit, "limit", "n", 0, "Limit number of results shown (0 = all)")
+	reconResultsViewCmd.Flags().StringVar(&viewTool, "tool", "subdomains", "Results to view ("+strings.Join(viewTools, ", ")+")")
+	reconResultsViewCmd.Flags().StringVar(&viewRecordType, "type", "", "DNS: show only this record type (A, AAAA, CNAME, MX, TXT, NS, CAA, SOA, SRV, PTR)")
+	reconResultsViewCmd.Flags().BoolVar(&viewTakeover, "takeover", false, "DNS: show only subdomain takeover risks")
+	reconResultsViewCmd.Flags().BoolVar(&viewDangling, "dangling", false, "DNS: show only subdomains with dangling records")
+	reconResultsViewCmd.Flags().StringVar(&viewCloud, "cloud", "", "Show only subdomains hosted by this cloud provider (needs DNS results)")
+	reconResultsViewCmd.Flags().BoolVar(&viewRaw, "raw", false, "WHOIS: show the raw WHOIS response")
+	reconResultsViewCmd.Flags().IntVar(&viewPort, "port", 0, "Ports: show only this port")
+	reconResultsViewCmd.Flags().StringVar(&viewHost, "host", "", "URLs: show only URLs on this host")
+	reconResultsViewCmd.Flags().StringVar(&viewExtension, "ext", "", "URLs: show only URLs with this file extension (e.g., js)")
+	reconResultsViewCmd.Flags().StringVar(&viewParam, "param", "", "URLs: show only URLs with this query parameter")
+	reconResultsViewCmd.Flags().StringVar(&viewContains, "contains", "", "URLs: show only URLs containing this text")
+	addResultSelectionFlags(reconResultsViewCmd, &viewSelection, "result")
 
 	// Flags for export command
-	reconResultsExportCmd.Flags().StringVarP(&exportFormat, "format", "f", "csv", "Export format (csv, json, markdown)")
+	reconResultsExportCmd.Flags().StringVarP(&exportFormat, "format", "f", "csv", "Export format (csv, json, markdown, html, txt, urls, burp, aquatone, eyewitness, stix, iocs, nmap-targets, template)")
 	reconResultsExportCmd.Flags().BoolVar(&exportAliveOnly, "alive-only", false, "Export only alive subdomains")
 	reconResultsExportCmd.Flags().BoolVar(&exportDeadOnly, "dead-only", false, "Export only dead subdomains")
 	reconResultsExportCmd.Flags().IntVar(&exportStatusCode, "status", 0, "Filter by HTTP status code")
 	reconResultsExportCmd.Flags().StringVar(&exportSource, "source", "", "Filter by discovery source")
-	reconResultsExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (default: auto-generated)")
+	reconResultsExportCmd.Flags().StringVar(&exportTech, "tech", "", "Export only hosts running a technology (e.g., wordpress)")
+	reconResultsExportCmd.Flags().StringVar(&exportTitle, "title-contains", "", "Export only hosts whose page title contains this text")
+	reconResultsExportCmd.Flags().StringVar(&exportCloud, "cloud", "", "Export only subdomains hosted by this cloud provider (needs DNS results)")
+	reconResultsExportCmd.Flags().BoolVar(&exportTakeover, "takeover-risk", false, "Export only subdomains flagged as takeover risks (needs DNS results)")
+	reconResultsExportCmd.Flags().StringVar(&exportIPCIDR, "ip-cidr", "", "Export only subdomains with an IP in this range (e.g., 10.0.0.0/8)")
+	reconResultsExportCmd.Flags().StringVar(&exportTag, "tag", "", "Export only subdomains with this tag")
+	reconResultsExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path, or - for stdout (default: timestamped name in the exports directory)")
+	reconResultsExportCmd.Flags().StringVar(&exportTool, "tool", "subdomains", "Results to export (subdomains, whois; with --format template also dns, ipwhois, urls, nuclei)")
+	reconResultsExportCmd.Flags().StringVar(&exportTemplate, "template", "", "Go template file for --format template")
+	reconResultsExportCmd.Flags().StringSliceVar(&exportExclude, "exclude", nil, "Burp: hosts to exclude from scope (e.g., logout.example.com,*.corp.example.com)")
+	reconResultsExportCmd.Flags().StringVar(&exportWebhook, "webhook", "", "POST the results as JSON to this URL instead of writing a file")
+	reconResultsExportCmd.Flags().BoolVar(&exportIncludeRaw, "include-raw", false, "WHOIS: include the raw WHOIS response")
+	reconResultsExportCmd.Flags().StringVar(&exportGroupBy, "group-by", "", "Markdown: group subdomains by status, tech, source, or cloud")
+	reconResultsExportCmd.Flags().BoolVar(&exportTOC, "toc", false, "Markdown: add a table of contents")
+	reconResultsExportCmd.Flags().BoolVar(&exportCollapse, "collapsible", false, "Markdown: fold tables into collapsible sections")
+	reconResultsExportCmd.Flags().BoolVar(&exportLatest, "latest-symlink", false, "Point <domain>_<name>_latest.<ext> at the new export")
+	reconResultsExportCmd.Flags().BoolVar(&exportAllDomains, "all-domains", false, "Export the latest results of every domain")
+	addResultSelectionFlags(reconResultsExportCmd, &exportSelection, "result")
+
+	reconResultsArchiveCmd.Flags().StringVarP(&archiveOutput, "output", "o", "", "Archive file path (default: <domain>.tar.gz)")
+	reconResultsRestoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite files that already exist")
+
+	reconResultsImportAnnotationsCmd.Flags().StringVar(&annotationsCSV, "csv", "", "CSV file with Subdomain, Tags, and Notes columns")
+	reconResultsImportAnnotationsCmd.MarkFlagRequired("csv")
+
+	reconResultsTagCmd.Flags().StringSliceVar(&tagAdd, "add", nil, "Tags to add (e.g., interesting,login-page)")
+	reconResultsTagCmd.Flags().StringSliceVar(&tagRemove, "remove", nil, "Tags to remove")
+	reconResultsNoteCmd.Flags().BoolVar(&noteClear, "clear", false, "Remove the subdomain's notes")
+
+	reconResultsPushCmd.Flags().StringVar(&pushTarget, "to", "", "Platform to push to ("+strings.Join(export.PushTargets, ", ")+")")
+	reconResultsPushCmd.Flags().StringVar(&pushEngagement, "engagement", "", "DefectDojo engagement ID (default: defectdojo.engagement config)")
+	reconResultsPushCmd.Flags().StringVar(&pushWorkspace, "workspace", "", "Faraday workspace (default: faraday.workspace config)")
+	reconResultsPushCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "Print the request body instead of sending it")
+	reconResultsPushCmd.MarkFlagRequired("to")
+}
+
+// addResultSelectionFlags adds --file and --timestamp, which choose a stored
+// result other than the latest
+func addResultSelectionFlags(cmd *cobra.Command, selection *recon.ResultSelection, kind string) {
+	cmd.Flags().StringVar(&selection.File, "file", "", "Use this stored "+kind+" file instead of the latest")
+	cmd.Flags().StringVar(&selection.Timestamp, "timestamp", "", "Use the newest "+kind+" from this date or time (e.g., 2025-01-31, '2025-01-31 14:05')")
+	cmd.MarkFlagsMutuallyExclusive("file", "timestamp")
 }
 
 func runReconResultsList(cmd *cobra.Command, args []string) error {
@@ -112,6 +556,9 @@ func runReconResultsList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list results: %w", err)
 	}
 
+	if structuredOutput() {
+		return renderOutput(resultsByDomain)
+	}
 	if len(resultsByDomain) == 0 {
 		fmt.Println("No results found.")
 		fmt.Println("\nRun 'recon subdomain <domain>' to start collecting data.")
@@ -167,6 +614,12 @@ func listResultsForDomain(domain string) error {
 		return fmt.Errorf("failed to list results for %s: %w", domain, err)
 	}
 
+	if structuredOutput() {
+		if results == nil {
+			results = []recon.ResultInfo{}
+		}
+		return renderOutput(results)
+	}
 	if len(results) == 0 {
 		fmt.Printf("No results found for %s\n", domain)
 		fmt.Printf("\nRun 'recon subdomain %s' to start collecting data.\n", domain)
@@ -218,26 +671,69 @@ func listResultsForDomain(domain string) error {
 func runReconResultsView(cmd *cobra.Command, args []string) error {
 	domain := args[0]
 
+	if err := checkViewToolFlags(cmd); err != nil {
+		return err
+	}
+	defer statusToStderr()()
+
+	switch viewTool {
+	case "dns":
+		return viewDNSResults(domain)
+	case "whois":
+		return viewWhoisResults(domain)
+	case "ports":
+		return viewPortResults(domain)
+	case "urls":
+		return viewURLResults(domain)
+	}
+
+	if viewGroupBy != "" && viewGroupBy != recon.GroupByCountry && viewGroupBy != recon.GroupByProvider {
+		return fmt.Errorf("invalid --group-by: %s (must be: country or provider)", viewGroupBy)
+	}
+
 	// Build query options
 	options := recon.QueryOptions{
-		AliveOnly:  viewAliveOnly,
-		DeadOnly:   viewDeadOnly,
-		StatusCode: viewStatusCode,
-		Source:     viewSource,
+		AliveOnly:     viewAliveOnly,
+		DeadOnly:      viewDeadOnly,
+		StatusCode:    viewStatusCode,
+		Source:        viewSource,
+		MissingHeader: viewMissingHeader,
+		FinalURL:      viewFinalURL,
+		CrossDomain:   viewCrossDomain,
+		Tech:          viewTech,
+		TitleContains: viewTitleContains,
+		Cloud:         viewCloud,
+		TakeoverRisk:  viewTakeoverRisk,
+		Tag:           viewTag,
+	}
+	if cmd.Flags().Changed("favicon-hash") {
+		options.FaviconHash = &viewFaviconHash
+	}
+	if err := completeQueryOptions(domain, viewIPCIDR, &options); err != nil {
+		return err
 	}
 
 	// Load and filter subdomains
-	subdomains, err := recon.QuerySubdomains(domain, options)
+	var result recon.SubdomainResults
+	filePath, err := recon.LoadSelectedResult(domain, "subdomains", viewSelection, &result)
+	if err != nil {
+		return fmt.Errorf("failed to load results: %w", err)
+	}
+	subdomains, err := recon.FilterSubdomains(result.Subdomains, options)
 	if err != nil {
 		return fmt.Errorf("failed to load results: %w", err)
 	}
 
 	if len(subdomains) == 0 {
 		fmt.Printf("No results found for %s", domain)
-		if viewAliveOnly || viewDeadOnly || viewStatusCode != 0 || viewSource != "" {
+		if viewAliveOnly || viewDeadOnly || viewStatusCode != 0 || viewSource != "" || viewMissingHeader != "" || options.FaviconHash != nil ||
+			viewFinalURL != "" || viewCrossDomain || viewTech != "" || viewTitleContains != "" || viewCloud != "" || viewTakeoverRisk || viewIPCIDR != "" || viewTag != "" {
 			fmt.Print(" matching filters")
 		}
 		fmt.Println()
+		if structuredOutput() {
+			return renderOutput([]recon.Subdomain{})
+		}
 		return nil
 	}
 
@@ -247,19 +743,25 @@ func runReconResultsView(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if len(resultInfo) > 0 {
-		latest := resultInfo[0]
+	for _, info := range resultInfo {
+		if info.FilePath != filePath {
+			continue
+		}
 		fmt.Printf("Results for %s\n", domain)
-		fmt.Printf("Scanned: %s (%s)\n", latest.Timestamp.Format("2006-01-02 15:04:05"), formatTimeAgo(latest.Timestamp))
-		if len(latest.SourcesUsed) > 0 {
-			fmt.Printf("Sources: %s\n", strings.Join(latest.SourcesUsed, ", "))
+		fmt.Printf("Scanned: %s (%s)\n", info.Timestamp.Format("2006-01-02 15:04:05"), formatTimeAgo(info.Timestamp))
+		if !viewSelection.IsLatest() {
+			fmt.Printf("File: %s\n", filePath)
+		}
+		if len(info.SourcesUsed) > 0 {
+			fmt.Printf("Sources: %s\n", strings.Join(info.SourcesUsed, ", "))
 		}
-		fmt.Printf("Total: %d subdomains", latest.TotalCount)
-		if latest.Verified {
-			fmt.Printf(" (%d alive, %d dead)", latest.AliveCount, latest.DeadCount)
+		fmt.Printf("Total: %d subdomains", info.TotalCount)
+		if info.Verified {
+			fmt.Printf(" (%d alive, %d dead)", info.AliveCount, info.DeadCount)
 		}
 		fmt.Println()
 		fmt.Println()
+		break
 	}
 
 	// Apply limit
@@ -267,9 +769,6 @@ func runReconResultsView(cmd *cobra.Command, args []string) error {
 		subdomains = subdomains[:viewLimit]
 	}
 
-	// Display results
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
 	// Determine if we need verification columns
 	hasVerification := false
 	for _, sub := range subdomains {
@@ -279,70 +778,331 @@ func runReconResultsView(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Print header
-	if hasVerification {
-		fmt.Fprintln(w, "SUBDOMAIN\tSTATUS\tHTTP\tTITLE\tSOURCES")
-		fmt.Fprintln(w, "─────────\t──────\t────\t─────\t───────")
+	// Display results, optionally grouped by IP location or owner
+	if viewGroupBy != "" {
+		dnsResults, err := recon.LoadDNSResults(domain)
+		if err != nil || len(dnsResults.IPInfo) == 0 {
+			return fmt.Errorf("no GeoIP data for %s\nRun 'recon dns %s --geoip' first", domain, domain)
+		}
+		groups, err := dnsResults.GroupSubdomainsByIP(subdomains, viewGroupBy)
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return renderOutput(groups)
+		}
+		counts := make(map[string]int)
+		for key, subs := range groups {
+			counts[key] = len(subs)
+		}
+		for _, key := range recon.TopCounts(counts, 0) {
+			fmt.Printf("%s (%d)\n", key, counts[key])
+			printSubdomainTable(groups[key], hasVerification)
+			fmt.Println()
+		}
+	} else if structuredOutput() {
+		return renderOutput(subdomains)
 	} else {
-		fmt.Fprintln(w, "SUBDOMAIN\tSOURCES")
-		fmt.Fprintln(w, "─────────\t───────")
+		printSubdomainTable(subdomains, hasVerification)
 	}
 
-	// Print subdomains
-	for _, sub := range subdomains {
-		sources := strings.Join(sub.DiscoveredBy, ",")
+	// Show totals
+	fmt.Printf("\nShowing %d subdomain(s)", len(subdomains))
+	if viewLimit > 0 {
+		fmt.Printf(" (limited to %d)", viewLimit)
+	}
+	fmt.Println()
+
+	// Show next steps
+	if !hasVerification {
+		fmt.Printf("\nNext: Run 'recon verify %s' to check which subdomains are alive\n", domain)
+	}
 
-		if hasVerification && sub.Verified != nil {
-			status := sub.Verified.Status
+	return nil
+}
+
+// printSubdomainTable prints subdomains with verification columns when
+// available, and tag and note columns when any subdomain has them
+func printSubdomainTable(subdomains []recon.Subdomain, hasVerification bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	annotated := false
+	for _, sub := range subdomains {
+		if len(sub.Tags) > 0 || sub.Notes != "" {
+			annotated = true
+			break
+		}
+	}
 
-			httpInfo := "-"
-			title := "-"
+	// Print header
+	header := []string{"SUBDOMAIN"}
+	if hasVerification {
+		header = append(header, "STATUS", "HTTP", "TITLE")
+		if viewWithPaths {
+			header = append(header, "PATHS")
+		}
+	}
+	header = append(header, "SOURCES")
+	if annotated {
+		header = append(header, "TAGS", "NOTES")
+	}
+	underline := make([]string, len(header))
+	for i, column := range header {
+		underline[i] = strings.Repeat("─", len(column))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	fmt.Fprintln(w, strings.Join(underline, "\t"))
 
-			if sub.Verified.HTTP != nil && sub.Verified.HTTP.Accessible {
-				httpInfo = fmt.Sprintf("%d", sub.Verified.HTTP.StatusCode)
-				if sub.Verified.HTTP.Title != "" {
-					title = sub.Verified.HTTP.Title
-					// Truncate long titles
-					if len(title) > 40 {
-						title = title[:37] + "..."
+	// Print subdomains
+	for _, sub := range subdomains {
+		row := []string{sub.Name}
+
+		if hasVerification {
+			status, httpInfo, title := "-", "-", "-"
+			if sub.Verified != nil {
+				status = sub.Verified.Status
+				if sub.Verified.HTTP != nil && sub.Verified.HTTP.Accessible {
+					httpInfo = fmt.Sprintf("%d", sub.Verified.HTTP.StatusCode)
+					if sub.Verified.HTTP.Title != "" {
+						title = sub.Verified.HTTP.Title
+						// Truncate long titles
+						if len(title) > 40 {
+							title = title[:37] + "..."
+						}
 					}
 				}
 			}
+			row = append(row, status, httpInfo, title)
+			if viewWithPaths {
+				row = append(row, formatPaths(sub))
+			}
+		}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				sub.Name,
-				status,
-				httpInfo,
-				title,
-				sources,
-			)
-		} else {
-			fmt.Fprintf(w, "%s\t%s\n", sub.Name, sources)
+		row = append(row, strings.Join(sub.DiscoveredBy, ","))
+		if annotated {
+			tags, notes := "-", "-"
+			if len(sub.Tags) > 0 {
+				tags = strings.Join(sub.Tags, ",")
+			}
+			if sub.Notes != "" {
+				notes = strings.Join(strings.Fields(sub.Notes), " ")
+				if len(notes) > 40 {
+					notes = notes[:37] + "..."
+				}
+			}
+			row = append(row, tags, notes)
 		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 
 	w.Flush()
+}
 
-	// Show totals
-	fmt.Printf("\nShowing %d subdomain(s)", len(subdomains))
-	if viewLimit > 0 {
-		fmt.Printf(" (limited to %d)", viewLimit)
+// formatPaths summarizes a subdomain's robots.txt, sitemap, and security.txt
+// data for the PATHS column
+func formatPaths(sub recon.Subdomain) string {
+	var parts []string
+
+	if disallow := sub.MetadataStrings(recon.MetadataRobotsDisallow); len(disallow) > 0 {
+		shown := disallow
+		if len(shown) > 3 {
+			shown = shown[:3]
+		}
+		part := strings.Join(shown, ",")
+		if len(disallow) > len(shown) {
+			part += fmt.Sprintf(" (+%d)", len(disallow)-len(shown))
+		}
+		parts = append(parts, part)
 	}
-	fmt.Println()
 
-	// Show next steps
-	if !hasVerification {
-		fmt.Printf("\nNext: Run 'recon verify %s' to check which subdomains are alive\n", domain)
+	if sitemap := sub.MetadataStrings(recon.MetadataSitemapURLs); len(sitemap) > 0 {
+		parts = append(parts, fmt.Sprintf("%d sitemap URLs", len(sitemap)))
 	}
 
-	return nil
+	if len(sub.MetadataStrings(recon.MetadataSecurityTxt)) > 0 {
+		parts = append(parts, "security.txt")
+	}
+
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, "; ")
 }
 
 func runReconResultsExport(cmd *cobra.Command, args []string) error {
-	domain := args[0]
+	exportStamp = time.Now().Format("20060102_150405")
+	if exportLatest && (exportOutput != "" || exportWebhook != "") {
+		return fmt.Errorf("--latest-symlink applies to auto-generated export names, not --output or --webhook")
+	}
+	if exportAllDomains {
+		return exportAllDomainResults(cmd)
+	}
+	if exportWebhook != "" {
+		return exportToWebhook(cmd, args[0])
+	}
+	if exportOutput == "-" {
+		return exportToStdout(func() error { return exportResults(cmd, args[0]) })
+	}
+	return exportResults(cmd, args[0])
+}
+
+// exportAllDomainResults exports the latest --tool results of every domain
+// that has them, continuing past domains that fail
+func exportAllDomainResults(cmd *cobra.Command) error {
+	for _, name := range []string{"output", "webhook", "file", "timestamp"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s does not apply to --all-domains", name)
+		}
+	}
+
+	resultsByDomain, err := recon.ListResults()
+	if err != nil {
+		return fmt.Errorf("failed to list results: %w", err)
+	}
+
+	var domains []string
+	for domain, results := range resultsByDomain {
+		for _, result := range results {
+			if result.ToolName == exportTool {
+				domains = append(domains, domain)
+				break
+			}
+		}
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("no domains have %s results", exportTool)
+	}
+	sort.Strings(domains)
+
+	var failed []string
+	for i, domain := range domains {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("[%d/%d] %s\n", i+1, len(domains), domain)
+		if err := exportResults(cmd, domain); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			failed = append(failed, domain)
+		}
+	}
+
+	fmt.Printf("\n✓ Exported %d of %d domain(s)\n", len(domains)-len(failed), len(domains))
+	if len(failed) > 0 {
+		return fmt.Errorf("export failed for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// defaultExportPath returns the auto-generated path of an export in the
+// exports directory: <domain>_<name>_<timestamp>.<ext>
+func defaultExportPath(domain, name, extension string) (string, error) {
+	exportsDir, err := export.GetExportsDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get exports directory: %w", err)
+	}
+	return filepath.Join(exportsDir, fmt.Sprintf("%s_%s_%s.%s", domain, name, exportStamp, extension)), nil
+}
+
+// linkLatestExports points the _latest symlink of each auto-generated export
+// file at it, with --latest-symlink
+func linkLatestExports(filePaths ...string) error {
+	if !exportLatest {
+		return nil
+	}
+	for _, filePath := range filePaths {
+		name := filepath.Base(filePath)
+		linkName := strings.Replace(name, "_"+exportStamp, "_latest", 1)
+		if linkName == name {
+			continue
+		}
+		linkPath := filepath.Join(filepath.Dir(filePath), linkName)
+
+		// Replace an earlier link, but never a regular file
+		if info, err := os.Lstat(linkPath); err == nil {
+			if info.Mode()&os.ModeSymlink == 0 {
+				return fmt.Errorf("%s exists and is not a symlink", linkPath)
+			}
+			if err := os.Remove(linkPath); err != nil {
+				return fmt.Errorf("failed to replace %s: %w", linkPath, err)
+			}
+		}
+		// A relative target keeps the link valid when the directory moves
+		if err := os.Symlink(name, linkPath); err != nil {
+			return fmt.Errorf("failed to create symlink: %w", err)
+		}
+		fmt.Fprintf(exportStatus, "Latest: %s\n", linkPath)
+	}
+	return nil
+}
+
+// exportToStdout runs an export into a temporary file and copies the file to
+// stdout, with status messages silenced so only the export reaches stdout
+func exportToStdout(run func() error) error {
+	tmpFile, err := os.CreateTemp("", "recon-export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	exportOutput, exportStatus, exportPiped = tmpPath, io.Discard, true
+	defer func() { exportOutput, exportStatus, exportPiped = "-", os.Stdout, false }()
+
+	if err := run(); err != nil {
+		return err
+	}
+
+	file, err := os.Open(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(os.Stdout, file); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// exportResults exports the latest or selected results of a domain
+func exportResults(cmd *cobra.Command, domain string) error {
+	if strings.EqualFold(exportFormat, string(export.FormatTemplate)) {
+		return exportWithTemplate(cmd, domain)
+	}
+	if exportTemplate != "" {
+		return fmt.Errorf("--template applies to --format template")
+	}
+
+	switch exportTool {
+	case "subdomains":
+		if exportIncludeRaw {
+			return fmt.Errorf("--include-raw applies to --tool whois")
+		}
+	case "whois":
+		for _, names := range [][]string{exportFilterFlags, {"group-by", "toc", "collapsible"}} {
+			for _, name := range names {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("--%s applies to --tool subdomains, not whois", name)
+				}
+			}
+		}
+		if !containsFold([]string{"csv", "json", "markdown", "md"}, exportFormat) {
+			return fmt.Errorf("unsupported WHOIS format: %s (supported: csv, json, markdown)", exportFormat)
+		}
+	default:
+		return fmt.Errorf("invalid --tool: %s (must be: subdomains, whois)", exportTool)
+	}
 
-	// Load latest subdomain results
-	result, err := recon.GetLatestSubdomainResult(domain)
+	// Load the latest or selected results
+	var result *recon.SubdomainResults
+	var whoisResults recon.WhoisResults
+	var err error
+	if exportTool == "whois" {
+		_, err = recon.LoadSelectedResult(domain, "whois", exportSelection, &whoisResults)
+	} else {
+		result, err = recon.GetSubdomainResult(domain, exportSelection)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to load results for %s: %w", domain, err)
 	}
@@ -356,31 +1116,83 @@ func runReconResultsExport(cmd *cobra.Command, args []string) error {
 		format = export.FormatJSON
 	case "markdown", "md":
 		format = export.FormatMarkdown
+	case "html":
+		format = export.FormatHTML
+	case "txt":
+		format = export.FormatHosts
+	case "urls":
+		format = export.FormatURLs
+	case "burp":
+		format = export.FormatBurp
+	case "aquatone":
+		format = export.FormatAquatone
+	case "eyewitness", "gowitness":
+		format = export.FormatEyeWitness
+	case "stix":
+		format = export.FormatSTIX
+	case "iocs", "ioc-csv":
+		format = export.FormatIOCs
+	case "nmap-targets", "nmap":
+		format = export.FormatNmap
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: csv, json, markdown, html, txt, urls, burp, aquatone, eyewitness, stix, iocs, nmap-targets)", exportFormat)
+	}
+	if len(exportExclude) > 0 && format != export.FormatBurp {
+		return fmt.Errorf("--exclude applies to --format burp")
+	}
+	if format != export.FormatMarkdown {
+		for _, name := range []string{"group-by", "toc", "collapsible"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s applies to --format markdown", name)
+			}
+		}
+	}
+	switch exportGroupBy {
+	case "", recon.GroupByStatus, recon.GroupByTech, recon.GroupBySource, recon.GroupByCloud:
 	default:
-		return fmt.Errorf("unsupported format: %s (supported: csv, json, markdown)", exportFormat)
+		return fmt.Errorf("invalid --group-by: %s (must be: status, tech, source, cloud)", exportGroupBy)
 	}
 
 	// Build output path
 	outputPath := exportOutput
 	if outputPath == "" {
-		exportsDir, err := export.GetExportsDir()
-		if err != nil {
-			return fmt.Errorf("failed to get exports directory: %w", err)
-		}
-
 		// Generate filename
 		var extension string
 		switch format {
-		case export.FormatCSV:
+		case export.FormatCSV, export.FormatIOCs:
 			extension = "csv"
-		case export.FormatJSON:
+		case export.FormatJSON, export.FormatBurp, export.FormatSTIX:
 			extension = "json"
 		case export.FormatMarkdown:
 			extension = "md"
+		case export.FormatHTML:
+			extension = "html"
+		case export.FormatHosts, export.FormatURLs, export.FormatAquatone, export.FormatEyeWitness, export.FormatNmap:
+			extension = "txt"
 		}
 
-		filename := fmt.Sprintf("%s_subdomains.%s", domain, extension)
-		outputPath = filepath.Join(exportsDir, filename)
+		name := exportTool
+		switch format {
+		case export.FormatHosts:
+			name = "hosts"
+		case export.FormatURLs:
+			name = "urls"
+		case export.FormatBurp:
+			name = "burp"
+		case export.FormatAquatone:
+			name = "aquatone"
+		case export.FormatEyeWitness:
+			name = "eyewitness"
+		case export.FormatSTIX:
+			name = "stix"
+		case export.FormatIOCs:
+			name = "iocs"
+		case export.FormatNmap:
+			name = "targets"
+		}
+		if outputPath, err = defaultExportPath(domain, name, extension); err != nil {
+			return err
+		}
 	} else {
 		// Expand home directory if present
 		if strings.HasPrefix(outputPath, "~/") {
@@ -415,12 +1227,34 @@ func runReconResultsExport(cmd *cobra.Command, args []string) error {
 
 	// Build export options with all filters
 	options := export.ExportOptions{
-		Format:     format,
-		OutputPath: outputPath,
-		AliveOnly:  exportAliveOnly,
-		DeadOnly:   exportDeadOnly,
-		StatusCode: exportStatusCode,
-		Source:     exportSource,
+		QueryOptions: exportQueryOptions(),
+		Format:       format,
+		OutputPath:   outputPath,
+		IncludeRaw:   exportIncludeRaw,
+		Exclude:      exportExclude,
+		GroupBy:      exportGroupBy,
+		TOC:          exportTOC,
+		Collapsible:  exportCollapse,
+	}
+	if exportTool == "subdomains" {
+		if err := completeQueryOptions(domain, exportIPCIDR, &options.QueryOptions); err != nil {
+			return err
+		}
+	}
+	if exportGroupBy == recon.GroupByCloud && options.DNS == nil {
+		dnsResults, err := recon.LoadDNSResults(domain)
+		if err != nil {
+			return fmt.Errorf("--group-by cloud needs DNS results for %s\nRun 'recon dns %s' first", domain, domain)
+		}
+		options.DNS = dnsResults
+	}
+
+	if exportTool == "whois" {
+		return exportWhoisResults(&whoisResults, options)
+	}
+
+	if format == export.FormatNmap {
+		return exportNmapTargets(domain, result, options)
 	}
 
 	// Export based on format
@@ -432,6 +1266,36 @@ func runReconResultsExport(cmd *cobra.Command, args []string) error {
 		filePath, err = export.ExportToJSON(result, options)
 	case export.FormatMarkdown:
 		filePath, err = export.ExportToMarkdown(result, options)
+	case export.FormatHTML:
+		// Takeover findings come from the latest DNS results, when present
+		var dnsResults *recon.DNSResults
+		if loaded, err := recon.LoadDNSResults(domain); err == nil {
+			dnsResults = loaded
+		}
+		filePath, err = export.ExportToHTML(result, dnsResults, options)
+	case export.FormatHosts:
+		filePath, err = export.ExportToHostList(result, options)
+	case export.FormatURLs:
+		filePath, err = export.ExportToURLList(result, options)
+	case export.FormatBurp:
+		filePath, err = export.ExportToBurp(result, options)
+	case export.FormatAquatone:
+		filePath, err = export.ExportToAquatone(result, options)
+	case export.FormatEyeWitness:
+		filePath, err = export.ExportToEyeWitness(result, options)
+	case export.FormatSTIX, export.FormatIOCs:
+		// IPs and CNAMEs are completed from the latest DNS results, when present
+		dnsResults := options.DNS
+		if dnsResults == nil {
+			if loaded, err := recon.LoadDNSResults(domain); err == nil {
+				dnsResults = loaded
+			}
+		}
+		if format == export.FormatSTIX {
+			filePath, err = export.ExportToSTIX(result, dnsResults, options)
+		} else {
+			filePath, err = export.ExportToIOCCSV(result, dnsResults, options)
+		}
 	default:
 		return fmt.Errorf("format not implemented: %s", format)
 	}
@@ -446,23 +1310,19 @@ func runReconResultsExport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	// Apply filters to count exported subdomains
-	queryOptions := recon.QueryOptions{
-		AliveOnly:  exportAliveOnly,
-		DeadOnly:   exportDeadOnly,
-		StatusCode: exportStatusCode,
-		Source:     exportSource,
-	}
-	filtered, err := recon.QuerySubdomains(domain, queryOptions)
-	if err != nil {
-		return fmt.Errorf("failed to count filtered subdomains: %w", err)
+	// Apply filters to count exported subdomains; web target lists and
+	// scopes only hold alive hosts
+	countOptions := options.QueryOptions
+	switch format {
+	case export.FormatURLs, export.FormatBurp, export.FormatAquatone, export.FormatEyeWitness:
+		countOptions.AliveOnly = true
 	}
-	exportedCount := len(filtered)
+	exportedCount := len(recon.MatchSubdomains(result.Subdomains, countOptions))
 
 	// Display success message
-	fmt.Printf("✓ Exported %d subdomain(s) to %s\n", exportedCount, strings.ToUpper(string(format)))
-	fmt.Printf("File: %s\n", filePath)
-	fmt.Printf("Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+	fmt.Fprintf(exportStatus, "✓ Exported %d subdomain(s) to %s\n", exportedCount, strings.ToUpper(string(format)))
+	fmt.Fprintf(exportStatus, "File: %s\n", filePath)
+	fmt.Fprintf(exportStatus, "Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
 
 	// Show active filters
 	var filters []string
@@ -478,10 +1338,741 @@ func runReconResultsExport(cmd *cobra.Command, args []string) error {
 	if exportSource != "" {
 		filters = append(filters, fmt.Sprintf("source=%s", exportSource))
 	}
+	if exportTech != "" {
+		filters = append(filters, fmt.Sprintf("tech=%s", exportTech))
+	}
+	if exportTitle != "" {
+		filters = append(filters, fmt.Sprintf("title contains %q", exportTitle))
+	}
+	if exportCloud != "" {
+		filters = append(filters, fmt.Sprintf("cloud=%s", exportCloud))
+	}
+	if exportTakeover {
+		filters = append(filters, "takeover risk")
+	}
+	if exportIPCIDR != "" {
+		filters = append(filters, fmt.Sprintf("ip in %s", exportIPCIDR))
+	}
+	if exportTag != "" {
+		filters = append(filters, fmt.Sprintf("tag=%s", exportTag))
+	}
 
 	if len(filters) > 0 {
-		fmt.Printf("Filters: %s\n", strings.Join(filters, ", "))
+		fmt.Fprintf(exportStatus, "Filters: %s\n", strings.Join(filters, ", "))
+	}
+
+	return linkLatestExports(filePath)
+}
+
+// exportFilterFlags are the export flags that filter subdomains
+var exportFilterFlags = []string{"alive-only", "dead-only", "status", "source", "tech", "title-contains", "cloud", "takeover-risk", "ip-cidr", "tag"}
+
+// exportQueryOptions returns the subdomain filters of the export flags
+func exportQueryOptions() recon.QueryOptions {
+	return recon.QueryOptions{
+		AliveOnly:     exportAliveOnly,
+		DeadOnly:      exportDeadOnly,
+		StatusCode:    exportStatusCode,
+		Source:        exportSource,
+		Tech:          exportTech,
+		TitleContains: exportTitle,
+		Cloud:         exportCloud,
+		TakeoverRisk:  exportTakeover,
+		Tag:           exportTag,
+	}
+}
+
+// completeQueryOptions parses an --ip-cidr range into query options and
+// loads the DNS results the cloud and takeover filters match against
+func completeQueryOptions(domain, ipCIDR string, options *recon.QueryOptions) error {
+	if ipCIDR != "" {
+		_, network, err := net.ParseCIDR(ipCIDR)
+		if err != nil {
+			return fmt.Errorf("invalid --ip-cidr: %s", ipCIDR)
+		}
+		options.IPCIDR = network
+	}
+	if options.Cloud != "" || options.TakeoverRisk || options.IPCIDR != nil {
+		if dnsResults, err := recon.LoadDNSResults(domain); err == nil {
+			options.DNS = dnsResults
+		} else if options.Cloud != "" || options.TakeoverRisk {
+			return fmt.Errorf("--cloud and --takeover-risk need DNS results for %s\nRun 'recon dns %s' first", domain, domain)
+		}
+	}
+	return options.Validate()
+}
+
+// templateTools are the results --format template can render, with the
+// type each is loaded into
+var templateTools = map[string]func() any{
+	"subdomains": func() any { return &recon.SubdomainResults{} },
+	"dns":        func() any { return &recon.DNSResults{} },
+	"whois":      func() any { return &recon.WhoisResults{} },
+	"ipwhois":    func() any { return &recon.IPWhoisResults{} },
+	"urls":       func() any { return &recon.URLResults{} },
+	"nuclei":     func() any { return &recon.NucleiResults{} },
+}
+
+// checkTemplateToolFlags rejects an unknown --tool of the template and
+// webhook exports, which take any tool of templateTools, and filters that
+// don't apply to the chosen tool
+func checkTemplateToolFlags(cmd *cobra.Command) error {
+	if _, ok := templateTools[exportTool]; !ok {
+		return fmt.Errorf("invalid --tool: %s (must be: subdomains, dns, whois, ipwhois, urls, nuclei)", exportTool)
+	}
+	if exportTool != "subdomains" {
+		for _, name := range exportFilterFlags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s applies to --tool subdomains, not %s", name, exportTool)
+			}
+		}
+	}
+	if exportIncludeRaw && exportTool != "whois" && exportTool != "ipwhois" {
+		return fmt.Errorf("--include-raw applies to --tool whois or ipwhois")
+	}
+	return nil
+}
+
+// exportToWebhook POSTs the latest or selected results of --tool as JSON to
+// the --webhook URL, signed when a webhook secret is configured
+func exportToWebhook(cmd *cobra.Command, domain string) error {
+	for _, name := range []string{"output", "template", "exclude"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s does not apply to --webhook", name)
+		}
+	}
+	if cmd.Flags().Changed("format") && !strings.EqualFold(exportFormat, "json") {
+		return fmt.Errorf("--webhook always sends JSON")
+	}
+	if err := checkTemplateToolFlags(cmd); err != nil {
+		return err
+	}
+	if err := export.ValidateWebhookURL(exportWebhook); err != nil {
+		return err
+	}
+
+	data := templateTools[exportTool]()
+	if _, err := recon.LoadSelectedResult(domain, exportTool, exportSelection, data); err != nil {
+		return fmt.Errorf("failed to load %s results for %s: %w", exportTool, domain, err)
+	}
+
+	options := export.ExportOptions{
+		QueryOptions: exportQueryOptions(),
+		Format:       export.FormatJSON,
+		IncludeRaw:   exportIncludeRaw,
+	}
+	if exportTool == "subdomains" {
+		if err := completeQueryOptions(domain, exportIPCIDR, &options.QueryOptions); err != nil {
+			return err
+		}
+	}
+
+	// The secret comes from config or the environment, never a flag, to keep
+	// it out of shell history
+	secret := os.Getenv("RECON_WEBHOOK_SECRET")
+	if cfg != nil && cfg.WebhookSecret != "" {
+		secret = cfg.WebhookSecret
+	}
+
+	result, err := export.ExportToWebhook(data, options, export.WebhookOptions{
+		URL:    exportWebhook,
+		Secret: secret,
+	})
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fmt.Printf("✓ Sent %s results for %s to %s\n", exportTool, domain, exportWebhook)
+	fmt.Printf("Response: HTTP %d\n", result.StatusCode)
+	fmt.Printf("Size: %s\n", recon.FormatFileSize(int64(result.Bytes)))
+	if result.Signed {
+		fmt.Printf("Signature: %s header (HMAC-SHA256)\n", export.WebhookSignatureHeader)
+	}
+
+	return nil
+}
+
+// exportWithTemplate renders the latest or selected results of --tool with
+// the --template file
+func exportWithTemplate(cmd *cobra.Command, domain string) error {
+	if exportTemplate == "" {
+		return fmt.Errorf("--format template requires --template <file>")
+	}
+	if err := checkTemplateToolFlags(cmd); err != nil {
+		return err
+	}
+
+	templatePath := exportTemplate
+	if strings.HasPrefix(templatePath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		templatePath = filepath.Join(homeDir, templatePath[2:])
+	}
+
+	data := templateTools[exportTool]()
+	if _, err := recon.LoadSelectedResult(domain, exportTool, exportSelection, data); err != nil {
+		return fmt.Errorf("failed to load %s results for %s: %w", exportTool, domain, err)
+	}
+
+	outputPath := exportOutput
+	if outputPath == "" {
+		var err error
+		if outputPath, err = defaultExportPath(domain, exportTool, export.TemplateExtension(templatePath)); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(outputPath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		outputPath = filepath.Join(homeDir, outputPath[2:])
+	}
+
+	options := export.ExportOptions{
+		QueryOptions: exportQueryOptions(),
+		Format:       export.FormatTemplate,
+		OutputPath:   outputPath,
+		IncludeRaw:   exportIncludeRaw,
+	}
+	if exportTool == "subdomains" {
+		if err := completeQueryOptions(domain, exportIPCIDR, &options.QueryOptions); err != nil {
+			return err
+		}
+	}
+
+	filePath, err := export.ExportToTemplate(data, templatePath, options)
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fmt.Fprintf(exportStatus, "✓ Exported %s results for %s with %s\n", exportTool, domain, filepath.Base(templatePath))
+	fmt.Fprintf(exportStatus, "File: %s\n", filePath)
+	fmt.Fprintf(exportStatus, "Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+
+	return linkLatestExports(filePath)
+}
+
+// exportWhoisResults writes WHOIS results and reports the exported file
+func exportWhoisResults(results *recon.WhoisResults, options export.ExportOptions) error {
+	filePath, err := export.ExportWhois(results, options)
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fmt.Fprintf(exportStatus, "✓ Exported WHOIS for %s to %s\n", results.Domain, strings.ToUpper(string(options.Format)))
+	fmt.Fprintf(exportStatus, "File: %s\n", filePath)
+	fmt.Fprintf(exportStatus, "Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+	if options.IncludeRaw {
+		fmt.Fprintln(exportStatus, "Includes: raw WHOIS response")
+	}
+
+	return linkLatestExports(filePath)
+}
+
+// exportNmapTargets writes port-scan target lists from the latest DNS results
+func exportNmapTargets(domain string, result *recon.SubdomainResults, options export.ExportOptions) error {
+	dnsResults, err := recon.LoadDNSResults(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load DNS results for %s: %w\nRun 'recon dns %s' first", domain, err, domain)
+	}
+
+	// A pipe takes one list, so the IPs are followed by the hostnames
+	if exportPiped {
+		if _, err := export.ExportToTargetList(result, dnsResults, options); err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+		return nil
+	}
+
+	targets, err := export.ExportToNmapTargets(result, dnsResults, options)
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	ipCount := 0
+	for _, block := range targets.Blocks {
+		ipCount += len(block.IPs)
+	}
+
+	fmt.Printf("✓ Exported %d IP(s) in %d block(s) from %d host(s)\n", ipCount, len(targets.Blocks), len(targets.Hosts))
+	fmt.Printf("IPs:   %s\n", targets.IPsPath)
+	fmt.Printf("Hosts: %s\n", targets.HostsPath)
+	fmt.Printf("CIDRs: %s\n", targets.CIDRsPath)
+
+	if len(targets.Blocks) > 0 {
+		fmt.Println("\nBlocks:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for i, block := range targets.Blocks {
+			if i >= 10 {
+				fmt.Fprintf(w, "  ... and %d more\n", len(targets.Blocks)-10)
+				break
+			}
+			fmt.Fprintf(w, "  %s\t%d IP(s)\n", block.CIDR, len(block.IPs))
+		}
+		w.Flush()
+	}
+
+	if err := linkLatestExports(targets.IPsPath, targets.HostsPath, targets.CIDRsPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nExample: nmap -iL %s\n", targets.IPsPath)
+
+	return nil
+}
+
+func runReconResultsHistory(cmd *cobra.Command, args []string) error {
+	domain, subdomain := args[0], args[1]
+
+	timeline, err := recon.AssetHistory(domain, subdomain)
+	if err != nil {
+		return err
+	}
+
+	if historyJSON {
+		data, err := json.MarshalIndent(timeline, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("History for %s\n", timeline.Subdomain)
+	fmt.Printf("  First seen: %s\n", timeline.FirstSeen.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Last seen:  %s\n", timeline.LastSeen.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Sources:    %s\n", strings.Join(timeline.Sources, ", "))
+	fmt.Printf("  Scans:      %d\n\n", len(timeline.Observations))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  TIME\tSCAN\tOBSERVED\t")
+	previous := make(map[string]string)
+	for _, observation := range timeline.Observations {
+		detail := describeObservation(observation)
+		marker := ""
+		if last, ok := previous[observation.Tool]; ok && last != detail {
+			marker = "*"
+		}
+		previous[observation.Tool] = detail
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", observation.Timestamp.Format("2006-01-02 15:04"), observation.Tool, detail, marker)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// describeObservation summarizes what one scan recorded about a subdomain
+func describeObservation(observation recon.AssetObservation) string {
+	if !observation.Found {
+		return "not listed"
+	}
+
+	var parts []string
+	if observation.Status != "" {
+		status := observation.Status
+		if observation.StatusCode != 0 {
+			status += fmt.Sprintf(" [%d]", observation.StatusCode)
+		}
+		if observation.Title != "" {
+			status += fmt.Sprintf(" %q", observation.Title)
+		}
+		parts = append(parts, status)
+	} else if observation.Tool == "subdomains" {
+		parts = append(parts, "unverified")
+	}
+	if len(observation.CNAME) > 0 {
+		parts = append(parts, "CNAME "+strings.Join(observation.CNAME, ", "))
+	}
+	if len(observation.IPs) > 0 {
+		parts = append(parts, strings.Join(observation.IPs, ", "))
+	}
+	if len(observation.Sources) > 0 {
+		parts = append(parts, "via "+strings.Join(observation.Sources, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func runReconResultsDelete(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	results, err := recon.ListResultsForDomain(domain)
+	if err != nil {
+		return fmt.Errorf("failed to list results: %w", err)
+	}
+	if len(results) == 0 {
+		fmt.Printf("No results found for %s\n", domain)
+		return nil
+	}
+
+	if deleteAll {
+		if !deleteYes {
+			confirmed, err := ui.Confirm(fmt.Sprintf("Delete all %d stored results for %s?", len(results), domain))
+			if err != nil {
+				return fmt.Errorf("confirmation failed: %w", err)
+			}
+			if !confirmed {
+				fmt.Println("Deletion cancelled.")
+				return nil
+			}
+		}
+		if err := recon.DeleteDomainResults(domain); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Deleted all results for %s\n", domain)
+		return nil
+	}
+
+	var policy recon.RetentionPolicy
+	if deleteOlderThan != "" {
+		maxAge, err := config.ParseDayDuration(deleteOlderThan)
+		if err != nil || maxAge <= 0 {
+			return fmt.Errorf("invalid --older-than value: %s (use: 30d, 12h, etc.)", deleteOlderThan)
+		}
+		policy.MaxAge = maxAge
+	} else {
+		if deleteKeepLast < 1 {
+			return fmt.Errorf("--keep-last must be at least 1 (use --all to delete everything)")
+		}
+		policy.KeepLast = deleteKeepLast
+	}
+
+	expired := recon.ExpiredResults(results, policy, time.Now())
+	if len(expired) == 0 {
+		fmt.Printf("No results to delete for %s\n", domain)
+		return nil
+	}
+
+	var totalSize int64
+	fmt.Printf("Results to delete for %s:\n", domain)
+	for _, result := range expired {
+		totalSize += result.FileSize
+		fmt.Printf("  %s  %s  [%s]\n",
+			result.Timestamp.Format("2006-01-02 15:04:05"),
+			result.ToolName,
+			recon.FormatFileSize(result.FileSize),
+		)
+	}
+	fmt.Println()
+
+	if !deleteYes {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Delete %d of %d results (%s)?", len(expired), len(results), recon.FormatFileSize(totalSize)))
+		if err != nil {
+			return fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Deletion cancelled.")
+			return nil
+		}
+	}
+
+	deleted, err := recon.DeleteResults(expired)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Deleted %d results (%d remaining)\n", deleted, len(results)-deleted)
+	return nil
+}
+
+func runReconResultsCompact(cmd *cobra.Command, args []string) error {
+	domain := ""
+	if len(args) == 1 {
+		domain = args[0]
+		if err := recon.ValidateDomain(domain); err != nil {
+			return fmt.Errorf("invalid domain: %w", err)
+		}
+	}
+
+	summary, err := recon.CompactResults(domain)
+	if err != nil {
+		if summary != nil && summary.Files > 0 {
+			fmt.Printf("Compressed %d files before the error\n", summary.Files)
+		}
+		return fmt.Errorf("failed to compact results: %w", err)
+	}
+
+	if summary.Files == 0 {
+		fmt.Println("No uncompressed results found.")
+		return nil
+	}
+
+	saved := summary.BytesBefore - summary.BytesAfter
+	fmt.Printf("✓ Compressed %d files: %s → %s (saved %s)\n",
+		summary.Files,
+		recon.FormatFileSize(summary.BytesBefore),
+		recon.FormatFileSize(summary.BytesAfter),
+		recon.FormatFileSize(saved),
+	)
+	return nil
+}
+
+func runReconResultsArchive(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	outputPath := archiveOutput
+	if outputPath == "" {
+		outputPath = domain + ".tar.gz"
+	} else if strings.HasPrefix(outputPath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		outputPath = filepath.Join(homeDir, outputPath[2:])
+	}
+
+	summary, err := recon.ArchiveDomain(domain, outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", domain, err)
+	}
+
+	fileInfo, err := os.Stat(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	fmt.Printf("✓ Archived %d files for %s (%s)\n", summary.Files, domain, recon.FormatFileSize(summary.Bytes))
+	fmt.Printf("File: %s\n", outputPath)
+	fmt.Printf("Size: %s\n", recon.FormatFileSize(fileInfo.Size()))
+	if summary.Sealed > 0 {
+		fmt.Printf("\n%d files are sealed by the vault; restoring them elsewhere requires the same vault key.\n", summary.Sealed)
+	}
+
+	return nil
+}
+
+func runReconResultsRestore(cmd *cobra.Command, args []string) error {
+	summary, err := recon.RestoreArchive(args[0], restoreForce)
+	if err != nil {
+		if summary != nil && summary.Files > 0 {
+			fmt.Printf("Restored %d files before the error\n", summary.Files)
+		}
+		return fmt.Errorf("failed to restore archive: %w", err)
+	}
+
+	fmt.Printf("✓ Restored %d files for %s (%s), archived %s\n",
+		summary.Files,
+		summary.Domain,
+		recon.FormatFileSize(summary.Bytes),
+		summary.CreatedAt.Format("2006-01-02 15:04:05"),
+	)
+	if summary.Sealed > 0 {
+		fmt.Printf("\n%d files are sealed by the vault and need the vault key they were sealed with.\n", summary.Sealed)
+	}
+	fmt.Printf("\nView them with 'recon results list %s'\n", summary.Domain)
+
+	return nil
+}
+
+func runReconResultsTag(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	sub, err := findLatestSubdomain(cmd, domain, args[1])
+	if err != nil {
+		return err
+	}
+	if len(tagAdd) == 0 && len(tagRemove) == 0 {
+		if len(sub.Tags) == 0 {
+			fmt.Printf("%s has no tags\n", sub.Name)
+			fmt.Printf("  Add: recon-cli recon results tag %s %s --add interesting\n", domain, sub.Name)
+			return nil
+		}
+		fmt.Printf("%s: %s\n", sub.Name, strings.Join(sub.Tags, ", "))
+		return nil
+	}
+
+	annotation, err := recon.AnnotateSubdomain(domain, sub.Name, tagAdd, tagRemove, nil)
+	if err != nil {
+		return err
+	}
+	if len(annotation.Tags) == 0 {
+		fmt.Printf("✓ %s has no tags\n", sub.Name)
+		return nil
+	}
+	fmt.Printf("✓ Tagged %s: %s\n", sub.Name, strings.Join(annotation.Tags, ", "))
+	return nil
+}
+
+func runReconResultsNote(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if len(args) == 3 && noteClear {
+		return fmt.Errorf("give either a note or --clear, not both")
+	}
+	sub, err := findLatestSubdomain(cmd, domain, args[1])
+	if err != nil {
+		return err
+	}
+	if len(args) == 2 && !noteClear {
+		if sub.Notes == "" {
+			fmt.Printf("%s has no notes\n", sub.Name)
+			return nil
+		}
+		fmt.Println(sub.Notes)
+		return nil
+	}
+
+	notes := ""
+	if len(args) == 3 {
+		notes = args[2]
+		if strings.TrimSpace(notes) == "" {
+			return fmt.Errorf("note is empty (use --clear to remove the notes)")
+		}
+	}
+	if _, err := recon.AnnotateSubdomain(domain, sub.Name, nil, nil, &notes); err != nil {
+		return err
+	}
+	if notes == "" {
+		fmt.Printf("✓ Cleared the notes of %s\n", sub.Name)
+	} else {
+		fmt.Printf("✓ Saved the notes of %s\n", sub.Name)
+	}
+	return nil
+}
+
+// findLatestSubdomain returns a subdomain of the latest results of a domain,
+// with its annotations
+func findLatestSubdomain(cmd *cobra.Command, domain, subdomain string) (*recon.Subdomain, error) {
+	results, err := recon.GetLatestSubdomainResult(domain)
+	if err != nil {
+		return nil, fmt.Errorf("no subdomain results for %s: %w", domain, err)
+	}
+	name := strings.TrimSuffix(subdomain, ".")
+	for i := range results.Subdomains {
+		if strings.EqualFold(results.Subdomains[i].Name, name) {
+			return &results.Subdomains[i], nil
+		}
+	}
+	cmd.SilenceUsage = true
+	return nil, fmt.Errorf("%s is not in the subdomain results of %s", subdomain, domain)
+}
+
+func runReconResultsImportAnnotations(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	csvPath := annotationsCSV
+	if strings.HasPrefix(csvPath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		csvPath = filepath.Join(homeDir, csvPath[2:])
+	}
+
+	annotations, err := recon.ParseAnnotationsCSV(csvPath)
+	if err != nil {
+		return err
+	}
+
+	summary, err := recon.ImportAnnotations(domain, annotations)
+	if err != nil {
+		return fmt.Errorf("failed to import annotations: %w", err)
+	}
+
+	if summary.Updated > 0 {
+		fmt.Printf("✓ Updated tags or notes of %d subdomain(s) for %s\n", summary.Updated, domain)
+		fmt.Printf("Saved to: %s\n", summary.FilePath)
+	} else {
+		fmt.Printf("✓ No changes: annotations for %s are already up to date\n", domain)
+	}
+	fmt.Printf("Rows: %d (%d unchanged)\n", summary.Rows, summary.Unchanged)
+
+	if len(summary.Unknown) > 0 {
+		fmt.Printf("\nSkipped %d row(s) for subdomains not in the latest results:\n", len(summary.Unknown))
+		for i, name := range summary.Unknown {
+			if i == 10 {
+				fmt.Printf("  ... and %d more\n", len(summary.Unknown)-10)
+				break
+			}
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+func runReconResultsPush(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	target := strings.ToLower(pushTarget)
+	if !containsFold(export.PushTargets, target) {
+		return fmt.Errorf("invalid --to: %s (must be: %s)", pushTarget, strings.Join(export.PushTargets, ", "))
+	}
+	if pushEngagement != "" && target != export.PushDefectDojo {
+		return fmt.Errorf("--engagement applies to --to defectdojo")
+	}
+	if pushWorkspace != "" && target != export.PushFaraday {
+		return fmt.Errorf("--workspace applies to --to faraday")
+	}
+
+	report, err := recon.BuildReport(domain)
+	if err != nil {
+		return fmt.Errorf("%w\nRun 'recon subdomain %s' first", err, domain)
+	}
+
+	if pushDryRun {
+		payload, err := export.PushPayload(report, target)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(payload))
+		return nil
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Printf("No findings to push for %s\n", domain)
+		return nil
+	}
+
+	options := export.PushOptions{
+		Engagement: pushEngagement,
+		Workspace:  pushWorkspace,
+	}
+	if cfg != nil {
+		switch target {
+		case export.PushDefectDojo:
+			options.URL, options.Token = cfg.DefectDojo.URL, cfg.DefectDojo.APIKey
+			if options.Engagement == "" {
+				options.Engagement = cfg.DefectDojo.Engagement
+			}
+		case export.PushFaraday:
+			options.URL, options.Token = cfg.Faraday.URL, cfg.Faraday.Token
+			if options.Workspace == "" {
+				options.Workspace = cfg.Faraday.Workspace
+			}
+		}
+	}
+	if options.URL == "" {
+		return fmt.Errorf("no %s URL configured\nRun 'recon-cli config set %s.url <url>'", target, target)
+	}
+
+	result, err := export.PushFindings(report, target, options)
+	if err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+
+	fmt.Printf("✓ Pushed %d finding(s) for %s to %s\n", result.Findings, domain, target)
+	for _, severity := range recon.ReportSeverities {
+		if count := report.Summary.Severities[severity]; count > 0 {
+			fmt.Printf("  %-8s %d\n", severity+":", count)
+		}
 	}
+	fmt.Printf("View: %s\n", result.Location)
 
 	return nil
 }
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// viewTools are the result types 'recon results view --tool' can render
+var viewTools = []string{"subdomains", "dns", "whois", "ports", "urls"}
+
+// viewToolFlags lists the filter flags each tool accepts besides --tool,
+// --limit, --file, and --timestamp
+var viewToolFlags = map[string][]string{
+	"subdomains": {"alive-only", "dead-only", "status", "source", "missing-header", "favicon-hash", "final-url", "cross-domain", "tech", "title-contains", "cloud", "takeover-risk", "ip-cidr", "with-paths", "group-by"},
+	"dns":        {"type", "takeover", "dangling", "cloud"},
+	"whois":      {"raw"},
+	"ports":      {"port", "alive-only", "status"},
+	"urls":       {"host", "ext", "param", "contains"},
+}
+
+// checkViewToolFlags rejects an unknown --tool and filters that don't apply
+// to the chosen tool
+func checkViewToolFlags(cmd *cobra.Command) error {
+	allowed, ok := viewToolFlags[viewTool]
+	if !ok {
+		return fmt.Errorf("invalid --tool: %s (must be: %s)", viewTool, strings.Join(viewTools, ", "))
+	}
+
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if err != nil || containsFold([]string{"tool", "limit", "file", "timestamp"}, flag.Name) || containsFold(allowed, flag.Name) {
+			return
+		}
+		if tool := viewFlagTool(flag.Name); tool != "" {
+			err = fmt.Errorf("--%s applies to --tool %s, not %s", flag.Name, tool, viewTool)
+		}
+	})
+	return err
+}
+
+// viewFlagTool returns the first tool accepting a view filter flag
+func viewFlagTool(name string) string {
+	for _, tool := range viewTools {
+		if containsFold(viewToolFlags[tool], name) {
+			return tool
+		}
+	}
+	return ""
+}
+
+// limitView truncates n items to --limit, returning the count to show
+func limitView(n int) int {
+	if viewLimit > 0 && n > viewLimit {
+		return viewLimit
+	}
+	return n
+}
+
+// printViewTotal prints the shown count of a view
+func printViewTotal(shown int, noun string) {
+	fmt.Printf("\nShowing %d %s", shown, noun)
+	if viewLimit > 0 {
+		fmt.Printf(" (limited to %d)", viewLimit)
+	}
+	fmt.Println()
+}
+
+func viewDNSResults(domain string) error {
+	var results recon.DNSResults
+	if _, err := recon.LoadSelectedResult(domain, "dns", viewSelection, &results); err != nil {
+		if !viewSelection.IsLatest() {
+			return err
+		}
+		return fmt.Errorf("no DNS results for %s\nRun 'recon dns %s' first", domain, domain)
+	}
+
+	allTypes := append(append([]string{}, recon.DefaultDNSRecordTypes...), "SRV")
+	recordType := strings.ToUpper(viewRecordType)
+	if recordType != "" && !containsFold(allTypes, recordType) {
+		return fmt.Errorf("invalid --type: %s (must be: %s)", viewRecordType, strings.Join(allTypes, ", "))
+	}
+
+	records := recon.QueryDNSRecords(&results, recon.DNSQueryOptions{
+		RecordType:    recordType,
+		TakeoverOnly:  viewTakeover,
+		DanglingOnly:  viewDangling,
+		CloudProvider: viewCloud,
+	})
+
+	fmt.Printf("DNS results for %s\n", domain)
+	fmt.Printf("Enumerated: %s (%s)\n", results.EnumeratedAt.Format("2006-01-02 15:04:05"), formatTimeAgo(results.EnumeratedAt))
+	fmt.Printf("Subdomains queried: %d, unique IPs: %d\n\n", results.TotalQueried, results.Summary.UniqueIPs)
+
+	if len(records) == 0 {
+		fmt.Println("No DNS records match the filters")
+		if structuredOutput() {
+			return renderOutput([]recon.DNSInfo{})
+		}
+		return nil
+	}
+
+	shown := limitView(len(records))
+	if structuredOutput() {
+		return renderOutput(records[:shown])
+	}
+
+	types := allTypes
+	if recordType != "" {
+		types = []string{recordType}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "SUBDOMAIN\tTYPE\tVALUE\tCLOUD\tRISK")
+	fmt.Fprintln(w, "─────────\t────\t─────\t─────\t────")
+	for _, info := range records[:shown] {
+		risk := "-"
+		switch {
+		case info.TakeoverRisk:
+			risk = fmt.Sprintf("takeover %d/10", info.TakeoverScore)
+		case len(info.Dangling) > 0:
+			risk = "dangling"
+		}
+
+		first := true
+		for _, recordTypeName := range types {
+			for _, value := range info.Values(recordTypeName) {
+				name, cloud, rowRisk := "", "", ""
+				if first {
+					name, cloud, rowRisk = info.Subdomain, valueOrDash(info.CloudProvider), risk
+					first = false
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, recordTypeName, value, cloud, rowRisk)
+			}
+		}
+		if first {
+			fmt.Fprintf(w, "%s\t-\t%s\t%s\t%s\n", info.Subdomain, valueOrDash(info.Error), valueOrDash(info.CloudProvider), risk)
+		}
+	}
+	w.Flush()
+
+	printViewTotal(shown, "subdomain(s)")
+	return nil
+}
+
+func viewWhoisResults(domain string) error {
+	var results recon.WhoisResults
+	_, whoisErr := recon.LoadSelectedResult(domain, "whois", viewSelection, &results)
+	if whoisErr != nil && !viewSelection.IsLatest() {
+		return whoisErr
+	}
+
+	// IP lookups are shown alongside the latest WHOIS only
+	var ipResults recon.IPWhoisResults
+	hasIPs := false
+	if viewSelection.IsLatest() {
+		hasIPs = recon.LoadLatestResult(domain, "ipwhois", &ipResults) == nil && len(ipResults.IPs) > 0
+	}
+
+	if whoisErr != nil && !hasIPs {
+		return fmt.Errorf("no WHOIS results for %s\nRun 'recon whois %s' first", domain, domain)
+	}
+
+	if structuredOutput() {
+		var view struct {
+			WHOIS   *recon.WhoisInfo  `json:"whois,omitempty"`
+			IPWhois []recon.WhoisInfo `json:"ip_whois,omitempty"`
+		}
+		if whoisErr == nil {
+			view.WHOIS = &results.Info
+		}
+		if hasIPs {
+			view.IPWhois = ipResults.IPs[:limitView(len(ipResults.IPs))]
+		}
+		return renderOutput(view)
+	}
+
+	if whoisErr == nil {
+		fmt.Printf("WHOIS for %s\n", domain)
+		fmt.Printf("Looked up: %s (%s)\n\n", results.LookedUpAt.Format("2006-01-02 15:04:05"), formatTimeAgo(results.LookedUpAt))
+		if viewRaw {
+			fmt.Println(results.Info.RawOutput)
+		} else {
+			fmt.Print(recon.FormatWhoisInfo(&results.Info))
+		}
+	}
+
+	if hasIPs {
+		if whoisErr == nil {
+			fmt.Println()
+		}
+		fmt.Printf("IP WHOIS (%s)\n\n", formatTimeAgo(ipResults.LookedUpAt))
+
+		shown := limitView(len(ipResults.IPs))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "IP\tNETBLOCK\tORGANIZATION\tCOUNTRY\tABUSE CONTACT")
+		fmt.Fprintln(w, "──\t────────\t────────────\t───────\t─────────────")
+		for _, info := range ipResults.IPs[:shown] {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				info.Domain,
+				valueOrDash(info.Netblock),
+				valueOrDash(info.Organization),
+				valueOrDash(info.Country),
+				valueOrDash(info.AbuseEmail),
+			)
+		}
+		w.Flush()
+
+		printViewTotal(shown, "IP(s)")
+	}
+
+	return nil
+}
+
+func viewPortResults(domain string) error {
+	result, err := recon.GetSubdomainResult(domain, viewSelection)
+	if err != nil {
+		return fmt.Errorf("failed to load results: %w", err)
+	}
+
+	ports := recon.QueryPorts(result, recon.PortQueryOptions{
+		Port:           viewPort,
+		AccessibleOnly: viewAliveOnly,
+		StatusCode:     viewStatusCode,
+	})
+
+	if len(ports) == 0 {
+		fmt.Printf("No probed ports found for %s\n", domain)
+		fmt.Printf("\nRun 'recon verify %s --ports 8080,8443' to probe additional web ports\n", domain)
+		if structuredOutput() {
+			return renderOutput([]recon.PortResult{})
+		}
+		return nil
+	}
+
+	shown := limitView(len(ports))
+	if structuredOutput() {
+		return renderOutput(ports[:shown])
+	}
+
+	fmt.Printf("Web ports for %s\n\n", domain)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "SUBDOMAIN\tPORT\tHTTP\tTITLE\tURL")
+	fmt.Fprintln(w, "─────────\t────\t────\t─────\t───")
+	for _, port := range ports[:shown] {
+		status := "-"
+		if port.HTTP.Accessible {
+			status = fmt.Sprintf("%d", port.HTTP.StatusCode)
+		} else if port.HTTP.Error != "" {
+			status = "error"
+		}
+
+		title := valueOrDash(port.HTTP.Title)
+		if len(title) > 40 {
+			title = title[:37] + "..."
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", port.Subdomain, port.Port, status, title, port.HTTP.URL)
+	}
+	w.Flush()
+
+	printViewTotal(shown, "port(s)")
+	return nil
+}
+
+func viewURLResults(domain string) error {
+	var results recon.URLResults
+	if _, err := recon.LoadSelectedResult(domain, "urls", viewSelection, &results); err != nil {
+		if !viewSelection.IsLatest() {
+			return err
+		}
+		return fmt.Errorf("no URL results for %s\nRun 'recon urls %s' first", domain, domain)
+	}
+
+	urls := recon.QueryURLs(&results, recon.URLQueryOptions{
+		Host:      viewHost,
+		Extension: viewExtension,
+		Param:     viewParam,
+		Contains:  viewContains,
+	})
+
+	fmt.Printf("URLs for %s\n", domain)
+	fmt.Printf("Collected: %s (%s)\n", results.Timestamp.Format("2006-01-02 15:04:05"), formatTimeAgo(results.Timestamp))
+	if len(results.SourcesUsed) > 0 {
+		fmt.Printf("Sources: %s\n", strings.Join(results.SourcesUsed, ", "))
+	}
+	fmt.Printf("Total: %d URLs across %d hosts\n\n", results.TotalUnique, len(results.Hosts))
+
+	if len(urls) == 0 {
+		fmt.Println("No URLs match the filters")
+		if structuredOutput() {
+			return renderOutput([]string{})
+		}
+		return nil
+	}
+
+	shown := limitView(len(urls))
+	if structuredOutput() {
+		return renderOutput(urls[:shown])
+	}
+	for _, url := range urls[:shown] {
+		fmt.Println(url)
+	}
+
+	printViewTotal(shown, "URL(s)")
+	return nil
+}
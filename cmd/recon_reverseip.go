@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconReverseIPCmd = &cobra.Command{
+	Use:   "reverseip <domain>",
+	Short: "Find co-hosted domains on the target's IPs",
+	Long: `Find other names hosted on the unique IPs from the latest DNS results.
+
+Each IP is looked up via PTR records and the HackerTarget passive DNS API
+(free tier: 50 queries per day; disable with --no-passive). Co-hosted names
+under the target domain are flagged as in-scope hits, and names missing from
+the DNS results are reported as new subdomains. IPs with many unrelated
+neighbors are flagged as likely shared hosting, where attacking the IP
+directly would be out of scope.
+
+Requires DNS data from 'recon dns <domain>'.
+
+Results are saved to ~/.recon-cli/results/<domain>/reverseip_<timestamp>.json
+
+Examples:
+  recon reverseip example.com
+  recon reverseip example.com --no-passive
+  recon reverseip example.com --shared-threshold 25`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconReverseIP,
+}
+
+var (
+	reverseIPConcurrency     int
+	reverseIPTimeout         time.Duration
+	reverseIPNoPassive       bool
+	reverseIPSharedThreshold int
+)
+
+func init() {
+	reconCmd.AddCommand(reconReverseIPCmd)
+
+	reconReverseIPCmd.Flags().IntVar(&reverseIPConcurrency, "concurrency", 5, "Number of IPs looked up at once")
+	reconReverseIPCmd.Flags().DurationVar(&reverseIPTimeout, "timeout", 5*time.Second, "Timeout per PTR lookup")
+	reconReverseIPCmd.Flags().BoolVar(&reverseIPNoPassive, "no-passive", false, "Only use PTR records (skip passive DNS)")
+	reconReverseIPCmd.Flags().IntVar(&reverseIPSharedThreshold, "shared-threshold", 10, "Neighbor count at which an IP is flagged as shared hosting")
+}
+
+func runReconReverseIP(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	resolvers, err := resolveReconResolvers()
+	if err != nil {
+		return err
+	}
+
+	dnsResults, err := recon.LoadDNSResults(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load DNS results for %s: %w\nRun 'recon dns %s' first", domain, err, domain)
+	}
+
+	fmt.Printf("Looking up co-hosted domains for %s (%d unique IPs)\n", domain, dnsResults.Summary.UniqueIPs)
+	if reverseIPNoPassive {
+		fmt.Println("Sources: PTR")
+	} else {
+		fmt.Println("Sources: PTR, HackerTarget")
+	}
+	if proxyURL != nil && !reverseIPNoPassive {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
+	fmt.Println()
+
+	options := recon.ReverseIPOptions{
+		Concurrency:     reverseIPConcurrency,
+		Timeout:         reverseIPTimeout,
+		Passive:         !reverseIPNoPassive,
+		SharedThreshold: reverseIPSharedThreshold,
+		Proxy:           proxyURL,
+		Resolvers:       resolvers,
+	}
+
+	startTime := time.Now()
+	results, err := recon.ReverseIPLookup(context.Background(), dnsResults, options)
+	if err != nil {
+		return fmt.Errorf("reverse IP lookup failed: %w", err)
+	}
+	duration := time.Since(startTime)
+	printResolverStats(resolvers)
+
+	filePath, err := recon.SaveResults(domain, "reverseip", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+
+	fmt.Println("Summary:")
+	fmt.Printf("  IPs queried: %d\n", results.Summary.UniqueIPs)
+	fmt.Printf("  PTR records: %d\n", results.Summary.PTRRecords)
+	fmt.Printf("  In-scope hits: %d (%d new)\n", results.Summary.InScopeHits, results.Summary.NewSubdomains)
+	fmt.Printf("  Neighbors: %d\n", results.Summary.Neighbors)
+	fmt.Printf("  Duration: %s\n", duration.Round(time.Second))
+	fmt.Printf("\nSaved to: %s\n", filePath)
+
+	fmt.Println("\nKey Findings:")
+	if len(results.NewSubdomains) > 0 {
+		fmt.Printf("  🎯 New in-scope subdomains: %d\n", len(results.NewSubdomains))
+		printFindingLines(results.NewSubdomains)
+	} else {
+		fmt.Println("  ✓ No new in-scope subdomains found")
+	}
+
+	if results.Summary.SharedHostingIPs > 0 {
+		fmt.Printf("  🏘️  Likely shared hosting: %d IPs\n", results.Summary.SharedHostingIPs)
+		var lines []string
+		for _, entry := range results.Entries {
+			if entry.SharedHosting {
+				lines = append(lines, fmt.Sprintf("%s (%d neighbors, serves %s)", entry.IP, len(entry.Neighbors), strings.Join(entry.Subdomains, ", ")))
+			}
+		}
+		printFindingLines(lines)
+	}
+
+	failed := 0
+	for _, entry := range results.Entries {
+		if entry.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("\nWarning: passive DNS failed for %d IPs (see JSON results)\n", failed)
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "reverseip",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d IPs, %d in-scope hits, %d neighbors", results.Summary.UniqueIPs, results.Summary.InScopeHits, results.Summary.Neighbors),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
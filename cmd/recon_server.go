@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// dashboardProbeTimeout bounds the dashboard's health check, so an
+// unreachable server doesn't hold it up
+const dashboardProbeTimeout = 3 * time.Second
+
+var reconServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Check the Recontronic server",
+	Long: `Check the Recontronic server the CLI is configured for.
+
+Available subcommands:
+  status - Probe the REST and gRPC endpoints and validate the API key`,
+}
+
+var reconServerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Probe the REST and gRPC endpoints and validate the API key",
+	Long: `Probe the configured server: the REST API's health endpoint with its
+latency, API version, and feature flags; the API key; and the gRPC health
+service used by streaming commands.
+
+Each check is a single attempt, without the configured retries. The command
+fails when any check does, so scripts can use it as a readiness check.
+
+Examples:
+  recon server status
+  recon server status --timeout 2s`,
+	Args: cobra.NoArgs,
+	RunE: runReconServerStatus,
+}
+
+var serverStatusTimeout time.Duration
+
+func init() {
+	reconCmd.AddCommand(reconServerCmd)
+	reconServerCmd.AddCommand(reconServerStatusCmd)
+
+	reconServerStatusCmd.Flags().DurationVar(&serverStatusTimeout, "timeout", 5*time.Second, "Time to wait for each check")
+}
+
+func runReconServerStatus(cmd *cobra.Command, args []string) error {
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+	if serverStatusTimeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+
+	apiKey := cfg.APIKey
+	if cfg.APIKeyLocked() {
+		apiKey = ""
+	}
+	restClient, err := newRestClient(apiKey)
+	if err != nil {
+		return err
+	}
+	restClient.SetRetryPolicy(client.RetryPolicy{})
+
+	failed := 0
+
+	fmt.Printf("REST API:     %s\n", cfg.Server)
+	ctx, cancel := context.WithTimeout(context.Background(), serverStatusTimeout)
+	start := time.Now()
+	health, err := restClient.Health(ctx)
+	latency := time.Since(start)
+	cancel()
+	if err != nil {
+		fmt.Printf("  Status:     ✗ %v\n", err)
+		failed++
+	} else {
+		fmt.Printf("  Status:     %s %s (%s)\n", healthIcon(health.Status), valueOrDash(health.Status), formatLatency(latency))
+		version := valueOrDash(health.APIVersion)
+		if health.Version != "" {
+			version += fmt.Sprintf(" (server %s)", health.Version)
+		}
+		fmt.Printf("  Version:    %s\n", version)
+		enabled, disabled := featureFlags(health.Features)
+		fmt.Printf("  Features:   %s\n", valueOrDash(strings.Join(enabled, ", ")))
+		if len(disabled) > 0 {
+			fmt.Printf("  Disabled:   %s\n", strings.Join(disabled, ", "))
+		}
+		if health.Status != "ok" {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case cfg.APIKeyLocked():
+		fmt.Println("API key:      - encrypted; run 'recon vault unlock' to check it")
+	case cfg.APIKey == "":
+		fmt.Println("API key:      - not configured; run 'recon-cli auth login'")
+	case health == nil:
+		fmt.Println("API key:      - not checked, the REST API is unreachable")
+	default:
+		ctx, cancel := context.WithTimeout(context.Background(), serverStatusTimeout)
+		user, err := restClient.GetCurrentUser(ctx)
+		cancel()
+		switch {
+		case client.IsAuthError(err):
+			fmt.Println("API key:      ✗ invalid or expired; run 'recon-cli auth login'")
+			failed++
+		case err != nil:
+			fmt.Printf("API key:      ✗ %v\n", err)
+			failed++
+		default:
+			fmt.Printf("API key:      ✓ valid (%s)\n", user.Username)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("gRPC:         %s\n", cfg.GRPCServer)
+	grpcStatus, latency, err := checkGRPCHealth(apiKey)
+	if err != nil {
+		fmt.Printf("  Status:     ✗ %v\n", err)
+		failed++
+	} else {
+		icon := "✓"
+		if grpcStatus != "SERVING" {
+			icon = "✗"
+			failed++
+		}
+		fmt.Printf("  Status:     %s %s (%s)\n", icon, grpcStatus, formatLatency(latency))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d server check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkGRPCHealth asks the gRPC server's health service for its status,
+// returning it with the round trip
+func checkGRPCHealth(apiKey string) (string, time.Duration, error) {
+	grpcClient, err := newGRPCClient(apiKey)
+	if err != nil {
+		return "", 0, err
+	}
+	defer grpcClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverStatusTimeout)
+	defer cancel()
+	start := time.Now()
+	status, err := grpcClient.CheckHealth(ctx)
+	return status, time.Since(start), err
+}
+
+// probeServer checks the REST API's health for the dashboard
+func probeServer() ui.ServerCheck {
+	restClient, err := newRestClient("")
+	if err != nil {
+		return ui.ServerCheck{}
+	}
+	restClient.SetRetryPolicy(client.RetryPolicy{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), dashboardProbeTimeout)
+	defer cancel()
+	start := time.Now()
+	if _, err := restClient.Health(ctx); err != nil {
+		return ui.ServerCheck{}
+	}
+	return ui.ServerCheck{Connected: true, Latency: time.Since(start)}
+}
+
+// healthIcon marks a server health status
+func healthIcon(status string) string {
+	if status == "ok" {
+		return "✓"
+	}
+	return "✗"
+}
+
+// formatLatency formats a round trip in milliseconds
+func formatLatency(latency time.Duration) string {
+	return fmt.Sprintf("%dms", latency.Milliseconds())
+}
+
+// featureFlags splits the server's feature flags into the enabled and the
+// disabled ones, each sorted
+func featureFlags(features map[string]bool) ([]string, []string) {
+	var enabled, disabled []string
+	for name, on := range features {
+		if on {
+			enabled = append(enabled, name)
+		} else {
+			disabled = append(disabled, name)
+		}
+	}
+	slices.Sort(enabled)
+	slices.Sort(disabled)
+	return enabled, disabled
+}
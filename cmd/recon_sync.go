@@ -0,0 +1,520 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/spf13/cobra"
+)
+
+var reconSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync local results to the Recontronic server",
+	Long: `Upload locally gathered results to the Recontronic server, so scans run
+on a laptop show up in the platform next to its own.
+
+Available subcommands:
+  push  - Upload a domain's subdomain (with verification) and DNS results
+  pull  - Download the server's results of a domain or program
+  flush - Replay the operations queued while the server was unreachable
+
+Results can also be pushed automatically after every scan:
+  recon-cli config set auto-sync true
+
+When the server can't be reached, pushes are queued locally and replayed
+by 'recon sync flush', or automatically by the next sync that gets through.`,
+}
+
+var reconSyncPushCmd = &cobra.Command{
+	Use:   "push <domain>",
+	Short: "Upload a domain's results to the server",
+	Long: `Upload every stored subdomain and DNS result of a domain that the server
+doesn't have yet, oldest first. Subdomain results carry the verification
+data of 'recon verify'. Files are sent in 1 MB chunks.
+
+What has been pushed is tracked per server in the domain's results
+directory, so later pushes only send new or changed results. When the
+server already holds a different version of a result, it is reported as a
+conflict and skipped; --force replaces the server's copy.
+
+Requires an API key ('recon-cli auth login').
+
+Examples:
+  recon sync push example.com
+  recon sync push example.com --dry-run
+  recon sync push example.com --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconSyncPush,
+}
+
+var reconSyncPullCmd = &cobra.Command{
+	Use:   "pull <program|domain>",
+	Short: "Download the server's results into local storage",
+	Long: `Download the results the server holds, e.g., from the platform's
+continuous scanning, into ~/.recon-cli/results, so 'recon results view',
+'recon diff', exports, and every other command work on them like on local
+scans. An argument with a dot is a domain; anything else is a program
+name, which pulls the results of every domain in the program.
+
+Results already stored locally (same tool and timestamp) are skipped, and
+pulled results are marked as synced so 'recon sync push' doesn't send them
+back. Downloads are checked against the server's SHA-256 checksum.
+
+Examples:
+  recon sync pull example.com
+  recon sync pull acme-bugbounty
+  recon sync pull example.com --tool dns
+  recon sync pull acme-bugbounty --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconSyncPull,
+}
+
+var reconSyncFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Replay the operations queued while the server was unreachable",
+	Long: `Replay the server operations queued while the server couldn't be
+reached, oldest first, e.g., pushes from field work on a flaky network.
+Operations that succeed leave the queue; the others stay queued with their
+error. Flushing stops early when the server is still unreachable.
+
+The queue is also flushed after any 'recon sync' command gets through.
+
+Examples:
+  recon sync flush
+  recon sync flush --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runReconSyncFlush,
+}
+
+var (
+	syncDryRun bool
+	syncForce  bool
+	syncTool   string
+)
+
+func init() {
+	reconCmd.AddCommand(reconSyncCmd)
+	reconSyncCmd.AddCommand(reconSyncPushCmd)
+	reconSyncCmd.AddCommand(reconSyncPullCmd)
+	reconSyncCmd.AddCommand(reconSyncFlushCmd)
+
+	reconSyncPushCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "List the results that would be pushed without uploading")
+	reconSyncPushCmd.Flags().BoolVar(&syncForce, "force", false, "Overwrite results the server holds a different version of")
+
+	reconSyncPullCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "List the results that would be pulled without downloading")
+	reconSyncPullCmd.Flags().StringVar(&syncTool, "tool", "", "Only pull results of this tool (e.g., subdomains, dns)")
+
+	reconSyncFlushCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "List the queued operations without replaying them")
+}
+
+// syncSummary counts the outcome of a push
+type syncSummary struct {
+	Pushed    int
+	UpToDate  int
+	Conflicts int
+}
+
+func runReconSyncPush(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	fmt.Printf("Syncing %s to %s\n", domain, cfg.Server)
+	summary, err := pushDomainResults(ctx, restClient, domain, syncForce, syncDryRun, os.Stdout)
+	if err != nil {
+		if client.IsAuthError(err) {
+			return fmt.Errorf("authentication failed: your API key may be invalid or expired\nPlease run 'recon-cli auth login' to get a new key")
+		}
+		if !syncDryRun && client.IsUnreachableError(err) {
+			return queueSyncPush(domain, syncForce, err)
+		}
+		return err
+	}
+	if syncDryRun {
+		fmt.Printf("\n%d result(s) would be pushed, %d up to date\n", summary.Pushed, summary.UpToDate)
+		return nil
+	}
+	fmt.Printf("\n✓ Pushed %d result(s), %d up to date\n", summary.Pushed, summary.UpToDate)
+	flushQueuedOperations(ctx, restClient)
+	if summary.Conflicts > 0 {
+		return fmt.Errorf("%d result(s) conflict with the server's copy; rerun with --force to overwrite", summary.Conflicts)
+	}
+	return nil
+}
+
+// syncClient returns a client for the configured server and API key
+func syncClient() (*client.RestClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration not loaded")
+	}
+	if cfg.APIKeyLocked() {
+		return nil, fmt.Errorf("the API key is encrypted and the vault is locked\nRun 'recon vault unlock' first")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("not authenticated: please run 'recon-cli auth login' first")
+	}
+
+	return newRestClient(cfg.APIKey)
+}
+
+// pushDomainResults uploads the results of a domain the server doesn't have
+// yet, writing a line per result to out, and records what was pushed. It
+// stops at the first failed upload other than a conflict.
+func pushDomainResults(ctx context.Context, restClient *client.RestClient, domain string, force, dryRun bool, out io.Writer) (syncSummary, error) {
+	var summary syncSummary
+
+	candidates, err := recon.SyncCandidates(domain)
+	if err != nil {
+		return summary, fmt.Errorf("failed to list results for %s: %w", domain, err)
+	}
+	if len(candidates) == 0 {
+		return summary, fmt.Errorf("no subdomain or DNS results for %s", domain)
+	}
+
+	state, err := recon.LoadSyncState(domain)
+	if err != nil {
+		return summary, err
+	}
+
+	var pushErr error
+	for _, result := range candidates {
+		name := filepath.Base(result.FilePath)
+		data, err := recon.ReadResultFile(result.FilePath)
+		if err != nil {
+			pushErr = fmt.Errorf("failed to read %s: %w", name, err)
+			break
+		}
+		if state.Pushed(cfg.Server, result.FilePath, client.ResultChecksum(data)) {
+			summary.UpToDate++
+			continue
+		}
+
+		if dryRun {
+			fmt.Fprintf(out, "  %s (%s)\n", name, recon.FormatFileSize(int64(len(data))))
+			summary.Pushed++
+			continue
+		}
+
+		upload, err := restClient.UploadResult(ctx, models.SyncUploadRequest{
+			Domain:    domain,
+			Tool:      result.ToolName,
+			File:      name,
+			Timestamp: result.Timestamp,
+			Overwrite: force,
+		}, data)
+		if client.IsConflictError(err) {
+			fmt.Fprintf(out, "  ✗ %s: the server has a different version\n", name)
+			summary.Conflicts++
+			continue
+		}
+		if err != nil {
+			pushErr = fmt.Errorf("failed to push %s: %w", name, err)
+			break
+		}
+
+		state.Record(cfg.Server, result.FilePath, recon.SyncRecord{
+			SHA256:   upload.SHA256,
+			PushedAt: time.Now(),
+			ResultID: upload.ResultID,
+		})
+		if upload.Existed {
+			fmt.Fprintf(out, "  = %s (already on the server)\n", name)
+			summary.UpToDate++
+			continue
+		}
+		fmt.Fprintf(out, "  ✓ %s (%s)\n", name, recon.FormatFileSize(int64(len(data))))
+		summary.Pushed++
+	}
+
+	if !dryRun {
+		if err := state.Save(); err != nil && pushErr == nil {
+			pushErr = err
+		}
+	}
+	return summary, pushErr
+}
+
+func runReconSyncPull(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	domain, program := target, ""
+	if !strings.Contains(target, ".") {
+		domain, program = "", target
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	listing, err := restClient.ListSyncResults(ctx, domain, program)
+	if err != nil {
+		if client.IsAuthError(err) {
+			return fmt.Errorf("authentication failed: your API key may be invalid or expired\nPlease run 'recon-cli auth login' to get a new key")
+		}
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("the server has no results for %s", target)
+		}
+		return err
+	}
+
+	// Oldest first, so an interrupted pull resumes where it stopped
+	results := listing.Results
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Timestamp.Before(results[j].Timestamp)
+	})
+
+	fmt.Printf("Pulling %s from %s\n", target, cfg.Server)
+	pulled, stored := 0, 0
+	domains := make(map[string]bool)
+	states := make(map[string]*recon.SyncState)
+	for _, result := range results {
+		if syncTool != "" && result.Tool != syncTool {
+			continue
+		}
+		label := fmt.Sprintf("%s/%s %s", result.Domain, result.Tool, result.Timestamp.Local().Format("2006-01-02 15:04:05"))
+
+		if syncDryRun {
+			fmt.Printf("  %s (%s)\n", label, recon.FormatFileSize(result.Size))
+			pulled++
+			domains[result.Domain] = true
+			continue
+		}
+
+		data, err := restClient.DownloadSyncResult(ctx, result.ID)
+		if err != nil {
+			return err
+		}
+		checksum := client.ResultChecksum(data)
+		if result.SHA256 != "" && !strings.EqualFold(checksum, result.SHA256) {
+			return fmt.Errorf("checksum mismatch for %s; the download may be corrupt", label)
+		}
+
+		filePath, created, err := recon.StoreSyncedResult(result.Domain, result.Tool, result.Timestamp, data)
+		if err != nil {
+			return fmt.Errorf("failed to store %s: %w", label, err)
+		}
+		if !created {
+			fmt.Printf("  = %s (already stored)\n", label)
+			stored++
+			continue
+		}
+
+		// Mark the result as on the server so push doesn't send it back
+		state := states[result.Domain]
+		if state == nil {
+			if state, err = recon.LoadSyncState(result.Domain); err != nil {
+				return err
+			}
+			states[result.Domain] = state
+		}
+		state.Record(cfg.Server, filePath, recon.SyncRecord{
+			SHA256:   checksum,
+			PushedAt: time.Now(),
+			ResultID: result.ID,
+		})
+		if err := state.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("  ✓ %s (%s)\n", label, recon.FormatFileSize(int64(len(data))))
+		pulled++
+		domains[result.Domain] = true
+	}
+
+	if syncDryRun {
+		fmt.Printf("\n%d result(s) for %d domain(s) would be pulled\n", pulled, len(domains))
+		return nil
+	}
+	fmt.Printf("\n✓ Pulled %d result(s) for %d domain(s), %d already stored\n", pulled, len(domains), stored)
+	flushQueuedOperations(ctx, restClient)
+	if pulled > 0 && domain != "" {
+		fmt.Printf("\nNext: 'recon results list %s' to see them\n", domain)
+	}
+	return nil
+}
+
+func runReconSyncFlush(cmd *cobra.Command, args []string) error {
+	ops, err := recon.LoadQueue()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		fmt.Println("No queued operations")
+		return nil
+	}
+
+	if syncDryRun {
+		fmt.Printf("%d queued operation(s):\n", len(ops))
+		for _, op := range ops {
+			line := fmt.Sprintf("  %s  %s %s", op.QueuedAt.Local().Format("2006-01-02 15:04"), op.Kind, op.Target)
+			if op.LastError != "" {
+				line += fmt.Sprintf(" (%d attempt(s), last error: %s)", op.Attempts, op.LastError)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Flushing %d queued operation(s) to %s\n", len(ops), cfg.Server)
+	done, failed, err := replayQueue(context.Background(), restClient, ops, os.Stdout)
+	fmt.Printf("\n✓ Replayed %d operation(s), %d still queued\n", done, len(ops)-done)
+	if err != nil {
+		return fmt.Errorf("server still unreachable: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d operation(s) failed; see 'recon sync flush --dry-run'", failed)
+	}
+	return nil
+}
+
+// queueSyncPush queues a push that failed because the server was
+// unreachable, so it isn't lost
+func queueSyncPush(domain string, force bool, cause error) error {
+	op := recon.QueuedOperation{Kind: recon.QueueSyncPush, Target: domain}
+	if force {
+		op.Params = map[string]string{"force": "true"}
+	}
+	added, err := recon.EnqueueOperation(op)
+	if err != nil {
+		return fmt.Errorf("server unreachable (%v) and the push could not be queued: %w", cause, err)
+	}
+
+	if added {
+		fmt.Printf("Warning: server unreachable (%v)\n", cause)
+		fmt.Printf("✓ Queued the push of %s; run 'recon sync flush' when back online\n", domain)
+	} else {
+		fmt.Printf("Warning: server unreachable (%v); the push of %s is already queued\n", cause, domain)
+	}
+	return nil
+}
+
+// replayQueue replays queued operations in order, writing a line per
+// operation to out, and updates the queue: done operations leave it, failed
+// ones keep their error. It stops with the error when the server turns out
+// to be unreachable, and returns how many operations were done and failed.
+func replayQueue(ctx context.Context, restClient *client.RestClient, ops []recon.QueuedOperation, out io.Writer) (int, int, error) {
+	doneIDs := make(map[string]bool)
+	errorsByID := make(map[string]string)
+	var stopErr error
+	for _, op := range ops {
+		var err error
+		switch op.Kind {
+		case recon.QueueSyncPush:
+			var summary syncSummary
+			summary, err = pushDomainResults(ctx, restClient, op.Target, op.Params["force"] == "true", false, io.Discard)
+			if err == nil && summary.Conflicts > 0 {
+				err = fmt.Errorf("%d result(s) conflict with the server's copy", summary.Conflicts)
+			}
+			if err == nil {
+				fmt.Fprintf(out, "  ✓ %s %s (%d pushed)\n", op.Kind, op.Target, summary.Pushed)
+			}
+		default:
+			err = fmt.Errorf("unknown operation kind %q", op.Kind)
+		}
+
+		if client.IsUnreachableError(err) {
+			stopErr = err
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(out, "  ✗ %s %s: %v\n", op.Kind, op.Target, err)
+			errorsByID[op.ID] = err.Error()
+			continue
+		}
+		doneIDs[op.ID] = true
+	}
+
+	updateErr := recon.UpdateQueue(func(queued []recon.QueuedOperation) []recon.QueuedOperation {
+		var kept []recon.QueuedOperation
+		for _, op := range queued {
+			if doneIDs[op.ID] {
+				continue
+			}
+			if message, ok := errorsByID[op.ID]; ok {
+				op.Attempts++
+				op.LastError = message
+			}
+			kept = append(kept, op)
+		}
+		return kept
+	})
+	if stopErr == nil {
+		stopErr = updateErr
+	}
+	return len(doneIDs), len(errorsByID), stopErr
+}
+
+// flushQueuedOperations replays the queue after a sync got through to the
+// server. Failures only warn, since the command itself succeeded.
+func flushQueuedOperations(ctx context.Context, restClient *client.RestClient) {
+	ops, err := recon.LoadQueue()
+	if err != nil || len(ops) == 0 {
+		return
+	}
+
+	done, _, err := replayQueue(ctx, restClient, ops, io.Discard)
+	if done > 0 {
+		fmt.Printf("✓ Flushed %d queued operation(s)\n", done)
+	}
+	if err != nil {
+		fmt.Printf("Warning: queued operations not flushed: %v\n", err)
+	} else if done < len(ops) {
+		fmt.Printf("Warning: %d queued operation(s) failed; see 'recon sync flush --dry-run'\n", len(ops)-done)
+	}
+}
+
+// autoSyncResult pushes a domain's pending results after a scan saves a
+// synced tool's result, with the 'auto_sync' config setting. Failures only
+// warn, so the scan itself still succeeds.
+func autoSyncResult(domain, toolName, filePath string) {
+	if !containsFold(recon.SyncTools, toolName) {
+		return
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		fmt.Printf("Warning: auto-sync skipped: %v\n", err)
+		return
+	}
+
+	ctx := context.Background()
+	summary, err := pushDomainResults(ctx, restClient, domain, false, false, io.Discard)
+	if client.IsUnreachableError(err) {
+		if _, err := recon.EnqueueOperation(recon.QueuedOperation{Kind: recon.QueueSyncPush, Target: domain}); err != nil {
+			fmt.Printf("Warning: auto-sync failed: %v\n", err)
+			return
+		}
+		fmt.Printf("Warning: server unreachable; queued auto-sync of %s for 'recon sync flush'\n", domain)
+		return
+	}
+	if summary.Pushed > 0 {
+		fmt.Printf("✓ Auto-synced %d result(s) to %s\n", summary.Pushed, cfg.Server)
+	}
+	if summary.Conflicts > 0 {
+		fmt.Printf("Warning: %d result(s) conflict with the server's copy; run 'recon sync push %s --force'\n", summary.Conflicts, domain)
+	}
+	if err != nil {
+		fmt.Printf("Warning: auto-sync failed: %v\n", err)
+		return
+	}
+	flushQueuedOperations(ctx, restClient)
+}
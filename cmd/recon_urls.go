@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconURLsCmd = &cobra.Command{
+	Use:   "urls <domain>",
+	Short: "Collect historical URLs for a domain",
+	Long: `Collect historical URLs for a domain from web archives and scan indexes.
+
+Available sources:
+  - wayback     (Wayback Machine CDX API, built-in)
+  - commoncrawl (Common Crawl index, built-in)
+  - urlscan     (urlscan.io search, built-in)
+  - gau         (if installed)
+
+URLs are normalized and deduplicated. When verification data exists, only
+URLs on alive subdomains are kept (use --all-hosts to keep every host).
+
+Results are saved to ~/.recon-cli/results/<domain>/urls_<timestamp>.json
+with per-host URL counts, a file extension breakdown, and a parameter
+inventory.
+
+Examples:
+  recon urls example.com
+  recon urls example.com --sources wayback,urlscan
+  recon urls example.com --all-hosts`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReconURLs,
+}
+
+var (
+	urlsSources  []string
+	urlsAllHosts bool
+)
+
+func init() {
+	reconCmd.AddCommand(reconURLsCmd)
+
+	reconURLsCmd.Flags().StringSliceVar(&urlsSources, "sources", []string{}, "Specific sources to use (wayback, commoncrawl, urlscan, gau)")
+	reconURLsCmd.Flags().BoolVar(&urlsAllHosts, "all-hosts", false, "Keep URLs for every host under the domain, not just alive subdomains")
+}
+
+func runReconURLs(cmd *cobra.Command, args []string) error {
+	domain := args[0]
+
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Collecting historical URLs for %s\n", domain)
+	fmt.Println("Mode: Passive reconnaissance (archive and index lookups)")
+	if proxyURL != nil {
+		fmt.Printf("Proxy: %s\n", proxyURL.Redacted())
+	}
+
+	// Restrict to alive subdomains when verification data is available
+	var options recon.URLCollectionOptions
+	if !urlsAllHosts {
+		if result, err := recon.GetLatestSubdomainResult(domain); err == nil {
+			for _, sub := range result.Subdomains {
+				if sub.Verified != nil && sub.Verified.Status == "alive" {
+					options.Hosts = append(options.Hosts, sub.Name)
+				}
+			}
+		}
+		if len(options.Hosts) > 0 {
+			fmt.Printf("Limiting to %d alive subdomains\n", len(options.Hosts))
+		}
+	}
+
+	allSources := []recon.URLSource{
+		&recon.WaybackSource{Proxy: proxyURL},
+		&recon.CommonCrawlSource{Proxy: proxyURL},
+		&recon.URLScanSource{Proxy: proxyURL},
+		&recon.GauSource{Proxy: proxyURL},
+	}
+
+	var sources []recon.URLSource
+	for _, source := range allSources {
+		if len(urlsSources) > 0 && !containsFold(urlsSources, source.Name()) {
+			continue
+		}
+		if source.IsAvailable() {
+			sources = append(sources, source)
+		}
+	}
+
+	if len(sources) == 0 {
+		return fmt.Errorf("no URL sources available")
+	}
+
+	fmt.Println("Sources:")
+	for _, source := range sources {
+		fmt.Printf("  ✓ %s\n", source.Name())
+	}
+	fmt.Println()
+
+	startTime := time.Now()
+	results, err := recon.CollectURLs(domain, sources, options)
+	if err != nil {
+		return fmt.Errorf("URL collection failed: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveResults(domain, "urls", results, recon.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+
+	fmt.Printf("\nTotal unique: %d URLs across %d hosts\n", results.TotalUnique, len(results.Hosts))
+	fmt.Printf("Time taken: %s\n\n", duration.Round(time.Second))
+	fmt.Printf("Saved to: %s\n", filePath)
+
+	if len(results.Hosts) > 0 {
+		fmt.Println("\nTop hosts:")
+		for _, host := range recon.TopCounts(results.Hosts, 10) {
+			fmt.Printf("  %-40s %d\n", host, results.Hosts[host])
+		}
+	}
+
+	if len(results.Extensions) > 0 {
+		var parts []string
+		for _, ext := range recon.TopCounts(results.Extensions, 10) {
+			parts = append(parts, fmt.Sprintf("%s (%d)", ext, results.Extensions[ext]))
+		}
+		fmt.Printf("\nExtensions: %s\n", strings.Join(parts, ", "))
+	}
+
+	if len(results.Parameters) > 0 {
+		var parts []string
+		for _, name := range recon.TopCounts(results.Parameters, 15) {
+			parts = append(parts, fmt.Sprintf("%s (%d)", name, results.Parameters[name]))
+		}
+		fmt.Printf("Parameters: %d unique - %s\n", len(results.Parameters), strings.Join(parts, ", "))
+	}
+
+	if err := ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "urls",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d URLs", results.TotalUnique),
+	}); err != nil {
+		fmt.Printf("Warning: failed to log activity: %v\n", err)
+	}
+
+	return nil
+}
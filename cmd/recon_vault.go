@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reconVaultCmd = &cobra.Command{
+	Use:   "vault",
+	Short: "Encrypt stored results and the API key at rest",
+	Long: `Encrypt stored results and the API key in the config file.
+
+Data is encrypted with age (https://age-encryption.org) to the vault's X25519
+key, which is itself encrypted with the passphrase (age scrypt).
+
+The first 'lock' sets a passphrase and encrypts everything already stored.
+From then on new results and API keys are written encrypted, even while the
+vault is locked. Reading them needs the vault unlocked: 'unlock' keeps the
+key in the OS keychain (macOS security, Linux secret-tool) until the next
+'lock'. Without a keychain it starts a session instead: the key is written
+to ~/.recon-cli/vault.session encrypted to a session key that 'unlock'
+prints for you to export as RECON_VAULT_SESSION, and the session ends after
+--ttl or at the next 'lock'. Commands that find the vault locked ask for the
+passphrase, which can also be supplied in the RECON_VAULT_PASSPHRASE
+environment variable.
+
+Wordlists saved with the results (.txt) are encrypted too. 'recon dirs'
+reads them as they are; for other tools, write a plain copy with
+'recon params --output'.
+
+Available subcommands:
+  lock   - Set up the vault, or encrypt new data and forget the key
+  unlock - Unlock the vault, or decrypt everything with --disable
+  status - Show whether the vault is enabled and unlocked`,
+}
+
+var reconVaultLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Encrypt stored data and lock the vault",
+	Args:  cobra.NoArgs,
+	RunE:  runReconVaultLock,
+}
+
+var reconVaultUnlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Unlock the vault for later commands",
+	Long: `Unlock the vault with its passphrase so later commands can read
+encrypted results and the API key without asking.
+
+Without an OS keychain, the key is kept in ~/.recon-cli/vault.session
+encrypted to a session key that is never written to disk. Export the
+printed RECON_VAULT_SESSION in the shell that runs recon-cli; the session
+stops working after --ttl.
+
+With --disable, every result and the API key are decrypted and the vault is
+removed.`,
+	Args: cobra.NoArgs,
+	RunE: runReconVaultUnlock,
+}
+
+var reconVaultStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the vault state",
+	Args:  cobra.NoArgs,
+	RunE:  runReconVaultStatus,
+}
+
+var (
+	vaultDisable bool
+	vaultTTL     time.Duration
+)
+
+func init() {
+	reconVaultUnlockCmd.Flags().BoolVar(&vaultDisable, "disable", false, "Decrypt all data and remove the vault")
+	reconVaultUnlockCmd.Flags().DurationVar(&vaultTTL, "ttl", config.DefaultVaultSessionTTL, "How long the session lasts without an OS keychain")
+
+	reconCmd.AddCommand(reconVaultCmd)
+	reconVaultCmd.AddCommand(reconVaultLockCmd)
+	reconVaultCmd.AddCommand(reconVaultUnlockCmd)
+	reconVaultCmd.AddCommand(reconVaultStatusCmd)
+}
+
+func runReconVaultLock(cmd *cobra.Command, args []string) error {
+	if !config.VaultEnabled() {
+		fmt.Println("Setting up the vault. The passphrase cannot be recovered;")
+		fmt.Println("encrypted data is lost if it is forgotten.")
+		fmt.Println()
+
+		passphrase, err := ui.ReadPasswordWithConfirm("New vault passphrase: ", "Confirm passphrase: ")
+		if err != nil {
+			return err
+		}
+		if err := config.InitVault(passphrase); err != nil {
+			return fmt.Errorf("failed to create vault: %w", err)
+		}
+		fmt.Println("✓ Vault created")
+	}
+
+	sealed, err := recon.SealResults()
+	if err != nil {
+		return fmt.Errorf("failed to encrypt results: %w", err)
+	}
+	if sealed > 0 {
+		fmt.Printf("✓ Encrypted %d result files\n", sealed)
+	}
+
+	// Saving the config seals the API keys
+	if (cfg.APIKey != "" && !cfg.APIKeyEncrypted()) || cfg.PlainProfileKeys() > 0 {
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to encrypt API key: %w", err)
+		}
+		fmt.Println("✓ Encrypted API key")
+	}
+
+	if err := config.LockVault(); err != nil {
+		return err
+	}
+	fmt.Println("🔒 Vault locked")
+	return nil
+}
+
+func runReconVaultUnlock(cmd *cobra.Command, args []string) error {
+	if !config.VaultEnabled() {
+		return fmt.Errorf("vault is not enabled; run 'recon vault lock' to set it up")
+	}
+
+	passphrase, err := ui.ReadPassword("Vault passphrase: ")
+	if err != nil {
+		return err
+	}
+	session, err := config.UnlockVault(passphrase, vaultTTL)
+	if err != nil {
+		return err
+	}
+
+	if !vaultDisable {
+		printVaultSession(session)
+		return nil
+	}
+
+	// Reload so the API key is opened with the unlocked key
+	current, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	opened, err := recon.UnsealResults()
+	if err != nil {
+		return fmt.Errorf("failed to decrypt results: %w", err)
+	}
+	if err := config.DisableVault(); err != nil {
+		return err
+	}
+	if current.APIKey != "" || len(current.Profiles) > 0 {
+		if err := config.Save(current); err != nil {
+			return fmt.Errorf("failed to save decrypted API key: %w", err)
+		}
+	}
+
+	fmt.Printf("✓ Decrypted %d result files\n", opened)
+	fmt.Println("✓ Vault removed; data is stored unencrypted")
+	return nil
+}
+
+// printVaultSession reports where the unlocked key is held and, for a
+// session file, the session key to export
+func printVaultSession(session *config.VaultSession) {
+	if session.Token == "" {
+		fmt.Printf("🔓 Vault unlocked (key held in %s)\n", session.KeyStore)
+		fmt.Println("Run 'recon vault lock' when done.")
+		return
+	}
+
+	fmt.Printf("🔓 Vault unlocked until %s\n", session.ExpiresAt.Format("2006-01-02 15:04:05"))
+	fmt.Println()
+	fmt.Println("Warning: no OS keychain found, so the vault key is kept in")
+	fmt.Printf("  %s, encrypted to the session key below.\n", session.KeyStore)
+	fmt.Println("  Anyone with both the file and the session key can read your results")
+	fmt.Println("  until the session expires; keep the key out of files and shell history.")
+	fmt.Println()
+	fmt.Println("Export the session key in this shell to use the session:")
+	fmt.Printf("  export %s=%q\n", config.VaultSessionEnv, session.Token)
+	fmt.Println()
+	fmt.Println("Run 'recon vault lock' when done.")
+}
+
+func runReconVaultStatus(cmd *cobra.Command, args []string) error {
+	status, err := config.GetVaultStatus()
+	if err != nil {
+		return err
+	}
+
+	if !status.Enabled {
+		fmt.Println("Vault: disabled (results and API key are stored unencrypted)")
+		fmt.Println("\nRun 'recon vault lock' to set a passphrase and encrypt them.")
+		return nil
+	}
+
+	state := "🔒 locked"
+	switch {
+	case status.Unlocked && !status.ExpiresAt.IsZero():
+		state = fmt.Sprintf("🔓 unlocked until %s (key held in %s)", status.ExpiresAt.Format("2006-01-02 15:04:05"), status.KeyStore)
+	case status.Unlocked:
+		state = fmt.Sprintf("🔓 unlocked (key held in %s)", status.KeyStore)
+	}
+	fmt.Printf("Vault: %s\n", state)
+	fmt.Printf("Created: %s\n", status.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	sealed, plain, err := recon.CountSealedResults()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Result files: %d encrypted, %d unencrypted\n", sealed, plain)
+
+	switch {
+	case cfg.APIKeyEncrypted():
+		fmt.Println("API key: encrypted")
+	case cfg.APIKey != "":
+		fmt.Println("API key: unencrypted")
+	default:
+		fmt.Println("API key: not set")
+	}
+	if n := cfg.PlainProfileKeys(); n > 0 {
+		fmt.Printf("Profile API keys: %d unencrypted\n", n)
+	}
+
+	if plain > 0 || (cfg.APIKey != "" && !cfg.APIKeyEncrypted()) || cfg.PlainProfileKeys() > 0 {
+		fmt.Println("\nRun 'recon vault lock' to encrypt the remaining data.")
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/presstronic/recontronic-cli-client/pkg/notify"
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
@@ -15,12 +18,34 @@ var reconVerifyCmd = &cobra.Command{
 	Long: `Verify which discovered subdomains are actually alive and responding.
 
 This command:
-1. Loads the latest subdomain results for the domain
+1. Loads the latest subdomain results for the domain (or an older scan
+   chosen with --file or --timestamp)
 2. Performs DNS resolution checks
-3. Probes HTTP/HTTPS endpoints
-4. Updates the results file with verification data
+3. Probes HTTP/HTTPS endpoints and inspects TLS certificates
+4. Collects robots.txt, sitemap.xml, and security.txt from alive hosts
+5. Updates the results file with verification data
 
-The verification process is passive and only checks if subdomains respond.`,
+The verification process is passive and only checks if subdomains respond.
+Opt-in --checks send a few additional benign requests per alive host to
+spot common misconfigurations (CORS origin reflection, open redirects, TRACE).
+
+Examples:
+  recon verify example.com
+  recon verify example.com --ports 8080,8443,3000,8000
+  recon verify example.com --status timeout,connection_refused --retries 4
+  recon verify example.com --only-new --only-dead
+  recon verify example.com --rate-limit 5/s --host-delay 500ms
+  recon verify example.com --proxy socks5://127.0.0.1:9050
+  recon verify example.com --resolvers 1.1.1.1,8.8.8.8
+  recon verify example.com --doh https://cloudflare-dns.com/dns-query
+  recon verify example.com --ip-mode
+  recon verify example.com --jarm
+  recon verify example.com --diff
+  recon verify example.com --notify
+  recon verify example.com --header 'X-Bug-Bounty: researcher' --basic-auth user:pass
+  recon verify example.com --checks cors,open-redirect,trace
+  recon verify example.com --quick
+  recon verify example.com --timestamp 2025-01-31`,
 	Args: cobra.ExactArgs(1),
 	RunE: runReconVerify,
 }
@@ -28,95 +53,247 @@ The verification process is passive and only checks if subdomains respond.`,
 var (
 	verifyConcurrency int
 	verifyTimeout     int
+	verifyPorts       []int
+	verifyRetries     int
+	verifyStatuses    []string
+	verifyOnlyNew     bool
+	verifyOnlyDead    bool
+	verifyRateLimit   string
+	verifyHostDelay   time.Duration
+	verifyIPMode      bool
+	verifyJARM        bool
+	verifyDiff        bool
+	verifyNotify      bool
+	verifyHeaders     []string
+	verifyBasicAuth   string
+	verifyNoPaths     bool
+	verifyChecks      []string
+	verifyQuick       bool
+	verifyFull        bool
+	verifySelection   recon.ResultSelection
 )
 
+// quickVerifyTimeout is the per-probe timeout in seconds used by --quick
+// unless --timeout is given explicitly
+const quickVerifyTimeout = 3
+
 func init() {
 	reconCmd.AddCommand(reconVerifyCmd)
 
 	// Flags for verify command
 	reconVerifyCmd.Flags().IntVar(&verifyConcurrency, "concurrency", 10, "Number of parallel probes")
 	reconVerifyCmd.Flags().IntVar(&verifyTimeout, "timeout", 10, "Timeout per probe in seconds")
+	reconVerifyCmd.Flags().IntVar(&verifyRetries, "retries", 2, "Retries per probe with exponential backoff")
+	reconVerifyCmd.Flags().StringSliceVar(&verifyStatuses, "status", []string{}, "Only re-verify subdomains with these previous statuses (dead, timeout, connection_refused, tls_error)")
+	reconVerifyCmd.Flags().BoolVar(&verifyOnlyNew, "only-new", false, "Only verify subdomains that have never been verified")
+	reconVerifyCmd.Flags().BoolVar(&verifyOnlyDead, "only-dead", false, "Only re-verify subdomains that were not alive last time")
+	reconVerifyCmd.Flags().StringVar(&verifyRateLimit, "rate-limit", "", "Maximum request rate across all probes (e.g., 5/s, 100/m)")
+	reconVerifyCmd.Flags().DurationVar(&verifyHostDelay, "host-delay", 0, "Minimum delay between requests to the same host (e.g., 500ms)")
+	reconVerifyCmd.Flags().BoolVar(&verifyIPMode, "ip-mode", false, "Also probe resolved IPs directly (with SNI and bare) to find origin servers")
+	reconVerifyCmd.Flags().BoolVar(&verifyJARM, "jarm", false, "Compute JARM TLS fingerprints for HTTPS hosts (through a proxy, it must tunnel TLS untouched)")
+	reconVerifyCmd.Flags().BoolVar(&verifyDiff, "diff", false, "Show hosts whose status, status code, title, or tech stack changed since the last run")
+	reconVerifyCmd.Flags().BoolVar(&verifyNotify, "notify", false, "Post newly alive hosts to the channels set up with 'recon notify'")
+	reconVerifyCmd.Flags().StringArrayVar(&verifyHeaders, "header", []string{}, "Custom header sent with every probe, e.g. 'X-Bug-Bounty: researcher' (repeatable)")
+	reconVerifyCmd.Flags().StringVar(&verifyBasicAuth, "basic-auth", "", "Basic auth credentials sent with every probe (user:pass)")
+	reconVerifyCmd.Flags().BoolVar(&verifyNoPaths, "no-paths", false, "Skip fetching robots.txt, sitemap.xml, and security.txt")
+	reconVerifyCmd.Flags().StringSliceVar(&verifyChecks, "checks", []string{}, "Misconfiguration checks to run on alive hosts (cors, open-redirect, trace)")
+	reconVerifyCmd.Flags().BoolVar(&verifyQuick, "quick", false, "Fast mode: HEAD requests only, no titles, bodies, favicons, or paths, shorter timeout")
+	reconVerifyCmd.Flags().BoolVar(&verifyFull, "full", false, "Full mode: GET requests with titles, hashes, favicons, and paths (default)")
+	reconVerifyCmd.Flags().IntSliceVar(&verifyPorts, "ports", []int{}, "Additional web ports to probe (e.g., 8080,8443,3000,8000)")
+	addResultSelectionFlags(reconVerifyCmd, &verifySelection, "subdomain scan")
 }
 
 func runReconVerify(cmd *cobra.Command, args []string) error {
 	domain := args[0]
 
+	// Configured defaults of the target apply unless the flag is given
+	target := reconTargetSettings(domain)
+	if target.Verify.Concurrency > 0 && !cmd.Flags().Changed("concurrency") {
+		verifyConcurrency = target.Verify.Concurrency
+	}
+	if target.Verify.HostDelay > 0 && !cmd.Flags().Changed("host-delay") {
+		verifyHostDelay = target.Verify.HostDelay
+	}
+	if target.RateLimit != "" && !cmd.Flags().Changed("rate-limit") {
+		verifyRateLimit = target.RateLimit
+	}
+	verifyHeaders = target.MergeHeaders(verifyHeaders)
+
+	rateLimit, err := recon.ParseRateLimit(verifyRateLimit)
+	if err != nil {
+		return err
+	}
+
+	proxyURL, err := resolveReconProxy()
+	if err != nil {
+		return err
+	}
+
+	resolvers, err := resolveReconResolvers()
+	if err != nil {
+		return err
+	}
+
+	headers, err := recon.ParseHeaders(verifyHeaders)
+	if err != nil {
+		return err
+	}
+
+	basicAuth, err := recon.ParseBasicAuth(verifyBasicAuth)
+	if err != nil {
+		return err
+	}
+
+	checks, err := recon.ParseChecks(verifyChecks)
+	if err != nil {
+		return err
+	}
+
+	if verifyQuick && verifyFull {
+		return fmt.Errorf("--quick and --full cannot be used together")
+	}
+	if verifyQuick && !cmd.Flags().Changed("timeout") {
+		verifyTimeout = quickVerifyTimeout
+	}
+	if target.Verify.Timeout > 0 && !cmd.Flags().Changed("timeout") {
+		verifyTimeout = target.Verify.Timeout
+	}
+
+	if verifyIPMode && proxyURL != nil {
+		return fmt.Errorf("--ip-mode connects to IPs directly and cannot be combined with a proxy")
+	}
+
+	defer statusToStderr()()
 	fmt.Printf("Verifying subdomains for %s\n", domain)
-	fmt.Println("Mode: Passive verification (DNS + HTTP probing)")
+	if verifyQuick {
+		fmt.Println("Mode: Quick verification (DNS + HTTP HEAD probing)")
+	} else {
+		fmt.Println("Mode: Passive verification (DNS + HTTP probing)")
+	}
+
+	// Hold the results lock until the verified results are saved, so a
+	// concurrent import or verification isn't lost
+	unlock, err := recon.LockDomainResults(domain)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	// Load latest subdomain results
+	// Load latest or selected subdomain results
 	var results recon.SubdomainResults
-	if err := recon.LoadLatestResult(domain, "subdomains", &results); err != nil {
+	sourcePath, err := recon.LoadSelectedResult(domain, "subdomains", verifySelection, &results)
+	if err != nil {
+		if !verifySelection.IsLatest() {
+			return fmt.Errorf("failed to load subdomain results: %w", err)
+		}
 		return fmt.Errorf("failed to load subdomain results: %w\nRun 'recon subdomain %s' first", err, domain)
 	}
 
-	fmt.Printf("Loaded %d subdomains from previous scan\n", len(results.Subdomains))
-	fmt.Printf("Starting verification (concurrency: %d, timeout: %ds)\n\n", verifyConcurrency, verifyTimeout)
+	if verifySelection.IsLatest() {
+		fmt.Printf("Loaded %d subdomains from previous scan\n", len(results.Subdomains))
+	} else {
+		fmt.Printf("Loaded %d subdomains from %s\n", len(results.Subdomains), sourcePath)
+	}
+
+	// Carry over verification data from earlier scans
+	merged, err := recon.MergePreviousVerification(domain, &results)
+	if err != nil {
+		fmt.Printf("Warning: failed to merge previous verification data: %v\n", err)
+	} else if merged > 0 {
+		fmt.Printf("Merged previous verification data for %d subdomains\n", merged)
+	}
+
+	// Select which subdomains to (re-)verify
+	targets := selectVerifyTargets(results.Subdomains)
+	if len(target.Exclude) > 0 {
+		inScope := targets[:0]
+		for _, index := range targets {
+			if !target.ExcludesHost(results.Subdomains[index].Name) {
+				inScope = append(inScope, index)
+			}
+		}
+		if skipped := len(targets) - len(inScope); skipped > 0 {
+			fmt.Printf("Skipping %d out-of-scope subdomains (exclude: %s)\n", skipped, strings.Join(target.Exclude, ", "))
+		}
+		targets = inScope
+	}
+	if len(targets) == 0 {
+		fmt.Println("No subdomains match the selection; nothing to verify.")
+		return nil
+	}
+	if len(targets) < len(results.Subdomains) {
+		fmt.Printf("Verifying %d of %d subdomains (skipping the rest)\n", len(targets), len(results.Subdomains))
+	}
+
+	fmt.Printf("Starting verification (concurrency: %d, timeout: %ds, retries: %d)\n", verifyConcurrency, verifyTimeout, verifyRetries)
+	if len(verifyPorts) > 0 {
+		var ports []string
+		for _, port := range verifyPorts {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		fmt.Printf("Additional ports: %s\n", strings.Join(ports, ", "))
+	}
+	fmt.Println()
 
 	// Set up verification options
 	options := recon.DefaultVerifyOptions()
 	options.Concurrency = verifyConcurrency
 	options.Timeout = time.Duration(verifyTimeout) * time.Second
+	options.Ports = verifyPorts
+	options.Retries = verifyRetries
+	options.Proxy = proxyURL
+	options.Resolvers = resolvers
+	options.IPMode = verifyIPMode
+	options.JARM = verifyJARM
+	options.Headers = headers
+	options.BasicAuth = basicAuth
+	options.Quick = verifyQuick
+	options.Paths = !verifyNoPaths && !verifyQuick
+	options.Checks = checks
+	if len(checks) > 0 {
+		fmt.Printf("Checks: %s\n\n", strings.Join(checks, ", "))
+	}
+	if proxyURL != nil {
+		fmt.Printf("Proxy: %s\n\n", proxyURL.Redacted())
+	}
+	if rateLimit > 0 || verifyHostDelay > 0 {
+		options.Limiter = recon.NewRateLimiter(rateLimit, verifyHostDelay)
+		fmt.Printf("Rate limit: %s, host delay: %s\n\n", formatRateLimit(rateLimit), verifyHostDelay)
+	}
 
 	// Track progress
 	startTime := time.Now()
-	total := len(results.Subdomains)
+	total := len(targets)
 	verified := 0
 	alive := 0
+	progress := ui.NewProgressBar(total)
 
-	// Progress ticker
-	progressTicker := time.NewTicker(2 * time.Second)
-	defer progressTicker.Stop()
-
-	// Channel to track completion
-	done := make(chan bool)
-
-	// Show progress in background
-	go func() {
-		for {
-			select {
-			case <-progressTicker.C:
-				if verified > 0 {
-					pct := float64(verified) / float64(total) * 100
-					fmt.Printf("\rProgress: %d/%d (%.1f%%) | Alive: %d", verified, total, pct, alive)
-				}
-			case <-done:
-				return
-			}
-		}
-	}()
-
-	// Verify subdomains with progress tracking
-	verifiedSubdomains := make([]recon.Subdomain, 0, len(results.Subdomains))
-	batchSize := options.Concurrency
+	batch := make([]recon.Subdomain, 0, len(targets))
+	for _, index := range targets {
+		batch = append(batch, results.Subdomains[index])
+	}
 
-	for i := 0; i < len(results.Subdomains); i += batchSize {
-		end := i + batchSize
-		if end > len(results.Subdomains) {
-			end = len(results.Subdomains)
-		}
+	// Verify subdomains, updating progress as each host completes
+	verifiedSubdomains := make([]recon.Subdomain, len(results.Subdomains))
+	copy(verifiedSubdomains, results.Subdomains)
 
-		batch := results.Subdomains[i:end]
-		verifiedBatch, err := recon.VerifySubdomains(batch, options)
-		if err != nil {
-			done <- true
-			return fmt.Errorf("verification failed: %w", err)
-		}
-
-		for _, sub := range verifiedBatch {
-			verifiedSubdomains = append(verifiedSubdomains, sub)
-			verified++
-			if sub.Verified != nil && sub.Verified.Status == "alive" {
+	for event := range recon.VerifySubdomainsStream(batch, options) {
+		verified++
+		if event.Err != nil {
+			progress.Clear()
+			fmt.Printf("Warning: failed to verify %s: %v\n", event.Subdomain.Name, event.Err)
+		} else {
+			verifiedSubdomains[targets[event.Index]] = event.Subdomain
+			if event.Subdomain.Verified != nil && event.Subdomain.Verified.Status == "alive" {
 				alive++
 			}
 		}
+		progress.Update(verified, fmt.Sprintf("Alive: %d", alive))
 	}
 
-	done <- true
 	duration := time.Since(startTime)
-
-	// Clear progress line
-	fmt.Print("\r" + string(make([]byte, 80)) + "\r")
+	progress.Clear()
+	printResolverStats(resolvers)
 
 	// Update results with verification data
 	results.Subdomains = verifiedSubdomains
@@ -126,9 +303,19 @@ func runReconVerify(cmd *cobra.Command, args []string) error {
 	if results.Summary == nil {
 		results.Summary = make(map[string]int)
 	}
-	results.Summary["verified_total"] = verified
-	results.Summary["verified_alive"] = alive
-	results.Summary["verified_dead"] = dead
+	totalVerified, totalAlive := 0, 0
+	for _, sub := range results.Subdomains {
+		if sub.Verified != nil {
+			totalVerified++
+			if sub.Verified.Status == "alive" {
+				totalAlive++
+			}
+		}
+	}
+	results.Summary["verified_total"] = totalVerified
+	results.Summary["verified_alive"] = totalAlive
+	results.Summary["verified_dead"] = totalVerified - totalAlive
+	results.Findings = recon.DetectAnomalies(results.Subdomains)
 
 	// Save updated results
 	filePath, err := recon.SaveResults(domain, "subdomains", results, recon.FormatJSON)
@@ -144,6 +331,19 @@ func runReconVerify(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Total verified: %d subdomains\n", verified)
 	fmt.Printf("  Alive:          %d (%.1f%%)\n", alive, float64(alive)/float64(verified)*100)
 	fmt.Printf("  Dead:           %d (%.1f%%)\n", dead, float64(dead)/float64(verified)*100)
+
+	// Break down why hosts were not reachable
+	statusCounts := make(map[string]int)
+	for _, index := range targets {
+		if sub := verifiedSubdomains[index]; sub.Verified != nil && sub.Verified.Status != "alive" {
+			statusCounts[sub.Verified.Status]++
+		}
+	}
+	for _, status := range []string{"timeout", "connection_refused", "tls_error"} {
+		if statusCounts[status] > 0 {
+			fmt.Printf("    %-20s %d\n", status+":", statusCounts[status])
+		}
+	}
 	fmt.Printf("\nUpdated: %s\n\n", filePath)
 
 	// Show sample alive subdomains
@@ -152,23 +352,77 @@ func runReconVerify(cmd *cobra.Command, args []string) error {
 		count := 0
 		for _, sub := range verifiedSubdomains {
 			if sub.Verified != nil && sub.Verified.Status == "alive" && count < 10 {
-				statusCode := ""
-				if sub.Verified.HTTP != nil {
-					statusCode = fmt.Sprintf(" [%d]", sub.Verified.HTTP.StatusCode)
+				httpResult := sub.Verified.HTTP
+				if (httpResult == nil || !httpResult.Accessible) && len(sub.Verified.HTTPResults) > 0 {
+					httpResult = &sub.Verified.HTTPResults[0]
+				}
+				if httpResult == nil {
+					continue
 				}
+				statusCode := fmt.Sprintf(" [%d]", httpResult.StatusCode)
 				title := ""
-				if sub.Verified.HTTP != nil && sub.Verified.HTTP.Title != "" {
-					title = fmt.Sprintf(" - %s", sub.Verified.HTTP.Title)
+				if httpResult.Title != "" {
+					title = fmt.Sprintf(" - %s", httpResult.Title)
 					if len(title) > 50 {
 						title = title[:50] + "..."
 					}
 				}
-				fmt.Printf("  %s%s%s\n", sub.Verified.HTTP.URL, statusCode, title)
+				ports := ""
+				if len(sub.Verified.HTTPResults) > 0 {
+					var openPorts []string
+					for _, r := range sub.Verified.HTTPResults {
+						openPorts = append(openPorts, strconv.Itoa(r.Port))
+					}
+					ports = fmt.Sprintf(" (ports: %s)", strings.Join(openPorts, ","))
+				}
+				product := ""
+				if httpResult.FaviconProduct != "" {
+					product = fmt.Sprintf(" {%s}", httpResult.FaviconProduct)
+				}
+				if httpResult.AddressFamily == "ipv6" {
+					product += " (IPv6)"
+				}
+				fmt.Printf("  %s%s%s%s%s\n", httpResult.URL, statusCode, title, ports, product)
 				count++
 			}
 		}
 	}
 
+	// Display key findings
+	displayVerifyFindings(verifiedSubdomains)
+	displayWorthALook(results.Findings)
+
+	// Display changes since the previous verification
+	if verifyDiff {
+		changed := make([]recon.Subdomain, 0, len(targets))
+		for _, index := range targets {
+			changed = append(changed, verifiedSubdomains[index])
+		}
+		displayVerifyChanges(recon.DiffVerifications(changed))
+	}
+
+	if verifyNotify {
+		var newlyAlive []string
+		for _, index := range targets {
+			sub := verifiedSubdomains[index]
+			if sub.Verified == nil || sub.Verified.Status != "alive" {
+				continue
+			}
+			// Alive for the first time, or alive again after a failed check
+			if len(sub.History) == 0 || sub.History[len(sub.History)-1].Status != "alive" {
+				newlyAlive = append(newlyAlive, sub.Name)
+			}
+		}
+		fmt.Println()
+		sendNotification(notify.Summary{
+			Domain:        domain,
+			Event:         "Verification",
+			Note:          fmt.Sprintf("%d/%d alive", alive, verified),
+			NewlyAlive:    newlyAlive,
+			TakeoverRisks: notifyTakeoverRisks(domain),
+		})
+	}
+
 	// Log activity
 	activityResult := fmt.Sprintf("%d/%d alive", alive, verified)
 	if err := ui.LogActivity(ui.ActivityEntry{
@@ -181,5 +435,180 @@ func runReconVerify(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Warning: failed to log activity: %v\n", err)
 	}
 
+	if structuredOutput() {
+		return renderOutput(results)
+	}
 	return nil
 }
+
+// selectVerifyTargets returns the indices of subdomains that should be
+// verified. With no selectors set every subdomain is selected; otherwise a
+// subdomain is selected if it matches any of them.
+func selectVerifyTargets(subdomains []recon.Subdomain) []int {
+	selecting := verifyOnlyNew || verifyOnlyDead || len(verifyStatuses) > 0
+
+	var targets []int
+	for i, sub := range subdomains {
+		if selecting {
+			isNew := sub.Verified == nil
+			isDead := sub.Verified != nil && sub.Verified.Status != "alive"
+			hasStatus := sub.Verified != nil && containsFold(verifyStatuses, sub.Verified.Status)
+
+			if !(verifyOnlyNew && isNew) && !(verifyOnlyDead && isDead) && !hasStatus {
+				continue
+			}
+		}
+		targets = append(targets, i)
+	}
+	return targets
+}
+
+func formatRateLimit(perSecond float64) string {
+	if perSecond <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%.2g/s", perSecond)
+}
+
+func containsFold(items []string, item string) bool {
+	for _, s := range items {
+		if strings.EqualFold(strings.TrimSpace(s), item) {
+			return true
+		}
+	}
+	return false
+}
+
+func displayVerifyFindings(subdomains []recon.Subdomain) {
+	var expired, expiring, selfSigned []recon.Subdomain
+	for _, sub := range subdomains {
+		if sub.Verified == nil || sub.Verified.TLS == nil {
+			continue
+		}
+		if sub.Verified.TLS.Expired {
+			expired = append(expired, sub)
+		} else if sub.Verified.TLS.ExpiresSoon {
+			expiring = append(expiring, sub)
+		}
+		if sub.Verified.TLS.SelfSigned {
+			selfSigned = append(selfSigned, sub)
+		}
+	}
+
+	var origins, jarmMatches, misconfigs []string
+	for _, sub := range subdomains {
+		if sub.Verified == nil {
+			continue
+		}
+		for _, finding := range sub.Verified.Findings {
+			misconfigs = append(misconfigs, fmt.Sprintf("%s → %s", sub.Name, finding.Detail))
+		}
+		if sub.Verified.TLS != nil && sub.Verified.TLS.JARMMatch != "" {
+			jarmMatches = append(jarmMatches, fmt.Sprintf("%s → %s", sub.Name, sub.Verified.TLS.JARMMatch))
+		}
+		for _, probe := range sub.Verified.IPProbes {
+			if probe.Differs {
+				origins = append(origins, fmt.Sprintf("%s → %s (%s): %s", sub.Name, probe.IP, probe.Mode, probe.Reason))
+			}
+		}
+	}
+
+	if len(expired) == 0 && len(expiring) == 0 && len(selfSigned) == 0 && len(origins) == 0 && len(jarmMatches) == 0 && len(misconfigs) == 0 {
+		return
+	}
+
+	fmt.Println("\nKey Findings:")
+
+	if len(expired) > 0 {
+		fmt.Printf("  ⚠️  Expired certificates: %d\n", len(expired))
+		printCertFindings(expired, func(tlsResult *recon.TLSResult) string {
+			return fmt.Sprintf("expired %s", tlsResult.NotAfter.Format("2006-01-02"))
+		})
+	}
+
+	if len(expiring) > 0 {
+		fmt.Printf("  ⚠️  Certificates expiring within 30 days: %d\n", len(expiring))
+		printCertFindings(expiring, func(tlsResult *recon.TLSResult) string {
+			return fmt.Sprintf("expires in %d days", tlsResult.DaysUntilExpiry)
+		})
+	}
+
+	if len(selfSigned) > 0 {
+		fmt.Printf("  🔒 Self-signed certificates: %d\n", len(selfSigned))
+		printCertFindings(selfSigned, func(tlsResult *recon.TLSResult) string {
+			return tlsResult.Subject
+		})
+	}
+
+	if len(origins) > 0 {
+		fmt.Printf("  🎯 Direct-IP responses differing from hostname: %d\n", len(origins))
+		printFindingLines(origins)
+	}
+
+	if len(misconfigs) > 0 {
+		fmt.Printf("  🚩 Misconfigurations: %d\n", len(misconfigs))
+		printFindingLines(misconfigs)
+	}
+
+	if len(jarmMatches) > 0 {
+		fmt.Printf("  ⚠️  JARM fingerprints matching known C2/products: %d\n", len(jarmMatches))
+		printFindingLines(jarmMatches)
+	}
+}
+
+func displayWorthALook(findings []recon.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Printf("\nWorth a look: %d\n", len(findings))
+	var lines []string
+	for _, finding := range findings {
+		lines = append(lines, fmt.Sprintf("%s → %s", finding.Subdomain, finding.Detail))
+	}
+	printFindingLines(lines)
+}
+
+func displayVerifyChanges(changes []recon.VerificationChange) {
+	if len(changes) == 0 {
+		fmt.Println("\nNo changes since the last verification")
+		return
+	}
+
+	hosts := make(map[string]bool)
+	for _, change := range changes {
+		hosts[change.Subdomain] = true
+	}
+
+	fmt.Printf("\nChanges since last verification: %d host(s)\n", len(hosts))
+	for _, change := range changes {
+		before, after := change.Before, change.After
+		if before == "" {
+			before = "(none)"
+		}
+		if after == "" {
+			after = "(none)"
+		}
+		fmt.Printf("  %s %s: %s → %s\n", change.Subdomain, change.Field, before, after)
+	}
+}
+
+func printFindingLines(lines []string) {
+	for i, line := range lines {
+		if i >= 5 {
+			fmt.Printf("      ... and %d more (see JSON results)\n", len(lines)-5)
+			break
+		}
+		fmt.Printf("      - %s\n", line)
+	}
+}
+
+func printCertFindings(subdomains []recon.Subdomain, describe func(*recon.TLSResult) string) {
+	for i, sub := range subdomains {
+		if i >= 5 {
+			fmt.Printf("      ... and %d more (see JSON results)\n", len(subdomains)-5)
+			break
+		}
+		fmt.Printf("      - %s → %s\n", sub.Name, describe(sub.Verified.TLS))
+	}
+}
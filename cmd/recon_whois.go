@@ -4,22 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	whoisTimeout time.Duration
-	whoisRaw     bool
-	whoisJSON    bool
+	whoisTimeout    time.Duration
+	whoisRaw        bool
+	whoisJSON       bool
+	whoisIPsFromDNS string
+	whoisDelay      time.Duration
+	whoisBulk       string
+	whoisWithin     string
 )
 
 var reconWhoisCmd = &cobra.Command{
-	Use:   "whois <domain>",
-	Short: "Lookup WHOIS information for a domain",
+	Use:   "whois <domain|ip|cidr>",
+	Short: "Lookup WHOIS information for a domain, IP, or netblock",
 	Long: `Lookup WHOIS information for a target domain including:
   - Registrar information
   - Registration and expiration dates
@@ -27,30 +36,93 @@ var reconWhoisCmd = &cobra.Command{
   - Domain status
   - Contact information (if available)
 
+For IP addresses and CIDR netblocks, the regional registry (ARIN, RIPE, APNIC,
+LACNIC, AFRINIC) response is parsed into netblock, network name, organization,
+country, and abuse contact.
+
+Lookups use RDAP first, which returns structured JSON from the registry (and
+registrar, when linked). If RDAP is unavailable, a built-in WHOIS client follows
+referrals from IANA to the registry and registrar, with the system whois binary
+as a last resort when installed.
+
+Use --ips-from-dns to look up every IP resolved by 'recon dns <domain>'.
+
+Use --bulk to look up every registrable domain found in a domain's stored
+subdomain and DNS results (sibling apexes, CNAME/MX/NS targets), or listed in a
+file of hostnames, and group domains that share a registrant.
+
 Results are automatically saved to ~/.recon-cli/results/<domain>/whois_<timestamp>.json
+(IP lookups from DNS to ipwhois_<timestamp>.json, bulk lookups to whoisbulk_<timestamp>.json)
 
 Examples:
   recon whois example.com
   recon whois example.com --timeout 30s
   recon whois example.com --json
-  recon whois example.com --raw`,
-	Args: cobra.ExactArgs(1),
+  recon whois example.com --raw
+  recon whois 93.184.216.34
+  recon whois 93.184.216.0/24
+  recon whois --ips-from-dns example.com
+  recon whois --bulk example.com --delay 2s
+  recon whois --bulk hosts.txt
+  recon whois expiring --within 60d`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if whoisIPsFromDNS != "" || whoisBulk != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runReconWhois,
 }
 
+var reconWhoisExpiringCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "List stored WHOIS records expiring soon",
+	Long: `Scan every domain's latest stored WHOIS and bulk WHOIS results for
+registrations expiring within the given window (already-expired domains are
+included).
+
+Domains that subdomains CNAME to are flagged: if one lapses, anyone can
+register it and take over the aliased subdomains. Record CNAME targets by
+running 'recon dns <domain>' before 'recon whois --bulk <domain>'.
+
+Examples:
+  recon whois expiring
+  recon whois expiring --within 30d`,
+	Args: cobra.NoArgs,
+	RunE: runReconWhoisExpiring,
+}
+
 func init() {
 	reconWhoisCmd.Flags().DurationVar(&whoisTimeout, "timeout", 30*time.Second, "Timeout for WHOIS lookup")
-	reconWhoisCmd.Flags().BoolVar(&whoisRaw, "raw", false, "Show raw WHOIS output")
+	reconWhoisCmd.Flags().BoolVar(&whoisRaw, "raw", false, "Show raw RDAP or WHOIS response")
 	reconWhoisCmd.Flags().BoolVar(&whoisJSON, "json", false, "Output results as JSON")
+	reconWhoisCmd.Flags().StringVar(&whoisIPsFromDNS, "ips-from-dns", "", "Look up every IP resolved in the latest DNS results for this domain")
+	reconWhoisCmd.Flags().StringVar(&whoisBulk, "bulk", "", "Look up every registrable domain from a domain's results or a file of hostnames")
+	reconWhoisCmd.Flags().DurationVar(&whoisDelay, "delay", time.Second, "Delay between lookups with --ips-from-dns or --bulk")
+	reconWhoisCmd.MarkFlagsMutuallyExclusive("ips-from-dns", "bulk")
 	reconCmd.AddCommand(reconWhoisCmd)
+
+	reconWhoisExpiringCmd.Flags().BoolVar(&whoisJSON, "json", false, "Output results as JSON")
+	reconWhoisExpiringCmd.Flags().StringVar(&whoisWithin, "within", "60d", "Report domains expiring within this window (e.g., 30d, 720h)")
+	reconWhoisCmd.AddCommand(reconWhoisExpiringCmd)
 }
 
 func runReconWhois(cmd *cobra.Command, args []string) error {
+	if whoisIPsFromDNS != "" {
+		return runReconWhoisIPs(whoisIPsFromDNS)
+	}
+	if whoisBulk != "" {
+		return runReconWhoisBulk(whoisBulk)
+	}
+
 	domain := args[0]
 
-	// Validate domain
-	if err := recon.ValidateDomain(domain); err != nil {
-		return fmt.Errorf("invalid domain: %w", err)
+	// Validate domain (IPs and netblocks are looked up as-is)
+	isIP := recon.IsIPQuery(domain)
+	if !isIP {
+		if err := recon.ValidateDomain(domain); err != nil {
+			return fmt.Errorf("invalid domain: %w", err)
+		}
 	}
 
 	fmt.Printf("Looking up WHOIS information for %s\n", domain)
@@ -64,16 +136,19 @@ func runReconWhois(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("WHOIS lookup failed: %w", err)
 	}
 
-	// Save results
-	if err := recon.SaveWhoisResults(domain, info); err != nil {
+	// Save results (CIDR slashes are not valid in directory names)
+	resultsDir := strings.ReplaceAll(domain, "/", "_")
+	if err := recon.SaveWhoisResults(resultsDir, info); err != nil {
 		fmt.Printf("Warning: Failed to save results: %v\n", err)
 	} else {
-		fmt.Printf("\n✓ Results saved to ~/.recon-cli/results/%s/\n", domain)
+		fmt.Printf("\n✓ Results saved to ~/.recon-cli/results/%s/\n", resultsDir)
 	}
 
 	// Log activity
 	result := fmt.Sprintf("Registrar: %s", info.Registrar)
-	if info.ExpiryDate != "" {
+	if isIP {
+		result = fmt.Sprintf("Netblock: %s, Org: %s", info.Netblock, info.Organization)
+	} else if info.ExpiryDate != "" {
 		result = fmt.Sprintf("%s, Expires: %s", result, info.ExpiryDate)
 	}
 	ui.LogActivity(ui.ActivityEntry{
@@ -102,3 +177,203 @@ func runReconWhois(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runReconWhoisIPs looks up every IP resolved for a domain
+func runReconWhoisIPs(domain string) error {
+	if err := recon.ValidateDomain(domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	dnsResults, err := recon.LoadDNSResults(domain)
+	if err != nil {
+		return fmt.Errorf("failed to load DNS results (run 'recon dns %s' first): %w", domain, err)
+	}
+
+	ips := dnsResults.UniqueIPs()
+	if len(ips) == 0 {
+		fmt.Printf("No resolved IPs found for %s\n", domain)
+		return nil
+	}
+
+	fmt.Printf("Looking up WHOIS information for %d IPs of %s\n", len(ips), domain)
+	fmt.Println("Mode: Passive reconnaissance (WHOIS query)")
+
+	startTime := time.Now()
+	results := recon.LookupIPsWhois(context.Background(), domain, ips, whoisTimeout, whoisDelay)
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveIPWhoisResults(domain, results)
+	if err != nil {
+		fmt.Printf("Warning: Failed to save results: %v\n", err)
+	} else {
+		fmt.Printf("✓ Results saved to %s\n", filePath)
+	}
+
+	ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    domain,
+		Action:    "whois",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d IPs looked up, %d failed", len(results.IPs), len(results.Failed)),
+	})
+
+	if whoisJSON {
+		jsonData, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IP\tNETBLOCK\tORGANIZATION\tCOUNTRY\tABUSE CONTACT")
+	for _, info := range results.IPs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.Domain, info.Netblock, info.Organization, info.Country, info.AbuseEmail)
+	}
+	w.Flush()
+
+	if len(results.Failed) > 0 {
+		fmt.Printf("\nFailed lookups: %s\n", strings.Join(results.Failed, ", "))
+	}
+	fmt.Printf("\nTime taken: %s\n", duration.Round(time.Second))
+
+	return nil
+}
+
+// runReconWhoisBulk looks up every registrable domain from a domain's results
+// or a hostname file and reports domains sharing a registrant
+func runReconWhoisBulk(source string) error {
+	var domains []string
+	var resultsDir string
+
+	if data, err := os.ReadFile(source); err == nil {
+		domains = recon.RegistrableDomains(strings.Split(string(data), "\n"))
+		resultsDir = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	} else {
+		if err := recon.ValidateDomain(source); err != nil {
+			return fmt.Errorf("%s is neither a readable file nor a valid domain", source)
+		}
+		domains, err = recon.CollectRegistrableDomains(source)
+		if err != nil {
+			return err
+		}
+		resultsDir = source
+	}
+
+	if len(domains) == 0 {
+		fmt.Printf("No registrable domains found in %s\n", source)
+		return nil
+	}
+
+	fmt.Printf("Looking up WHOIS information for %d registrable domains from %s\n", len(domains), source)
+	fmt.Println("Mode: Passive reconnaissance (WHOIS query)")
+
+	startTime := time.Now()
+	results := recon.LookupBulkWhois(context.Background(), source, domains, whoisTimeout, whoisDelay)
+	if resultsDir == source {
+		results.CNAMETargets = recon.CNAMETargetDomains(source)
+	}
+	duration := time.Since(startTime)
+
+	filePath, err := recon.SaveBulkWhoisResults(resultsDir, results)
+	if err != nil {
+		fmt.Printf("Warning: Failed to save results: %v\n", err)
+	} else {
+		fmt.Printf("✓ Results saved to %s\n", filePath)
+	}
+
+	ui.LogActivity(ui.ActivityEntry{
+		Timestamp: time.Now(),
+		Domain:    resultsDir,
+		Action:    "whois",
+		Status:    "completed",
+		Result:    fmt.Sprintf("%d domains looked up, %d registrants", len(results.Domains), len(results.Registrants)),
+	})
+
+	if whoisJSON {
+		jsonData, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tREGISTRAR\tREGISTRANT\tEXPIRES")
+	for _, info := range results.Domains {
+		registrant := info.RegistrantOrg
+		if registrant == "" {
+			registrant = info.RegistrantEmail
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", info.Domain, info.Registrar, registrant, info.ExpiryDate)
+	}
+	w.Flush()
+
+	// Highlight owners holding more than one domain
+	var shared []recon.RegistrantGroup
+	for _, group := range results.Registrants {
+		if len(group.Domains) > 1 {
+			shared = append(shared, group)
+		}
+	}
+	if len(shared) > 0 {
+		fmt.Println("\nKey Findings:")
+		fmt.Printf("  🔗 Registrants owning multiple domains: %d\n", len(shared))
+		var lines []string
+		for _, group := range shared {
+			lines = append(lines, fmt.Sprintf("%s: %s", group.Registrant, strings.Join(group.Domains, ", ")))
+		}
+		printFindingLines(lines)
+	}
+
+	if len(results.Failed) > 0 {
+		fmt.Printf("\nFailed lookups: %s\n", strings.Join(results.Failed, ", "))
+	}
+	fmt.Printf("\nTime taken: %s\n", duration.Round(time.Second))
+
+	return nil
+}
+
+func runReconWhoisExpiring(cmd *cobra.Command, args []string) error {
+	within, err := config.ParseDayDuration(whoisWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	expiring, err := recon.ExpiringWhois(within)
+	if err != nil {
+		return fmt.Errorf("failed to scan WHOIS results: %w", err)
+	}
+
+	if whoisJSON {
+		jsonData, err := json.MarshalIndent(expiring, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	if len(expiring) == 0 {
+		fmt.Printf("No stored WHOIS records expire within %s\n", whoisWithin)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tTARGET\tEXPIRES\tDAYS LEFT\tNOTE")
+	for _, entry := range expiring {
+		note := ""
+		if entry.CNAMETarget {
+			note = "⚠️  CNAME target (takeover risk)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", entry.Domain, entry.Target,
+			entry.ExpiresAt.Format("2006-01-02"), entry.DaysLeft, note)
+	}
+	w.Flush()
+
+	return nil
+}
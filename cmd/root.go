@@ -5,13 +5,17 @@ import (
 	"os"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	cfgFile string
-	debug   bool
-	output  string
+	cfgFile     string
+	debug       bool
+	output      string
+	profileName string
 
 	// Global config instance
 	cfg *config.Config
@@ -29,24 +33,32 @@ The CLI provides tools for:
 - Bug bounty program management
 - Reconnaissance scan control and monitoring
 - Security anomaly tracking and review
-- Real-time dashboards and statistics`,
+- Real-time dashboards and statistics
+- Sharing programs and findings with your team`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Load configuration
 		var err error
+		config.SetProfileOverride(profileName)
+		applySettingFlags(cmd.Root())
 		cfg, err = config.Load(cfgFile)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
 		// Override with command-line flags if provided
-		if output != "" {
-			cfg.OutputFormat = output
+		if err := applyOutputFlag(); err != nil {
+			return err
 		}
 		if debug {
 			cfg.LogLevel = "debug"
 		}
 
-		return nil
+		warnConfigSecurity(cmd)
+		if err := applyFlagDefaults(cmd); err != nil {
+			return err
+		}
+
+		return applyStorageConfig(cfg)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no subcommand is provided, start interactive mode
@@ -66,7 +78,10 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.recon-cli/config.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "use this profile for the command, without switching to it")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "append transcripts of API requests and responses (secrets redacted) to this file")
 	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "", "output format (table|json|yaml)")
+	addSettingFlags(rootCmd.PersistentFlags())
 
 	// Add subcommands
 	rootCmd.AddCommand(authCmd)
@@ -74,7 +89,114 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 }
 
+// settingUsage is the help of the global flags overriding a config setting
+// for one command, named after its key (e.g., --set-tls-ca-file for tls.ca_file).
+// Secrets, which would end up in shell history, and settings other flags
+// cover (--output, recon --proxy and --doh) only have RECON_ variables.
+var settingUsage = map[string]string{
+	"server":                   "server URL",
+	"grpc_server":              "gRPC server address (host:port)",
+	"grpc_tls":                 "TLS for the gRPC server (auto|on|off)",
+	"grpc_ca_cert":             "PEM CA bundle for the gRPC server only",
+	"timeout":                  "API request timeout (e.g., 30s)",
+	"max_retries":              "retries of failed API requests (0-10)",
+	"log_level":                "log level (debug|info|warn|error)",
+	"wordlists.dirs":           "default wordlist for 'recon dirs'",
+	"retention.max_age":        "delete stored results older than this after each scan (e.g., 30d)",
+	"retention.keep_last":      "newest results per tool always kept",
+	"defectdojo.url":           "DefectDojo URL for 'recon results push'",
+	"defectdojo.engagement":    "default DefectDojo engagement ID",
+	"faraday.url":              "Faraday URL for 'recon results push'",
+	"faraday.workspace":        "default Faraday workspace",
+	"tls.ca_file":              "PEM CA bundle to verify the server with",
+	"tls.client_cert":          "PEM client certificate for mutual TLS",
+	"tls.client_key":           "PEM key of the client certificate",
+	"tls.insecure_skip_verify": "skip server certificate verification (testing only)",
+	"api.proxy":                "proxy for API traffic to the server",
+	"api.max_idle_conns":       "idle API connections kept open",
+	"api.idle_timeout":         "close idle API connections after this long (e.g., 90s)",
+	"api.keepalive":            "TCP keep-alive interval of API connections (e.g., 30s)",
+	"compress_results":         "save recon results gzip-compressed",
+	"auto_sync":                "push results to the server after each scan",
+}
+
+// flagSettings returns the settings that have a global flag
+func flagSettings() []config.Setting {
+	var settings []config.Setting
+	for _, setting := range config.OverrideSettings() {
+		switch setting.Key {
+		case "output_format", "proxy", "doh":
+			continue
+		}
+		if !config.IsSecretKey(setting.Key) {
+			settings = append(settings, setting)
+		}
+	}
+	return settings
+}
+
+// addSettingFlags adds a --set-<key> flag of the setting's type for each
+// flagSettings entry to the flags of a root command. They are hidden to keep
+// them out of every subcommand's help; 'config list' describes them.
+func addSettingFlags(flags *pflag.FlagSet) {
+	for _, setting := range flagSettings() {
+		name := config.FlagName(setting.Key)
+		usage := settingUsage[setting.Key]
+		if usage == "" {
+			usage = "override " + setting.Key + " from the config file"
+		}
+		switch setting.Type {
+		case "bool":
+			flags.Bool(name, false, usage)
+		case "int":
+			flags.Int(name, 0, usage)
+		case "duration":
+			flags.Duration(name, 0, usage)
+		default:
+			flags.String(name, "", usage)
+		}
+		flags.Lookup(name).Hidden = true
+	}
+}
+
+// applySettingFlags has Load apply the setting flags given to a root command
+func applySettingFlags(root *cobra.Command) {
+	overrides := make(map[string]string)
+	for _, setting := range flagSettings() {
+		if flag := root.PersistentFlags().Lookup(config.FlagName(setting.Key)); flag != nil && flag.Changed {
+			overrides[setting.Key] = flag.Value.String()
+		}
+	}
+	config.SetFlagOverrides(overrides)
+}
+
 // GetConfig returns the loaded configuration
 func GetConfig() *config.Config {
 	return cfg
 }
+
+// applyStorageConfig installs the configured result compression, retention
+// policy, and auto-sync so they take effect whenever a scan saves its
+// results, and lets commands ask for the vault passphrase when it is locked
+func applyStorageConfig(cfg *config.Config) error {
+	var policy recon.RetentionPolicy
+	if cfg.Retention.MaxAge != "" {
+		maxAge, err := config.ParseDayDuration(cfg.Retention.MaxAge)
+		if err != nil {
+			return fmt.Errorf("invalid retention max_age in config: %w", err)
+		}
+		policy.MaxAge = maxAge
+	}
+	policy.KeepLast = cfg.Retention.KeepLast
+	recon.SetRetentionPolicy(policy)
+	recon.SetCompressResults(cfg.Compress)
+	if cfg.AutoSync {
+		recon.SetSaveHook(autoSyncResult)
+	} else {
+		recon.SetSaveHook(nil)
+	}
+	config.SetVaultPrompt(func() (string, error) {
+		return ui.ReadPassword("Vault passphrase: ")
+	})
+	return nil
+}
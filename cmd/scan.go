@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// scanWatchRetries is how often 'scan watch' reconnects after the stream
+// drops without a progress update in between
+const scanWatchRetries = 5
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Monitor and schedule scans on the Recontronic server",
+	Long: `Monitor the scans the Recontronic platform runs, and schedule them.
+
+Available subcommands:
+  watch    - Stream the live progress of a scan
+  schedule - Manage how often the server scans your programs`,
+}
+
+var scanWatchCmd = &cobra.Command{
+	Use:   "watch <scan-id>",
+	Short: "Stream the live progress of a scan",
+	Long: `Stream the progress of a scan from the gRPC server (grpc-server) as it
+happens, instead of polling the REST API. The stream ends when the scan
+completes or fails; a dropped connection is re-established.
+
+The connection uses TLS except to loopback addresses; see the grpc-tls and
+grpc-ca-cert config keys. Requires an API key ('recon-cli auth login').
+
+Examples:
+  recon-cli scan watch 42
+  recon-cli config set grpc-server platform.example.com:9090
+  recon-cli config set grpc-ca-cert ~/certs/platform-ca.pem`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScanWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+	scanCmd.AddCommand(scanWatchCmd)
+}
+
+func runScanWatch(cmd *cobra.Command, args []string) error {
+	scanID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || scanID <= 0 {
+		return fmt.Errorf("invalid scan ID: %s", args[0])
+	}
+
+	grpcClient, err := streamClient()
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching scan %d on %s (Ctrl+C to stop)\n\n", scanID, cfg.GRPCServer)
+	bar := ui.NewProgressBar(100)
+	var last *models.ScanProgress
+	retries := 0
+	for {
+		err = grpcClient.WatchScan(ctx, scanID, func(progress models.ScanProgress) error {
+			last = &progress
+			retries = 0
+			bar.Update(min(max(progress.Progress, 0), 100), fmt.Sprintf("%s | %s | %d assets", progress.Status, valueOrDash(progress.CurrentStep), progress.AssetsFound))
+			return nil
+		})
+		if err == nil || ctx.Err() != nil || !client.IsUnavailableError(err) || retries >= scanWatchRetries {
+			break
+		}
+
+		retries++
+		delay := time.Duration(1<<(retries-1)) * time.Second
+		bar.Clear()
+		fmt.Printf("Warning: connection lost, reconnecting in %s (%d/%d)\n", delay, retries, scanWatchRetries)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+	bar.Clear()
+
+	switch {
+	case ctx.Err() != nil:
+		fmt.Println("Stopped watching; the scan keeps running on the server")
+		return nil
+	case client.IsAuthError(err):
+		return fmt.Errorf("authentication failed: your API key may be invalid or expired\nPlease run 'recon-cli auth login' to get a new key")
+	case client.IsNotFoundError(err):
+		return fmt.Errorf("scan %d not found", scanID)
+	case client.IsUnavailableError(err):
+		return fmt.Errorf("gRPC server %s unavailable: %w", cfg.GRPCServer, err)
+	case err != nil:
+		return fmt.Errorf("failed to watch scan %d: %w", scanID, err)
+	case last == nil:
+		fmt.Printf("Scan %d sent no progress updates\n", scanID)
+		return nil
+	}
+
+	switch last.Status {
+	case "completed":
+		fmt.Printf("✓ Scan %d completed: %d assets found\n", scanID, last.AssetsFound)
+	case "failed":
+		return fmt.Errorf("scan %d failed at %s (%d assets found)", scanID, valueOrDash(last.CurrentStep), last.AssetsFound)
+	default:
+		fmt.Printf("Scan %d: %s, %d%% (%d assets found)\n", scanID, last.Status, last.Progress, last.AssetsFound)
+	}
+	return nil
+}
+
+// streamClient returns a gRPC client for the configured server and API key
+func streamClient() (*client.GRPCClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration not loaded")
+	}
+	if cfg.APIKeyLocked() {
+		return nil, fmt.Errorf("the API key is encrypted and the vault is locked\nRun 'recon vault unlock' first")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("not authenticated: please run 'recon-cli auth login' first")
+	}
+
+	return newGRPCClient(cfg.APIKey)
+}
+
+// newGRPCClient creates a gRPC client for the configured server with the
+// TLS settings
+func newGRPCClient(apiKey string) (*client.GRPCClient, error) {
+	tlsOptions := serverTLSOptions()
+	if cfg.GRPCCACert != "" {
+		tlsOptions.CAFile = cfg.GRPCCACert
+	}
+	return client.NewGRPCClient(cfg.GRPCServer, apiKey, client.GRPCOptions{
+		TLS:        cfg.GRPCTLS,
+		TLSOptions: tlsOptions,
+		Logger:     apiLogger(),
+	})
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// scheduleWeekdays maps the --day values to cron weekday numbers
+var scheduleWeekdays = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+var scanScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage how often the server scans your programs",
+	Long: `Manage the continuous reconnaissance cadence: the cron schedule the
+Recontronic server scans each program on.
+
+Available subcommands:
+  set  - Set the scan schedule of a program
+  list - List the scan schedules of your programs`,
+}
+
+var scanScheduleSetCmd = &cobra.Command{
+	Use:   "set <program>",
+	Short: "Set the scan schedule of a program",
+	Long: `Set how often the server scans a program (by name or ID).
+
+Frequencies:
+  hourly - Every hour, at the minute of --at
+  daily  - Every day at --at
+  weekly - Every week on --day at --at
+
+Any other cadence can be given as a five-field cron expression with
+--cron. Times are in --timezone (default UTC). --disable pauses the
+schedule without changing it.
+
+Examples:
+  recon-cli scan schedule set acme --frequency daily --at 02:00
+  recon-cli scan schedule set acme --frequency weekly --day sat --at 23:30 --timezone Europe/Berlin
+  recon-cli scan schedule set acme --cron "0 */6 * * *"
+  recon-cli scan schedule set acme --disable`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScanScheduleSet,
+}
+
+var scanScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the scan schedules of your programs",
+	Long: `List the scan schedules of your programs with their next and last runs.
+Every page of schedules is fetched unless --page or --limit is given.
+
+Examples:
+  recon-cli scan schedule list
+  recon-cli scan schedule list --limit 10
+  recon-cli scan schedule list --page 2`,
+	Args: cobra.NoArgs,
+	RunE: runScanScheduleList,
+}
+
+var (
+	scheduleFrequency string
+	scheduleAt        string
+	scheduleDay       string
+	scheduleCron      string
+	scheduleTimezone  string
+	scheduleDisable   bool
+	scheduleLimit     int
+	schedulePage      int
+)
+
+func init() {
+	scanCmd.AddCommand(scanScheduleCmd)
+	scanScheduleCmd.AddCommand(scanScheduleSetCmd)
+	scanScheduleCmd.AddCommand(scanScheduleListCmd)
+
+	scanScheduleSetCmd.Flags().StringVar(&scheduleFrequency, "frequency", "", "How often to scan: hourly, daily, weekly")
+	scanScheduleSetCmd.Flags().StringVar(&scheduleAt, "at", "00:00", "Time of day to scan (HH:MM)")
+	scanScheduleSetCmd.Flags().StringVar(&scheduleDay, "day", "mon", "Day of the week for weekly scans (sun-sat)")
+	scanScheduleSetCmd.Flags().StringVar(&scheduleCron, "cron", "", "Cron expression instead of --frequency (e.g., \"0 */6 * * *\")")
+	scanScheduleSetCmd.Flags().StringVar(&scheduleTimezone, "timezone", "UTC", "IANA time zone of the schedule (e.g., Europe/Berlin)")
+	scanScheduleSetCmd.Flags().BoolVar(&scheduleDisable, "disable", false, "Pause the schedule, keeping its cadence")
+	scanScheduleListCmd.Flags().IntVar(&scheduleLimit, "limit", 0, "Show at most this many schedules (0 = all)")
+	scanScheduleListCmd.Flags().IntVar(&schedulePage, "page", 0, fmt.Sprintf("Show only this page of %d schedules", client.DefaultPerPage))
+}
+
+func runScanScheduleSet(cmd *cobra.Command, args []string) error {
+	program := args[0]
+	if _, err := time.LoadLocation(scheduleTimezone); err != nil {
+		return fmt.Errorf("invalid --timezone: %s", scheduleTimezone)
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	req := models.ScheduleRequest{Timezone: scheduleTimezone, Enabled: !scheduleDisable}
+	switch {
+	case scheduleCron != "" && scheduleFrequency != "":
+		return fmt.Errorf("use either --frequency or --cron")
+	case scheduleCron != "":
+		if len(strings.Fields(scheduleCron)) != 5 {
+			return fmt.Errorf("invalid --cron: expected 5 fields (minute hour day month weekday)")
+		}
+		req.Cron = strings.Join(strings.Fields(scheduleCron), " ")
+	case scheduleFrequency != "":
+		req.Cron, err = frequencyCron(scheduleFrequency, scheduleAt, scheduleDay)
+		if err != nil {
+			return err
+		}
+	case scheduleDisable:
+		// Keep the server's cadence
+		current, err := findSchedule(ctx, restClient, program)
+		if err != nil {
+			return err
+		}
+		req.Cron, req.Timezone = current.Cron, current.Timezone
+	default:
+		return fmt.Errorf("--frequency, --cron, or --disable is required")
+	}
+
+	schedule, err := restClient.SetSchedule(ctx, program, req)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("program not found: %s", program)
+		}
+		return err
+	}
+
+	name := schedule.ProgramName
+	if name == "" {
+		name = program
+	}
+	if !schedule.Enabled {
+		fmt.Printf("✓ Paused the scan schedule of %s (%s)\n", name, describeCron(schedule.Cron))
+		return nil
+	}
+	if cadence := describeCron(schedule.Cron); cadence != schedule.Cron {
+		fmt.Printf("✓ %s is scanned %s (%s, cron %q)\n", name, cadence, schedule.Timezone, schedule.Cron)
+	} else {
+		fmt.Printf("✓ %s is scanned on cron %q (%s)\n", name, schedule.Cron, schedule.Timezone)
+	}
+	if schedule.NextRunAt != nil {
+		fmt.Printf("  Next run: %s\n", formatScheduleTime(*schedule.NextRunAt, schedule.Timezone))
+	}
+	return nil
+}
+
+func runScanScheduleList(cmd *cobra.Command, args []string) error {
+	if scheduleLimit < 0 || schedulePage < 0 {
+		return fmt.Errorf("--limit and --page must not be negative")
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := restClient.ListSchedules(context.Background(), client.ListOptions{Page: schedulePage, Limit: scheduleLimit})
+	if err != nil {
+		return err
+	}
+	if len(response.Schedules) == 0 {
+		fmt.Println("No scan schedules")
+		fmt.Println("\nSet one with 'recon-cli scan schedule set <program> --frequency daily --at 02:00'")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PROGRAM\tSCHEDULE\tTIMEZONE\tNEXT RUN\tLAST RUN\tSTATUS")
+	for _, schedule := range response.Schedules {
+		nextRun, lastRun, status := "-", "Never", "enabled"
+		if schedule.NextRunAt != nil && schedule.Enabled {
+			nextRun = formatScheduleTime(*schedule.NextRunAt, schedule.Timezone)
+		}
+		if schedule.LastRunAt != nil {
+			lastRun = formatTimeAgo(*schedule.LastRunAt)
+		}
+		if !schedule.Enabled {
+			status = "paused"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			valueOrDash(schedule.ProgramName), describeCron(schedule.Cron), valueOrDash(schedule.Timezone), nextRun, lastRun, status)
+	}
+	w.Flush()
+
+	if len(response.Schedules) < response.Total {
+		fmt.Printf("\nShowing %d of %d schedule(s)\n", len(response.Schedules), response.Total)
+	} else {
+		fmt.Printf("\nTotal: %d schedule(s)\n", response.Total)
+	}
+	return nil
+}
+
+// findSchedule returns the schedule of a program, by name or ID
+func findSchedule(ctx context.Context, restClient *client.RestClient, program string) (*models.Schedule, error) {
+	response, err := restClient.ListSchedules(ctx, client.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range response.Schedules {
+		if strings.EqualFold(schedule.ProgramName, program) || strconv.FormatInt(schedule.ProgramID, 10) == program {
+			return &schedule, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no scan schedule to pause", program)
+}
+
+// frequencyCron builds the cron expression of a --frequency
+func frequencyCron(frequency, at, day string) (string, error) {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return "", fmt.Errorf("invalid --at %q (expected HH:MM)", at)
+	}
+	minute, hour := clock.Minute(), clock.Hour()
+
+	switch strings.ToLower(frequency) {
+	case "hourly":
+		return fmt.Sprintf("%d * * * *", minute), nil
+	case "daily":
+		return fmt.Sprintf("%d %d * * *", minute, hour), nil
+	case "weekly":
+		weekday, ok := scheduleWeekdays[strings.ToLower(day)]
+		if !ok {
+			return "", fmt.Errorf("invalid --day %q (sun, mon, tue, wed, thu, fri, sat)", day)
+		}
+		return fmt.Sprintf("%d %d * * %d", minute, hour, weekday), nil
+	default:
+		return "", fmt.Errorf("invalid --frequency %q (hourly, daily, weekly)", frequency)
+	}
+}
+
+// describeCron describes the cron expressions --frequency builds in words,
+// and returns others as they are
+func describeCron(cron string) string {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 || fields[2] != "*" || fields[3] != "*" {
+		return cron
+	}
+	minute, err := strconv.Atoi(fields[0])
+	if err != nil || minute < 0 || minute > 59 {
+		return cron
+	}
+	if fields[1] == "*" && fields[4] == "*" {
+		return fmt.Sprintf("hourly at :%02d", minute)
+	}
+	hour, err := strconv.Atoi(fields[1])
+	if err != nil || hour < 0 || hour > 23 {
+		return cron
+	}
+	if fields[4] == "*" {
+		return fmt.Sprintf("daily at %02d:%02d", hour, minute)
+	}
+	weekday, err := strconv.Atoi(fields[4])
+	if err != nil || weekday < 0 || weekday > 7 {
+		return cron
+	}
+	return fmt.Sprintf("weekly on %s at %02d:%02d", time.Weekday(weekday % 7).String()[:3], hour, minute)
+}
+
+// formatScheduleTime formats a run time in the schedule's time zone
+func formatScheduleTime(t time.Time, timezone string) string {
+	if location, err := time.LoadLocation(timezone); err == nil && timezone != "" {
+		t = t.In(location)
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/client"
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"github.com/presstronic/recontronic-cli-client/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// teamRoles are the roles a member can be invited with
+var teamRoles = []string{"member", "admin"}
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Manage the hunters you collaborate with",
+	Long: `Manage your team on the Recontronic server: the hunters on the same
+platform instance you can share programs and findings with
+('recon-cli program share').
+
+Available subcommands:
+  list   - List the members of your team
+  invite - Invite a hunter to your team
+  remove - Remove a member from your team`,
+}
+
+var teamListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the members of your team",
+	Long: `List the members of your team with their roles, including the invites
+not accepted yet. Every page of members is fetched unless --page or
+--limit is given.
+
+Examples:
+  recon-cli team list
+  recon-cli team list --limit 10
+  recon-cli team list --page 2`,
+	Args: cobra.NoArgs,
+	RunE: runTeamList,
+}
+
+var teamInviteCmd = &cobra.Command{
+	Use:   "invite <username|email>",
+	Short: "Invite a hunter to your team",
+	Long: `Invite a hunter to your team, by username or email. They join once they
+accept the invite.
+
+Roles:
+  member - Sees the programs shared with them (default)
+  admin  - Also invites and removes members
+
+Examples:
+  recon-cli team invite alice
+  recon-cli team invite bob@example.com --role admin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTeamInvite,
+}
+
+var teamRemoveCmd = &cobra.Command{
+	Use:   "remove <username|email>",
+	Short: "Remove a member from your team",
+	Long: `Remove a member from your team, or withdraw their invite. Programs
+shared with them are no longer shared.
+
+Examples:
+  recon-cli team remove alice
+  recon-cli team remove bob@example.com --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTeamRemove,
+}
+
+var (
+	teamRole        string
+	teamRemoveForce bool
+	teamLimit       int
+	teamPage        int
+)
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+	teamCmd.AddCommand(teamListCmd)
+	teamCmd.AddCommand(teamInviteCmd)
+	teamCmd.AddCommand(teamRemoveCmd)
+
+	teamInviteCmd.Flags().StringVar(&teamRole, "role", "member", "Role of the new member: member, admin")
+	teamListCmd.Flags().IntVar(&teamLimit, "limit", 0, "Show at most this many members (0 = all)")
+	teamListCmd.Flags().IntVar(&teamPage, "page", 0, fmt.Sprintf("Show only this page of %d members", client.DefaultPerPage))
+	teamRemoveCmd.Flags().BoolVarP(&teamRemoveForce, "force", "f", false, "Skip confirmation prompt")
+}
+
+func runTeamList(cmd *cobra.Command, args []string) error {
+	if teamLimit < 0 || teamPage < 0 {
+		return fmt.Errorf("--limit and --page must not be negative")
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := restClient.ListTeamMembers(context.Background(), client.ListOptions{Page: teamPage, Limit: teamLimit})
+	if err != nil {
+		return err
+	}
+	if len(response.Members) == 0 {
+		fmt.Println("No team members")
+		fmt.Println("\nInvite one with 'recon-cli team invite <username|email>'")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tEMAIL\tROLE\tSTATUS\tSINCE")
+	for _, member := range response.Members {
+		since := "-"
+		if member.JoinedAt != nil {
+			since = formatTimeAgo(*member.JoinedAt)
+		} else if member.InvitedAt != nil {
+			since = formatTimeAgo(*member.InvitedAt)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			valueOrDash(member.Username), valueOrDash(member.Email), valueOrDash(member.Role), valueOrDash(member.Status), since)
+	}
+	w.Flush()
+
+	if len(response.Members) < response.Total {
+		fmt.Printf("\nShowing %d of %d member(s)\n", len(response.Members), response.Total)
+	} else {
+		fmt.Printf("\nTotal: %d member(s)\n", response.Total)
+	}
+	return nil
+}
+
+func runTeamInvite(cmd *cobra.Command, args []string) error {
+	user := strings.TrimSpace(args[0])
+	role := strings.ToLower(teamRole)
+	if !containsFold(teamRoles, role) {
+		return fmt.Errorf("invalid --role %q (%s)", teamRole, strings.Join(teamRoles, ", "))
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	member, err := restClient.InviteTeamMember(context.Background(), models.TeamInviteRequest{User: user, Role: role})
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("no user %s on this server", user)
+		}
+		if client.IsConflictError(err) {
+			return fmt.Errorf("%s is already on your team or invited", user)
+		}
+		return err
+	}
+
+	name := member.Username
+	if name == "" {
+		name = user
+	}
+	if member.Status == "active" {
+		fmt.Printf("✓ Added %s to your team as %s\n", name, member.Role)
+	} else {
+		fmt.Printf("✓ Invited %s to your team as %s\n", name, member.Role)
+		fmt.Println("  They join once they accept the invite")
+	}
+	return nil
+}
+
+func runTeamRemove(cmd *cobra.Command, args []string) error {
+	user := strings.TrimSpace(args[0])
+
+	if !teamRemoveForce {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Remove %s from your team? Programs shared with them will no longer be shared.", user))
+		if err != nil {
+			return fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Removal cancelled.")
+			return nil
+		}
+	}
+
+	restClient, err := syncClient()
+	if err != nil {
+		return err
+	}
+
+	if err := restClient.RemoveTeamMember(context.Background(), user); err != nil {
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("%s is not on your team", user)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ Removed %s from your team\n", user)
+	return nil
+}
@@ -3,10 +3,18 @@ module github.com/presstronic/recontronic-cli-client
 go 1.25.3
 
 require (
+	filippo.io/age v1.2.1
 	github.com/chzyer/readline v1.5.1
+	github.com/miekg/dns v1.1.68
 	github.com/spf13/cobra v1.10.1
+	github.com/spf13/pflag v1.0.10
 	github.com/spf13/viper v1.21.0
+	go.yaml.in/yaml/v3 v3.0.4
+	golang.org/x/net v0.42.0
+	golang.org/x/sys v0.37.0
 	golang.org/x/term v0.36.0
+	google.golang.org/grpc v1.76.0
+	google.golang.org/protobuf v1.36.12
 )
 
 require (
@@ -18,9 +26,11 @@ require (
 	github.com/sourcegraph/conc v0.3.1-0.20240121214520-5f936abd7ae8 // indirect
 	github.com/spf13/afero v1.15.0 // indirect
 	github.com/spf13/cast v1.10.0 // indirect
-	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
-	go.yaml.in/yaml/v3 v3.0.4 // indirect
-	golang.org/x/sys v0.37.0 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
+	golang.org/x/tools v0.35.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
 )
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+)
+
+// DeviceClientID identifies the CLI to the server's OAuth2 device flow
+const DeviceClientID = "recontronic-cli"
+
+// deviceGrantType is the grant type of device code token requests (RFC 8628)
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Defaults of the device flow when the server doesn't say
+const (
+	defaultDeviceInterval = 5 * time.Second
+	defaultDeviceExpiry   = 15 * time.Minute
+)
+
+// Errors ending a device login without an API key
+var (
+	ErrDeviceAccessDenied = errors.New("the login was denied")
+	ErrDeviceCodeExpired  = errors.New("the code expired before the login was approved")
+)
+
+// StartDeviceLogin starts an SSO login with the OAuth2 device authorization
+// grant; the user approves it by entering the returned user code at the
+// verification URI, through the organization's identity provider
+func (c *RestClient) StartDeviceLogin(ctx context.Context) (*models.DeviceCodeResponse, error) {
+	req := models.DeviceCodeRequest{ClientID: DeviceClientID}
+
+	var response models.DeviceCodeResponse
+	err := c.doRequest(ctx, "POST", "/api/v1/auth/device/code", req, &response, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SSO login: %w", err)
+	}
+	if response.DeviceCode == "" || response.UserCode == "" || response.VerificationURI == "" {
+		return nil, fmt.Errorf("failed to start SSO login: incomplete response from the server")
+	}
+
+	return &response, nil
+}
+
+// WaitDeviceLogin polls the server, at the interval it asks for, until the
+// user approves the device login, and returns the login with its API key.
+// It fails with ErrDeviceAccessDenied or ErrDeviceCodeExpired when the login
+// won't be approved, and stops when ctx is done.
+func (c *RestClient) WaitDeviceLogin(ctx context.Context, code *models.DeviceCodeResponse) (*models.LoginResponse, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceInterval
+	}
+	expiry := time.Duration(code.ExpiresIn) * time.Second
+	if expiry <= 0 {
+		expiry = defaultDeviceExpiry
+	}
+	ctx, cancel := context.WithTimeout(ctx, expiry)
+	defer cancel()
+
+	req := models.DeviceTokenRequest{
+		GrantType:  deviceGrantType,
+		DeviceCode: code.DeviceCode,
+		ClientID:   DeviceClientID,
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var loginResp models.LoginResponse
+		err := c.doRequest(ctx, "POST", "/api/v1/auth/device/token", req, &loginResp, false)
+		if err == nil {
+			return &loginResp, nil
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, fmt.Errorf("SSO login failed: %w", err)
+		}
+		switch apiErr.Message {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, ErrDeviceAccessDenied
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		default:
+			return nil, fmt.Errorf("SSO login failed: %w", err)
+		}
+	}
+}
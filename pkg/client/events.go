@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+)
+
+// maxEventSize caps one line of the event stream
+const maxEventSize = 1 << 20
+
+// ListEvents lists the most recent events, newest last, optionally only of
+// the given types
+func (c *RestClient) ListEvents(ctx context.Context, types []string, limit int) (*models.EventListResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	var response models.EventListResponse
+	err := c.doRequest(ctx, "GET", "/api/v1/events?"+eventQuery(types, limit).Encode(), nil, &response, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return &response, nil
+}
+
+// StreamEvents subscribes to the server's Server-Sent Events feed and calls
+// onEvent for each event until the server closes the stream (nil), onEvent
+// returns an error, or ctx is done. With lastEventID set, the server replays
+// the events after it, so a reconnect misses nothing. It returns the ID of
+// the last event received.
+func (c *RestClient) StreamEvents(ctx context.Context, types []string, lastEventID string, onEvent func(models.Event) error) (string, error) {
+	if c.apiKey == "" {
+		return lastEventID, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	streamURL := c.baseURL + "/api/v1/events/stream?" + eventQuery(types, 0).Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, nil)
+	if err != nil {
+		return lastEventID, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("User-Agent", "recontronic-cli/1.0.0")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	requestID := newRequestID()
+	req.Header.Set("X-Request-ID", requestID)
+
+	// The stream stays open, so it can't use the client's request timeout
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	c.logRequest(requestID, 1, req, nil)
+	start := time.Now()
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		c.logResponse(requestID, nil, nil, time.Since(start), err)
+		return lastEventID, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		c.logResponse(requestID, resp, body, time.Since(start), nil)
+		var errResp models.ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+			return lastEventID, &APIError{StatusCode: resp.StatusCode, Message: errResp.Error}
+		}
+		return lastEventID, &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.Status)}
+	}
+	// The body is the stream; each event is handed to onEvent instead
+	c.logResponse(requestID, resp, nil, time.Since(start), nil)
+	if mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";"); strings.TrimSpace(mediaType) != "text/event-stream" {
+		return lastEventID, fmt.Errorf("server did not answer with an event stream (Content-Type %q)", resp.Header.Get("Content-Type"))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+
+	var eventType, eventID string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line dispatches the event gathered so far
+			if eventID != "" {
+				lastEventID = eventID
+			}
+			if len(data) > 0 {
+				var event models.Event
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event); err != nil {
+					return lastEventID, fmt.Errorf("failed to parse event: %w", err)
+				}
+				if event.Type == "" {
+					event.Type = eventType
+				}
+				if event.ID == "" {
+					event.ID = eventID
+				}
+				if err := onEvent(event); err != nil {
+					return lastEventID, err
+				}
+			}
+			eventType, eventID, data = "", "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, e.g., a heartbeat
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			eventID = value
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return lastEventID, fmt.Errorf("event stream failed: %w", err)
+	}
+
+	return lastEventID, nil
+}
+
+// eventQuery builds the query of the event endpoints
+func eventQuery(types []string, limit int) url.Values {
+	query := url.Values{}
+	if len(types) > 0 {
+		query.Set("types", strings.Join(types, ","))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return query
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	reconv1 "github.com/presstronic/recontronic-cli-client/proto/recon/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCOptions configures the connection to the gRPC server
+type GRPCOptions struct {
+	TLS        string       // auto (TLS except on loopback addresses), on, or off
+	TLSOptions TLSOptions   // CA, client certificate, and verification of TLS connections
+	Logger     *slog.Logger // Logs each call at debug level; nil discards the records
+}
+
+// GRPCClient handles streaming communication with the Recontronic gRPC server
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	apiKey string
+	logger *slog.Logger
+}
+
+// NewGRPCClient creates a gRPC client for address. The connection is made
+// lazily on the first call, kept alive while streams are open, and
+// re-established by later calls after it drops.
+func NewGRPCClient(address, apiKey string, opts GRPCOptions) (*GRPCClient, error) {
+	creds, err := transportCredentials(address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &GRPCClient{apiKey: apiKey, logger: opts.Logger}
+	if c.logger == nil {
+		c.logger = discardLogger
+	}
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.WithUserAgent("recontronic-cli/1.0.0"),
+		grpc.WithUnaryInterceptor(c.unaryAuthInterceptor),
+		grpc.WithStreamInterceptor(c.streamAuthInterceptor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	c.conn = conn
+
+	return c, nil
+}
+
+// Close closes the connection
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// WatchScan streams the progress of a scan, calling onProgress for each
+// update until the server ends the stream (nil), onProgress returns an
+// error, or ctx is done
+func (c *GRPCClient) WatchScan(ctx context.Context, scanID int64, onProgress func(models.ScanProgress) error) error {
+	stream, err := reconv1.NewStreamServiceClient(c.conn).WatchScan(ctx, &reconv1.WatchScanRequest{ScanId: scanID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := onProgress(scanProgressModel(progress)); err != nil {
+			return err
+		}
+	}
+}
+
+// scanProgressModel converts a recon.v1.ScanProgress, whose timestamp is in
+// Unix seconds
+func scanProgressModel(progress *reconv1.ScanProgress) models.ScanProgress {
+	return models.ScanProgress{
+		ScanID:      progress.GetScanId(),
+		Status:      progress.GetStatus(),
+		Progress:    int(progress.GetProgress()),
+		CurrentStep: progress.GetCurrentStep(),
+		AssetsFound: int(progress.GetAssetsFound()),
+		Timestamp:   time.Unix(progress.GetTimestamp(), 0),
+	}
+}
+
+// CheckHealth asks the server's standard gRPC health service whether it is
+// serving, returning its status (e.g., SERVING). A server without the health
+// service reports UNKNOWN: that it answered says nothing about whether
+// StreamService is ready.
+func (c *GRPCClient) CheckHealth(ctx context.Context) (string, error) {
+	response, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return healthpb.HealthCheckResponse_UNKNOWN.String(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return response.GetStatus().String(), nil
+}
+
+// unaryAuthInterceptor adds the API key to unary calls
+func (c *GRPCClient) unaryAuthInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(c.authContext(ctx, method), method, req, reply, cc, opts...)
+}
+
+// streamAuthInterceptor adds the API key to streaming calls
+func (c *GRPCClient) streamAuthInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.authContext(ctx, method), desc, cc, method, opts...)
+}
+
+// authContext carries the API key as a bearer token in the call's metadata
+func (c *GRPCClient) authContext(ctx context.Context, method string) context.Context {
+	c.logger.Debug("gRPC call",
+		"target", c.conn.Target(),
+		"method", method,
+		"authenticated", c.apiKey != "")
+	if c.apiKey == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.apiKey)
+}
+
+// transportCredentials returns plaintext or TLS credentials for address as
+// opts.TLS asks
+func transportCredentials(address string, opts GRPCOptions) (credentials.TransportCredentials, error) {
+	useTLS := false
+	switch opts.TLS {
+	case "", "auto":
+		useTLS = !isLoopbackAddress(address)
+	case "on":
+		useTLS = true
+	case "off":
+	default:
+		return nil, fmt.Errorf("invalid gRPC TLS mode %q (must be: auto, on, or off)", opts.TLS)
+	}
+	if !useTLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig, err := opts.TLSOptions.Config()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// isLoopbackAddress reports whether a host:port address is on this machine
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// IsUnavailableError returns true if the gRPC server could not be reached or
+// the connection dropped, which a retry may recover from
+func IsUnavailableError(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
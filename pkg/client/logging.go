@@ -0,0 +1,177 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLoggedBody caps the bodies included in debug log records; the trace
+// file gets them whole
+const maxLoggedBody = 1024
+
+// redacted replaces secrets in logs and traces
+const redacted = "[REDACTED]"
+
+// secretHeaders are the headers whose values never reach logs or traces
+var secretHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Api-Key":     true,
+}
+
+// secretFields are the JSON fields whose values never reach logs or traces
+var secretFields = map[string]bool{
+	"password":      true,
+	"api_key":       true,
+	"plain_key":     true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"device_code":   true,
+	"secret":        true,
+	"client_secret": true,
+}
+
+// discardLogger is the logger of clients not given one
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// Tracer writes full transcripts of API requests and responses, with
+// secrets redacted, e.g., to attach to a support case
+type Tracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTracer creates a tracer writing to w
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{w: w}
+}
+
+// request writes a request to the transcript
+func (t *Tracer) request(id string, attempt int, req *http.Request, body []byte) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s request %s (attempt %d)\n", time.Now().UTC().Format(time.RFC3339Nano), id, attempt)
+	fmt.Fprintf(&b, "%s %s\n", req.Method, req.URL)
+	writeHeaders(&b, req.Header)
+	writeBody(&b, body)
+	t.write(b.String())
+}
+
+// response writes a response, or the failure to get one, to the transcript
+func (t *Tracer) response(id string, resp *http.Response, body []byte, elapsed time.Duration, err error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s response %s after %s\n", time.Now().UTC().Format(time.RFC3339Nano), id, elapsed.Round(time.Millisecond))
+	if err != nil {
+		fmt.Fprintf(&b, "error: %v\n\n", err)
+		t.write(b.String())
+		return
+	}
+	fmt.Fprintf(&b, "%s %s\n", resp.Proto, resp.Status)
+	writeHeaders(&b, resp.Header)
+	writeBody(&b, body)
+	t.write(b.String())
+}
+
+func (t *Tracer) write(entry string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.w, entry)
+}
+
+// writeHeaders writes headers sorted by name, secrets redacted
+func writeHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if secretHeaders[http.CanonicalHeaderKey(name)] {
+				value = redacted
+			}
+			fmt.Fprintf(b, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// writeBody writes a body after a blank line, secrets redacted
+func writeBody(b *strings.Builder, body []byte) {
+	b.WriteString("\n")
+	if len(body) > 0 {
+		b.Write(redactBody(body))
+		b.WriteString("\n\n")
+	}
+}
+
+// redactBody replaces the values of secret fields of a JSON body; other
+// bodies are returned unchanged
+func redactBody(body []byte) []byte {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return body
+	}
+	if !redactValue(document) {
+		return body
+	}
+	redactedBody, err := json.Marshal(document)
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+// redactValue redacts secret fields in a decoded JSON value in place,
+// reporting whether it found any
+func redactValue(value interface{}) bool {
+	found := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if secretFields[strings.ToLower(key)] {
+				if s, ok := field.(string); !ok || s != "" {
+					v[key] = redacted
+					found = true
+				}
+				continue
+			}
+			if redactValue(field) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactValue(item) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// logBody returns a body for a debug log record: redacted and truncated
+func logBody(body []byte) string {
+	body = redactBody(body)
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + fmt.Sprintf("... (%d bytes)", len(body))
+	}
+	return string(body)
+}
+
+// newRequestID returns a random ID sent with a request as X-Request-ID, so
+// log records, traces, and the server's logs can be matched up
+func newRequestID() string {
+	var id [8]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPerPage is the page size list requests use unless told otherwise
+const DefaultPerPage = 50
+
+// ListOptions select what a list request returns
+type ListOptions struct {
+	Page    int // Fetch only this page (1-based); 0 fetches every page
+	PerPage int // Items per page (0 = DefaultPerPage)
+	Limit   int // Stop after this many items (0 = no limit)
+}
+
+// paginate fetches the items of a list endpoint whose responses carry them
+// in the JSON array key, with the total count in "total". Pages are
+// requested with page and per_page query parameters until a short or empty
+// page, the total, or opts.Limit is reached. It returns the items and the
+// server's total, or the number of items when the server sends none.
+func paginate[T any](ctx context.Context, c *RestClient, path, key string, query url.Values, opts ListOptions) ([]T, int, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if opts.Limit > 0 && opts.Limit < perPage {
+		perPage = opts.Limit
+	}
+	page := max(opts.Page, 1)
+
+	query = cloneQuery(query)
+	var items []T
+	total := -1
+	for {
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(perPage))
+
+		var response map[string]json.RawMessage
+		if err := c.doRequest(ctx, "GET", path+"?"+query.Encode(), nil, &response, true); err != nil {
+			return nil, 0, err
+		}
+		var pageItems []T
+		if raw, ok := response[key]; ok {
+			if err := json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, 0, fmt.Errorf("failed to parse %s: %w", key, err)
+			}
+		}
+		if raw, ok := response["total"]; ok {
+			if err := json.Unmarshal(raw, &total); err != nil {
+				return nil, 0, fmt.Errorf("failed to parse total: %w", err)
+			}
+		}
+
+		items = append(items, pageItems...)
+		if opts.Limit > 0 && len(items) >= opts.Limit {
+			items = items[:opts.Limit]
+			break
+		}
+		// A server that ignores the parameters sends everything at once
+		if opts.Page > 0 || len(pageItems) == 0 || len(pageItems) != perPage || (total >= 0 && len(items) >= total) {
+			break
+		}
+		page++
+	}
+
+	if total < 0 {
+		total = len(items)
+	}
+	return items, total, nil
+}
+
+// cloneQuery copies a query so paginate can set its parameters
+func cloneQuery(query url.Values) url.Values {
+	clone := url.Values{}
+	for name, values := range query {
+		clone[name] = append([]string(nil), values...)
+	}
+	return clone
+}
@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // RestClient handles HTTP communication with the Recontronic API
@@ -18,7 +24,9 @@ type RestClient struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
-	debug      bool
+	retry      RetryPolicy
+	logger     *slog.Logger
+	tracer     *Tracer
 }
 
 // NewRestClient creates a new REST API client
@@ -29,13 +37,29 @@ func NewRestClient(baseURL, apiKey string, timeout time.Duration) *RestClient {
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
-		debug: false,
+		retry:  DefaultRetryPolicy(),
+		logger: discardLogger,
 	}
 }
 
-// SetDebug enables or disables debug logging
-func (c *RestClient) SetDebug(debug bool) {
-	c.debug = debug
+// SetLogger sets the logger of requests, responses, and retries; requests
+// are logged at debug level. A nil logger discards the records.
+func (c *RestClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = discardLogger
+	}
+	c.logger = logger
+}
+
+// SetTracer sets a tracer that gets a transcript of every request and
+// response; nil turns tracing off
+func (c *RestClient) SetTracer(tracer *Tracer) {
+	c.tracer = tracer
+}
+
+// SetRetryPolicy sets how failed requests are retried
+func (c *RestClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
 }
 
 // SetAPIKey updates the API key for authenticated requests
@@ -43,87 +67,171 @@ func (c *RestClient) SetAPIKey(apiKey string) {
 	c.apiKey = apiKey
 }
 
-// doRequest performs an HTTP request with proper error handling
+// doRequest performs an HTTP request with proper error handling. Failed
+// requests are retried as the retry policy allows, waiting for the
+// server's Retry-After when it sends one.
 func (c *RestClient) doRequest(ctx context.Context, method, path string, body interface{}, response interface{}, authenticated bool) error {
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	// Retries keep the ID, so the server can tell them apart from new requests
+	requestID := newRequestID()
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.send(ctx, requestID, attempt+1, method, path, jsonData, authenticated)
+		if err == nil {
+			// Parse success response
+			if response != nil && len(respBody) > 0 {
+				if err := json.Unmarshal(respBody, response); err != nil {
+					return fmt.Errorf("failed to unmarshal response: %w", err)
+				}
+			}
+			return nil
+		}
 
-		if c.debug {
-			fmt.Printf("→ Request Body: %s\n", string(jsonData))
+		status := 0
+		var retryAfter time.Duration
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			status = apiErr.StatusCode
+			retryAfter = apiErr.RetryAfter
+		}
+		if attempt >= c.retry.MaxRetries || ctx.Err() != nil || !retryable(method, status) {
+			return err
+		}
+
+		delay := c.retry.backoff(attempt + 1)
+		if retryAfter > 0 {
+			if retryAfter > c.retry.MaxDelay {
+				return err // Not worth blocking on; the error carries the wait
+			}
+			delay = retryAfter
+		}
+		c.logger.Debug("retrying API request",
+			"request_id", requestID,
+			"delay", delay.Round(time.Millisecond),
+			"retry", attempt+1,
+			"max_retries", c.retry.MaxRetries,
+			"error", err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
 		}
 	}
+}
+
+// send makes one attempt of a request and returns the response body, or an
+// *APIError for an error response
+func (c *RestClient) send(ctx context.Context, requestID string, attempt int, method, path string, jsonData []byte, authenticated bool) ([]byte, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
 
 	url := c.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "recontronic-cli/1.0.0")
+	req.Header.Set("X-Request-ID", requestID)
 
 	// Add authentication header if required and API key is available
 	if authenticated && c.apiKey != "" {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-		if c.debug {
-			// Sanitize API key in debug output
-			sanitized := c.apiKey
-			if len(sanitized) > 12 {
-				sanitized = sanitized[:8] + "..." + sanitized[len(sanitized)-4:]
-			}
-			fmt.Printf("→ Authorization: Bearer %s\n", sanitized)
-		}
-	}
-
-	if c.debug {
-		fmt.Printf("→ %s %s\n", method, url)
 	}
 
+	c.logRequest(requestID, attempt, req, jsonData)
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		c.logResponse(requestID, nil, nil, time.Since(start), err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if c.debug {
-		fmt.Printf("← %d %s\n", resp.StatusCode, resp.Status)
-		fmt.Printf("← Response Body: %s\n", string(respBody))
+		c.logResponse(requestID, resp, nil, time.Since(start), err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	c.logResponse(requestID, resp, respBody, time.Since(start), nil)
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.Status),
+		}
 		var errResp models.ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    errResp.Error,
-			}
+			apiErr.Message = errResp.Error
 		}
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.Status),
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 		}
+		return nil, apiErr
 	}
 
-	// Parse success response
-	if response != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, response); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+	return respBody, nil
+}
+
+// logRequest logs a request at debug level and traces it
+func (c *RestClient) logRequest(requestID string, attempt int, req *http.Request, body []byte) {
+	attrs := []any{
+		"request_id", requestID,
+		"method", req.Method,
+		"url", req.URL.String(),
+		"attempt", attempt,
+		"authenticated", req.Header.Get("Authorization") != "",
+	}
+	if len(body) > 0 {
+		attrs = append(attrs, "body", logBody(body))
+	}
+	c.logger.Debug("API request", attrs...)
+
+	if c.tracer != nil {
+		c.tracer.request(requestID, attempt, req, body)
+	}
+}
+
+// logResponse logs a response, or the failure to get one, at debug level
+// and traces it
+func (c *RestClient) logResponse(requestID string, resp *http.Response, body []byte, elapsed time.Duration, err error) {
+	if err != nil {
+		c.logger.Debug("API request failed",
+			"request_id", requestID,
+			"duration", elapsed.Round(time.Millisecond),
+			"error", err)
+	} else {
+		attrs := []any{
+			"request_id", requestID,
+			"status", resp.StatusCode,
+			"duration", elapsed.Round(time.Millisecond),
+			"bytes", len(body),
 		}
+		if serverID := resp.Header.Get("X-Request-ID"); serverID != "" && serverID != requestID {
+			attrs = append(attrs, "server_request_id", serverID)
+		}
+		if len(body) > 0 {
+			attrs = append(attrs, "body", logBody(body))
+		}
+		c.logger.Debug("API response", attrs...)
 	}
 
-	return nil
+	if c.tracer != nil {
+		c.tracer.response(requestID, resp, body, elapsed, err)
+	}
 }
 
 // Register creates a new user account
@@ -174,8 +282,19 @@ func (c *RestClient) GetCurrentUser(ctx context.Context) (*models.User, error) {
 	return &user, nil
 }
 
-// CreateAPIKey generates a new API key
-func (c *RestClient) CreateAPIKey(ctx context.Context, name string, expiresAt *time.Time) (*models.APIKey, error) {
+// Health retrieves the server's health report; it needs no authentication
+func (c *RestClient) Health(ctx context.Context) (*models.HealthResponse, error) {
+	var health models.HealthResponse
+	err := c.doRequest(ctx, "GET", "/api/v1/health", nil, &health, false)
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+
+	return &health, nil
+}
+
+// CreateAPIKey generates a new API key, limited to scopes when given
+func (c *RestClient) CreateAPIKey(ctx context.Context, name string, expiresAt *time.Time, scopes []string) (*models.APIKey, error) {
 	if c.apiKey == "" {
 		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
 	}
@@ -183,6 +302,7 @@ func (c *RestClient) CreateAPIKey(ctx context.Context, name string, expiresAt *t
 	req := models.CreateAPIKeyRequest{
 		Name:      name,
 		ExpiresAt: expiresAt,
+		Scopes:    scopes,
 	}
 
 	var apiKey models.APIKey
@@ -194,19 +314,19 @@ func (c *RestClient) CreateAPIKey(ctx context.Context, name string, expiresAt *t
 	return &apiKey, nil
 }
 
-// ListAPIKeys retrieves all API keys for the current user
-func (c *RestClient) ListAPIKeys(ctx context.Context) (*models.APIKeyListResponse, error) {
+// ListAPIKeys retrieves the API keys of the current user, every page of
+// them unless opts selects a page or a limit
+func (c *RestClient) ListAPIKeys(ctx context.Context, opts ListOptions) (*models.APIKeyListResponse, error) {
 	if c.apiKey == "" {
 		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
 	}
 
-	var response models.APIKeyListResponse
-	err := c.doRequest(ctx, "GET", "/api/v1/auth/keys", nil, &response, true)
+	keys, total, err := paginate[models.APIKey](ctx, c, "/api/v1/auth/keys", "api_keys", nil, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list API keys: %w", err)
 	}
 
-	return &response, nil
+	return &models.APIKeyListResponse{APIKeys: keys, Total: total}, nil
 }
 
 // RevokeAPIKey deletes/revokes an API key by ID
@@ -228,35 +348,171 @@ func (c *RestClient) RevokeAPIKey(ctx context.Context, keyID int64) error {
 	return nil
 }
 
+// StartSyncUpload announces a local result file to upload
+func (c *RestClient) StartSyncUpload(ctx context.Context, req models.SyncUploadRequest) (*models.SyncUploadResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	var response models.SyncUploadResponse
+	err := c.doRequest(ctx, "POST", "/api/v1/sync/uploads", req, &response, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start upload: %w", err)
+	}
+
+	return &response, nil
+}
+
+// UploadSyncChunk sends one chunk of an upload
+func (c *RestClient) UploadSyncChunk(ctx context.Context, uploadID string, chunk models.SyncChunkRequest) error {
+	path := fmt.Sprintf("/api/v1/sync/uploads/%s/chunks/%d", url.PathEscape(uploadID), chunk.Index)
+	err := c.doRequest(ctx, "PUT", path, chunk, nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to upload chunk %d: %w", chunk.Index, err)
+	}
+
+	return nil
+}
+
+// CompleteSyncUpload finishes an upload once every chunk is sent
+func (c *RestClient) CompleteSyncUpload(ctx context.Context, uploadID string) (*models.SyncCompleteResponse, error) {
+	path := fmt.Sprintf("/api/v1/sync/uploads/%s/complete", url.PathEscape(uploadID))
+	var response models.SyncCompleteResponse
+	err := c.doRequest(ctx, "POST", path, nil, &response, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ListSyncResults lists the result files the server holds for a domain or,
+// when program is set, for every domain of a program
+func (c *RestClient) ListSyncResults(ctx context.Context, domain, program string) (*models.SyncResultListResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	query := url.Values{}
+	if program != "" {
+		query.Set("program", program)
+	} else {
+		query.Set("domain", domain)
+	}
+
+	results, total, err := paginate[models.SyncResult](ctx, c, "/api/v1/sync/results", "results", query, ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server results: %w", err)
+	}
+
+	return &models.SyncResultListResponse{Results: results, Total: total}, nil
+}
+
+// DownloadSyncResult downloads the JSON document of a result file
+func (c *RestClient) DownloadSyncResult(ctx context.Context, resultID int64) ([]byte, error) {
+	path := fmt.Sprintf("/api/v1/sync/results/%d/content", resultID)
+	var document json.RawMessage
+	err := c.doRequest(ctx, "GET", path, nil, &document, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download result %d: %w", resultID, err)
+	}
+
+	return document, nil
+}
+
 // APIError represents an error returned from the API
 type APIError struct {
 	StatusCode int
 	Message    string
+	RetryAfter time.Duration // The server's Retry-After on 429 and 503, if any
 }
 
 func (e *APIError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("API error (%d): %s (retry after %s)", e.StatusCode, e.Message, e.RetryAfter)
+	}
 	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
 }
 
-// IsAuthError returns true if the error is an authentication error (401)
+// IsAuthError returns true if the error is an authentication error (401, or
+// Unauthenticated from the gRPC server)
 func IsAuthError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.StatusCode == http.StatusUnauthorized
 	}
-	return false
+	return status.Code(err) == codes.Unauthenticated
 }
 
-// IsNotFoundError returns true if the error is a not found error (404)
+// IsNotFoundError returns true if the error is a not found error (404, or
+// NotFound from the gRPC server)
 func IsNotFoundError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.StatusCode == http.StatusNotFound
 	}
+	return status.Code(err) == codes.NotFound
+}
+
+// IsConflictError returns true if the error is a conflict error (409), e.g.,
+// the server holding a different version of an uploaded result
+func IsConflictError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusConflict
+	}
 	return false
 }
 
+// IsRateLimitError returns true if the error is a rate limit error (429)
+// that persisted through the retries; its RetryAfter says how long to wait
+func IsRateLimitError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsUnreachableError returns true if the server could not be reached: the
+// connection failed or dropped, or a gateway in front of the server
+// answered 502, 503, or 504. Operations that fail this way can be retried
+// once the network is back.
+func IsUnreachableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		return true
+	case errors.As(err, &opErr):
+		// Not "remote error", which is the server rejecting the TLS handshake
+		return opErr.Op == "dial" || opErr.Op == "read" || opErr.Op == "write"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	return status.Code(err) == codes.Unavailable
+}
+
 // IsValidationError returns true if the error is a validation error (400)
 func IsValidationError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.StatusCode == http.StatusBadRequest
 	}
 	return false
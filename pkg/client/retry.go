@@ -0,0 +1,76 @@
+package client
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt (0 = none)
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each further one
+	MaxDelay   time.Duration // Cap on the backoff, and on the Retry-After the client waits for
+}
+
+// DefaultRetryPolicy returns the policy new clients start with
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// idempotentMethods may be sent again after a failure without side effects
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryable reports whether a failed request may be retried; status is
+// that of the error response, or 0 when no response arrived. A rate-limited
+// request (429) was not processed, so it is retried whatever the method;
+// connection failures and server errors (5xx) only for idempotent methods.
+func retryable(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	if !idempotentMethods[method] {
+		return false
+	}
+	return status == 0 || status >= 500
+}
+
+// backoff returns the delay before retry attempt (1-based): exponential from
+// BaseDelay, capped at MaxDelay, with jitter so clients don't retry in step
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header, in seconds or as an HTTP date;
+// zero when absent or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
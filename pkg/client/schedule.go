@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+)
+
+// ListSchedules lists the scan schedules of the user's programs
+func (c *RestClient) ListSchedules(ctx context.Context, opts ListOptions) (*models.ScheduleListResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	schedules, total, err := paginate[models.Schedule](ctx, c, "/api/v1/schedules", "schedules", url.Values{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	return &models.ScheduleListResponse{Schedules: schedules, Total: total}, nil
+}
+
+// SetSchedule sets how often the server scans a program, by name or ID
+func (c *RestClient) SetSchedule(ctx context.Context, program string, req models.ScheduleRequest) (*models.Schedule, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	path := fmt.Sprintf("/api/v1/programs/%s/schedule", url.PathEscape(program))
+	var schedule models.Schedule
+	err := c.doRequest(ctx, "PUT", path, req, &schedule, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
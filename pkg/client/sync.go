@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+)
+
+// SyncChunkSize is the number of raw bytes sent per upload chunk
+const SyncChunkSize = 1 << 20
+
+// SyncUpload is the outcome of uploading one result file
+type SyncUpload struct {
+	SHA256   string
+	ResultID int64
+	Existed  bool // The server already had an identical copy
+}
+
+// ResultChecksum returns the hex SHA-256 of a result file's contents, the
+// checksum uploads are identified by
+func ResultChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// UploadResult uploads the contents of one result file in chunks of
+// SyncChunkSize. req needs its domain, tool, file, and timestamp; the size,
+// checksum, and chunk count are filled in. The server answers a conflict
+// (409) when it holds a different version of the file and overwrite is off.
+func (c *RestClient) UploadResult(ctx context.Context, req models.SyncUploadRequest, data []byte) (*SyncUpload, error) {
+	req.SHA256 = ResultChecksum(data)
+	req.Size = int64(len(data))
+	req.Chunks = (len(data) + SyncChunkSize - 1) / SyncChunkSize
+
+	started, err := c.StartSyncUpload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if started.Status == "exists" {
+		return &SyncUpload{SHA256: req.SHA256, ResultID: started.ResultID, Existed: true}, nil
+	}
+
+	for index := 0; index < req.Chunks; index++ {
+		end := min((index+1)*SyncChunkSize, len(data))
+		chunk := models.SyncChunkRequest{
+			Index: index,
+			Data:  base64.StdEncoding.EncodeToString(data[index*SyncChunkSize : end]),
+		}
+		if err := c.UploadSyncChunk(ctx, started.UploadID, chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	completed, err := c.CompleteSyncUpload(ctx, started.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncUpload{SHA256: req.SHA256, ResultID: completed.ResultID}, nil
+}
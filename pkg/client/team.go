@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/models"
+)
+
+// ListTeamMembers lists the members of the user's team, invites included
+func (c *RestClient) ListTeamMembers(ctx context.Context, opts ListOptions) (*models.TeamMemberListResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	members, total, err := paginate[models.TeamMember](ctx, c, "/api/v1/team/members", "members", url.Values{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+
+	return &models.TeamMemberListResponse{Members: members, Total: total}, nil
+}
+
+// InviteTeamMember invites a user to the team, by username or email
+func (c *RestClient) InviteTeamMember(ctx context.Context, req models.TeamInviteRequest) (*models.TeamMember, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	var member models.TeamMember
+	err := c.doRequest(ctx, "POST", "/api/v1/team/invites", req, &member, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite team member: %w", err)
+	}
+
+	return &member, nil
+}
+
+// RemoveTeamMember removes a member from the team, or withdraws their
+// invite; programs shared with them are no longer shared
+func (c *RestClient) RemoveTeamMember(ctx context.Context, user string) error {
+	if c.apiKey == "" {
+		return fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	path := fmt.Sprintf("/api/v1/team/members/%s", url.PathEscape(user))
+	err := c.doRequest(ctx, "DELETE", path, nil, nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	return nil
+}
+
+// ShareProgram shares a program, by name or ID, and its findings with a user
+func (c *RestClient) ShareProgram(ctx context.Context, program string, req models.ProgramShareRequest) (*models.ProgramShare, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("authentication required: please run 'recon-cli auth login' first")
+	}
+
+	path := fmt.Sprintf("/api/v1/programs/%s/shares", url.PathEscape(program))
+	var share models.ProgramShare
+	err := c.doRequest(ctx, "POST", path, req, &share, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to share program: %w", err)
+	}
+
+	return &share, nil
+}
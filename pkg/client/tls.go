@@ -0,0 +1,55 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions are the TLS settings of connections to the server, shared by
+// the REST and gRPC clients
+type TLSOptions struct {
+	CAFile             string // PEM CA bundle to verify the server with; system roots when empty
+	ClientCert         string // PEM client certificate for mutual TLS
+	ClientKey          string // PEM key of the client certificate
+	InsecureSkipVerify bool
+}
+
+// Empty reports whether no TLS setting differs from the defaults
+func (o TLSOptions) Empty() bool {
+	return o == TLSOptions{}
+}
+
+// Config builds the tls.Config of the options
+func (o TLSOptions) Config() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		if o.ClientCert == "" || o.ClientKey == "" {
+			return nil, fmt.Errorf("mutual TLS needs both a client certificate and its key (tls.client-cert, tls.client-key)")
+		}
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
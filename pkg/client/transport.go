@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions tune the client's connections to the server, e.g., for
+// corporate proxies that drop idle connections
+type TransportOptions struct {
+	Proxy           *url.URL      // Route API traffic through this proxy; nil honors HTTPS_PROXY, HTTP_PROXY, and NO_PROXY
+	MaxIdleConns    int           // Idle connections kept open to the server (0 = default)
+	IdleConnTimeout time.Duration // Close idle connections after this long (0 = default)
+	KeepAlive       time.Duration // TCP keep-alive interval (0 = default, negative disables)
+	TLS             TLSOptions
+}
+
+// Transport builds the http.Transport of the options, starting from the
+// standard library's defaults
+func (o TransportOptions) Transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if o.Proxy != nil {
+		transport.Proxy = http.ProxyURL(o.Proxy)
+	}
+	if o.MaxIdleConns > 0 {
+		transport.MaxIdleConns = o.MaxIdleConns
+		transport.MaxIdleConnsPerHost = o.MaxIdleConns
+	}
+	if o.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = o.IdleConnTimeout
+	}
+	if o.KeepAlive != 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: o.KeepAlive}
+		transport.DialContext = dialer.DialContext
+	}
+	if !o.TLS.Empty() {
+		tlsConfig, err := o.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// SetTransport replaces the client's connection settings; the event stream
+// shares them
+func (c *RestClient) SetTransport(opts TransportOptions) error {
+	transport, err := opts.Transport()
+	if err != nil {
+		return err
+	}
+	c.httpClient.Transport = transport
+	return nil
+}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,12 +16,102 @@ import (
 
 // Config represents the CLI configuration
 type Config struct {
-	Server       string        `mapstructure:"server"`
-	GRPCServer   string        `mapstructure:"grpc_server"`
-	APIKey       string        `mapstructure:"api_key"`
-	Timeout      time.Duration `mapstructure:"timeout"`
-	OutputFormat string        `mapstructure:"output_format"`
-	LogLevel     string        `mapstructure:"log_level"`
+	Server        string                 `mapstructure:"server"`
+	GRPCServer    string                 `mapstructure:"grpc_server"`
+	GRPCTLS       string                 `mapstructure:"grpc_tls"`     // auto (TLS except on loopback), on, or off
+	GRPCCACert    string                 `mapstructure:"grpc_ca_cert"` // PEM CA bundle to verify the gRPC server with
+	APIKey        string                 `mapstructure:"api_key"`
+	Timeout       time.Duration          `mapstructure:"timeout"`
+	MaxRetries    int                    `mapstructure:"max_retries"` // Retries of failed API requests (0 = none)
+	OutputFormat  string                 `mapstructure:"output_format"`
+	LogLevel      string                 `mapstructure:"log_level"`
+	Proxy         string                 `mapstructure:"proxy"`
+	DoH           string                 `mapstructure:"doh"` // DNS-over-HTTPS endpoint for recon DNS lookups
+	GitHubToken   string                 `mapstructure:"github_token"`
+	GitLabToken   string                 `mapstructure:"gitlab_token"`
+	WebhookSecret string                 `mapstructure:"webhook_secret"` // HMAC key for results export --webhook
+	Wordlists     map[string]string      `mapstructure:"wordlists"`      // Wordlist path per purpose (e.g., dirs)
+	Retention     RetentionConfig        `mapstructure:"retention"`
+	Notify        NotifyConfig           `mapstructure:"notify"`
+	DefectDojo    DefectDojoConfig       `mapstructure:"defectdojo"`
+	Faraday       FaradayConfig          `mapstructure:"faraday"`
+	TLS           TLSConfig              `mapstructure:"tls"`
+	API           APIConfig              `mapstructure:"api"`
+	Compress      bool                   `mapstructure:"compress_results"` // Save recon results as .json.gz
+	AutoSync      bool                   `mapstructure:"auto_sync"`        // Push results to the server after each scan
+	Profile       string                 `mapstructure:"profile"`          // Name of the active account ("" = none)
+	Profiles      map[string]Profile     `mapstructure:"profiles"`         // Saved accounts by name
+	Targets       []TargetConfig         `mapstructure:"targets"`          // Recon defaults per domain or program
+	Defaults      map[string]interface{} `mapstructure:"defaults"`         // Flag defaults, nested by command path
+
+	sealedAPIKey     string              // API key as stored while the vault is locked
+	apiKeyEncrypted  bool                // API key is sealed by the vault on disk
+	plainProfileKeys int                 // Profile API keys stored unsealed on disk
+	diskProfile      string              // Active profile on disk while another is in use for this run
+	diskActive       *Profile            // Active settings on disk while another profile is in use
+	overrides        map[string]override // Settings given as flags or environment variables
+	previous         *Config             // Configuration before the overrides
+}
+
+// RetentionConfig limits how many stored recon results are kept per domain
+// and tool; it is applied after each scan
+type RetentionConfig struct {
+	MaxAge   string `mapstructure:"max_age"`   // e.g., 30d or 720h ("" = no age limit)
+	KeepLast int    `mapstructure:"keep_last"` // Newest results always kept (0 = no minimum)
+}
+
+// NotifyConfig holds the incoming webhook URLs that 'recon notify' and the
+// --notify flags post summaries to
+type NotifyConfig struct {
+	Slack   string `mapstructure:"slack"`
+	Discord string `mapstructure:"discord"`
+}
+
+// Webhooks returns the configured webhook URL of each channel
+func (n NotifyConfig) Webhooks() map[string]string {
+	webhooks := make(map[string]string)
+	if n.Slack != "" {
+		webhooks["slack"] = n.Slack
+	}
+	if n.Discord != "" {
+		webhooks["discord"] = n.Discord
+	}
+	return webhooks
+}
+
+// DefectDojoConfig holds the DefectDojo instance 'recon results push'
+// imports findings into
+type DefectDojoConfig struct {
+	URL        string `mapstructure:"url"`
+	APIKey     string `mapstructure:"api_key"`
+	Engagement string `mapstructure:"engagement"` // Default engagement ID
+}
+
+// TLSConfig holds the TLS settings of connections to the Recontronic
+// server, REST and gRPC, for deployments behind an internal CA or a
+// mutual-TLS gateway
+type TLSConfig struct {
+	CAFile             string `mapstructure:"ca_file"`     // PEM CA bundle to verify the server with
+	ClientCert         string `mapstructure:"client_cert"` // PEM client certificate for mutual TLS
+	ClientKey          string `mapstructure:"client_key"`  // PEM key of the client certificate
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// APIConfig holds the connection settings of API traffic to the Recontronic
+// server, separate from the proxy recon traffic goes through
+type APIConfig struct {
+	Proxy        string        `mapstructure:"proxy"`          // Proxy for API requests; HTTPS_PROXY and friends when empty
+	MaxIdleConns int           `mapstructure:"max_idle_conns"` // Idle connections kept open (0 = default)
+	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`   // Close idle connections after this long (0 = default)
+	KeepAlive    time.Duration `mapstructure:"keepalive"`      // TCP keep-alive interval (0 = default)
+}
+
+// FaradayConfig holds the Faraday instance 'recon results push' creates
+// findings in
+type FaradayConfig struct {
+	URL       string `mapstructure:"url"`
+	Token     string `mapstructure:"token"`
+	Workspace string `mapstructure:"workspace"` // Default workspace
 }
 
 // DefaultConfig returns a configuration with default values
@@ -24,7 +119,9 @@ func DefaultConfig() *Config {
 	return &Config{
 		Server:       "http://localhost:8080",
 		GRPCServer:   "localhost:9090",
+		GRPCTLS:      "auto",
 		Timeout:      30 * time.Second,
+		MaxRetries:   3,
 		OutputFormat: "table",
 		LogLevel:     "info",
 	}
@@ -43,6 +140,29 @@ func GetConfigPath() (string, error) {
 	return configFile, nil
 }
 
+// configFile returns the config file to use: cfgFile, or the default one
+func configFile(cfgFile string) (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	return GetConfigPath()
+}
+
+// existingConfigFile returns the config file to use, which must exist
+func existingConfigFile(cfgFile string) (string, error) {
+	path, err := configFile(cfgFile)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("no config file at %s; create one with 'recon-cli config init'", path)
+		}
+		return "", err
+	}
+	return path, nil
+}
+
 // GetConfigDir returns the path to the config directory
 func GetConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
@@ -92,19 +212,33 @@ func SecureConfigFile(path string) error {
 	return nil
 }
 
+// APIKeyLocked reports whether the API key is sealed by a locked vault
+func (c *Config) APIKeyLocked() bool {
+	return c.sealedAPIKey != ""
+}
+
+// APIKeyEncrypted reports whether the API key is stored sealed by the vault
+func (c *Config) APIKeyEncrypted() bool {
+	return c.apiKeyEncrypted
+}
+
+// PlainProfileKeys returns how many API keys of saved profiles are stored
+// unencrypted
+func (c *Config) PlainProfileKeys() int {
+	return c.plainProfileKeys
+}
+
 // Load reads the configuration from file and environment
 func Load(cfgFile string) (*Config, error) {
 	// Set defaults
 	viper.SetDefault("server", "http://localhost:8080")
 	viper.SetDefault("grpc_server", "localhost:9090")
+	viper.SetDefault("grpc_tls", "auto")
 	viper.SetDefault("timeout", "30s")
+	viper.SetDefault("max_retries", 3)
 	viper.SetDefault("output_format", "table")
 	viper.SetDefault("log_level", "info")
 
-	// Environment variable support with RECON_ prefix
-	viper.SetEnvPrefix("RECON")
-	viper.AutomaticEnv()
-
 	// If a config file is specified, use it
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
@@ -134,6 +268,28 @@ func Load(cfgFile string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	// Open the API key when the vault sealed it. While the vault is locked
+	// it is left unset and written back unchanged by Save.
+	cfg.apiKeyEncrypted = strings.HasPrefix(cfg.APIKey, vaultSealedPrefix)
+	if apiKey, ok := openConfigValue(cfg.APIKey); ok {
+		cfg.APIKey = apiKey
+	} else {
+		cfg.sealedAPIKey = cfg.APIKey
+		cfg.APIKey = ""
+	}
+	for name, profile := range cfg.Profiles {
+		if profile.APIKey == "" {
+			continue
+		}
+		if !strings.HasPrefix(profile.APIKey, vaultSealedPrefix) {
+			cfg.plainProfileKeys++
+		}
+		if apiKey, ok := openConfigValue(profile.APIKey); ok {
+			profile.APIKey = apiKey
+			cfg.Profiles[name] = profile
+		}
+	}
+
 	// Parse timeout string to duration if needed
 	if viper.IsSet("timeout") {
 		timeoutStr := viper.GetString("timeout")
@@ -144,11 +300,22 @@ func Load(cfgFile string) (*Config, error) {
 		cfg.Timeout = duration
 	}
 
+	if err := cfg.useProfileOverride(); err != nil {
+		return nil, err
+	}
+
+	// Flags and RECON_ environment variables win over the file
+	if err := cfg.applyOverrides(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
 // Save writes the current configuration to file
 func Save(cfg *Config) error {
+	cfg = cfg.withoutOverrides().onDisk()
+
 	// Ensure config directory exists
 	if err := EnsureConfigDir(); err != nil {
 		return err
@@ -159,16 +326,127 @@ func Save(cfg *Config) error {
 		return err
 	}
 
+	// Seal the API key when the vault is enabled
+	apiKey, err := sealConfigValue(cfg.APIKey)
+	if err != nil {
+		return fmt.Errorf("failed to seal API key: %w", err)
+	}
+	if cfg.APIKey == "" && cfg.sealedAPIKey != "" {
+		apiKey = cfg.sealedAPIKey
+	}
+
+	// Write through a fresh viper, so entries removed from the maps below
+	// aren't merged back from the file read; keys unknown to the CLI are kept
+	v := viper.New()
+	settings := viper.AllSettings()
+	for _, key := range []string{"wordlists", "profiles", "targets", "defaults"} {
+		delete(settings, key)
+	}
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
 	// Set values in viper
-	viper.Set("server", cfg.Server)
-	viper.Set("grpc_server", cfg.GRPCServer)
-	viper.Set("api_key", cfg.APIKey)
-	viper.Set("timeout", cfg.Timeout.String())
-	viper.Set("output_format", cfg.OutputFormat)
-	viper.Set("log_level", cfg.LogLevel)
+	v.Set("server", cfg.Server)
+	v.Set("grpc_server", cfg.GRPCServer)
+	v.Set("grpc_tls", cfg.GRPCTLS)
+	v.Set("grpc_ca_cert", cfg.GRPCCACert)
+	v.Set("api_key", apiKey)
+	v.Set("timeout", cfg.Timeout.String())
+	v.Set("max_retries", cfg.MaxRetries)
+	v.Set("output_format", cfg.OutputFormat)
+	v.Set("log_level", cfg.LogLevel)
+	v.Set("proxy", cfg.Proxy)
+	v.Set("doh", cfg.DoH)
+	v.Set("github_token", cfg.GitHubToken)
+	v.Set("gitlab_token", cfg.GitLabToken)
+	v.Set("webhook_secret", cfg.WebhookSecret)
+	v.Set("wordlists", cfg.Wordlists)
+	v.Set("retention", map[string]interface{}{
+		"max_age":   cfg.Retention.MaxAge,
+		"keep_last": cfg.Retention.KeepLast,
+	})
+	v.Set("notify", map[string]interface{}{
+		"slack":   cfg.Notify.Slack,
+		"discord": cfg.Notify.Discord,
+	})
+	v.Set("defectdojo", map[string]interface{}{
+		"url":        cfg.DefectDojo.URL,
+		"api_key":    cfg.DefectDojo.APIKey,
+		"engagement": cfg.DefectDojo.Engagement,
+	})
+	v.Set("faraday", map[string]interface{}{
+		"url":       cfg.Faraday.URL,
+		"token":     cfg.Faraday.Token,
+		"workspace": cfg.Faraday.Workspace,
+	})
+	v.Set("tls", map[string]interface{}{
+		"ca_file":              cfg.TLS.CAFile,
+		"client_cert":          cfg.TLS.ClientCert,
+		"client_key":           cfg.TLS.ClientKey,
+		"insecure_skip_verify": cfg.TLS.InsecureSkipVerify,
+	})
+	v.Set("api", map[string]interface{}{
+		"proxy":          cfg.API.Proxy,
+		"max_idle_conns": cfg.API.MaxIdleConns,
+		"idle_timeout":   cfg.API.IdleTimeout.String(),
+		"keepalive":      cfg.API.KeepAlive.String(),
+	})
+	v.Set("compress_results", cfg.Compress)
+	v.Set("auto_sync", cfg.AutoSync)
+	v.Set("profile", cfg.Profile)
+
+	profiles := make(map[string]interface{}, len(cfg.Profiles))
+	for name, profile := range cfg.Profiles {
+		// Keys still sealed by the locked vault are written back unchanged
+		apiKey := profile.APIKey
+		if !strings.HasPrefix(apiKey, vaultSealedPrefix) {
+			if apiKey, err = sealConfigValue(apiKey); err != nil {
+				return fmt.Errorf("failed to seal API key of profile %s: %w", name, err)
+			}
+		}
+		profiles[name] = map[string]interface{}{
+			"server":        profile.Server,
+			"grpc_server":   profile.GRPCServer,
+			"api_key":       apiKey,
+			"username":      profile.Username,
+			"grpc_tls":      profile.GRPCTLS,
+			"grpc_ca_cert":  profile.GRPCCACert,
+			"output_format": profile.OutputFormat,
+			"tls": map[string]interface{}{
+				"ca_file":              profile.TLS.CAFile,
+				"client_cert":          profile.TLS.ClientCert,
+				"client_key":           profile.TLS.ClientKey,
+				"insecure_skip_verify": profile.TLS.InsecureSkipVerify,
+			},
+		}
+	}
+	v.Set("profiles", profiles)
+
+	targets := make([]map[string]interface{}, 0, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		targets = append(targets, map[string]interface{}{
+			"domain":  t.Domain,
+			"program": t.Program,
+			"domains": t.Domains,
+			"verify": map[string]interface{}{
+				"concurrency": t.Verify.Concurrency,
+				"timeout":     t.Verify.Timeout,
+				"host_delay":  t.Verify.HostDelay.String(),
+			},
+			"dirs": map[string]interface{}{
+				"threads": t.Dirs.Threads,
+			},
+			"rate_limit": t.RateLimit,
+			"headers":    t.Headers,
+			"exclude":    t.Exclude,
+		})
+	}
+	v.Set("targets", targets)
+	v.Set("defaults", cfg.Defaults)
 
 	// Write config file
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	if err := v.WriteConfigAs(configPath); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -189,20 +467,92 @@ func Set(key, value string) error {
 		cfg = DefaultConfig()
 	}
 
-	// Update the specified key
+	if err := applySetting(cfg, key, value); err != nil {
+		return err
+	}
+
+	// Save updated config
+	return Save(cfg)
+}
+
+// Unset resets a configuration value to its default, removing a secret or
+// an optional setting. A per-target key removes that setting, and
+// domains.<domain> or programs.<name> all settings of the target.
+func Unset(key string) error {
+	cfg, err := Load("")
+	if err != nil {
+		return err
+	}
+
+	if err := unsetSetting(cfg, key); err != nil {
+		return err
+	}
+
+	return Save(cfg)
+}
+
+// unsetSetting resets a value of cfg to its default
+func unsetSetting(cfg *Config, key string) error {
+	if key == "profile" {
+		return fmt.Errorf("the active profile can't be unset; use 'recon-cli auth switch <name>' to change it")
+	}
+	if name, ok := strings.CutPrefix(key, "wordlists."); ok && name != "" {
+		delete(cfg.Wordlists, name)
+		return nil
+	}
+	if _, _, _, ok := parseTargetKey(key); ok {
+		return applySetting(cfg, key, "")
+	}
+	if kind, name, ok := strings.Cut(key, "."); ok && (kind == "domains" || kind == "programs") {
+		t := cfg.target(kind, strings.ToLower(name), false)
+		if t == nil {
+			return fmt.Errorf("no settings for %s", key)
+		}
+		removed := *t
+		cfg.Targets = slices.DeleteFunc(cfg.Targets, func(other TargetConfig) bool {
+			return other.Domain == removed.Domain && other.Program == removed.Program
+		})
+		return nil
+	}
+
+	value, err := getSetting(DefaultConfig(), key)
+	if err != nil {
+		return err
+	}
+	return applySetting(cfg, key, value)
+}
+
+// applySetting checks a value and sets it in cfg
+func applySetting(cfg *Config, key, value string) error {
 	switch key {
+	case "profile":
+		return fmt.Errorf("use 'recon-cli auth switch %s' to change the active profile", value)
 	case "server":
 		cfg.Server = value
 	case "grpc-server", "grpc_server":
 		cfg.GRPCServer = value
+	case "grpc-tls", "grpc_tls":
+		if value != "auto" && value != "on" && value != "off" {
+			return fmt.Errorf("invalid grpc-tls value (must be: auto, on, or off)")
+		}
+		cfg.GRPCTLS = value
+	case "grpc-ca-cert", "grpc_ca_cert":
+		cfg.GRPCCACert = value
 	case "api-key", "api_key":
 		cfg.APIKey = value
+		cfg.sealedAPIKey = ""
 	case "timeout":
 		duration, err := time.ParseDuration(value)
 		if err != nil {
 			return fmt.Errorf("invalid timeout format (use: 30s, 1m, etc.): %w", err)
 		}
 		cfg.Timeout = duration
+	case "max-retries", "max_retries":
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 || retries > 10 {
+			return fmt.Errorf("invalid max-retries value (must be a number from 0 to 10)")
+		}
+		cfg.MaxRetries = retries
 	case "output-format", "output_format":
 		if value != "table" && value != "json" && value != "yaml" {
 			return fmt.Errorf("invalid output format (must be: table, json, or yaml)")
@@ -213,12 +563,153 @@ func Set(key, value string) error {
 			return fmt.Errorf("invalid log level (must be: debug, info, warn, or error)")
 		}
 		cfg.LogLevel = value
+	case "proxy":
+		if value != "" {
+			proxyURL, err := url.Parse(value)
+			if err != nil || proxyURL.Host == "" {
+				return fmt.Errorf("invalid proxy URL (use: http://host:port or socks5://host:port)")
+			}
+		}
+		cfg.Proxy = value
+	case "doh":
+		if value != "" {
+			dohURL, err := url.Parse(value)
+			if err != nil || dohURL.Scheme != "https" || dohURL.Host == "" {
+				return fmt.Errorf("invalid DoH endpoint (use: https://host/dns-query)")
+			}
+		}
+		cfg.DoH = value
+	case "github-token", "github_token":
+		cfg.GitHubToken = value
+	case "gitlab-token", "gitlab_token":
+		cfg.GitLabToken = value
+	case "webhook-secret", "webhook_secret":
+		cfg.WebhookSecret = value
+	case "retention.max-age", "retention.max_age":
+		if value != "" {
+			if _, err := ParseDayDuration(value); err != nil {
+				return fmt.Errorf("invalid retention max age (use: 30d, 720h, etc.): %w", err)
+			}
+		}
+		cfg.Retention.MaxAge = value
+	case "retention.keep-last", "retention.keep_last":
+		keep, err := strconv.Atoi(value)
+		if err != nil || keep < 0 {
+			return fmt.Errorf("invalid retention keep-last (must be a non-negative number)")
+		}
+		cfg.Retention.KeepLast = keep
+	case "notify.slack", "notify.discord":
+		if value != "" {
+			webhookURL, err := url.Parse(value)
+			if err != nil || webhookURL.Scheme != "https" || webhookURL.Host == "" {
+				return fmt.Errorf("invalid webhook URL (use: https://hooks.slack.com/services/... or https://discord.com/api/webhooks/...)")
+			}
+		}
+		if key == "notify.slack" {
+			cfg.Notify.Slack = value
+		} else {
+			cfg.Notify.Discord = value
+		}
+	case "defectdojo.url", "faraday.url":
+		if value != "" {
+			platformURL, err := url.Parse(value)
+			if err != nil || (platformURL.Scheme != "http" && platformURL.Scheme != "https") || platformURL.Host == "" {
+				return fmt.Errorf("invalid URL (use: https://host)")
+			}
+		}
+		if key == "defectdojo.url" {
+			cfg.DefectDojo.URL = value
+		} else {
+			cfg.Faraday.URL = value
+		}
+	case "defectdojo.api-key", "defectdojo.api_key":
+		cfg.DefectDojo.APIKey = value
+	case "defectdojo.engagement":
+		if value != "" {
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("invalid DefectDojo engagement (must be a numeric ID)")
+			}
+		}
+		cfg.DefectDojo.Engagement = value
+	case "faraday.token":
+		cfg.Faraday.Token = value
+	case "faraday.workspace":
+		cfg.Faraday.Workspace = value
+	case "tls.ca-file", "tls.ca_file", "tls.client-cert", "tls.client_cert", "tls.client-key", "tls.client_key":
+		path, err := tlsFilePath(value)
+		if err != nil {
+			return err
+		}
+		switch strings.ReplaceAll(key, "_", "-") {
+		case "tls.ca-file":
+			cfg.TLS.CAFile = path
+		case "tls.client-cert":
+			cfg.TLS.ClientCert = path
+		default:
+			cfg.TLS.ClientKey = path
+		}
+	case "tls.insecure-skip-verify", "tls.insecure_skip_verify":
+		skip, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid tls.insecure-skip-verify value (must be: true or false)")
+		}
+		cfg.TLS.InsecureSkipVerify = skip
+	case "api.proxy":
+		if value != "" {
+			proxyURL, err := url.Parse(value)
+			if err != nil || proxyURL.Host == "" {
+				return fmt.Errorf("invalid proxy URL (use: http://host:port or socks5://host:port)")
+			}
+		}
+		cfg.API.Proxy = value
+	case "api.max-idle-conns", "api.max_idle_conns":
+		conns, err := strconv.Atoi(value)
+		if err != nil || conns < 0 {
+			return fmt.Errorf("invalid api.max-idle-conns value (must be a non-negative number)")
+		}
+		cfg.API.MaxIdleConns = conns
+	case "api.idle-timeout", "api.idle_timeout", "api.keepalive":
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration < 0 {
+			return fmt.Errorf("invalid %s value (use: 30s, 1m, etc.)", key)
+		}
+		if key == "api.keepalive" {
+			cfg.API.KeepAlive = duration
+		} else {
+			cfg.API.IdleTimeout = duration
+		}
+	case "compress-results", "compress_results":
+		compress, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid compress-results value (must be: true or false)")
+		}
+		cfg.Compress = compress
+	case "auto-sync", "auto_sync":
+		autoSync, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid auto-sync value (must be: true or false)")
+		}
+		cfg.AutoSync = autoSync
 	default:
-		return fmt.Errorf("unknown config key: %s", key)
+		if path, ok := strings.CutPrefix(key, "defaults."); ok {
+			return cfg.setFlagDefault(path, strings.TrimSpace(value))
+		}
+		if kind, target, setting, ok := parseTargetKey(key); ok {
+			if err := cfg.setTarget(kind, target, setting, value); err != nil {
+				return err
+			}
+			break
+		}
+		name, ok := strings.CutPrefix(key, "wordlists.")
+		if !ok || name == "" {
+			return fmt.Errorf("unknown config key: %s", key)
+		}
+		if cfg.Wordlists == nil {
+			cfg.Wordlists = make(map[string]string)
+		}
+		cfg.Wordlists[name] = value
 	}
-
-	// Save updated config
-	return Save(cfg)
+	return nil
 }
 
 // Get retrieves a single configuration value
@@ -227,25 +718,109 @@ func Get(key string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return getSetting(cfg, key)
+}
 
+// getSetting returns a value of cfg
+func getSetting(cfg *Config, key string) (string, error) {
 	switch key {
 	case "server":
 		return cfg.Server, nil
 	case "grpc-server", "grpc_server":
 		return cfg.GRPCServer, nil
+	case "grpc-tls", "grpc_tls":
+		return cfg.GRPCTLS, nil
+	case "grpc-ca-cert", "grpc_ca_cert":
+		return cfg.GRPCCACert, nil
 	case "api-key", "api_key":
 		return cfg.APIKey, nil
 	case "timeout":
 		return cfg.Timeout.String(), nil
+	case "max-retries", "max_retries":
+		return strconv.Itoa(cfg.MaxRetries), nil
 	case "output-format", "output_format":
 		return cfg.OutputFormat, nil
 	case "log-level", "log_level":
 		return cfg.LogLevel, nil
+	case "proxy":
+		return cfg.Proxy, nil
+	case "doh":
+		return cfg.DoH, nil
+	case "github-token", "github_token":
+		return cfg.GitHubToken, nil
+	case "gitlab-token", "gitlab_token":
+		return cfg.GitLabToken, nil
+	case "webhook-secret", "webhook_secret":
+		return cfg.WebhookSecret, nil
+	case "retention.max-age", "retention.max_age":
+		return cfg.Retention.MaxAge, nil
+	case "retention.keep-last", "retention.keep_last":
+		return strconv.Itoa(cfg.Retention.KeepLast), nil
+	case "notify.slack":
+		return cfg.Notify.Slack, nil
+	case "notify.discord":
+		return cfg.Notify.Discord, nil
+	case "defectdojo.url":
+		return cfg.DefectDojo.URL, nil
+	case "defectdojo.api-key", "defectdojo.api_key":
+		return cfg.DefectDojo.APIKey, nil
+	case "defectdojo.engagement":
+		return cfg.DefectDojo.Engagement, nil
+	case "faraday.url":
+		return cfg.Faraday.URL, nil
+	case "faraday.token":
+		return cfg.Faraday.Token, nil
+	case "faraday.workspace":
+		return cfg.Faraday.Workspace, nil
+	case "tls.ca-file", "tls.ca_file":
+		return cfg.TLS.CAFile, nil
+	case "tls.client-cert", "tls.client_cert":
+		return cfg.TLS.ClientCert, nil
+	case "tls.client-key", "tls.client_key":
+		return cfg.TLS.ClientKey, nil
+	case "tls.insecure-skip-verify", "tls.insecure_skip_verify":
+		return strconv.FormatBool(cfg.TLS.InsecureSkipVerify), nil
+	case "api.proxy":
+		return cfg.API.Proxy, nil
+	case "api.max-idle-conns", "api.max_idle_conns":
+		return strconv.Itoa(cfg.API.MaxIdleConns), nil
+	case "api.idle-timeout", "api.idle_timeout":
+		return cfg.API.IdleTimeout.String(), nil
+	case "api.keepalive":
+		return cfg.API.KeepAlive.String(), nil
+	case "compress-results", "compress_results":
+		return strconv.FormatBool(cfg.Compress), nil
+	case "auto-sync", "auto_sync":
+		return strconv.FormatBool(cfg.AutoSync), nil
+	case "profile":
+		return cfg.Profile, nil
 	default:
+		if name, ok := strings.CutPrefix(key, "wordlists."); ok && name != "" {
+			return cfg.Wordlists[name], nil
+		}
+		if path, ok := strings.CutPrefix(key, "defaults."); ok {
+			return cfg.FlagDefaults()[strings.ToLower(path)], nil
+		}
+		if kind, target, setting, ok := parseTargetKey(key); ok {
+			return cfg.getTarget(kind, target, setting), nil
+		}
 		return "", fmt.Errorf("unknown config key: %s", key)
 	}
 }
 
+// ParseDayDuration parses a duration that may use a day suffix
+// (e.g., 30d) in addition to Go duration units
+func ParseDayDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid day count: %s", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
 // SaveAPIKey saves only the API key to config
 func SaveAPIKey(apiKey string) error {
 	cfg, err := Load("")
@@ -271,3 +846,26 @@ func ValidateAPIKey(apiKey string) error {
 	}
 	return nil
 }
+
+// tlsFilePath expands and checks the path of a TLS file setting; an empty
+// value clears the setting
+func tlsFilePath(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if rest, ok := strings.CutPrefix(value, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		value = filepath.Join(home, rest)
+	}
+	path, err := filepath.Abs(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", value, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flag defaults are kept in the defaults section, nested by command path
+// without recon-cli and recon, e.g.:
+//
+//	defaults:
+//	  verify:
+//	    concurrency: 20
+//	  results:
+//	    export:
+//	      format: csv
+//
+// Commands apply them to the flags not given on the command line.
+
+// FlagDefaults returns the configured flag defaults by <command>.<flag>,
+// e.g., verify.concurrency or results.export.format; lists are joined with
+// commas
+func (c *Config) FlagDefaults() map[string]string {
+	defaults := make(map[string]string)
+	flattenDefaults("", c.Defaults, defaults)
+	return defaults
+}
+
+func flattenDefaults(prefix string, node map[string]interface{}, defaults map[string]string) {
+	for name, value := range node {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenDefaults(key, v, defaults)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			defaults[key] = strings.Join(items, ",")
+		default:
+			defaults[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// setFlagDefault sets the default of a flag, path being <command>.<flag>;
+// "" removes it, or all the defaults of a command
+func (c *Config) setFlagDefault(path, value string) error {
+	parts := strings.Split(strings.ToLower(path), ".")
+	for _, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid key defaults.%s (expected defaults.<command>.<flag>, e.g., defaults.verify.concurrency)", path)
+		}
+	}
+	if value == "" {
+		removeDefault(c.Defaults, parts)
+		return nil
+	}
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid key defaults.%s (expected defaults.<command>.<flag>, e.g., defaults.verify.concurrency)", path)
+	}
+
+	if c.Defaults == nil {
+		c.Defaults = make(map[string]interface{})
+	}
+	node := c.Defaults
+	for i, part := range parts[:len(parts)-1] {
+		child, ok := node[part].(map[string]interface{})
+		if !ok {
+			if _, exists := node[part]; exists {
+				return fmt.Errorf("defaults.%s is a flag, not a command", strings.Join(parts[:i+1], "."))
+			}
+			child = make(map[string]interface{})
+			node[part] = child
+		}
+		node = child
+	}
+	flag := parts[len(parts)-1]
+	if _, ok := node[flag].(map[string]interface{}); ok {
+		return fmt.Errorf("defaults.%s is a command, not a flag", strings.Join(parts, "."))
+	}
+	node[flag] = value
+	return nil
+}
+
+// removeDefault removes a flag default or the defaults of a command, and
+// the commands left without any
+func removeDefault(node map[string]interface{}, parts []string) {
+	if len(parts) == 1 {
+		delete(node, parts[0])
+		return
+	}
+	child, ok := node[parts[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	removeDefault(child, parts[1:])
+	if len(child) == 0 {
+		delete(node, parts[0])
+	}
+}
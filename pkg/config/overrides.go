@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Setting is a config key that can be overridden for a run, with the type
+// of value it takes
+type Setting struct {
+	Key  string
+	Type string // "string", "bool", "int", or "duration"
+}
+
+// overrideSettings are the settings that can be overridden for a run, as in
+// the config file: each with a RECON_ environment variable, e.g.,
+// RECON_TLS_CA_FILE for tls.ca_file, and most with a flag, e.g.,
+// --tls-ca-file, for CI jobs and containers that have no config file
+var overrideSettings = configSettings(reflect.TypeOf(Config{}), "")
+
+// configSettings lists the scalar settings of a config section from its
+// mapstructure tags, in file order. Lists and maps (profiles, targets, flag
+// defaults) have keys of their own, except the 'recon dirs' wordlist.
+func configSettings(section reflect.Type, prefix string) []Setting {
+	var settings []Setting
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		// The active profile is switched with 'auth switch' or --profile
+		if !field.IsExported() || tag == "" || tag == "profile" {
+			continue
+		}
+
+		key := prefix + tag
+		switch {
+		case field.Type == reflect.TypeOf(time.Duration(0)):
+			settings = append(settings, Setting{Key: key, Type: "duration"})
+		case field.Type.Kind() == reflect.Struct:
+			settings = append(settings, configSettings(field.Type, key+".")...)
+		case field.Type.Kind() == reflect.String:
+			settings = append(settings, Setting{Key: key, Type: "string"})
+		case field.Type.Kind() == reflect.Bool:
+			settings = append(settings, Setting{Key: key, Type: "bool"})
+		case field.Type.Kind() == reflect.Int:
+			settings = append(settings, Setting{Key: key, Type: "int"})
+		case key == "wordlists":
+			settings = append(settings, Setting{Key: "wordlists.dirs", Type: "string"})
+		}
+	}
+	return settings
+}
+
+// flagOverrides are the settings given as flags, by config key
+var flagOverrides map[string]string
+
+// override is a setting overridden for this run
+type override struct {
+	source string // Flag or environment variable it came from
+	value  string // Value it set, as getSetting returns it
+}
+
+// OverrideSettings returns the settings that can be overridden for a run
+func OverrideSettings() []Setting {
+	return slices.Clone(overrideSettings)
+}
+
+// EnvVar returns the environment variable overriding a config key
+func EnvVar(key string) string {
+	return "RECON_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// FlagName returns the name of the flag overriding a config key. The set-
+// prefix keeps it from clashing with command flags such as recon verify
+// --timeout.
+func FlagName(key string) string {
+	return "set-" + strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+// SetFlagOverrides makes Load override settings with the values of flags,
+// by config key. Flags win over environment variables, which win over the
+// config file; Save keeps the file's values.
+func SetFlagOverrides(overrides map[string]string) {
+	flagOverrides = overrides
+}
+
+// Overrides returns the settings overridden for this run with the flag or
+// environment variable each came from, by config key
+func (c *Config) Overrides() map[string]string {
+	sources := make(map[string]string, len(c.overrides))
+	for key, o := range c.overrides {
+		sources[key] = o.source
+	}
+	return sources
+}
+
+// applyOverrides applies the settings given as flags or environment
+// variables, checked like 'config set' does, remembering the configuration
+// they change so Save writes it back. Empty variables are ignored.
+func (c *Config) applyOverrides() error {
+	for _, setting := range overrideSettings {
+		key := setting.Key
+		source := "--" + FlagName(key)
+		value, ok := flagOverrides[key]
+		if !ok {
+			source = EnvVar(key)
+			if value = os.Getenv(source); value == "" {
+				continue
+			}
+		}
+
+		if c.overrides == nil {
+			previous := *c
+			previous.Wordlists = maps.Clone(c.Wordlists)
+			c.previous = &previous
+			c.overrides = make(map[string]override)
+		}
+		if err := applySetting(c, key, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s: %w", source, err)
+		}
+		applied, _ := getSetting(c, key)
+		c.overrides[key] = override{source: source, value: applied}
+	}
+	return nil
+}
+
+// withoutOverrides returns the configuration to write: settings still
+// holding the value of a flag or environment variable get the value they
+// had before back, while settings the command changed are kept
+func (c *Config) withoutOverrides() *Config {
+	if len(c.overrides) == 0 {
+		return c
+	}
+	disk := *c
+	disk.Wordlists = maps.Clone(c.Wordlists)
+	for key, o := range c.overrides {
+		if current, _ := getSetting(c, key); current != o.value {
+			continue
+		}
+		// The key may be sealed by the locked vault, so it's copied as is
+		if key == "api_key" {
+			disk.APIKey, disk.sealedAPIKey, disk.apiKeyEncrypted = c.previous.APIKey, c.previous.sealedAPIKey, c.previous.apiKeyEncrypted
+			continue
+		}
+		previous, _ := getSetting(c.previous, key)
+		if name, ok := strings.CutPrefix(key, "wordlists."); ok && previous == "" {
+			delete(disk.Wordlists, name)
+			continue
+		}
+		applySetting(&disk, key, previous)
+	}
+	disk.overrides, disk.previous = nil, nil
+	return &disk
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Profile holds the credentials and connection settings of one account or
+// environment, e.g., work, personal, a self-hosted instance, or a local dev
+// server, so switching between them needs no new login or config edits.
+// The active profile's settings are the top-level ones; the others wait
+// here.
+type Profile struct {
+	Server       string    `mapstructure:"server"`
+	GRPCServer   string    `mapstructure:"grpc_server"`
+	APIKey       string    `mapstructure:"api_key"` // Sealed like api_key while the vault is locked
+	Username     string    `mapstructure:"username"`
+	GRPCTLS      string    `mapstructure:"grpc_tls"`
+	GRPCCACert   string    `mapstructure:"grpc_ca_cert"`
+	TLS          TLSConfig `mapstructure:"tls"`
+	OutputFormat string    `mapstructure:"output_format"`
+}
+
+// defaultProfileName is the profile an unnamed active account is saved as
+// when another profile is created
+const defaultProfileName = "default"
+
+// profileOverride is the profile Load makes active for this run only
+var profileOverride string
+
+// SetProfileOverride makes Load use the named profile instead of the active
+// one, e.g., for the --profile flag. Save keeps the active profile on disk
+// as it is and stores changes under the named one. "" uses the active one.
+func SetProfileOverride(name string) {
+	profileOverride = name
+}
+
+// profileNamePattern matches valid profile names; config keys are case
+// insensitive, so names are lowercase
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateProfileName checks that a profile name can be stored in the config
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (lowercase letters, digits, '-', and '_')", name)
+	}
+	return nil
+}
+
+// ProfileNames returns the names of the stored profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// storeProfile saves the active credentials under the active profile, if any
+func (c *Config) storeProfile() {
+	if c.Profile == "" {
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+
+	profile := c.activeProfile()
+	profile.Username = c.Profiles[c.Profile].Username
+	c.Profiles[c.Profile] = profile
+}
+
+// activeProfile returns the active credentials and connection settings
+func (c *Config) activeProfile() Profile {
+	profile := Profile{
+		Server:       c.Server,
+		GRPCServer:   c.GRPCServer,
+		APIKey:       c.APIKey,
+		GRPCTLS:      c.GRPCTLS,
+		GRPCCACert:   c.GRPCCACert,
+		TLS:          c.TLS,
+		OutputFormat: c.OutputFormat,
+	}
+	if c.APIKey == "" {
+		profile.APIKey = c.sealedAPIKey
+	}
+	return profile
+}
+
+// applyProfile makes a profile's credentials and connection settings the
+// active ones; settings profiles saved before they were stored keep their
+// active values
+func (c *Config) applyProfile(profile Profile) {
+	c.Server = profile.Server
+	if profile.GRPCServer != "" {
+		c.GRPCServer = profile.GRPCServer
+	}
+	if profile.GRPCTLS != "" {
+		c.GRPCTLS = profile.GRPCTLS
+	}
+	if profile.OutputFormat != "" {
+		c.OutputFormat = profile.OutputFormat
+	}
+	c.GRPCCACert = profile.GRPCCACert
+	c.TLS = profile.TLS
+	c.setAPIKey(profile.APIKey)
+}
+
+// useProfile makes the named profile's credentials the active ones, after
+// saving the active profile's
+func (c *Config) useProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("no profile named %q; create one with 'recon-cli auth login --profile %s'", name, name)
+		}
+		return fmt.Errorf("no profile named %q (profiles: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	c.storeProfile()
+	c.Profile = name
+	c.applyProfile(profile)
+	return nil
+}
+
+// useProfileOverride makes the profile of SetProfileOverride the active one
+// for this run, remembering the active one on disk
+func (c *Config) useProfileOverride() error {
+	if profileOverride == "" || profileOverride == c.Profile {
+		return nil
+	}
+	name, active := c.Profile, c.activeProfile()
+	if err := c.useProfile(profileOverride); err != nil {
+		return err
+	}
+	c.diskProfile, c.diskActive = name, &active
+	return nil
+}
+
+// onDisk returns the configuration to write. While a profile is in use for
+// this run only, its changes are stored under it and the profile active on
+// disk stays active; a command switching profiles is written as it is.
+func (c *Config) onDisk() *Config {
+	if c.diskActive == nil || c.Profile != profileOverride {
+		return c
+	}
+	disk := *c
+	disk.Profiles = maps.Clone(c.Profiles)
+	disk.storeProfile()
+	disk.Profile = c.diskProfile
+	disk.applyProfile(*c.diskActive)
+	disk.diskProfile, disk.diskActive = "", nil
+	return &disk
+}
+
+// setAPIKey makes apiKey the active key; a key sealed by the locked vault is
+// kept as stored, like Load does
+func (c *Config) setAPIKey(apiKey string) {
+	c.apiKeyEncrypted = strings.HasPrefix(apiKey, vaultSealedPrefix)
+	c.APIKey, c.sealedAPIKey = apiKey, ""
+	if c.apiKeyEncrypted {
+		c.APIKey, c.sealedAPIKey = "", apiKey
+	}
+}
+
+// Login describes a successful login to save
+type Login struct {
+	Profile    string // Profile to save the credentials under ("" = the active one, if any)
+	Server     string
+	GRPCServer string // Kept as configured when empty
+	APIKey     string
+	Username   string
+}
+
+// SaveLogin saves the credentials of a login as the active ones, under
+// login.Profile when set; the credentials of the previously active profile
+// are kept under its name
+func SaveLogin(login Login) error {
+	cfg, err := Load("")
+	if err != nil {
+		// If config doesn't exist, start with defaults
+		cfg = DefaultConfig()
+	}
+
+	if login.Profile != "" && login.Profile != cfg.Profile {
+		if err := ValidateProfileName(login.Profile); err != nil {
+			return err
+		}
+		if _, ok := cfg.Profiles[login.Profile]; ok {
+			if err := cfg.useProfile(login.Profile); err != nil {
+				return err
+			}
+		} else {
+			cfg.storeProfile()
+			cfg.Profile = login.Profile
+		}
+	}
+
+	cfg.Server = login.Server
+	if login.GRPCServer != "" {
+		cfg.GRPCServer = login.GRPCServer
+	}
+	cfg.setAPIKey(login.APIKey)
+	cfg.storeProfile()
+	if cfg.Profile != "" {
+		profile := cfg.Profiles[cfg.Profile]
+		profile.Username = login.Username
+		cfg.Profiles[cfg.Profile] = profile
+	}
+	return Save(cfg)
+}
+
+// SwitchProfile makes the named profile the active account and returns the
+// updated configuration
+func SwitchProfile(name string) (*Config, error) {
+	cfg, err := Load("")
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.useProfile(name); err != nil {
+		return nil, err
+	}
+	if err := Save(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Logout removes the API key of the active account, from its profile too.
+// The profile stays, so logging in again restores its server settings.
+func Logout() error {
+	cfg, err := Load("")
+	if err != nil {
+		return err
+	}
+
+	cfg.setAPIKey("")
+	cfg.storeProfile()
+	return Save(cfg)
+}
+
+// CreateProfile saves a profile for another environment, e.g., a local dev
+// server, without logging in to it. Settings not given are copied from the
+// active account, without its API key. An unnamed active account is first
+// saved as profile "default", so it can be switched back to; the name it
+// was saved under is returned.
+func CreateProfile(name string, settings Profile) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	if settings.GRPCTLS != "" && settings.GRPCTLS != "auto" && settings.GRPCTLS != "on" && settings.GRPCTLS != "off" {
+		return "", fmt.Errorf("invalid grpc-tls value (must be: auto, on, or off)")
+	}
+	if f := settings.OutputFormat; f != "" && f != "table" && f != "json" && f != "yaml" {
+		return "", fmt.Errorf("invalid output format (must be: table, json, or yaml)")
+	}
+	cfg, err := Load("")
+	if err != nil {
+		return "", err
+	}
+	if _, ok := cfg.Profiles[name]; ok {
+		return "", fmt.Errorf("profile %q already exists", name)
+	}
+
+	saved := ""
+	if _, taken := cfg.Profiles[defaultProfileName]; cfg.Profile == "" && !taken && name != defaultProfileName {
+		cfg.Profile = defaultProfileName
+		cfg.storeProfile()
+		saved = defaultProfileName
+	}
+
+	profile := cfg.activeProfile()
+	profile.APIKey = ""
+	if settings.Server != "" {
+		profile.Server = settings.Server
+	}
+	if settings.GRPCServer != "" {
+		profile.GRPCServer = settings.GRPCServer
+	}
+	if settings.GRPCTLS != "" {
+		profile.GRPCTLS = settings.GRPCTLS
+	}
+	if settings.OutputFormat != "" {
+		profile.OutputFormat = settings.OutputFormat
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+	cfg.Profiles[name] = profile
+	return saved, Save(cfg)
+}
+
+// NameProfile saves the active credentials as a new profile and makes it
+// the active one, for accounts logged in before profiles were used
+func NameProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	cfg, err := Load("")
+	if err != nil {
+		return err
+	}
+	if cfg.Profile != "" {
+		return fmt.Errorf("the active account is already profile %q; add another with 'recon-cli auth login --profile %s'", cfg.Profile, name)
+	}
+	if _, ok := cfg.Profiles[name]; ok {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	cfg.Profile = name
+	cfg.storeProfile()
+	return Save(cfg)
+}
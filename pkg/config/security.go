@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// InsecurePath is a config file or directory other users can access
+type InsecurePath struct {
+	Path string
+	Mode os.FileMode // Current permissions
+	Want os.FileMode // Owner-only permissions it should have
+}
+
+// Exposure is a secret found in a file of a git repository
+type Exposure struct {
+	File   string // Relative to the repository
+	Secret string // What it is, e.g., api_key
+}
+
+// recontronicKeyPattern matches Recontronic API keys, as an extended
+// regular expression for git grep
+const recontronicKeyPattern = `rct_[A-Za-z0-9_-]{16,}`
+
+// minScannedSecret is the length below which configured secrets aren't
+// scanned for, as they would match too much
+const minScannedSecret = 8
+
+// CheckPermissions returns the config directory and the files in it, the
+// config file, backups, and vault files, that other users can access
+func CheckPermissions(cfgFile string) ([]InsecurePath, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	path, err := configFile(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var insecure []InsecurePath
+	if info, err := os.Stat(dir); err == nil && info.Mode().Perm()&0077 != 0 {
+		insecure = append(insecure, InsecurePath{Path: dir, Mode: info.Mode().Perm(), Want: 0700})
+	}
+
+	files := []string{path}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if file := filepath.Join(dir, entry.Name()); entry.Type().IsRegular() && file != path {
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0077 == 0 {
+			continue
+		}
+		insecure = append(insecure, InsecurePath{Path: file, Mode: info.Mode().Perm(), Want: 0600})
+	}
+	return insecure, nil
+}
+
+// FixPermissions restricts the paths CheckPermissions finds to their owner,
+// returning those it changed
+func FixPermissions(cfgFile string) ([]InsecurePath, error) {
+	insecure, err := CheckPermissions(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range insecure {
+		if err := os.Chmod(path.Path, path.Want); err != nil {
+			return insecure[:i], fmt.Errorf("failed to restrict %s: %w", path.Path, err)
+		}
+	}
+	return insecure, nil
+}
+
+// FileSecrets returns the secrets set in a config file, e.g., an export
+// made without --redact-secrets
+func FileSecrets(path string) ([]string, error) {
+	v, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var found []string
+	for _, key := range secretKeys {
+		if v.GetString(key) != "" {
+			found = append(found, key)
+		}
+	}
+	for _, key := range v.AllKeys() {
+		if match := profileKeyPattern.FindStringSubmatch(key); match != nil && match[2] == "api_key" && v.GetString(key) != "" {
+			found = append(found, key)
+		}
+	}
+	return found, nil
+}
+
+// secrets returns the configured secrets by value, naming their key
+func (c *Config) secrets() map[string]string {
+	secrets := map[string]string{
+		c.APIKey:            "api_key",
+		c.GitHubToken:       "github_token",
+		c.GitLabToken:       "gitlab_token",
+		c.WebhookSecret:     "webhook_secret",
+		c.Notify.Slack:      "notify.slack",
+		c.Notify.Discord:    "notify.discord",
+		c.DefectDojo.APIKey: "defectdojo.api_key",
+		c.Faraday.Token:     "faraday.token",
+	}
+	for name, profile := range c.Profiles {
+		if _, ok := secrets[profile.APIKey]; !ok && !strings.HasPrefix(profile.APIKey, vaultSealedPrefix) {
+			secrets[profile.APIKey] = fmt.Sprintf("profiles.%s.api_key", name)
+		}
+	}
+	for secret := range secrets {
+		if len(secret) < minScannedSecret {
+			delete(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// ScanRepository looks for the configured secrets and any Recontronic API
+// key in the files of the git work tree dir is in, tracked or not ignored,
+// as configs get pasted into repositories. It returns the repository's
+// root, or "" when dir isn't in one.
+func (c *Config) ScanRepository(ctx context.Context, dir string) (string, []Exposure, error) {
+	root := gitWorkTree(dir)
+	if root == "" {
+		return "", nil, nil
+	}
+
+	secrets := c.secrets()
+	patterns := []string{recontronicKeyPattern}
+	for secret := range secrets {
+		patterns = append(patterns, ereQuote(secret))
+	}
+
+	grep := exec.CommandContext(ctx, "git", "-C", root, "grep", "-I", "-z", "-o", "-E", "--untracked", "-f", "-")
+	grep.Stdin = strings.NewReader(strings.Join(patterns, "\n") + "\n")
+	out, err := grep.Output()
+	if err != nil {
+		// git grep exits with 1 when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return root, nil, nil
+		}
+		return root, nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	var exposures []Exposure
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		file, match, ok := bytes.Cut(line, []byte{0})
+		if !ok {
+			continue
+		}
+		name, known := secrets[string(match)]
+		if !known {
+			name = "a Recontronic API key"
+		}
+		exposure := Exposure{File: string(file), Secret: name}
+		if !slices.Contains(exposures, exposure) {
+			exposures = append(exposures, exposure)
+		}
+	}
+	return root, exposures, nil
+}
+
+// gitWorkTree returns the root of the git work tree dir is in, or ""
+func gitWorkTree(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// erePattern matches the characters special in extended regular expressions
+var erePattern = regexp.MustCompile(`[\\.^$|?*+()\[\]{}]`)
+
+// ereQuote escapes a literal for an extended regular expression
+func ereQuote(literal string) string {
+	return erePattern.ReplaceAllString(literal, `\$0`)
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// secretKeys are the config keys holding credentials, as in the file
+var secretKeys = []string{
+	"api_key", "github_token", "gitlab_token", "webhook_secret",
+	"notify.slack", "notify.discord", "defectdojo.api_key", "faraday.token",
+}
+
+// IsSecretKey reports whether a config key holds a credential
+func IsSecretKey(key string) bool {
+	return slices.Contains(secretKeys, strings.ReplaceAll(key, "-", "_"))
+}
+
+// Export writes the configuration file to w as YAML, e.g., to share
+// team-standard settings. With redactSecrets, credentials, including the
+// API keys of profiles, are left out, so importing the file keeps the
+// importer's own.
+func Export(cfgFile string, w io.Writer, redactSecrets bool) error {
+	path, err := existingConfigFile(cfgFile)
+	if err != nil {
+		return err
+	}
+	v, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	settings := v.AllSettings()
+	if redactSecrets {
+		removeSecrets(settings)
+	}
+
+	out := viper.New()
+	out.SetConfigType("yaml")
+	if err := out.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to export config: %w", err)
+	}
+	return out.WriteConfigTo(w)
+}
+
+// Import writes the settings of a config file, e.g., one shared with
+// 'config export --redact-secrets', to the configuration file. With merge
+// they are added to the current settings, targets replacing those of the
+// same domain or program; otherwise the file replaces the configuration,
+// keeping the current secrets it doesn't set. The previous file is kept as
+// <file>.bak. It returns the keys that changed.
+func Import(cfgFile, path string, merge bool) ([]string, error) {
+	imported, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	target, err := configFile(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	current := viper.New()
+	previous, err := os.ReadFile(target)
+	if err == nil {
+		if current, err = readConfigFile(target); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	result := viper.New()
+	result.SetConfigType("yaml")
+	if merge {
+		if err := result.MergeConfigMap(current.AllSettings()); err != nil {
+			return nil, fmt.Errorf("failed to merge config: %w", err)
+		}
+	}
+	if err := result.MergeConfigMap(imported.AllSettings()); err != nil {
+		return nil, fmt.Errorf("failed to merge config: %w", err)
+	}
+	if merge && current.IsSet("targets") && imported.IsSet("targets") {
+		result.Set("targets", mergeTargets(current.Get("targets"), imported.Get("targets")))
+	}
+	if !merge {
+		for _, key := range secretKeys {
+			if current.IsSet(key) && !imported.IsSet(key) {
+				result.Set(key, current.Get(key))
+			}
+		}
+	}
+
+	var changed []string
+	for _, key := range result.AllKeys() {
+		if formatSetting(current.Get(key)) != formatSetting(result.Get(key)) {
+			changed = append(changed, key)
+		}
+	}
+	for _, key := range current.AllKeys() {
+		if !result.IsSet(key) && formatSetting(current.Get(key)) != "" {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+
+	if err := EnsureConfigDir(); err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		if err := os.WriteFile(target+".bak", previous, 0600); err != nil {
+			return nil, fmt.Errorf("failed to back up config: %w", err)
+		}
+	}
+	if err := result.WriteConfigAs(target); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := SecureConfigFile(target); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// formatSetting formats a value of a config file for comparison; unset and
+// zero values, as Save writes them, are ""
+func formatSetting(value interface{}) string {
+	switch formatted := fmt.Sprint(value); formatted {
+	case "<nil>", "0", "false", "0s", "[]", "map[]":
+		return ""
+	default:
+		return formatted
+	}
+}
+
+// readConfigFile reads a config file with a separate viper, without
+// defaults or environment variables
+func readConfigFile(path string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// removeSecrets removes the credentials from the settings of a config file
+func removeSecrets(settings map[string]interface{}) {
+	for _, key := range secretKeys {
+		deleteSetting(settings, key)
+	}
+	if profiles, ok := settings["profiles"].(map[string]interface{}); ok {
+		for _, profile := range profiles {
+			if profile, ok := profile.(map[string]interface{}); ok {
+				delete(profile, "api_key")
+			}
+		}
+	}
+}
+
+// deleteSetting removes a key, e.g., defectdojo.api_key, from settings
+func deleteSetting(settings map[string]interface{}, key string) {
+	parent, rest, nested := strings.Cut(key, ".")
+	if !nested {
+		delete(settings, key)
+		return
+	}
+	if child, ok := settings[parent].(map[string]interface{}); ok {
+		deleteSetting(child, rest)
+	}
+}
+
+// mergeTargets adds imported per-target settings to the current ones,
+// replacing those of the same domain or program
+func mergeTargets(current, imported interface{}) []interface{} {
+	name := func(target interface{}) string {
+		settings, _ := target.(map[string]interface{})
+		domain, _ := settings["domain"].(string)
+		program, _ := settings["program"].(string)
+		return strings.ToLower(domain) + "/" + strings.ToLower(program)
+	}
+
+	importedTargets, _ := imported.([]interface{})
+	names := make(map[string]bool, len(importedTargets))
+	for _, target := range importedTargets {
+		names[name(target)] = true
+	}
+
+	currentTargets, _ := current.([]interface{})
+	var merged []interface{}
+	for _, target := range currentTargets {
+		if !names[name(target)] {
+			merged = append(merged, target)
+		}
+	}
+	return append(merged, importedTargets...)
+}
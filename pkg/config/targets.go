@@ -0,0 +1,329 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TargetConfig holds recon defaults for a domain, with its subdomains, or
+// for the domains of a program, e.g., gentler limits for a fragile target or
+// the header a program requires. Recon commands apply them unless the flag
+// is given. They are stored as a list, since viper splits map keys on dots.
+type TargetConfig struct {
+	Domain    string             `mapstructure:"domain"`     // Domain the settings apply to
+	Program   string             `mapstructure:"program"`    // Or: program whose domains they apply to
+	Domains   []string           `mapstructure:"domains"`    // Domains of the program
+	Verify    TargetVerifyConfig `mapstructure:"verify"`     // 'recon verify' defaults
+	Dirs      TargetDirsConfig   `mapstructure:"dirs"`       // 'recon dirs' defaults
+	RateLimit string             `mapstructure:"rate_limit"` // Maximum request rate, e.g., 5/s
+	Headers   []string           `mapstructure:"headers"`    // Sent with every probe, as "Name: value"
+	Exclude   []string           `mapstructure:"exclude"`    // Out-of-scope hosts, with optional *. prefix
+}
+
+// TargetVerifyConfig holds the 'recon verify' defaults of a target
+type TargetVerifyConfig struct {
+	Concurrency int           `mapstructure:"concurrency"`
+	Timeout     int           `mapstructure:"timeout"` // Per probe, in seconds
+	HostDelay   time.Duration `mapstructure:"host_delay"`
+}
+
+// TargetDirsConfig holds the 'recon dirs' defaults of a target
+type TargetDirsConfig struct {
+	Threads int `mapstructure:"threads"`
+}
+
+// targetKeys are the settings of a target, as the last part of its config
+// keys, e.g., domains.example.com.verify.concurrency
+var targetKeys = []string{"verify.concurrency", "verify.timeout", "verify.host-delay", "dirs.threads", "rate-limit", "headers", "exclude"}
+
+// rateLimitPattern matches rate limits such as 5/s, 100/m, or 5
+var rateLimitPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(/(s|sec|second|m|min|minute|h|hour))?$`)
+
+// parseTargetKey splits a key like domains.example.com.verify.concurrency
+// into the kind of target (domains or programs), its name, and the setting
+func parseTargetKey(key string) (kind, name, setting string, ok bool) {
+	kind, rest, ok := strings.Cut(key, ".")
+	if !ok || (kind != "domains" && kind != "programs") {
+		return "", "", "", false
+	}
+	rest = strings.ToLower(rest)
+
+	settings := targetKeys
+	if kind == "programs" {
+		settings = append([]string{"domains"}, targetKeys...)
+	}
+	for _, setting := range settings {
+		for _, spelling := range []string{setting, strings.ReplaceAll(setting, "-", "_")} {
+			if name, found := strings.CutSuffix(rest, "."+spelling); found && name != "" {
+				return kind, name, setting, true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// target returns the settings of a domain or program, adding them when add
+// is set; nil when there are none
+func (c *Config) target(kind, name string, add bool) *TargetConfig {
+	for i := range c.Targets {
+		t := &c.Targets[i]
+		if (kind == "domains" && t.Domain == name) || (kind == "programs" && t.Program == name) {
+			return t
+		}
+	}
+	if !add {
+		return nil
+	}
+	t := TargetConfig{Domain: name}
+	if kind == "programs" {
+		t = TargetConfig{Program: name}
+	}
+	c.Targets = append(c.Targets, t)
+	return &c.Targets[len(c.Targets)-1]
+}
+
+// setTarget updates a setting of a domain or program
+func (c *Config) setTarget(kind, name, setting, value string) error {
+	t := c.target(kind, name, true)
+	value = strings.TrimSpace(value)
+
+	switch setting {
+	case "domains":
+		t.Domains = splitList(value)
+	case "verify.concurrency", "verify.timeout", "dirs.threads":
+		n, err := strconv.Atoi(value)
+		if value == "" {
+			n, err = 0, nil
+		}
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s value (must be a positive number; 0 or \"\" uses the flag default)", setting)
+		}
+		switch setting {
+		case "verify.concurrency":
+			t.Verify.Concurrency = n
+		case "verify.timeout":
+			t.Verify.Timeout = n
+		default:
+			t.Dirs.Threads = n
+		}
+	case "verify.host-delay":
+		delay, err := time.ParseDuration(value)
+		if value == "" {
+			delay, err = 0, nil
+		}
+		if err != nil || delay < 0 {
+			return fmt.Errorf("invalid verify.host-delay value (use: 500ms, 2s, etc.)")
+		}
+		t.Verify.HostDelay = delay
+	case "rate-limit":
+		if value != "" && !rateLimitPattern.MatchString(value) {
+			return fmt.Errorf("invalid rate-limit value (use: 5/s, 100/m, etc.)")
+		}
+		t.RateLimit = value
+	case "headers":
+		// Each call sets one header; "Name:" removes it and "" removes all
+		if value == "" {
+			t.Headers = nil
+			break
+		}
+		header, headerValue, ok := strings.Cut(value, ":")
+		header = strings.TrimSpace(header)
+		if !ok || header == "" || strings.ContainsAny(header, " \t") {
+			return fmt.Errorf("invalid header %q (expected 'Name: value'; 'Name:' removes it)", value)
+		}
+		t.Headers = slices.DeleteFunc(t.Headers, func(h string) bool {
+			name, _, _ := strings.Cut(h, ":")
+			return strings.EqualFold(strings.TrimSpace(name), header)
+		})
+		if headerValue = strings.TrimSpace(headerValue); headerValue != "" {
+			t.Headers = append(t.Headers, header+": "+headerValue)
+		}
+	case "exclude":
+		t.Exclude = splitList(strings.ToLower(value))
+	}
+
+	if t.empty() {
+		c.Targets = slices.DeleteFunc(c.Targets, func(other TargetConfig) bool {
+			return other.Domain == t.Domain && other.Program == t.Program
+		})
+	}
+	return nil
+}
+
+// getTarget returns a setting of a domain or program
+func (c *Config) getTarget(kind, name, setting string) string {
+	t := c.target(kind, name, false)
+	if t == nil {
+		return ""
+	}
+	switch setting {
+	case "domains":
+		return strings.Join(t.Domains, ",")
+	case "verify.concurrency":
+		return formatTargetInt(t.Verify.Concurrency)
+	case "verify.timeout":
+		return formatTargetInt(t.Verify.Timeout)
+	case "verify.host-delay":
+		if t.Verify.HostDelay == 0 {
+			return ""
+		}
+		return t.Verify.HostDelay.String()
+	case "dirs.threads":
+		return formatTargetInt(t.Dirs.Threads)
+	case "rate-limit":
+		return t.RateLimit
+	case "headers":
+		return strings.Join(t.Headers, "; ")
+	default:
+		return strings.Join(t.Exclude, ",")
+	}
+}
+
+// empty reports whether a target has no settings left
+func (t TargetConfig) empty() bool {
+	return len(t.Domains) == 0 && t.Verify == (TargetVerifyConfig{}) && t.Dirs == (TargetDirsConfig{}) &&
+		t.RateLimit == "" && len(t.Headers) == 0 && len(t.Exclude) == 0
+}
+
+// Name returns the config key prefix of a target, e.g., domains.example.com
+func (t TargetConfig) Name() string {
+	if t.Program != "" {
+		return "programs." + t.Program
+	}
+	return "domains." + t.Domain
+}
+
+// TargetSettings resolves the recon defaults of a domain or host: the
+// settings of programs listing it or a parent domain, then those of its
+// parent domains and itself, the most specific winning. Headers are merged
+// by name and exclusions are combined. The second result names the targets
+// that applied.
+func (c *Config) TargetSettings(host string) (TargetConfig, []string) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	var resolved TargetConfig
+	var applied []string
+
+	for _, t := range c.Targets {
+		if t.Program == "" {
+			continue
+		}
+		for _, domain := range t.Domains {
+			if coversHost(domain, host) {
+				resolved.merge(t)
+				applied = append(applied, t.Name())
+				break
+			}
+		}
+	}
+
+	// Parent domains first, so the most specific domain wins
+	var domains []TargetConfig
+	for _, t := range c.Targets {
+		if t.Domain != "" && coversHost(t.Domain, host) {
+			domains = append(domains, t)
+		}
+	}
+	slices.SortFunc(domains, func(a, b TargetConfig) int {
+		return len(a.Domain) - len(b.Domain)
+	})
+	for _, t := range domains {
+		resolved.merge(t)
+		applied = append(applied, t.Name())
+	}
+
+	return resolved, applied
+}
+
+// merge applies the settings of other over t
+func (t *TargetConfig) merge(other TargetConfig) {
+	if other.Verify.Concurrency > 0 {
+		t.Verify.Concurrency = other.Verify.Concurrency
+	}
+	if other.Verify.Timeout > 0 {
+		t.Verify.Timeout = other.Verify.Timeout
+	}
+	if other.Verify.HostDelay > 0 {
+		t.Verify.HostDelay = other.Verify.HostDelay
+	}
+	if other.Dirs.Threads > 0 {
+		t.Dirs.Threads = other.Dirs.Threads
+	}
+	if other.RateLimit != "" {
+		t.RateLimit = other.RateLimit
+	}
+	for _, header := range other.Headers {
+		name, _, _ := strings.Cut(header, ":")
+		t.Headers = slices.DeleteFunc(t.Headers, func(h string) bool {
+			existing, _, _ := strings.Cut(h, ":")
+			return strings.EqualFold(strings.TrimSpace(existing), strings.TrimSpace(name))
+		})
+		t.Headers = append(t.Headers, header)
+	}
+	for _, host := range other.Exclude {
+		if !slices.Contains(t.Exclude, host) {
+			t.Exclude = append(t.Exclude, host)
+		}
+	}
+}
+
+// ExcludesHost reports whether a host is out of scope by the Exclude rules:
+// a hostname, or *.domain for the subdomains of a domain
+func (t TargetConfig) ExcludesHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, rule := range t.Exclude {
+		if parent, ok := strings.CutPrefix(rule, "*."); ok {
+			if strings.HasSuffix(host, "."+parent) {
+				return true
+			}
+		} else if host == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeHeaders returns the target's headers followed by the given ones, so
+// headers given as flags replace configured ones of the same name
+func (t TargetConfig) MergeHeaders(headers []string) []string {
+	if len(t.Headers) == 0 {
+		return headers
+	}
+	merged := slices.Clone(t.Headers)
+	for _, header := range headers {
+		name, _, _ := strings.Cut(header, ":")
+		merged = slices.DeleteFunc(merged, func(h string) bool {
+			existing, _, _ := strings.Cut(h, ":")
+			return strings.EqualFold(strings.TrimSpace(existing), strings.TrimSpace(name))
+		})
+	}
+	return append(merged, headers...)
+}
+
+// coversHost reports whether host is domain or one of its subdomains
+func coversHost(domain, host string) bool {
+	domain = strings.ToLower(domain)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// splitList splits a comma-separated setting, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatTargetInt formats a numeric target setting; 0 is unset
+func formatTargetInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
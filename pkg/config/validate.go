@@ -0,0 +1,397 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Issue is a problem found in the configuration file
+type Issue struct {
+	Key     string // Key in the file's spelling, e.g., tls.client_key ("" = the whole file)
+	Message string
+	Fix     string // Suggested fix, e.g., a command to run
+	Warning bool   // The CLI still works, perhaps not as intended
+}
+
+// checkedKeys are the keys whose values Validate checks like 'config set'
+// does; the others are free-form or checked separately
+var checkedKeys = []string{
+	"grpc_tls", "timeout", "max_retries", "output_format", "log_level", "proxy", "doh",
+	"retention.max_age", "retention.keep_last", "notify.slack", "notify.discord",
+	"defectdojo.url", "defectdojo.engagement", "faraday.url",
+	"tls.ca_file", "tls.client_cert", "tls.client_key", "tls.insecure_skip_verify",
+	"api.proxy", "api.max_idle_conns", "api.idle_timeout", "api.keepalive",
+	"compress_results", "auto_sync",
+}
+
+// knownKeys are the other keys of the configuration file
+var knownKeys = []string{
+	"server", "grpc_server", "grpc_ca_cert", "api_key", "github_token", "gitlab_token", "webhook_secret",
+	"defectdojo.api_key", "faraday.token", "faraday.workspace",
+	"profile", "profiles", "targets", "wordlists",
+}
+
+// profileKeys are the keys of a saved profile, below profiles.<name>
+var profileKeys = []string{
+	"server", "grpc_server", "api_key", "username", "grpc_tls", "grpc_ca_cert", "output_format",
+	"tls.ca_file", "tls.client_cert", "tls.client_key", "tls.insecure_skip_verify",
+}
+
+// profileKeyPattern splits profiles.<name>.<key>
+var profileKeyPattern = regexp.MustCompile(`^profiles\.([^.]+)\.(.+)$`)
+
+// Validate checks the configuration file: that it parses, that its keys
+// are known, that values are valid, and that settings don't conflict.
+// Issues are sorted by key, errors first.
+func Validate(cfgFile string) ([]Issue, error) {
+	path, err := existingConfigFile(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// A separate viper reads the file alone, without defaults or RECON_*
+	// environment variables
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return []Issue{{
+			Message: fmt.Sprintf("the file can't be parsed: %v", err),
+			Fix:     fmt.Sprintf("fix the YAML syntax in %s, or recreate it with 'recon-cli config init --force'", path),
+		}}, nil
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return []Issue{{
+			Message: fmt.Sprintf("the file doesn't match the config schema: %v", err),
+			Fix:     "check the types of the values, e.g., numbers unquoted and lists as YAML lists",
+		}}, nil
+	}
+
+	var issues []Issue
+	issues = append(issues, checkKeys(v)...)
+	issues = append(issues, checkValues(v)...)
+	issues = append(issues, checkConflicts(&cfg, v)...)
+	issues = append(issues, checkProfiles(&cfg)...)
+	issues = append(issues, checkTargets(&cfg)...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Warning != issues[j].Warning {
+			return !issues[i].Warning
+		}
+		return issues[i].Key < issues[j].Key
+	})
+	return issues, nil
+}
+
+// checkKeys reports the keys the CLI doesn't know, which it ignores
+func checkKeys(v *viper.Viper) []Issue {
+	known := append(slices.Clone(checkedKeys), knownKeys...)
+
+	var issues []Issue
+	for _, key := range v.AllKeys() {
+		if slices.Contains(known, key) || strings.HasPrefix(key, "wordlists.") || strings.HasPrefix(key, "defaults.") {
+			continue
+		}
+		if match := profileKeyPattern.FindStringSubmatch(key); match != nil && slices.Contains(profileKeys, match[2]) {
+			continue
+		}
+
+		issue := Issue{Key: key, Message: "unknown key, ignored", Warning: true}
+		if suggestion := closestKey(key, known); suggestion != "" {
+			issue.Fix = fmt.Sprintf("did you mean %s?", suggestion)
+		} else {
+			issue.Fix = "remove it, or see 'recon-cli config set --help' for the keys"
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// checkValues checks the values of the file like 'config set' would
+func checkValues(v *viper.Viper) []Issue {
+	var issues []Issue
+	for _, key := range checkedKeys {
+		if !v.IsSet(key) {
+			continue
+		}
+		value := v.GetString(key)
+		if err := applySetting(DefaultConfig(), key, value); err != nil {
+			issues = append(issues, Issue{
+				Key:     key,
+				Message: err.Error(),
+				Fix:     fmt.Sprintf("recon-cli config set %s <value>", strings.ReplaceAll(key, "_", "-")),
+			})
+		}
+	}
+	for _, key := range v.AllKeys() {
+		name, ok := strings.CutPrefix(key, "wordlists.")
+		if !ok || v.GetString(key) == "" {
+			continue
+		}
+		value := v.GetString(key)
+		if _, err := os.Stat(value); err == nil {
+			continue
+		}
+		issue := Issue{
+			Key:     key,
+			Message: fmt.Sprintf("wordlist not found: %s", value),
+			Fix:     fmt.Sprintf("recon-cli config set wordlists.%s /path/to/wordlist.txt", name),
+			Warning: true,
+		}
+		if !strings.ContainsAny(value, `/\`) {
+			// A name refers to a wordlist downloaded by 'recon assets'
+			if dir, err := GetConfigDir(); err == nil {
+				if _, err := os.Stat(filepath.Join(dir, "assets", value+".txt")); err == nil {
+					continue
+				}
+			}
+			issue.Message = fmt.Sprintf("wordlist %s is neither a file nor a downloaded asset", value)
+			issue.Fix = fmt.Sprintf("recon-cli recon assets download %s", value)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// checkConflicts checks the server addresses and the settings that only
+// work together, or contradict each other
+func checkConflicts(cfg *Config, v *viper.Viper) []Issue {
+	var issues []Issue
+
+	if v.IsSet("server") {
+		if issue, ok := checkServerURL("server", cfg.Server); ok {
+			issues = append(issues, issue)
+		}
+	}
+	if v.IsSet("grpc_server") {
+		if _, _, err := net.SplitHostPort(cfg.GRPCServer); err != nil {
+			issues = append(issues, Issue{
+				Key:     "grpc_server",
+				Message: fmt.Sprintf("invalid gRPC server address %q (expected host:port)", cfg.GRPCServer),
+				Fix:     "recon-cli config set grpc-server <host>:9090",
+			})
+		}
+	}
+
+	if (cfg.TLS.ClientCert == "") != (cfg.TLS.ClientKey == "") {
+		key, missing := "tls.client_cert", "tls.client-key"
+		if cfg.TLS.ClientCert == "" {
+			key, missing = "tls.client_key", "tls.client-cert"
+		}
+		issues = append(issues, Issue{
+			Key:     key,
+			Message: "a client certificate needs both tls.client_cert and tls.client_key",
+			Fix:     fmt.Sprintf("recon-cli config set %s <path>", missing),
+		})
+	}
+	if cfg.TLS.InsecureSkipVerify {
+		issue := Issue{
+			Key:     "tls.insecure_skip_verify",
+			Message: "server certificates are not verified",
+			Fix:     "recon-cli config set tls.insecure-skip-verify false, and trust the server's CA with tls.ca-file",
+			Warning: true,
+		}
+		if cfg.TLS.CAFile != "" {
+			issue.Message = "server certificates are not verified, so tls.ca_file is ignored"
+			issue.Fix = "recon-cli config set tls.insecure-skip-verify false"
+		}
+		issues = append(issues, issue)
+	}
+	if cfg.GRPCTLS == "off" && cfg.GRPCCACert != "" {
+		issues = append(issues, Issue{
+			Key:     "grpc_ca_cert",
+			Message: "grpc_tls is off, so the gRPC CA bundle is ignored",
+			Fix:     "recon-cli config set grpc-tls auto, or clear grpc-ca-cert",
+			Warning: true,
+		})
+	}
+
+	if cfg.AutoSync && cfg.APIKey == "" {
+		issues = append(issues, Issue{
+			Key:     "auto_sync",
+			Message: "auto_sync is on, but no API key is set, so results are not pushed",
+			Fix:     "recon-cli auth login",
+			Warning: true,
+		})
+	}
+	if cfg.Retention.KeepLast > 0 && cfg.Retention.MaxAge == "" {
+		issues = append(issues, Issue{
+			Key:     "retention.keep_last",
+			Message: "retention.keep_last has no effect without retention.max_age",
+			Fix:     "recon-cli config set retention.max-age 30d",
+			Warning: true,
+		})
+	}
+	if cfg.DefectDojo.URL != "" && cfg.DefectDojo.APIKey == "" {
+		issues = append(issues, Issue{
+			Key:     "defectdojo.api_key",
+			Message: "defectdojo.url is set without an API key",
+			Fix:     "recon-cli config set defectdojo.api-key <key>",
+			Warning: true,
+		})
+	}
+	if cfg.Faraday.URL != "" && cfg.Faraday.Token == "" {
+		issues = append(issues, Issue{
+			Key:     "faraday.token",
+			Message: "faraday.url is set without a token",
+			Fix:     "recon-cli config set faraday.token <token>",
+			Warning: true,
+		})
+	}
+	return issues
+}
+
+// checkServerURL checks a server URL, flagging plain HTTP to other hosts
+// than this one since the API key would travel unencrypted
+func checkServerURL(key, server string) (Issue, bool) {
+	serverURL, err := url.Parse(server)
+	if err != nil || (serverURL.Scheme != "http" && serverURL.Scheme != "https") || serverURL.Host == "" {
+		return Issue{
+			Key:     key,
+			Message: fmt.Sprintf("invalid server URL %q", server),
+			Fix:     "recon-cli config set server https://<host>",
+		}, true
+	}
+	if serverURL.Scheme == "http" {
+		host := serverURL.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return Issue{
+				Key:     key,
+				Message: "the server is reached over plain HTTP, so the API key is sent unencrypted",
+				Fix:     fmt.Sprintf("recon-cli config set server https://%s", serverURL.Host),
+				Warning: true,
+			}, true
+		}
+	}
+	return Issue{}, false
+}
+
+// checkProfiles checks the saved profiles and the active profile's name
+func checkProfiles(cfg *Config) []Issue {
+	var issues []Issue
+	if cfg.Profile != "" {
+		if _, ok := cfg.Profiles[cfg.Profile]; !ok {
+			issues = append(issues, Issue{
+				Key:     "profile",
+				Message: fmt.Sprintf("the active profile %q is not saved", cfg.Profile),
+				Fix:     "recon-cli auth switch, to list the saved profiles",
+			})
+		}
+	}
+	for _, name := range cfg.ProfileNames() {
+		key := "profiles." + name
+		if err := ValidateProfileName(name); err != nil {
+			issues = append(issues, Issue{Key: key, Message: err.Error(), Fix: "rename the profile in the config file"})
+		}
+		profile := cfg.Profiles[name]
+		if profile.Server == "" {
+			issues = append(issues, Issue{
+				Key:     key + ".server",
+				Message: "the profile has no server",
+				Fix:     fmt.Sprintf("recon-cli auth login --profile %s --server <url>", name),
+			})
+		} else if issue, ok := checkServerURL(key+".server", profile.Server); ok {
+			issue.Fix = fmt.Sprintf("recon-cli auth login --profile %s --server https://<host>", name)
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// checkTargets checks the per-domain and per-program recon defaults
+func checkTargets(cfg *Config) []Issue {
+	var issues []Issue
+	seen := make(map[string]bool)
+	for i, t := range cfg.Targets {
+		key := fmt.Sprintf("targets[%d]", i)
+		switch {
+		case t.Domain == "" && t.Program == "":
+			issues = append(issues, Issue{Key: key, Message: "the entry has neither a domain nor a program", Fix: "add domain: or program: to the entry, or remove it"})
+			continue
+		case t.Domain != "" && t.Program != "":
+			issues = append(issues, Issue{Key: key, Message: "the entry has both a domain and a program", Fix: "split it into a domain entry and a program entry"})
+			continue
+		}
+
+		key = t.Name()
+		if seen[key] {
+			issues = append(issues, Issue{Key: key, Message: "duplicate entry; only the first is changed by 'config set'", Fix: "merge the entries in the config file", Warning: true})
+		}
+		seen[key] = true
+
+		if t.Program != "" && len(t.Domains) == 0 {
+			issues = append(issues, Issue{
+				Key:     key + ".domains",
+				Message: "the program lists no domains, so its settings never apply",
+				Fix:     fmt.Sprintf("recon-cli config set %s.domains example.com,example.net", key),
+				Warning: true,
+			})
+		}
+		if t.RateLimit != "" && !rateLimitPattern.MatchString(t.RateLimit) {
+			issues = append(issues, Issue{
+				Key:     key + ".rate-limit",
+				Message: fmt.Sprintf("invalid rate limit %q", t.RateLimit),
+				Fix:     fmt.Sprintf("recon-cli config set %s.rate-limit 5/s", key),
+			})
+		}
+		for _, header := range t.Headers {
+			if name, _, ok := strings.Cut(header, ":"); !ok || strings.TrimSpace(name) == "" || strings.ContainsAny(strings.TrimSpace(name), " \t") {
+				issues = append(issues, Issue{
+					Key:     key + ".headers",
+					Message: fmt.Sprintf("invalid header %q (expected 'Name: value')", header),
+					Fix:     fmt.Sprintf("recon-cli config set %s.headers \"Name: value\"", key),
+				})
+			}
+		}
+		if t.Verify.Concurrency < 0 || t.Verify.Timeout < 0 || t.Verify.HostDelay < 0 || t.Dirs.Threads < 0 {
+			issues = append(issues, Issue{Key: key, Message: "negative numbers are not valid settings", Fix: "set them to a positive number, or remove them"})
+		}
+	}
+	return issues
+}
+
+// closestKey returns the known key a mistyped key most likely meant, e.g.,
+// with dashes instead of underscores; "" when none is close
+func closestKey(key string, known []string) string {
+	if underscored := strings.ReplaceAll(key, "-", "_"); slices.Contains(known, underscored) {
+		return underscored
+	}
+	best, bestDistance := "", 3
+	for _, candidate := range known {
+		if d := editDistance(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,601 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// The vault encrypts recon results and the API key at rest with age
+// (https://age-encryption.org). Data is encrypted to the vault's X25519
+// recipient, so new results can be written while the vault is locked, and
+// the matching identity is kept in vault.json encrypted to the passphrase
+// with age's scrypt recipient. Sealed data is a binary age file, whose
+// "age-encryption.org/v1" header line versions the format.
+const (
+	vaultVersion         = 2 // Version of vault.json; 1 predates age
+	vaultHeader          = "age-encryption.org/v1\n"
+	vaultSealedPrefix    = "vault:" // Prefix of sealed config values
+	vaultKeychainService = "recon-cli-vault"
+	vaultPassphraseEnv   = "RECON_VAULT_PASSPHRASE"
+
+	// VaultSessionEnv holds the session key of an unlocked vault when no
+	// OS keychain is available
+	VaultSessionEnv = "RECON_VAULT_SESSION"
+	// DefaultVaultSessionTTL is how long a session file stays usable
+	DefaultVaultSessionTTL = 8 * time.Hour
+)
+
+// VaultHeaderSize is how many leading bytes IsVaultSealed needs
+const VaultHeaderSize = len(vaultHeader)
+
+// ErrVaultLocked is returned when sealed data is read without the vault key
+var ErrVaultLocked = errors.New("vault is locked: run 'recon vault unlock'")
+
+// vaultFile is the on-disk vault description (~/.recon-cli/vault.json)
+type vaultFile struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Recipient string    `json:"recipient"` // age X25519 recipient (age1...)
+	Identity  string    `json:"identity"`  // age identity encrypted to the passphrase (armored)
+}
+
+// vaultSession is the session file UnlockVault writes when no OS keychain
+// is available (~/.recon-cli/vault.session). The identity is encrypted to a
+// per-session key that is only handed to the user, never written to disk.
+type vaultSession struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Identity  string    `json:"identity"` // vaultSessionKey encrypted to the session key (armored)
+}
+
+// vaultSessionKey is the encrypted part of a session file. It repeats the
+// expiry so editing the file cannot extend the session.
+type vaultSessionKey struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Identity  string    `json:"identity"`
+}
+
+// VaultStatus describes the vault for 'recon vault status'
+type VaultStatus struct {
+	Enabled   bool
+	Unlocked  bool
+	KeyStore  string    // Where the unlocked key is held ("keychain", a file path, "environment", or "")
+	ExpiresAt time.Time // When the session file expires (zero for the keychain and environment)
+	CreatedAt time.Time
+}
+
+// VaultSession describes where UnlockVault keeps the unlocked key
+type VaultSession struct {
+	KeyStore  string    // "keychain" or the session file path
+	Token     string    // Session key to export as VaultSessionEnv (session file only)
+	ExpiresAt time.Time // When the session file stops working (session file only)
+}
+
+var (
+	// vaultKey caches the identity once unlocked in this process
+	vaultKey *age.X25519Identity
+	// vaultPrompt asks for the passphrase when sealed data is read while
+	// the vault is locked (set by the CLI; nil disables prompting)
+	vaultPrompt func() (string, error)
+)
+
+// SetVaultPrompt sets the function used to ask for the vault passphrase
+func SetVaultPrompt(prompt func() (string, error)) {
+	vaultPrompt = prompt
+}
+
+// getVaultPath returns the path to the vault description
+func getVaultPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "vault.json"), nil
+}
+
+// getVaultSessionPath returns the file holding the encrypted session key
+// when no OS keychain is available
+func getVaultSessionPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "vault.session"), nil
+}
+
+func loadVaultFile() (*vaultFile, error) {
+	path, err := getVaultPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vault vaultFile
+	if err := json.Unmarshal(data, &vault); err != nil {
+		return nil, fmt.Errorf("invalid vault file %s: %w", path, err)
+	}
+	if vault.Version != vaultVersion {
+		return nil, fmt.Errorf("unsupported vault file %s (version %d, expected %d)", path, vault.Version, vaultVersion)
+	}
+	return &vault, nil
+}
+
+// VaultEnabled reports whether a vault has been set up
+func VaultEnabled() bool {
+	path, err := getVaultPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// InitVault creates a vault protected by passphrase and keeps it unlocked
+// for the current process
+func InitVault(passphrase string) error {
+	if VaultEnabled() {
+		return fmt.Errorf("vault already exists")
+	}
+	if passphrase == "" {
+		return fmt.Errorf("passphrase cannot be empty")
+	}
+	if err := EnsureConfigDir(); err != nil {
+		return err
+	}
+
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return fmt.Errorf("failed to generate vault key: %w", err)
+	}
+	wrapped, err := wrapVaultKey(key, passphrase)
+	if err != nil {
+		return err
+	}
+
+	vault := vaultFile{
+		Version:   vaultVersion,
+		CreatedAt: time.Now(),
+		Recipient: key.Recipient().String(),
+		Identity:  wrapped,
+	}
+	data, err := json.MarshalIndent(vault, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault: %w", err)
+	}
+
+	path, err := getVaultPath()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+
+	vaultKey = key
+	return nil
+}
+
+// UnlockVault checks passphrase and stores the vault key in the OS keychain
+// when one is available, so later commands can read sealed data. Without a
+// keychain it writes a session file holding the key encrypted to a new
+// session key, which stops working after ttl; later commands need the
+// returned Token in VaultSessionEnv to use it.
+func UnlockVault(passphrase string, ttl time.Duration) (*VaultSession, error) {
+	key, err := unwrapVaultKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	vaultKey = key
+
+	if keychainAvailable() {
+		if err := keychainStore(key.String()); err == nil {
+			return &VaultSession{KeyStore: "keychain"}, nil
+		}
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("session lifetime must be positive")
+	}
+	sessionKey, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session key: %w", err)
+	}
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+
+	payload, err := json.Marshal(vaultSessionKey{ExpiresAt: expiresAt, Identity: key.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vault session: %w", err)
+	}
+	wrapped, err := armoredEncrypt(payload, sessionKey.Recipient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt vault session: %w", err)
+	}
+	data, err := json.MarshalIndent(vaultSession{ExpiresAt: expiresAt, Identity: wrapped}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vault session: %w", err)
+	}
+
+	path, err := getVaultSessionPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write vault session: %w", err)
+	}
+	return &VaultSession{KeyStore: path, Token: sessionKey.String(), ExpiresAt: expiresAt}, nil
+}
+
+// LockVault forgets the unlocked vault key
+func LockVault() error {
+	vaultKey = nil
+
+	if keychainAvailable() {
+		_ = keychainDelete()
+	}
+
+	path, err := getVaultSessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove vault session: %w", err)
+	}
+	return nil
+}
+
+// DisableVault locks and removes the vault. Sealed data must be opened
+// before calling it or it becomes unreadable.
+func DisableVault() error {
+	if err := LockVault(); err != nil {
+		return err
+	}
+	path, err := getVaultPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove vault: %w", err)
+	}
+	return nil
+}
+
+// GetVaultStatus reports whether the vault exists and is unlocked
+func GetVaultStatus() (*VaultStatus, error) {
+	vault, err := loadVaultFile()
+	if os.IsNotExist(err) {
+		return &VaultStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := &VaultStatus{Enabled: true, CreatedAt: vault.CreatedAt}
+	if _, store, expiresAt := storedVaultKey(); store != "" {
+		status.Unlocked = true
+		status.KeyStore = store
+		status.ExpiresAt = expiresAt
+	} else if os.Getenv(vaultPassphraseEnv) != "" {
+		status.Unlocked = true
+		status.KeyStore = "environment"
+	}
+	return status, nil
+}
+
+// IsVaultSealed reports whether data was sealed by the vault
+func IsVaultSealed(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(vaultHeader))
+}
+
+// VaultSeal encrypts data to the vault's recipient. It does not need the
+// vault to be unlocked.
+func VaultSeal(data []byte) ([]byte, error) {
+	vault, err := loadVaultFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vault: %w", err)
+	}
+	recipient, err := age.ParseX25519Recipient(vault.Recipient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault recipient: %w", err)
+	}
+
+	var sealed bytes.Buffer
+	writer, err := age.Encrypt(&sealed, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return sealed.Bytes(), nil
+}
+
+// VaultOpen decrypts data sealed by VaultSeal, prompting for the passphrase
+// when the vault is locked and a prompt is set
+func VaultOpen(data []byte) ([]byte, error) {
+	return vaultOpen(data, true)
+}
+
+func vaultOpen(data []byte, interactive bool) ([]byte, error) {
+	if !IsVaultSealed(data) {
+		return data, nil
+	}
+
+	key, err := currentVaultKey(interactive)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(data), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sealed data (wrong vault?): %w", err)
+	}
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sealed data: %w", err)
+	}
+	return plain, nil
+}
+
+// sealConfigValue seals a config string for storage, leaving it unchanged
+// when the vault is disabled
+func sealConfigValue(value string) (string, error) {
+	if value == "" || !VaultEnabled() {
+		return value, nil
+	}
+	sealed, err := VaultSeal([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return vaultSealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openConfigValue opens a sealed config string without prompting. ok is
+// false when the value is sealed and the vault is locked.
+func openConfigValue(value string) (string, bool) {
+	encoded, sealed := strings.CutPrefix(value, vaultSealedPrefix)
+	if !sealed {
+		return value, true
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false
+	}
+	plain, err := vaultOpen(data, false)
+	if err != nil {
+		return "", false
+	}
+	return string(plain), true
+}
+
+// currentVaultKey returns the unlocked identity from this process, the
+// keychain or an unexpired session, the passphrase environment variable, or (when
+// interactive) a passphrase prompt
+func currentVaultKey(interactive bool) (*age.X25519Identity, error) {
+	if vaultKey != nil {
+		return vaultKey, nil
+	}
+	if key, _, _ := storedVaultKey(); key != nil {
+		vaultKey = key
+		return key, nil
+	}
+	if passphrase := os.Getenv(vaultPassphraseEnv); passphrase != "" {
+		key, err := unwrapVaultKey(passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", vaultPassphraseEnv, err)
+		}
+		vaultKey = key
+		return key, nil
+	}
+	if interactive && vaultPrompt != nil {
+		passphrase, err := vaultPrompt()
+		if err != nil {
+			return nil, err
+		}
+		key, err := unwrapVaultKey(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		vaultKey = key
+		return key, nil
+	}
+	return nil, ErrVaultLocked
+}
+
+// storedVaultKey loads the key saved by UnlockVault, where it was found, and
+// when a session file expires. A session file is only usable with its
+// session key in VaultSessionEnv; once expired it is removed.
+func storedVaultKey() (*age.X25519Identity, string, time.Time) {
+	if keychainAvailable() {
+		if secret, err := keychainLoad(); err == nil {
+			if key := parseVaultKey(secret); key != nil {
+				return key, "keychain", time.Time{}
+			}
+		}
+	}
+
+	path, err := getVaultSessionPath()
+	if err != nil {
+		return nil, "", time.Time{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", time.Time{}
+	}
+	var session vaultSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, "", time.Time{}
+	}
+	if time.Now().After(session.ExpiresAt) {
+		os.Remove(path)
+		return nil, "", time.Time{}
+	}
+
+	sessionKey := parseVaultKey(os.Getenv(VaultSessionEnv))
+	if sessionKey == nil {
+		return nil, "", time.Time{}
+	}
+	payload, err := armoredDecrypt(session.Identity, sessionKey)
+	if err != nil {
+		return nil, "", time.Time{}
+	}
+	var stored vaultSessionKey
+	if err := json.Unmarshal(payload, &stored); err != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, "", time.Time{}
+	}
+	if key := parseVaultKey(stored.Identity); key != nil {
+		return key, path, stored.ExpiresAt
+	}
+	return nil, "", time.Time{}
+}
+
+func parseVaultKey(secret string) *age.X25519Identity {
+	key, err := age.ParseX25519Identity(strings.TrimSpace(secret))
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+// wrapVaultKey encrypts the vault identity to passphrase
+func wrapVaultKey(key *age.X25519Identity, passphrase string) (string, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive key: %w", err)
+	}
+	wrapped, err := armoredEncrypt([]byte(key.String()), recipient)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt vault key: %w", err)
+	}
+	return wrapped, nil
+}
+
+// unwrapVaultKey decrypts the vault's identity with passphrase
+func unwrapVaultKey(passphrase string) (*age.X25519Identity, error) {
+	vault, err := loadVaultFile()
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("vault is not enabled")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	secret, err := armoredDecrypt(vault.Identity, identity)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, fmt.Errorf("incorrect vault passphrase")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault key: %w", err)
+	}
+
+	key := parseVaultKey(string(secret))
+	if key == nil {
+		return nil, fmt.Errorf("invalid vault key")
+	}
+	return key, nil
+}
+
+// armoredEncrypt encrypts data to recipient as an armored age file
+func armoredEncrypt(data []byte, recipient age.Recipient) (string, error) {
+	var out bytes.Buffer
+	armored := armor.NewWriter(&out)
+	writer, err := age.Encrypt(armored, recipient)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	if err := armored.Close(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// armoredDecrypt decrypts an armored age file with identity
+func armoredDecrypt(data string, identity age.Identity) ([]byte, error) {
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(data)), identity)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// keychainAvailable reports whether an OS keychain CLI is installed
+// (security on macOS, secret-tool on Linux)
+func keychainAvailable() bool {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := exec.LookPath("security")
+		return err == nil
+	case "linux":
+		_, err := exec.LookPath("secret-tool")
+		return err == nil
+	}
+	return false
+}
+
+// keychainStore saves secret in the OS keychain. The secret is passed on
+// stdin so it never appears in the process list.
+func keychainStore(secret string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -a recon-cli -s %s -w %s\n", vaultKeychainService, secret))
+	default:
+		cmd = exec.Command("secret-tool", "store", "--label=recon-cli vault", "service", vaultKeychainService)
+		cmd.Stdin = strings.NewReader(secret)
+	}
+	return cmd.Run()
+}
+
+func keychainLoad() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-a", "recon-cli", "-s", vaultKeychainService, "-w")
+	default:
+		cmd = exec.Command("secret-tool", "lookup", "service", vaultKeychainService)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func keychainDelete() error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-a", "recon-cli", "-s", vaultKeychainService)
+	default:
+		cmd = exec.Command("secret-tool", "clear", "service", vaultKeychainService)
+	}
+	return cmd.Run()
+}
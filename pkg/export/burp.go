@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// burpProject is the subset of a Burp Suite project options file holding the
+// target scope, importable via Project options > Load
+type burpProject struct {
+	Target burpTarget `json:"target"`
+}
+
+type burpTarget struct {
+	Scope burpScope `json:"scope"`
+}
+
+// burpScope uses Burp's advanced scope mode, where each rule matches
+// protocol, host, and port regexes
+type burpScope struct {
+	AdvancedMode bool       `json:"advanced_mode"`
+	Include      []burpRule `json:"include"`
+	Exclude      []burpRule `json:"exclude"`
+}
+
+type burpRule struct {
+	Enabled  bool   `json:"enabled"`
+	Protocol string `json:"protocol"` // "http", "https", or "any"
+	Host     string `json:"host"`
+	Port     string `json:"port,omitempty"`
+}
+
+// ExportToBurp exports a Burp Suite project scope: an include rule for each
+// accessible web port of the alive subdomains (or for the host on any
+// protocol when it has none) and an exclude rule for each of
+// options.Exclude, which are hostnames with an optional *. wildcard prefix
+func ExportToBurp(result *recon.SubdomainResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_burp.json", result.Domain)
+	}
+
+	options.AliveOnly = true
+	filtered := *result
+	filtered.Subdomains = filterSubdomains(result.Subdomains, options)
+
+	scope := burpScope{
+		AdvancedMode: true,
+		Include:      []burpRule{},
+		Exclude:      []burpRule{},
+	}
+
+	covered := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, port := range recon.QueryPorts(&filtered, recon.PortQueryOptions{AccessibleOnly: true}) {
+		protocol := "any"
+		if parsed, err := url.Parse(port.HTTP.URL); err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") {
+			protocol = parsed.Scheme
+		}
+		rule := burpRule{
+			Enabled:  true,
+			Protocol: protocol,
+			Host:     burpHostPattern(port.Subdomain),
+			Port:     "^" + strconv.Itoa(port.Port) + "$",
+		}
+		key := rule.Protocol + " " + rule.Host + " " + rule.Port
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		covered[port.Subdomain] = true
+		scope.Include = append(scope.Include, rule)
+	}
+	for _, sub := range filtered.Subdomains {
+		if !covered[sub.Name] {
+			scope.Include = append(scope.Include, burpRule{
+				Enabled:  true,
+				Protocol: "any",
+				Host:     burpHostPattern(sub.Name),
+			})
+		}
+	}
+
+	for _, host := range options.Exclude {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			continue
+		}
+		scope.Exclude = append(scope.Exclude, burpRule{
+			Enabled:  true,
+			Protocol: "any",
+			Host:     burpHostPattern(host),
+		})
+	}
+
+	data, err := json.MarshalIndent(burpProject{Target: burpTarget{Scope: scope}}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Burp scope: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write Burp scope: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// burpHostPattern returns the anchored host regex of a hostname; a leading
+// *. matches any subdomain of the rest
+func burpHostPattern(host string) string {
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		return `^.+\.` + regexp.QuoteMeta(rest) + "$"
+	}
+	return "^" + regexp.QuoteMeta(host) + "$"
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 )
 
-// ExportToCSV exports subdomain results to CSV format
+// ExportToCSV exports subdomain results to CSV format. The Tags and Notes
+// columns can be edited in a spreadsheet and read back with
+// recon.ParseAnnotationsCSV.
 func ExportToCSV(result *recon.SubdomainResults, options ExportOptions) (string, error) {
 	filePath := options.OutputPath
 	if filePath == "" {
@@ -54,12 +56,16 @@ func ExportToCSV(result *recon.SubdomainResults, options ExportOptions) (string,
 			"Content Length",
 			"Discovered By",
 			"First Seen",
+			"Tags",
+			"Notes",
 		}
 	} else {
 		header = []string{
 			"Subdomain",
 			"Discovered By",
 			"First Seen",
+			"Tags",
+			"Notes",
 		}
 	}
 
@@ -121,12 +127,28 @@ func ExportToCSV(result *recon.SubdomainResults, options ExportOptions) (string,
 				contentLength,
 				strings.Join(sub.DiscoveredBy, ";"),
 				sub.FirstSeen.Format("2006-01-02 15:04:05"),
+				strings.Join(sub.Tags, ";"),
+				sub.Notes,
+			}
+		} else if hasVerification {
+			// Unverified subdomain in a verified scan: keep the columns
+			// aligned so Tags and Notes stay in place
+			row = []string{
+				sub.Name,
+				"unverified",
+				"-", "-", "-", "-", "-", "-", "-", "-",
+				strings.Join(sub.DiscoveredBy, ";"),
+				sub.FirstSeen.Format("2006-01-02 15:04:05"),
+				strings.Join(sub.Tags, ";"),
+				sub.Notes,
 			}
 		} else {
 			row = []string{
 				sub.Name,
 				strings.Join(sub.DiscoveredBy, ";"),
 				sub.FirstSeen.Format("2006-01-02 15:04:05"),
+				strings.Join(sub.Tags, ";"),
+				sub.Notes,
 			}
 		}
 
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// defectDojoFinding is one finding of DefectDojo's Generic Findings Import
+// format
+type defectDojoFinding struct {
+	Title            string               `json:"title"`
+	Description      string               `json:"description"`
+	Severity         string               `json:"severity"` // Critical, High, Medium, Low, or Info
+	Active           bool                 `json:"active"`
+	Verified         bool                 `json:"verified"`
+	UniqueIDFromTool string               `json:"unique_id_from_tool"`
+	Date             string               `json:"date"`
+	Endpoints        []defectDojoEndpoint `json:"endpoints,omitempty"`
+	DynamicFinding   bool                 `json:"dynamic_finding"`
+	VulnIDFromTool   string               `json:"vuln_id_from_tool,omitempty"`
+}
+
+type defectDojoEndpoint struct {
+	Host string `json:"host"`
+}
+
+// defectDojoFindings builds a Generic Findings Import file from the findings
+// of a report
+func defectDojoFindings(report *recon.Report) ([]byte, error) {
+	findings := make([]defectDojoFinding, 0, len(report.Findings))
+	for _, finding := range report.Findings {
+		dojo := defectDojoFinding{
+			Title:            fmt.Sprintf("%s: %s", finding.Category, finding.Title),
+			Description:      findingEvidence(report, finding),
+			Severity:         defectDojoSeverity(finding.Severity),
+			Active:           true,
+			UniqueIDFromTool: findingID(finding),
+			Date:             report.GeneratedAt.Format("2006-01-02"),
+			DynamicFinding:   true,
+			VulnIDFromTool:   finding.Category,
+		}
+		if finding.Subdomain != "" {
+			dojo.Endpoints = []defectDojoEndpoint{{Host: finding.Subdomain}}
+		}
+		findings = append(findings, dojo)
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"findings": findings}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal DefectDojo findings: %w", err)
+	}
+	return data, nil
+}
+
+// pushDefectDojo imports the findings into an engagement through
+// /api/v2/import-scan/ as a Generic Findings Import scan
+func pushDefectDojo(report *recon.Report, options PushOptions) (*PushResult, error) {
+	if options.Engagement == "" {
+		return nil, fmt.Errorf("no DefectDojo engagement ID configured")
+	}
+
+	file, err := defectDojoFindings(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"scan_type":          "Generic Findings Import",
+		"engagement":         options.Engagement,
+		"active":             "true",
+		"verified":           "false",
+		"minimum_severity":   "Info",
+		"close_old_findings": "false",
+		"test_title":         "recon-cli: " + report.Domain,
+		"scan_date":          report.GeneratedAt.Format("2006-01-02"),
+	}
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("failed to build DefectDojo request: %w", err)
+		}
+	}
+	part, err := form.CreateFormFile("file", report.Domain+"_findings.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build DefectDojo request: %w", err)
+	}
+	part.Write(file)
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build DefectDojo request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, options.URL+"/api/v2/import-scan/", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DefectDojo request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Token "+options.Token)
+
+	respBody, err := doPush(req, "DefectDojo", options.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PushResult{
+		Target:   PushDefectDojo,
+		Findings: len(report.Findings),
+		Location: fmt.Sprintf("%s/engagement/%s", options.URL, options.Engagement),
+	}
+	var imported struct {
+		Test int `json:"test"`
+	}
+	if json.Unmarshal(respBody, &imported) == nil && imported.Test > 0 {
+		result.Location = fmt.Sprintf("%s/test/%d", options.URL, imported.Test)
+	}
+	return result, nil
+}
+
+// defectDojoSeverity maps a report severity to DefectDojo's
+func defectDojoSeverity(severity string) string {
+	if severity == "" || severity == "info" {
+		return "Info"
+	}
+	return strings.ToUpper(severity[:1]) + severity[1:]
+}
+
+// findingID identifies a finding across pushes so platforms can deduplicate
+// repeated imports
+func findingID(finding recon.ReportFinding) string {
+	return strings.ToLower(strings.Join([]string{finding.Category, finding.Subdomain, finding.Title}, "|"))
+}
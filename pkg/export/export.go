@@ -12,19 +12,32 @@ import (
 type ExportFormat string
 
 const (
-	FormatCSV      ExportFormat = "csv"
-	FormatJSON     ExportFormat = "json"
-	FormatMarkdown ExportFormat = "markdown"
+	FormatCSV        ExportFormat = "csv"
+	FormatJSON       ExportFormat = "json"
+	FormatMarkdown   ExportFormat = "markdown"
+	FormatHTML       ExportFormat = "html"         // Standalone report with inline CSS and JavaScript
+	FormatHosts      ExportFormat = "txt"          // One hostname per line
+	FormatURLs       ExportFormat = "urls"         // One base URL per alive web host
+	FormatNmap       ExportFormat = "nmap-targets" // Plain IP, hostname, and CIDR target lists
+	FormatBurp       ExportFormat = "burp"         // Burp Suite project scope JSON
+	FormatAquatone   ExportFormat = "aquatone"     // host:port lines of alive web ports
+	FormatEyeWitness ExportFormat = "eyewitness"   // URLs of alive web ports, also read by gowitness
+	FormatSTIX       ExportFormat = "stix"         // STIX 2.1 bundle of domains, IPs, and certificates
+	FormatIOCs       ExportFormat = "iocs"         // CSV of the same infrastructure indicators
+	FormatPDF        ExportFormat = "pdf"          // Full reports only, printed from the HTML report
+	FormatTemplate   ExportFormat = "template"     // Rendered with a user-supplied Go template
 )
 
 // ExportOptions configures export behavior
 type ExportOptions struct {
-	Format     ExportFormat
-	OutputPath string
-	AliveOnly  bool
-	DeadOnly   bool
-	StatusCode int
-	Source     string
+	recon.QueryOptions // Subdomain filters
+	Format             ExportFormat
+	OutputPath         string
+	IncludeRaw         bool     // Include raw tool output, such as WHOIS responses
+	Exclude            []string // Hosts to exclude from scope exports, with optional *. prefix
+	GroupBy            string   // Markdown: group subdomains by status, tech, source, or cloud
+	TOC                bool     // Markdown: add a table of contents
+	Collapsible        bool     // Markdown: put tables in collapsible <details> blocks
 }
 
 // GetExportsDir returns the default exports directory
@@ -43,41 +56,7 @@ func GetExportsDir() (string, error) {
 	return exportsDir, nil
 }
 
-// filterSubdomains applies export options to filter subdomains
+// filterSubdomains applies the filters of export options to subdomains
 func filterSubdomains(subdomains []recon.Subdomain, options ExportOptions) []recon.Subdomain {
-	var filtered []recon.Subdomain
-
-	for _, sub := range subdomains {
-		// Apply filters
-		if options.AliveOnly && (sub.Verified == nil || sub.Verified.Status != "alive") {
-			continue
-		}
-
-		if options.DeadOnly && (sub.Verified == nil || sub.Verified.Status != "dead") {
-			continue
-		}
-
-		if options.StatusCode != 0 {
-			if sub.Verified == nil || sub.Verified.HTTP == nil || sub.Verified.HTTP.StatusCode != options.StatusCode {
-				continue
-			}
-		}
-
-		if options.Source != "" {
-			found := false
-			for _, source := range sub.DiscoveredBy {
-				if source == options.Source {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
-		}
-
-		filtered = append(filtered, sub)
-	}
-
-	return filtered
+	return recon.MatchSubdomains(subdomains, options.QueryOptions)
 }
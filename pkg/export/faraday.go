@@ -0,0 +1,142 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// faradayHost is a host of Faraday's bulk_create payload, carrying its
+// vulnerabilities
+type faradayHost struct {
+	IP              string        `json:"ip"`
+	Hostnames       []string      `json:"hostnames"`
+	Description     string        `json:"description"`
+	Vulnerabilities []faradayVuln `json:"vulnerabilities"`
+}
+
+type faradayVuln struct {
+	Name        string   `json:"name"`
+	Desc        string   `json:"desc"`
+	Data        string   `json:"data"`     // Evidence
+	Severity    string   `json:"severity"` // critical, high, medium, low, or informational
+	Type        string   `json:"type"`
+	Status      string   `json:"status"`
+	Confirmed   bool     `json:"confirmed"`
+	ExternalID  string   `json:"external_id"`
+	Refs        []string `json:"refs"`
+	PolicyViols []string `json:"policyviolations"`
+}
+
+type faradayCommand struct {
+	Tool         string `json:"tool"`
+	Command      string `json:"command"`
+	Params       string `json:"params"`
+	StartDate    string `json:"start_date"`
+	ImportSource string `json:"import_source"`
+}
+
+// faradayBulkCreate builds a bulk_create payload grouping the findings of a
+// report by host
+func faradayBulkCreate(report *recon.Report) ([]byte, error) {
+	hosts := make(map[string]*faradayHost)
+	for _, finding := range report.Findings {
+		name := finding.Subdomain
+		if name == "" {
+			name = report.Domain
+		}
+		host, ok := hosts[name]
+		if !ok {
+			host = &faradayHost{
+				IP:              name,
+				Hostnames:       []string{name},
+				Description:     "Discovered by recon-cli for " + report.Domain,
+				Vulnerabilities: []faradayVuln{},
+			}
+			hosts[name] = host
+		}
+		host.Vulnerabilities = append(host.Vulnerabilities, faradayVuln{
+			Name:        fmt.Sprintf("%s: %s", finding.Category, finding.Title),
+			Desc:        findingEvidence(report, finding),
+			Data:        finding.Detail,
+			Severity:    faradaySeverity(finding.Severity),
+			Type:        "Vulnerability",
+			Status:      "open",
+			ExternalID:  findingID(finding),
+			Refs:        []string{},
+			PolicyViols: []string{},
+		})
+	}
+
+	names := make([]string, 0, len(hosts))
+	for name := range hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	payload := struct {
+		Hosts   []*faradayHost `json:"hosts"`
+		Command faradayCommand `json:"command"`
+	}{
+		Hosts: make([]*faradayHost, 0, len(names)),
+		Command: faradayCommand{
+			Tool:         "recon-cli",
+			Command:      "recon results push",
+			Params:       report.Domain,
+			StartDate:    report.GeneratedAt.UTC().Format("2006-01-02T15:04:05.000000"),
+			ImportSource: "report",
+		},
+	}
+	for _, name := range names {
+		payload.Hosts = append(payload.Hosts, hosts[name])
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Faraday findings: %w", err)
+	}
+	return data, nil
+}
+
+// pushFaraday creates the findings in a workspace through
+// /_api/v3/ws/<workspace>/bulk_create
+func pushFaraday(report *recon.Report, options PushOptions) (*PushResult, error) {
+	if options.Workspace == "" {
+		return nil, fmt.Errorf("no Faraday workspace configured")
+	}
+
+	payload, err := faradayBulkCreate(report)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/_api/v3/ws/%s/bulk_create", options.URL, url.PathEscape(options.Workspace))
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Faraday request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Token "+options.Token)
+
+	if _, err := doPush(req, "Faraday", options.Timeout); err != nil {
+		return nil, err
+	}
+
+	return &PushResult{
+		Target:   PushFaraday,
+		Findings: len(report.Findings),
+		Location: fmt.Sprintf("%s/#/manage/ws/%s", options.URL, url.PathEscape(options.Workspace)),
+	}, nil
+}
+
+// faradaySeverity maps a report severity to Faraday's
+func faradaySeverity(severity string) string {
+	if severity == "" || severity == "info" {
+		return "informational"
+	}
+	return severity
+}
@@ -0,0 +1,361 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// htmlChartBars caps the bars of each report chart
+const htmlChartBars = 10
+
+// htmlReport is the data rendered by htmlReportTemplate
+type htmlReport struct {
+	Domain     string
+	Generated  string
+	ScanDate   string
+	Sources    string
+	Total      int
+	Alive      int
+	Dead       int
+	Unverified int
+	Takeovers  []htmlTakeover
+	HasDNS     bool
+	DNSDate    string
+	Charts     []htmlChart
+	Subdomains []htmlSubdomain
+}
+
+// htmlChart is a horizontal bar chart of counts
+type htmlChart struct {
+	Title string
+	Bars  []htmlBar
+}
+
+type htmlBar struct {
+	Label   string
+	Count   int
+	Percent float64 // Width relative to the largest bar
+}
+
+type htmlSubdomain struct {
+	Name       string
+	Status     string
+	StatusCode int
+	Title      string
+	URL        string
+	IPs        string
+	Tech       string
+	Sources    string
+}
+
+type htmlTakeover struct {
+	Subdomain string
+	CNAME     string
+	Status    string
+	Score     int
+	Reason    string
+}
+
+// ExportToHTML exports subdomain results to a standalone HTML report with
+// summary charts, a sortable and filterable subdomain table, and the
+// takeover findings of dnsResults, which may be nil. All CSS and JavaScript
+// is inline so the report can be sent as a single file.
+func ExportToHTML(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_subdomains.html", result.Domain)
+	}
+
+	report := buildHTMLReport(result, dnsResults, filterSubdomains(result.Subdomains, options))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTML file: %w", err)
+	}
+	defer file.Close()
+
+	if err := htmlReportTemplate.Execute(file, report); err != nil {
+		return "", fmt.Errorf("failed to write HTML report: %w", err)
+	}
+
+	return filePath, nil
+}
+
+func buildHTMLReport(result *recon.SubdomainResults, dnsResults *recon.DNSResults, subdomains []recon.Subdomain) *htmlReport {
+	report := &htmlReport{
+		Domain:    result.Domain,
+		Generated: time.Now().Format("2006-01-02 15:04:05"),
+		ScanDate:  result.Timestamp.Format("2006-01-02 15:04:05"),
+		Sources:   strings.Join(result.SourcesUsed, ", "),
+		Total:     len(subdomains),
+	}
+
+	statuses := make(map[string]int)
+	codes := make(map[string]int)
+	sources := make(map[string]int)
+	techs := make(map[string]int)
+
+	for _, sub := range subdomains {
+		row := htmlSubdomain{
+			Name:    sub.Name,
+			Sources: strings.Join(sub.DiscoveredBy, ", "),
+		}
+		for _, source := range sub.DiscoveredBy {
+			sources[source]++
+		}
+
+		if sub.Verified == nil {
+			report.Unverified++
+			statuses["unverified"]++
+		} else {
+			row.Status = sub.Verified.Status
+			statuses[row.Status]++
+			if row.Status == "alive" {
+				report.Alive++
+			} else {
+				report.Dead++
+			}
+
+			if sub.Verified.DNS != nil {
+				row.IPs = strings.Join(sub.Verified.DNS.IPs, ", ")
+			}
+			if http := sub.Verified.HTTP; http != nil && http.Accessible {
+				row.StatusCode = http.StatusCode
+				row.Title = http.Title
+				row.URL = http.URL
+				codes[fmt.Sprintf("%d", http.StatusCode)]++
+
+				tech := recon.TechStack(http)
+				row.Tech = strings.Join(tech, ", ")
+				for _, name := range tech {
+					techs[name]++
+				}
+			}
+		}
+
+		report.Subdomains = append(report.Subdomains, row)
+	}
+
+	report.Charts = append(report.Charts, newHTMLChart("Verification Status", statuses))
+	if len(codes) > 0 {
+		report.Charts = append(report.Charts, newHTMLChart("HTTP Status Codes", codes))
+	}
+	if len(sources) > 0 {
+		report.Charts = append(report.Charts, newHTMLChart("Discovery Sources", sources))
+	}
+	if len(techs) > 0 {
+		report.Charts = append(report.Charts, newHTMLChart("Technologies", techs))
+	}
+
+	if dnsResults != nil {
+		report.HasDNS = true
+		report.DNSDate = dnsResults.EnumeratedAt.Format("2006-01-02 15:04:05")
+
+		clouds := make(map[string]int)
+		for _, info := range dnsResults.Records {
+			if info.CloudProvider != "" {
+				clouds[info.CloudProvider]++
+			}
+			if !info.TakeoverRisk {
+				continue
+			}
+			report.Takeovers = append(report.Takeovers, htmlTakeover{
+				Subdomain: info.Subdomain,
+				CNAME:     strings.Join(info.CNAME, ", "),
+				Status:    info.TakeoverStatus,
+				Score:     info.TakeoverScore,
+				Reason:    info.TakeoverReason,
+			})
+		}
+		sort.SliceStable(report.Takeovers, func(i, j int) bool {
+			return report.Takeovers[i].Score > report.Takeovers[j].Score
+		})
+		if len(clouds) > 0 {
+			report.Charts = append(report.Charts, newHTMLChart("Cloud Providers", clouds))
+		}
+	}
+
+	return report
+}
+
+// newHTMLChart charts the most common keys of counts
+func newHTMLChart(title string, counts map[string]int) htmlChart {
+	chart := htmlChart{Title: title}
+	keys := recon.TopCounts(counts, htmlChartBars)
+	if len(keys) == 0 {
+		return chart
+	}
+
+	largest := counts[keys[0]]
+	for _, key := range keys {
+		chart.Bars = append(chart.Bars, htmlBar{
+			Label:   key,
+			Count:   counts[key],
+			Percent: float64(counts[key]) / float64(largest) * 100,
+		})
+	}
+	return chart
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Reconnaissance Report: {{.Domain}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; background: #f6f8fa; }
+header { background: #24292f; color: #fff; padding: 24px 32px; }
+header h1 { margin: 0 0 8px; font-size: 24px; }
+header p { margin: 2px 0; color: #c9d1d9; font-size: 14px; }
+main { padding: 24px 32px; }
+section { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 16px 20px; margin-bottom: 24px; }
+h2 { font-size: 18px; margin: 0 0 12px; }
+.cards { display: flex; flex-wrap: wrap; gap: 16px; }
+.card { flex: 1; min-width: 140px; background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 16px; }
+.card .value { font-size: 28px; font-weight: 600; }
+.card .label { color: #57606a; font-size: 13px; }
+.card.alive .value { color: #1a7f37; }
+.card.dead .value { color: #cf222e; }
+.card.risk .value { color: #bc4c00; }
+.charts { display: grid; grid-template-columns: repeat(auto-fill, minmax(320px, 1fr)); gap: 16px; }
+.chart h3 { font-size: 14px; margin: 0 0 8px; }
+.bar { display: flex; align-items: center; font-size: 13px; margin: 4px 0; }
+.bar .name { width: 120px; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.bar .track { flex: 1; background: #eaeef2; border-radius: 3px; margin: 0 8px; }
+.bar .fill { background: #0969da; height: 14px; border-radius: 3px; }
+.bar .count { width: 48px; text-align: right; color: #57606a; }
+table { width: 100%; border-collapse: collapse; font-size: 13px; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #d0d7de; vertical-align: top; }
+th { background: #f6f8fa; cursor: pointer; user-select: none; white-space: nowrap; }
+th.sorted-asc::after { content: " ▲"; }
+th.sorted-desc::after { content: " ▼"; }
+td.status-alive { color: #1a7f37; font-weight: 600; }
+td.status-dead, td.status-timeout, td.status-connection_refused, td.status-tls_error { color: #cf222e; }
+.filters { display: flex; gap: 12px; margin-bottom: 12px; }
+.filters input, .filters select { padding: 6px 8px; border: 1px solid #d0d7de; border-radius: 6px; font-size: 13px; }
+.filters input { flex: 1; }
+.muted { color: #57606a; font-size: 13px; }
+a { color: #0969da; text-decoration: none; }
+footer { padding: 0 32px 24px; color: #57606a; font-size: 12px; }
+</style>
+</head>
+<body>
+<header>
+<h1>Reconnaissance Report: {{.Domain}}</h1>
+<p>Scan date: {{.ScanDate}}{{if .HasDNS}} &middot; DNS enumerated: {{.DNSDate}}{{end}}</p>
+{{if .Sources}}<p>Sources: {{.Sources}}</p>{{end}}
+<p>Generated: {{.Generated}}</p>
+</header>
+<main>
+<div class="cards">
+<div class="card"><div class="value">{{.Total}}</div><div class="label">Subdomains</div></div>
+<div class="card alive"><div class="value">{{.Alive}}</div><div class="label">Alive</div></div>
+<div class="card dead"><div class="value">{{.Dead}}</div><div class="label">Dead</div></div>
+<div class="card"><div class="value">{{.Unverified}}</div><div class="label">Unverified</div></div>
+{{if .HasDNS}}<div class="card risk"><div class="value">{{len .Takeovers}}</div><div class="label">Takeover Risks</div></div>{{end}}
+</div>
+<br>
+<section>
+<h2>Summary</h2>
+<div class="charts">
+{{range .Charts}}<div class="chart">
+<h3>{{.Title}}</h3>
+{{range .Bars}}<div class="bar"><span class="name" title="{{.Label}}">{{.Label}}</span><span class="track"><div class="fill" style="width: {{printf "%.1f" .Percent}}%"></div></span><span class="count">{{.Count}}</span></div>
+{{end}}</div>
+{{end}}</div>
+</section>
+{{if .HasDNS}}<section>
+<h2>Takeover Findings</h2>
+{{if .Takeovers}}<table>
+<thead><tr><th>Subdomain</th><th>CNAME</th><th>Status</th><th>Score</th><th>Reason</th></tr></thead>
+<tbody>
+{{range .Takeovers}}<tr><td>{{.Subdomain}}</td><td>{{.CNAME}}</td><td>{{.Status}}</td><td>{{if .Score}}{{.Score}}/10{{end}}</td><td>{{.Reason}}</td></tr>
+{{end}}</tbody>
+</table>{{else}}<p class="muted">No subdomain takeover risks were found.</p>{{end}}
+</section>
+{{end}}<section>
+<h2>Subdomains</h2>
+<div class="filters">
+<input id="filter" type="search" placeholder="Filter by name, title, IP, or technology">
+<select id="status-filter">
+<option value="">All statuses</option>
+<option value="alive">Alive</option>
+<option value="dead">Not alive</option>
+<option value="unverified">Unverified</option>
+</select>
+</div>
+<table id="subdomains">
+<thead><tr><th data-type="text">Subdomain</th><th data-type="text">Status</th><th data-type="number">HTTP</th><th data-type="text">Title</th><th data-type="text">IPs</th><th data-type="text">Technologies</th><th data-type="text">Sources</th></tr></thead>
+<tbody>
+{{range .Subdomains}}<tr data-status="{{if .Status}}{{.Status}}{{else}}unverified{{end}}">
+<td>{{if .URL}}<a href="{{.URL}}" rel="noopener noreferrer">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td>
+<td class="status-{{.Status}}">{{if .Status}}{{.Status}}{{else}}-{{end}}</td>
+<td>{{if .StatusCode}}{{.StatusCode}}{{end}}</td>
+<td>{{.Title}}</td>
+<td>{{.IPs}}</td>
+<td>{{.Tech}}</td>
+<td>{{.Sources}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+<p class="muted" id="shown"></p>
+</section>
+</main>
+<footer>Report generated by Recontronic CLI</footer>
+<script>
+(function () {
+  var table = document.getElementById("subdomains");
+  var body = table.tBodies[0];
+  var rows = Array.prototype.slice.call(body.rows);
+  var filter = document.getElementById("filter");
+  var statusFilter = document.getElementById("status-filter");
+  var shown = document.getElementById("shown");
+
+  function applyFilters() {
+    var text = filter.value.toLowerCase();
+    var status = statusFilter.value;
+    var count = 0;
+    rows.forEach(function (row) {
+      var rowStatus = row.getAttribute("data-status");
+      var statusMatch = !status ||
+        (status === "dead" ? rowStatus !== "alive" && rowStatus !== "unverified" : rowStatus === status);
+      var visible = statusMatch && row.textContent.toLowerCase().indexOf(text) !== -1;
+      row.style.display = visible ? "" : "none";
+      if (visible) { count++; }
+    });
+    shown.textContent = "Showing " + count + " of " + rows.length + " subdomains";
+  }
+
+  Array.prototype.forEach.call(table.tHead.rows[0].cells, function (th, column) {
+    th.addEventListener("click", function () {
+      var ascending = !th.classList.contains("sorted-asc");
+      Array.prototype.forEach.call(th.parentNode.cells, function (cell) {
+        cell.classList.remove("sorted-asc", "sorted-desc");
+      });
+      th.classList.add(ascending ? "sorted-asc" : "sorted-desc");
+      var numeric = th.getAttribute("data-type") === "number";
+      rows.sort(function (a, b) {
+        var x = a.cells[column].textContent.trim();
+        var y = b.cells[column].textContent.trim();
+        var result = numeric ? (parseFloat(x) || 0) - (parseFloat(y) || 0) : x.localeCompare(y);
+        return ascending ? result : -result;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+
+  filter.addEventListener("input", applyFilters);
+  statusFilter.addEventListener("change", applyFilters);
+  applyFilters();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,189 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// ExportInventory exports the consolidated asset inventory to CSV (one row
+// per asset), JSON, or Markdown. Only AliveOnly of the filter options
+// applies.
+func ExportInventory(inventory *recon.Inventory, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("inventory.%s", formatExtension(options.Format))
+	}
+
+	assets := inventory.Assets
+	if options.AliveOnly {
+		assets = nil
+		for _, asset := range inventory.Assets {
+			if asset.Status == "alive" {
+				assets = append(assets, asset)
+			}
+		}
+	}
+
+	var err error
+	switch options.Format {
+	case FormatCSV:
+		err = writeInventoryCSV(filePath, assets)
+	case FormatJSON:
+		filtered := *inventory
+		filtered.Assets = assets
+		err = writeInventoryJSON(filePath, &filtered)
+	case FormatMarkdown:
+		err = writeInventoryMarkdown(filePath, inventory, assets)
+	default:
+		return "", fmt.Errorf("unsupported inventory format: %s", options.Format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// formatExtension returns the file extension of an export format
+func formatExtension(format ExportFormat) string {
+	if format == FormatMarkdown {
+		return "md"
+	}
+	return string(format)
+}
+
+func writeInventoryCSV(filePath string, assets []recon.InventoryAsset) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Domain", "Subdomain", "Status", "Status Code", "Title", "IP Addresses", "Technologies", "Cloud Provider", "Discovered By"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, asset := range assets {
+		statusCode := "-"
+		if asset.StatusCode > 0 {
+			statusCode = strconv.Itoa(asset.StatusCode)
+		}
+
+		row := []string{
+			asset.Domain,
+			asset.Name,
+			valueOrDash(asset.Status),
+			statusCode,
+			valueOrDash(asset.Title),
+			valueOrDash(strings.Join(asset.IPs, ";")),
+			valueOrDash(strings.Join(asset.Tech, ";")),
+			valueOrDash(asset.CloudProvider),
+			strings.Join(asset.Sources, ";"),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeInventoryJSON(filePath string, inventory *recon.Inventory) error {
+	data, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+
+	return nil
+}
+
+func writeInventoryMarkdown(filePath string, inventory *recon.Inventory, assets []recon.InventoryAsset) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create Markdown file: %w", err)
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "# Asset Inventory\n\n")
+	fmt.Fprintf(file, "**Generated:** %s\n\n", inventory.GeneratedAt.Format("2006-01-02 15:04:05"))
+
+	fmt.Fprintf(file, "## Summary\n\n")
+	fmt.Fprintf(file, "- **Domains:** %d\n", len(inventory.Domains))
+	fmt.Fprintf(file, "- **Total Assets:** %d\n", inventory.TotalAssets)
+	fmt.Fprintf(file, "- **Verified:** %d\n", inventory.VerifiedAssets)
+	fmt.Fprintf(file, "- **Alive:** %d\n\n", inventory.AliveAssets)
+
+	fmt.Fprintf(file, "## Domains\n\n")
+	fmt.Fprintf(file, "| Domain | Assets | Alive | Takeover Risks | Cloud | Last Scan |\n")
+	fmt.Fprintf(file, "|--------|--------|-------|----------------|-------|-----------|\n")
+	for _, domain := range inventory.Domains {
+		fmt.Fprintf(file, "| %s | %d | %d | %d | %s | %s |\n",
+			domain.Domain,
+			domain.Assets,
+			domain.Alive,
+			domain.TakeoverRisks,
+			valueOrDash(strings.Join(domain.CloudProviders, ", ")),
+			domain.LastScan.Format("2006-01-02"),
+		)
+	}
+	fmt.Fprintf(file, "\n")
+
+	writeCountTable(file, "Technologies", "Technology", "Alive Assets", inventory.Technologies)
+	writeCountTable(file, "Cloud Providers", "Provider", "Assets", inventory.CloudProviders)
+
+	fmt.Fprintf(file, "## Assets\n\n")
+	fmt.Fprintf(file, "| Subdomain | Status | HTTP | Title | Technologies |\n")
+	fmt.Fprintf(file, "|-----------|--------|------|-------|--------------|\n")
+	for _, asset := range assets {
+		statusCode := "-"
+		if asset.StatusCode > 0 {
+			statusCode = strconv.Itoa(asset.StatusCode)
+		}
+		fmt.Fprintf(file, "| %s | %s | %s | %s | %s |\n",
+			asset.Name,
+			valueOrDash(asset.Status),
+			statusCode,
+			valueOrDash(strings.ReplaceAll(asset.Title, "|", "\\|")),
+			valueOrDash(strings.Join(asset.Tech, ", ")),
+		)
+	}
+
+	return nil
+}
+
+// writeCountTable writes a Markdown section tabulating counts, most common
+// first
+func writeCountTable(file *os.File, title, keyHeader, countHeader string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+
+	fmt.Fprintf(file, "## %s\n\n", title)
+	fmt.Fprintf(file, "| %s | %s |\n", keyHeader, countHeader)
+	fmt.Fprintf(file, "|%s|%s|\n", strings.Repeat("-", len(keyHeader)+2), strings.Repeat("-", len(countHeader)+2))
+	for _, key := range recon.TopCounts(counts, 0) {
+		fmt.Fprintf(file, "| %s | %d |\n", key, counts[key])
+	}
+	fmt.Fprintf(file, "\n")
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
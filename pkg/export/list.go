@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// ExportToHostList writes one subdomain per line, for tools that read plain
+// hostname lists
+func ExportToHostList(result *recon.SubdomainResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_hosts.txt", result.Domain)
+	}
+
+	var lines []string
+	for _, sub := range filterSubdomains(result.Subdomains, options) {
+		lines = append(lines, sub.Name)
+	}
+
+	if err := writeLines(filePath, lines); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// ExportToURLList writes the base URL (scheme://host[:port]) of every alive
+// subdomain with a reachable web server, one per line, for tools such as
+// ffuf, nuclei, and aquatone. Hosts without an HTTP result are skipped.
+func ExportToURLList(result *recon.SubdomainResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_urls.txt", result.Domain)
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, sub := range filterSubdomains(result.Subdomains, options) {
+		if sub.Verified == nil || sub.Verified.Status != "alive" || sub.Verified.HTTP == nil || !sub.Verified.HTTP.Accessible {
+			continue
+		}
+		base := baseURL(sub.Verified.HTTP.URL)
+		if base == "" || seen[base] {
+			continue
+		}
+		seen[base] = true
+		lines = append(lines, base)
+	}
+
+	if err := writeLines(filePath, lines); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// baseURL reduces a URL to its scheme and host
+func baseURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// writeLines writes one value per line
+func writeLines(filePath string, lines []string) error {
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write list: %w", err)
+	}
+	return nil
+}
@@ -5,11 +5,15 @@ import (
 	"os"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/recon"
 )
 
-// ExportToMarkdown exports subdomain results to Markdown format
+// ExportToMarkdown exports subdomain results to Markdown format, with the
+// subdomains grouped by options.GroupBy, a table of contents with
+// options.TOC, and tables in collapsible <details> blocks with
+// options.Collapsible
 func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (string, error) {
 	filePath := options.OutputPath
 	if filePath == "" {
@@ -25,11 +29,45 @@ func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (st
 	// Filter subdomains based on options
 	subdomains := filterSubdomains(result.Subdomains, options)
 
+	var groups map[string][]recon.Subdomain
+	var groupKeys []string
+	if options.GroupBy != "" {
+		groups, err = recon.GroupSubdomains(subdomains, options.GroupBy, options.DNS)
+		if err != nil {
+			return "", err
+		}
+		counts := make(map[string]int, len(groups))
+		for key, subs := range groups {
+			counts[key] = len(subs)
+		}
+		groupKeys = recon.TopCounts(counts, 0)
+	}
+
 	// Write header
 	fmt.Fprintf(file, "# Subdomain Enumeration Report: %s\n\n", result.Domain)
 	fmt.Fprintf(file, "**Generated:** %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
 	fmt.Fprintf(file, "**Scan Date:** %s\n\n", result.Timestamp.Format("2006-01-02 15:04:05"))
 
+	// Table of contents, linking GitHub-style heading anchors
+	anchors := make(map[string]int)
+	groupHeadings := make([]string, len(groupKeys))
+	for i, key := range groupKeys {
+		groupHeadings[i] = fmt.Sprintf("%s (%d)", key, len(groups[key]))
+	}
+	if options.TOC {
+		fmt.Fprintf(file, "## Contents\n\n")
+		anchors[markdownAnchor("Contents", anchors)]++
+		fmt.Fprintf(file, "- [Summary](#%s)\n", markdownAnchor("Summary", anchors))
+		if len(result.Summary) > 0 {
+			fmt.Fprintf(file, "- [Source Breakdown](#%s)\n", markdownAnchor("Source Breakdown", anchors))
+		}
+		fmt.Fprintf(file, "- [Subdomains](#%s)\n", markdownAnchor("Subdomains", anchors))
+		for _, heading := range groupHeadings {
+			fmt.Fprintf(file, "  - [%s](#%s)\n", heading, markdownAnchor(heading, anchors))
+		}
+		fmt.Fprintf(file, "\n")
+	}
+
 	// Summary section
 	fmt.Fprintf(file, "## Summary\n\n")
 	fmt.Fprintf(file, "- **Total Subdomains:** %d\n", len(subdomains))
@@ -47,7 +85,7 @@ func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (st
 			hasVerification = true
 			if sub.Verified.Status == "alive" {
 				aliveCount++
-			} else if sub.Verified.Status == "dead" {
+			} else {
 				deadCount++
 			}
 		}
@@ -57,6 +95,9 @@ func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (st
 		fmt.Fprintf(file, "- **Alive:** %d (%.1f%%)\n", aliveCount, float64(aliveCount)/float64(len(subdomains))*100)
 		fmt.Fprintf(file, "- **Dead:** %d (%.1f%%)\n", deadCount, float64(deadCount)/float64(len(subdomains))*100)
 	}
+	if options.GroupBy != "" {
+		fmt.Fprintf(file, "- **Groups (by %s):** %d\n", options.GroupBy, len(groupKeys))
+	}
 
 	fmt.Fprintf(file, "\n")
 
@@ -72,6 +113,28 @@ func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (st
 	// Subdomains table
 	fmt.Fprintf(file, "## Subdomains\n\n")
 
+	if options.GroupBy == "" {
+		writeMarkdownTable(file, subdomains, hasVerification, options.Collapsible)
+	}
+	for i, key := range groupKeys {
+		fmt.Fprintf(file, "### %s\n\n", groupHeadings[i])
+		writeMarkdownTable(file, groups[key], hasVerification, options.Collapsible)
+	}
+
+	// Footer
+	fmt.Fprintf(file, "---\n\n")
+	fmt.Fprintf(file, "*Report generated by Recontronic CLI*\n")
+
+	return filePath, nil
+}
+
+// writeMarkdownTable writes a subdomain table, inside a collapsible
+// <details> block when collapsible is set
+func writeMarkdownTable(file *os.File, subdomains []recon.Subdomain, hasVerification, collapsible bool) {
+	if collapsible {
+		fmt.Fprintf(file, "<details>\n<summary>%d subdomain(s)</summary>\n\n", len(subdomains))
+	}
+
 	if hasVerification {
 		fmt.Fprintf(file, "| Subdomain | Status | HTTP | Title | Sources |\n")
 		fmt.Fprintf(file, "|-----------|--------|------|-------|----------|\n")
@@ -113,9 +176,30 @@ func ExportToMarkdown(result *recon.SubdomainResults, options ExportOptions) (st
 		}
 	}
 
-	// Footer
-	fmt.Fprintf(file, "\n---\n\n")
-	fmt.Fprintf(file, "*Report generated by Recontronic CLI*\n")
+	if collapsible {
+		fmt.Fprintf(file, "\n</details>\n")
+	}
+	fmt.Fprintf(file, "\n")
+}
 
-	return filePath, nil
+// markdownAnchor returns the anchor GitHub generates for a heading: lower
+// case, punctuation dropped, and spaces turned into hyphens, with a -1, -2,
+// ... suffix for repeated headings, counted in used
+func markdownAnchor(heading string, used map[string]int) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	anchor := b.String()
+	if n := used[anchor]; n > 0 {
+		used[anchor]++
+		return fmt.Sprintf("%s-%d", anchor, n)
+	}
+	used[anchor]++
+	return anchor
 }
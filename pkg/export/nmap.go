@@ -0,0 +1,180 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// TargetBlock is a /24 (IPv4) or /64 (IPv6) block and the resolved IPs in it
+type TargetBlock struct {
+	CIDR string
+	IPs  []string
+}
+
+// NmapTargets contains the target lists written by ExportToNmapTargets or
+// ExportToTargetList
+type NmapTargets struct {
+	IPsPath   string // One IP per line, sorted so each block is contiguous
+	HostsPath string // One hostname per line
+	CIDRsPath string // One block per line, for sweeping whole ranges
+	Blocks    []TargetBlock
+	Hosts     []string
+}
+
+// ExportToNmapTargets writes deduplicated IP, hostname, and CIDR lists from
+// DNS results, in the plain one-target-per-line format read by nmap -iL,
+// naabu -list, and masscan -iL. Only subdomains passing the export filters
+// are included. The IP list is written to options.OutputPath and the other
+// lists next to it with _hosts and _cidrs suffixes.
+func ExportToNmapTargets(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) (*NmapTargets, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_targets.txt", result.Domain)
+	}
+	ext := filepath.Ext(filePath)
+	base := strings.TrimSuffix(filePath, ext)
+
+	targets := collectNmapTargets(result, dnsResults, options)
+	targets.IPsPath = filePath
+	targets.HostsPath = base + "_hosts" + ext
+	targets.CIDRsPath = base + "_cidrs" + ext
+
+	var ipLines, cidrLines []string
+	for _, block := range targets.Blocks {
+		ipLines = append(ipLines, block.IPs...)
+		cidrLines = append(cidrLines, block.CIDR)
+	}
+
+	for path, lines := range map[string][]string{
+		targets.IPsPath:   ipLines,
+		targets.HostsPath: targets.Hosts,
+		targets.CIDRsPath: cidrLines,
+	} {
+		if err := writeTargetList(path, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	return targets, nil
+}
+
+// ExportToTargetList writes the deduplicated IPs and then the hostnames of
+// ExportToNmapTargets as a single list to options.OutputPath, for piping to
+// one tool
+func ExportToTargetList(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) (*NmapTargets, error) {
+	targets := collectNmapTargets(result, dnsResults, options)
+	targets.IPsPath = options.OutputPath
+	targets.HostsPath = options.OutputPath
+
+	var lines []string
+	for _, block := range targets.Blocks {
+		lines = append(lines, block.IPs...)
+	}
+	lines = append(lines, targets.Hosts...)
+
+	if err := writeTargetList(options.OutputPath, lines); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// collectNmapTargets groups the resolved IPs of the subdomains passing the
+// export filters into sorted blocks and lists their hostnames
+func collectNmapTargets(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) *NmapTargets {
+	included := make(map[string]bool)
+	for _, sub := range filterSubdomains(result.Subdomains, options) {
+		included[strings.ToLower(sub.Name)] = true
+	}
+
+	targets := &NmapTargets{}
+	blocks := make(map[string]map[string]bool)
+	for _, record := range dnsResults.Records {
+		if !included[strings.ToLower(record.Subdomain)] {
+			continue
+		}
+		ips := append(append([]string{}, record.A...), record.AAAA...)
+		if len(ips) == 0 {
+			continue
+		}
+		targets.Hosts = append(targets.Hosts, record.Subdomain)
+
+		for _, ip := range ips {
+			cidr := targetBlock(ip)
+			if cidr == "" {
+				continue
+			}
+			if blocks[cidr] == nil {
+				blocks[cidr] = make(map[string]bool)
+			}
+			blocks[cidr][ip] = true
+		}
+	}
+
+	for cidr, ips := range blocks {
+		block := TargetBlock{CIDR: cidr}
+		for ip := range ips {
+			block.IPs = append(block.IPs, ip)
+		}
+		sort.Slice(block.IPs, func(i, j int) bool { return compareIPs(block.IPs[i], block.IPs[j]) < 0 })
+		targets.Blocks = append(targets.Blocks, block)
+	}
+	sort.Slice(targets.Blocks, func(i, j int) bool {
+		return compareIPs(targets.Blocks[i].IPs[0], targets.Blocks[j].IPs[0]) < 0
+	})
+	targets.Hosts = recon.SortDomains(recon.Deduplicate(targets.Hosts))
+
+	return targets
+}
+
+// writeTargetList writes one target per line
+func writeTargetList(path string, lines []string) error {
+	data := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		data += "\n"
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		return fmt.Errorf("failed to write target list: %w", err)
+	}
+	return nil
+}
+
+// targetBlock returns the /24 or /64 containing ip
+func targetBlock(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}).String()
+}
+
+// compareIPs orders IPv4 before IPv6, then numerically
+func compareIPs(a, b string) int {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	v4A, v4B := ipA.To4(), ipB.To4()
+	switch {
+	case v4A != nil && v4B != nil:
+		x, y := binary.BigEndian.Uint32(v4A), binary.BigEndian.Uint32(v4B)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	case v4A != nil:
+		return -1
+	case v4B != nil:
+		return 1
+	}
+	return bytes.Compare(ipA.To16(), ipB.To16())
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// Vulnerability management platforms findings can be pushed to
+const (
+	PushDefectDojo = "defectdojo"
+	PushFaraday    = "faraday"
+)
+
+// PushTargets lists the platforms PushFindings supports
+var PushTargets = []string{PushDefectDojo, PushFaraday}
+
+// PushOptions configures pushing report findings to a platform
+type PushOptions struct {
+	URL        string // Base URL of the platform, e.g., https://dojo.example.com
+	Token      string // API key (DefectDojo) or API token (Faraday)
+	Engagement string // DefectDojo engagement ID
+	Workspace  string // Faraday workspace name
+	Timeout    time.Duration
+}
+
+// PushResult describes findings accepted by a platform
+type PushResult struct {
+	Target   string
+	Findings int
+	Location string // Where the findings can be viewed
+}
+
+// PushFindings sends the findings of a report (takeover risks, dangling DNS,
+// misconfigurations, nuclei hits, and anomalies) to DefectDojo or Faraday
+func PushFindings(report *recon.Report, target string, options PushOptions) (*PushResult, error) {
+	parsed, err := url.Parse(options.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid %s URL: %q", target, options.URL)
+	}
+	if options.Token == "" {
+		return nil, fmt.Errorf("no %s API token configured", target)
+	}
+	options.URL = strings.TrimSuffix(options.URL, "/")
+	if options.Timeout == 0 {
+		options.Timeout = time.Minute
+	}
+
+	switch target {
+	case PushDefectDojo:
+		return pushDefectDojo(report, options)
+	case PushFaraday:
+		return pushFaraday(report, options)
+	default:
+		return nil, fmt.Errorf("unsupported target: %s (supported: %s)", target, strings.Join(PushTargets, ", "))
+	}
+}
+
+// PushPayload returns the request body PushFindings would send, for
+// reviewing before a push
+func PushPayload(report *recon.Report, target string) ([]byte, error) {
+	switch target {
+	case PushDefectDojo:
+		return defectDojoFindings(report)
+	case PushFaraday:
+		return faradayBulkCreate(report)
+	default:
+		return nil, fmt.Errorf("unsupported target: %s (supported: %s)", target, strings.Join(PushTargets, ", "))
+	}
+}
+
+// findingEvidence describes a report finding for a platform's description
+// field
+func findingEvidence(report *recon.Report, finding recon.ReportFinding) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s finding on %s from recon-cli (%s).\n", finding.Category, finding.Subdomain, report.Domain)
+	if finding.Detail != "" {
+		fmt.Fprintf(&b, "\nEvidence: %s\n", finding.Detail)
+	}
+	return b.String()
+}
+
+// doPush sends a request to a platform and fails on a non-2xx response,
+// returning the response body
+func doPush(req *http.Request, target string, timeout time.Duration) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "recontronic-cli/1.0.0")
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", target, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := strings.TrimSpace(string(body))
+		if len(message) > 200 {
+			message = message[:200] + "..."
+		}
+		return nil, fmt.Errorf("%s returned HTTP %d: %s", target, resp.StatusCode, message)
+	}
+	return body, nil
+}
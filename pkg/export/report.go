@@ -0,0 +1,378 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// pdfTimeout bounds the external HTML-to-PDF conversion
+const pdfTimeout = 2 * time.Minute
+
+// pdfBrowsers are headless browsers that can print the HTML report to PDF
+// when wkhtmltopdf is not installed
+var pdfBrowsers = []string{"chromium", "chromium-browser", "google-chrome", "google-chrome-stable"}
+
+// GetTemplatesDir returns the directory of user report templates, which
+// override the built-in ones: report.md.tmpl for Markdown, and
+// report.html.tmpl for HTML and PDF
+func GetTemplatesDir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "templates"), nil
+}
+
+// ReportTemplateName returns the file name of the user template of a report
+// format
+func ReportTemplateName(format ExportFormat) string {
+	if format == FormatMarkdown {
+		return "report.md.tmpl"
+	}
+	return "report.html.tmpl"
+}
+
+// DefaultReportTemplate returns the built-in report template of a format,
+// a starting point for user templates
+func DefaultReportTemplate(format ExportFormat) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return markdownReportTemplate, nil
+	case FormatHTML, FormatPDF:
+		return htmlReportPageTemplate, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s (supported: markdown, html, pdf)", format)
+	}
+}
+
+// ExportReport renders a full report to Markdown, HTML, or PDF. The template
+// is templatePath when set, else the user template in GetTemplatesDir when
+// present, else the built-in one. PDF output prints the HTML report with
+// wkhtmltopdf or headless Chrome/Chromium.
+func ExportReport(report *recon.Report, options ExportOptions, templatePath string) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_report.%s", report.Domain, formatExtension(options.Format))
+	}
+
+	text, err := loadReportTemplate(options.Format, templatePath)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered bytes.Buffer
+	if options.Format == FormatMarkdown {
+		tmpl, err := template.New("report").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse report template: %w", err)
+		}
+		err = tmpl.Execute(&rendered, report)
+		if err != nil {
+			return "", fmt.Errorf("failed to render report: %w", err)
+		}
+	} else {
+		tmpl, err := htmltemplate.New("report").Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse report template: %w", err)
+		}
+		err = tmpl.Execute(&rendered, report)
+		if err != nil {
+			return "", fmt.Errorf("failed to render report: %w", err)
+		}
+	}
+
+	if options.Format != FormatPDF {
+		if err := os.WriteFile(filePath, rendered.Bytes(), 0600); err != nil {
+			return "", fmt.Errorf("failed to write report: %w", err)
+		}
+		return filePath, nil
+	}
+
+	htmlFile, err := os.CreateTemp("", "recon-report-*.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary HTML file: %w", err)
+	}
+	defer os.Remove(htmlFile.Name())
+	if _, err := htmlFile.Write(rendered.Bytes()); err != nil {
+		htmlFile.Close()
+		return "", fmt.Errorf("failed to write temporary HTML file: %w", err)
+	}
+	htmlFile.Close()
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", err
+	}
+	if err := convertHTMLToPDF(htmlFile.Name(), absPath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// loadReportTemplate reads the report template of a format
+func loadReportTemplate(format ExportFormat, templatePath string) (string, error) {
+	text, err := DefaultReportTemplate(format)
+	if err != nil {
+		return "", err
+	}
+
+	if templatePath == "" {
+		templatesDir, err := GetTemplatesDir()
+		if err != nil {
+			return "", err
+		}
+		userTemplate := filepath.Join(templatesDir, ReportTemplateName(format))
+		if _, err := os.Stat(userTemplate); err != nil {
+			return text, nil
+		}
+		templatePath = userTemplate
+	}
+
+	data, err := os.ReadFile(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read report template: %w", err)
+	}
+	return string(data), nil
+}
+
+// convertHTMLToPDF prints an HTML file to PDF with the first available
+// converter
+func convertHTMLToPDF(htmlPath, pdfPath string) error {
+	if recon.IsToolAvailable("wkhtmltopdf") {
+		if _, err := recon.ExecuteWithTimeout("wkhtmltopdf", pdfTimeout, "--quiet", "--enable-local-file-access", htmlPath, pdfPath); err != nil {
+			return fmt.Errorf("wkhtmltopdf failed: %w", err)
+		}
+		return nil
+	}
+
+	for _, browser := range pdfBrowsers {
+		if !recon.IsToolAvailable(browser) {
+			continue
+		}
+		args := []string{"--headless", "--disable-gpu", "--no-pdf-header-footer", "--print-to-pdf=" + pdfPath, "file://" + htmlPath}
+		if _, err := recon.ExecuteWithTimeout(browser, pdfTimeout, args...); err != nil {
+			return fmt.Errorf("%s failed to print PDF: %w", browser, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("PDF output requires wkhtmltopdf or Chrome/Chromium; install one or use --format html")
+}
+
+// templateFuncs are available to report and export templates
+var templateFuncs = map[string]any{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format("2006-01-02 15:04")
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"json": func(v any) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+	"dash": valueOrDash,
+	// cell escapes a value for a Markdown table cell
+	"cell": func(value string) string {
+		value = strings.ReplaceAll(value, "|", "\\|")
+		return valueOrDash(strings.Join(strings.Fields(value), " "))
+	},
+	"percent": func(part, total int) string {
+		if total == 0 {
+			return "0%"
+		}
+		return fmt.Sprintf("%.0f%%", float64(part)/float64(total)*100)
+	},
+	"severities": func() []string {
+		return recon.ReportSeverities
+	},
+	// findingsOf returns the findings of one severity
+	"findingsOf": func(findings []recon.ReportFinding, severity string) []recon.ReportFinding {
+		var matched []recon.ReportFinding
+		for _, finding := range findings {
+			if finding.Severity == severity {
+				matched = append(matched, finding)
+			}
+		}
+		return matched
+	},
+	// severitySummary describes finding counts, e.g., "1 critical, 3 high"
+	"severitySummary": func(counts map[string]int) string {
+		var parts []string
+		for _, severity := range recon.ReportSeverities {
+			if counts[severity] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+			}
+		}
+		return strings.Join(parts, ", ")
+	},
+	"title": func(value string) string {
+		if value == "" {
+			return value
+		}
+		return strings.ToUpper(value[:1]) + value[1:]
+	},
+}
+
+const markdownReportTemplate = `# Reconnaissance Report: {{.Domain}}
+
+**Generated:** {{date .GeneratedAt}}
+
+## Executive Summary
+
+Passive enumeration identified **{{.Summary.Subdomains}} subdomains** of {{.Domain}}.
+{{- if .Summary.Verified}} **{{.Summary.Alive}} of {{.Summary.Verified}}** verified subdomains are alive, serving {{.Summary.WebPorts}} web ports.{{end}}
+{{- if .Summary.CloudProviders}} Hosting spans {{join .Summary.CloudProviders ", "}}.{{end}}
+{{- if .Findings}} The assessment produced **{{len .Findings}} notable findings** ({{severitySummary .Summary.Severities}}).{{else}} No notable findings were identified.{{end}}
+{{- if .Summary.TakeoverRisks}} Subdomains at risk of takeover (**{{.Summary.TakeoverRisks}}**) should be remediated first.{{end}}
+
+| Metric | Value |
+|--------|-------|
+| Subdomains | {{.Summary.Subdomains}} |
+| Verified | {{.Summary.Verified}} |
+| Alive | {{.Summary.Alive}} ({{percent .Summary.Alive .Summary.Subdomains}}) |
+| Web ports | {{.Summary.WebPorts}} |
+| Takeover risks | {{.Summary.TakeoverRisks}} |
+| Dangling DNS records | {{.Summary.DanglingRisks}} |
+{{- if .Summary.Registrar}}
+| Registrar | {{cell .Summary.Registrar}} |{{end}}
+{{- if .Summary.ExpiryDate}}
+| Registration expires | {{.Summary.ExpiryDate}} |{{end}}
+
+## Methodology
+
+All data was collected with passive sources and low-impact probes; no
+exploitation was attempted.
+
+| Module | Date | Scope |
+|--------|------|-------|
+{{range .Methodology}}| {{.Module}} | {{date .RanAt}} | {{cell .Details}} |
+{{end}}
+## Asset Inventory
+
+| Subdomain | Status | HTTP | Title | IPs | Technologies | Cloud |
+|-----------|--------|------|-------|-----|--------------|-------|
+{{range .Assets}}| {{.Name}} | {{dash .Status}} | {{if .StatusCode}}{{.StatusCode}}{{else}}-{{end}} | {{cell .Title}} | {{cell (join .IPs ", ")}} | {{cell (join .Tech ", ")}} | {{dash .CloudProvider}} |
+{{end}}
+{{- if .Ports}}
+### Web Ports
+
+| Subdomain | Port | HTTP | Title |
+|-----------|------|------|-------|
+{{range .Ports}}| {{.Subdomain}} | {{.Port}} | {{.HTTP.StatusCode}} | {{cell .HTTP.Title}} |
+{{end}}{{end}}
+## Notable Findings
+{{if not .Findings}}
+No notable findings were identified.
+{{end}}
+{{- range $severity := severities}}{{with findingsOf $.Findings $severity}}
+### {{title $severity}}
+
+| Category | Subdomain | Finding | Detail |
+|----------|-----------|---------|--------|
+{{range .}}| {{.Category}} | {{.Subdomain}} | {{cell .Title}} | {{cell .Detail}} |
+{{end}}{{end}}{{end}}
+---
+
+*Report generated by Recontronic CLI*
+`
+
+const htmlReportPageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Reconnaissance Report: {{.Domain}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 0 auto; max-width: 1100px; padding: 32px; line-height: 1.5; }
+h1 { font-size: 28px; margin-bottom: 4px; }
+h2 { font-size: 20px; border-bottom: 1px solid #d0d7de; padding-bottom: 6px; margin-top: 36px; }
+h3 { font-size: 16px; }
+.meta { color: #57606a; font-size: 14px; }
+.cards { display: flex; flex-wrap: wrap; gap: 12px; margin: 16px 0; }
+.card { flex: 1; min-width: 120px; border: 1px solid #d0d7de; border-radius: 6px; padding: 12px; }
+.card .value { font-size: 24px; font-weight: 600; }
+.card .label { color: #57606a; font-size: 12px; }
+table { width: 100%; border-collapse: collapse; font-size: 12px; margin: 8px 0 16px; }
+th, td { text-align: left; padding: 5px 8px; border-bottom: 1px solid #d0d7de; vertical-align: top; word-break: break-word; }
+th { background: #f6f8fa; }
+.severity { display: inline-block; padding: 1px 8px; border-radius: 10px; color: #fff; font-size: 11px; font-weight: 600; text-transform: uppercase; }
+.severity-critical { background: #82071e; }
+.severity-high { background: #cf222e; }
+.severity-medium { background: #bc4c00; }
+.severity-low { background: #9a6700; }
+.severity-info { background: #57606a; }
+.alive { color: #1a7f37; font-weight: 600; }
+footer { color: #57606a; font-size: 12px; margin-top: 36px; }
+@media print { body { padding: 0; } h2 { page-break-after: avoid; } tr { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>Reconnaissance Report: {{.Domain}}</h1>
+<p class="meta">Generated {{date .GeneratedAt}}</p>
+
+<h2>Executive Summary</h2>
+<p>Passive enumeration identified <strong>{{.Summary.Subdomains}} subdomains</strong> of {{.Domain}}.
+{{- if .Summary.Verified}} <strong>{{.Summary.Alive}} of {{.Summary.Verified}}</strong> verified subdomains are alive, serving {{.Summary.WebPorts}} web ports.{{end}}
+{{- if .Summary.CloudProviders}} Hosting spans {{join .Summary.CloudProviders ", "}}.{{end}}
+{{- if .Findings}} The assessment produced <strong>{{len .Findings}} notable findings</strong> ({{severitySummary .Summary.Severities}}).{{else}} No notable findings were identified.{{end}}
+{{- if .Summary.TakeoverRisks}} Subdomains at risk of takeover (<strong>{{.Summary.TakeoverRisks}}</strong>) should be remediated first.{{end}}</p>
+<div class="cards">
+<div class="card"><div class="value">{{.Summary.Subdomains}}</div><div class="label">Subdomains</div></div>
+<div class="card"><div class="value">{{.Summary.Alive}}</div><div class="label">Alive ({{percent .Summary.Alive .Summary.Subdomains}})</div></div>
+<div class="card"><div class="value">{{.Summary.WebPorts}}</div><div class="label">Web ports</div></div>
+<div class="card"><div class="value">{{.Summary.TakeoverRisks}}</div><div class="label">Takeover risks</div></div>
+<div class="card"><div class="value">{{len .Findings}}</div><div class="label">Findings</div></div>
+</div>
+{{if .Summary.Registrar}}<p>Registrar: {{.Summary.Registrar}}{{if .Summary.ExpiryDate}}, registration expires {{.Summary.ExpiryDate}}{{end}}.</p>{{end}}
+
+<h2>Methodology</h2>
+<p>All data was collected with passive sources and low-impact probes; no exploitation was attempted.</p>
+<table>
+<thead><tr><th>Module</th><th>Date</th><th>Scope</th></tr></thead>
+<tbody>
+{{range .Methodology}}<tr><td>{{.Module}}</td><td>{{date .RanAt}}</td><td>{{.Details}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Asset Inventory</h2>
+<table>
+<thead><tr><th>Subdomain</th><th>Status</th><th>HTTP</th><th>Title</th><th>IPs</th><th>Technologies</th><th>Cloud</th></tr></thead>
+<tbody>
+{{range .Assets}}<tr><td>{{.Name}}</td><td{{if eq .Status "alive"}} class="alive"{{end}}>{{dash .Status}}</td><td>{{if .StatusCode}}{{.StatusCode}}{{end}}</td><td>{{.Title}}</td><td>{{join .IPs ", "}}</td><td>{{join .Tech ", "}}</td><td>{{.CloudProvider}}</td></tr>
+{{end}}</tbody>
+</table>
+{{if .Ports}}<h3>Web Ports</h3>
+<table>
+<thead><tr><th>Subdomain</th><th>Port</th><th>HTTP</th><th>Title</th></tr></thead>
+<tbody>
+{{range .Ports}}<tr><td>{{.Subdomain}}</td><td>{{.Port}}</td><td>{{.HTTP.StatusCode}}</td><td>{{.HTTP.Title}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+<h2>Notable Findings</h2>
+{{if not .Findings}}<p>No notable findings were identified.</p>{{end}}
+{{range $severity := severities}}{{with findingsOf $.Findings $severity}}<h3><span class="severity severity-{{$severity}}">{{$severity}}</span></h3>
+<table>
+<thead><tr><th>Category</th><th>Subdomain</th><th>Finding</th><th>Detail</th></tr></thead>
+<tbody>
+{{range .}}<tr><td>{{.Category}}</td><td>{{.Subdomain}}</td><td>{{.Title}}</td><td>{{.Detail}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}{{end}}
+<footer>Report generated by Recontronic CLI</footer>
+</body>
+</html>
+`
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// ExportToAquatone writes a host:port line for every accessible web port of
+// the alive subdomains, the layout aquatone reads from stdin
+// (cat list.txt | aquatone)
+func ExportToAquatone(result *recon.SubdomainResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_aquatone.txt", result.Domain)
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, port := range screenshotPorts(result, options) {
+		if port.Port == 0 {
+			continue
+		}
+		line := port.Subdomain + ":" + strconv.Itoa(port.Port)
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+
+	if err := writeLines(filePath, lines); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// ExportToEyeWitness writes the URL (scheme://host[:port]) of every
+// accessible web port of the alive subdomains, one per line, the layout of
+// EyeWitness -f and gowitness file -f
+func ExportToEyeWitness(result *recon.SubdomainResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_eyewitness.txt", result.Domain)
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, port := range screenshotPorts(result, options) {
+		base := baseURL(port.HTTP.URL)
+		if base == "" || seen[base] {
+			continue
+		}
+		seen[base] = true
+		lines = append(lines, base)
+	}
+
+	if err := writeLines(filePath, lines); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// screenshotPorts returns the accessible web ports of the alive subdomains
+// that match the export filters, in scan order
+func screenshotPorts(result *recon.SubdomainResults, options ExportOptions) []recon.PortResult {
+	options.AliveOnly = true
+	filtered := *result
+	filtered.Subdomains = filterSubdomains(result.Subdomains, options)
+	return recon.QueryPorts(&filtered, recon.PortQueryOptions{AccessibleOnly: true})
+}
@@ -0,0 +1,321 @@
+package export
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// stixSCONamespace is the UUIDv5 namespace STIX 2.1 defines for the
+// deterministic IDs of cyber-observable objects, so the same domain or IP
+// gets the same ID in every bundle
+var stixSCONamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+
+// stixTimeFormat is the STIX timestamp format, always UTC
+const stixTimeFormat = "2006-01-02T15:04:05.000Z"
+
+type stixBundle struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Objects []any  `json:"objects"`
+}
+
+type stixObservable struct {
+	Type        string `json:"type"`
+	SpecVersion string `json:"spec_version"`
+	ID          string `json:"id"`
+	Value       string `json:"value"`
+}
+
+type stixCertificate struct {
+	Type              string          `json:"type"`
+	SpecVersion       string          `json:"spec_version"`
+	ID                string          `json:"id"`
+	IsSelfSigned      bool            `json:"is_self_signed,omitempty"`
+	Subject           string          `json:"subject,omitempty"`
+	Issuer            string          `json:"issuer,omitempty"`
+	ValidityNotBefore string          `json:"validity_not_before,omitempty"`
+	ValidityNotAfter  string          `json:"validity_not_after,omitempty"`
+	Extensions        *stixExtensions `json:"x509_v3_extensions,omitempty"`
+}
+
+type stixExtensions struct {
+	SubjectAlternativeName string `json:"subject_alternative_name"`
+}
+
+type stixRelationship struct {
+	Type             string `json:"type"`
+	SpecVersion      string `json:"spec_version"`
+	ID               string `json:"id"`
+	Created          string `json:"created"`
+	Modified         string `json:"modified"`
+	RelationshipType string `json:"relationship_type"`
+	Description      string `json:"description,omitempty"`
+	SourceRef        string `json:"source_ref"`
+	TargetRef        string `json:"target_ref"`
+}
+
+// infraObservation is one infrastructure fact about a subdomain: the
+// subdomain itself, an IP or CNAME target it resolves to, or the
+// certificate it presents
+type infraObservation struct {
+	Subdomain    string
+	Type         string // domain-name, ipv4-addr, ipv6-addr, or x509-certificate
+	Value        string // Name, IP, or certificate subject
+	Relationship string // resolves-to or presents; empty for the subdomain itself
+	TLS          *recon.TLSResult
+}
+
+// ExportToSTIX exports a STIX 2.1 bundle for threat-intel platforms: a
+// domain-name object per subdomain, ipv4-addr and ipv6-addr objects for the
+// IPs it resolves to, domain-name objects for its CNAME targets, and an
+// x509-certificate object for the certificate it presents, linked by
+// resolves-to and related-to relationships. IPs and CNAMEs come from
+// verification and, when given, the DNS results.
+func ExportToSTIX(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_stix.json", result.Domain)
+	}
+
+	created := time.Now().UTC().Format(stixTimeFormat)
+	bundle := stixBundle{
+		Type:    "bundle",
+		ID:      "bundle--" + uuidV4(),
+		Objects: []any{},
+	}
+
+	objectIDs := make(map[string]string)
+	addObservable := func(kind, value string) string {
+		key := kind + " " + value
+		if id, ok := objectIDs[key]; ok {
+			return id
+		}
+		id := stixObservableID(kind, value)
+		objectIDs[key] = id
+		bundle.Objects = append(bundle.Objects, stixObservable{
+			Type:        kind,
+			SpecVersion: "2.1",
+			ID:          id,
+			Value:       value,
+		})
+		return id
+	}
+	addCertificate := func(tls *recon.TLSResult) string {
+		key := "x509-certificate " + certificateKey(tls)
+		if id, ok := objectIDs[key]; ok {
+			return id
+		}
+		// Without a serial number or hashes STIX calls for a random ID
+		id := "x509-certificate--" + uuidV4()
+		objectIDs[key] = id
+		cert := stixCertificate{
+			Type:              "x509-certificate",
+			SpecVersion:       "2.1",
+			ID:                id,
+			IsSelfSigned:      tls.SelfSigned,
+			Subject:           tls.Subject,
+			Issuer:            tls.Issuer,
+			ValidityNotBefore: stixTime(tls.NotBefore),
+			ValidityNotAfter:  stixTime(tls.NotAfter),
+		}
+		if len(tls.SANs) > 0 {
+			sans := make([]string, len(tls.SANs))
+			for i, san := range tls.SANs {
+				sans[i] = "DNS:" + san
+			}
+			cert.Extensions = &stixExtensions{SubjectAlternativeName: strings.Join(sans, ", ")}
+		}
+		bundle.Objects = append(bundle.Objects, cert)
+		return id
+	}
+
+	seen := make(map[string]bool)
+	for _, observation := range infraObservations(result, dnsResults, options) {
+		domainID := addObservable("domain-name", observation.Subdomain)
+		if observation.Relationship == "" {
+			continue
+		}
+
+		relationship := stixRelationship{
+			Type:             "relationship",
+			SpecVersion:      "2.1",
+			Created:          created,
+			Modified:         created,
+			RelationshipType: "resolves-to",
+			SourceRef:        domainID,
+		}
+		if observation.TLS != nil {
+			relationship.RelationshipType = "related-to"
+			relationship.Description = "Certificate presented over HTTPS"
+			relationship.TargetRef = addCertificate(observation.TLS)
+		} else {
+			relationship.TargetRef = addObservable(observation.Type, observation.Value)
+		}
+
+		key := relationship.SourceRef + " " + relationship.TargetRef
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		relationship.ID = "relationship--" + uuidV4()
+		bundle.Objects = append(bundle.Objects, relationship)
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal STIX bundle: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write STIX bundle: %w", err)
+	}
+	return filePath, nil
+}
+
+// ExportToIOCCSV exports the same observations as ExportToSTIX as a flat
+// CSV of indicators, one row per subdomain, IP, CNAME target, and
+// certificate, for platforms that import IOC lists
+func ExportToIOCCSV(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_iocs.csv", result.Domain)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Type", "Value", "Subdomain", "Relationship"}); err != nil {
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, observation := range infraObservations(result, dnsResults, options) {
+		record := []string{observation.Type, observation.Value, observation.Subdomain, observation.Relationship}
+		if err := writer.Write(record); err != nil {
+			return "", fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	return filePath, nil
+}
+
+// infraObservations lists the infrastructure observations of the filtered
+// subdomains in name order, each subdomain followed by its IPs, CNAME
+// targets, and certificate
+func infraObservations(result *recon.SubdomainResults, dnsResults *recon.DNSResults, options ExportOptions) []infraObservation {
+	recordsByName := make(map[string]recon.DNSInfo)
+	if dnsResults != nil {
+		for _, record := range dnsResults.Records {
+			recordsByName[strings.ToLower(record.Subdomain)] = record
+		}
+	}
+
+	subdomains := filterSubdomains(result.Subdomains, options)
+	sort.Slice(subdomains, func(i, j int) bool {
+		return subdomains[i].Name < subdomains[j].Name
+	})
+
+	var observations []infraObservation
+	for _, sub := range subdomains {
+		name := strings.ToLower(sub.Name)
+		observations = append(observations, infraObservation{Subdomain: name, Type: "domain-name", Value: name})
+
+		record := recordsByName[name]
+		ips := append(append([]string{}, record.A...), record.AAAA...)
+		if sub.Verified != nil && sub.Verified.DNS != nil {
+			ips = append(ips, sub.Verified.DNS.IPs...)
+		}
+		seen := make(map[string]bool)
+		for _, ip := range ips {
+			parsed := net.ParseIP(ip)
+			if parsed == nil || seen[parsed.String()] {
+				continue
+			}
+			seen[parsed.String()] = true
+			kind := "ipv6-addr"
+			if parsed.To4() != nil {
+				kind = "ipv4-addr"
+			}
+			observations = append(observations, infraObservation{Subdomain: name, Type: kind, Value: parsed.String(), Relationship: "resolves-to"})
+		}
+
+		for _, cname := range record.CNAME {
+			target := strings.ToLower(strings.TrimSuffix(cname, "."))
+			if target == "" || seen[target] {
+				continue
+			}
+			seen[target] = true
+			observations = append(observations, infraObservation{Subdomain: name, Type: "domain-name", Value: target, Relationship: "resolves-to"})
+		}
+
+		if sub.Verified != nil && sub.Verified.TLS != nil {
+			observations = append(observations, infraObservation{
+				Subdomain:    name,
+				Type:         "x509-certificate",
+				Value:        sub.Verified.TLS.Subject,
+				Relationship: "presents",
+				TLS:          sub.Verified.TLS,
+			})
+		}
+	}
+
+	return observations
+}
+
+// stixObservableID returns the deterministic ID of a domain-name or IP
+// object: a UUIDv5 of its canonical JSON value
+func stixObservableID(kind, value string) string {
+	contributing, _ := json.Marshal(map[string]string{"value": value})
+	return kind + "--" + uuidV5(stixSCONamespace, contributing)
+}
+
+// certificateKey identifies a certificate within one bundle
+func certificateKey(tls *recon.TLSResult) string {
+	return strings.Join([]string{tls.Subject, tls.Issuer, stixTime(tls.NotBefore), stixTime(tls.NotAfter)}, "|")
+}
+
+// stixTime formats a time as a STIX timestamp, empty for the zero time
+func stixTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(stixTimeFormat)
+}
+
+// uuidV5 returns the name-based (SHA-1) UUID of name in namespace
+func uuidV5(namespace [16]byte, name []byte) string {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write(name)
+	var u [16]byte
+	copy(u[:], h.Sum(nil))
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatUUID(u)
+}
+
+// uuidV4 returns a random UUID
+func uuidV4() string {
+	var u [16]byte
+	rand.Read(u[:])
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatUUID(u)
+}
+
+func formatUUID(u [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// TemplateExtension returns the output extension of a template file: the
+// name without .tmpl, so wiki.md.tmpl writes .md files, or txt
+func TemplateExtension(templatePath string) string {
+	name := strings.TrimSuffix(filepath.Base(templatePath), ".tmpl")
+	if ext := filepath.Ext(name); ext != "" && name != filepath.Base(templatePath) {
+		return ext[1:]
+	}
+	return "txt"
+}
+
+// ExportToTemplate renders a tool's results with a text/template file. The
+// template's dot is the result itself, e.g., *recon.SubdomainResults with
+// the subdomain filters of options applied, and it can call the report
+// template functions (date, join, cell, json, ...). Raw tool output is left
+// out unless IncludeRaw is set.
+func ExportToTemplate(data any, templatePath string, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = "export." + TemplateExtension(templatePath)
+	}
+
+	text, err := os.ReadFile(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs).Parse(string(text))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if result, ok := data.(*recon.SubdomainResults); ok {
+		filtered := *result
+		filtered.Subdomains = filterSubdomains(result.Subdomains, options)
+		filtered.TotalUnique = len(filtered.Subdomains)
+		data = &filtered
+	}
+	if !options.IncludeRaw {
+		recon.StripRawOutputs(data)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, rendered.Bytes(), 0600); err != nil {
+		return "", fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return filePath, nil
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 of the request body as
+// sha256=<hex>, computed with the webhook secret
+const WebhookSignatureHeader = "X-Recon-Signature"
+
+// WebhookOptions configures a webhook export
+type WebhookOptions struct {
+	URL     string
+	Secret  string        // Signs the body when set
+	Timeout time.Duration // Defaults to 30s
+}
+
+// WebhookResult describes the endpoint's answer to a webhook export
+type WebhookResult struct {
+	StatusCode int
+	Bytes      int // Size of the posted body
+	Signed     bool
+}
+
+// ValidateWebhookURL checks that a webhook URL is an absolute http(s) URL
+func ValidateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL: %s (use: https://host/path)", raw)
+	}
+	return nil
+}
+
+// ExportToWebhook POSTs results as JSON to a webhook. Subdomain results get
+// the subdomain filters of options applied, and raw tool output is left out
+// unless IncludeRaw is set, as in the JSON exports.
+func ExportToWebhook(data any, options ExportOptions, webhook WebhookOptions) (*WebhookResult, error) {
+	if err := ValidateWebhookURL(webhook.URL); err != nil {
+		return nil, err
+	}
+
+	if result, ok := data.(*recon.SubdomainResults); ok {
+		filtered := *result
+		filtered.Subdomains = filterSubdomains(result.Subdomains, options)
+		filtered.TotalUnique = len(filtered.Subdomains)
+		data = &filtered
+	}
+	if !options.IncludeRaw {
+		recon.StripRawOutputs(data)
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recontronic-cli/1.0.0")
+	if webhook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookBody(body, webhook.Secret))
+	}
+
+	timeout := webhook.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+
+	return &WebhookResult{
+		StatusCode: resp.StatusCode,
+		Bytes:      len(body),
+		Signed:     webhook.Secret != "",
+	}, nil
+}
+
+// SignWebhookBody returns the signature header value of a webhook body:
+// sha256= followed by the hex HMAC-SHA256 of the body keyed with secret
+func SignWebhookBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/recon"
+)
+
+// ExportWhois exports WHOIS results to CSV, JSON, or Markdown. The raw WHOIS
+// response is left out unless IncludeRaw is set.
+func ExportWhois(results *recon.WhoisResults, options ExportOptions) (string, error) {
+	filePath := options.OutputPath
+	if filePath == "" {
+		filePath = fmt.Sprintf("%s_whois.%s", results.Domain, formatExtension(options.Format))
+	}
+
+	exported := *results
+	if !options.IncludeRaw {
+		recon.StripRawOutputs(&exported)
+	}
+	exported.Info.RawRef = nil
+
+	var err error
+	switch options.Format {
+	case FormatCSV:
+		err = writeWhoisCSV(filePath, &exported.Info, options.IncludeRaw)
+	case FormatJSON:
+		err = writeWhoisJSON(filePath, &exported)
+	case FormatMarkdown:
+		err = writeWhoisMarkdown(filePath, &exported, options.IncludeRaw)
+	default:
+		return "", fmt.Errorf("unsupported WHOIS format: %s", options.Format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+func writeWhoisCSV(filePath string, info *recon.WhoisInfo, includeRaw bool) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Query", "Registrar", "Created", "Updated", "Expires", "Name Servers", "Status", "Registrant Org", "Organization", "Netblock", "Country", "Abuse Email"}
+	row := []string{
+		info.Domain,
+		valueOrDash(info.Registrar),
+		valueOrDash(info.CreatedDate),
+		valueOrDash(info.UpdatedDate),
+		valueOrDash(info.ExpiryDate),
+		valueOrDash(strings.Join(info.NameServers, ";")),
+		valueOrDash(strings.Join(info.Status, ";")),
+		valueOrDash(info.RegistrantOrg),
+		valueOrDash(info.Organization),
+		valueOrDash(info.Netblock),
+		valueOrDash(info.Country),
+		valueOrDash(info.AbuseEmail),
+	}
+	if includeRaw {
+		header = append(header, "Raw Output")
+		row = append(row, info.RawOutput)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	if err := writer.Write(row); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	return nil
+}
+
+func writeWhoisJSON(filePath string, results *recon.WhoisResults) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+
+	return nil
+}
+
+func writeWhoisMarkdown(filePath string, results *recon.WhoisResults, includeRaw bool) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create Markdown file: %w", err)
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "# WHOIS Report: %s\n\n", results.Domain)
+	fmt.Fprintf(file, "**Generated:** %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(file, "**Looked Up:** %s\n\n", results.LookedUpAt.Format("2006-01-02 15:04:05"))
+
+	fmt.Fprintf(file, "## Registration\n\n")
+	fmt.Fprintf(file, "```\n%s```\n", recon.FormatWhoisInfo(&results.Info))
+
+	if includeRaw && results.Info.RawOutput != "" {
+		fmt.Fprintf(file, "\n## Raw Output\n\n")
+		fmt.Fprintf(file, "```\n%s\n```\n", strings.TrimRight(results.Info.RawOutput, "\n"))
+	}
+
+	return nil
+}
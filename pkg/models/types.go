@@ -19,6 +19,7 @@ type APIKey struct {
 	Name       string     `json:"name,omitempty"`
 	KeyPrefix  string     `json:"key_prefix"`
 	PlainKey   string     `json:"plain_key,omitempty"` // Only returned during creation
+	Scopes     []string   `json:"scopes,omitempty"`    // Granted permissions, e.g., read:results; none means full access
 	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	IsActive   bool       `json:"is_active"`
@@ -46,6 +47,30 @@ type LoginResponse struct {
 	Message string `json:"message"`
 }
 
+// DeviceCodeRequest starts an SSO login with the OAuth2 device
+// authorization grant (RFC 8628)
+type DeviceCodeRequest struct {
+	ClientID string `json:"client_id"`
+}
+
+// DeviceCodeResponse holds the code the user enters at the verification
+// URI, and the device code the CLI polls for the login with
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"` // URI with the user code filled in
+	ExpiresIn               int    `json:"expires_in"`                          // Seconds until the codes expire
+	Interval                int    `json:"interval,omitempty"`                  // Seconds between polls
+}
+
+// DeviceTokenRequest polls for the login of a device code
+type DeviceTokenRequest struct {
+	GrantType  string `json:"grant_type"`
+	DeviceCode string `json:"device_code"`
+	ClientID   string `json:"client_id"`
+}
+
 // APIKeyListResponse contains a list of API keys
 type APIKeyListResponse struct {
 	APIKeys []APIKey `json:"api_keys"`
@@ -56,6 +81,7 @@ type APIKeyListResponse struct {
 type CreateAPIKeyRequest struct {
 	Name      string     `json:"name,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"` // Permissions to limit the key to (none = full access)
 }
 
 // ErrorResponse represents an API error response
@@ -88,6 +114,17 @@ type Scan struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
 
+// ScanProgress is one progress update of a running scan, streamed by the
+// gRPC WatchScan call
+type ScanProgress struct {
+	ScanID      int64     `json:"scan_id"`
+	Status      string    `json:"status"`
+	Progress    int       `json:"progress"` // Percent complete
+	CurrentStep string    `json:"current_step"`
+	AssetsFound int       `json:"assets_found"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
 // Anomaly represents a detected security anomaly (future use)
 type Anomaly struct {
 	ID            int64                  `json:"id"`
@@ -100,3 +137,143 @@ type Anomaly struct {
 	IsReviewed    bool                   `json:"is_reviewed"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
 }
+
+// SyncUploadRequest starts a chunked upload of one local result file
+type SyncUploadRequest struct {
+	Domain    string    `json:"domain"`
+	Tool      string    `json:"tool"` // subdomains (including verification data) or dns
+	File      string    `json:"file"`
+	Timestamp time.Time `json:"timestamp"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Chunks    int       `json:"chunks"`
+	Overwrite bool      `json:"overwrite,omitempty"` // Replace a different version the server already has
+}
+
+// SyncUploadResponse is returned when an upload starts
+type SyncUploadResponse struct {
+	UploadID string `json:"upload_id"`
+	Status   string `json:"status"` // "pending", or "exists" when the server already has this file
+	ResultID int64  `json:"result_id,omitempty"`
+}
+
+// SyncChunkRequest carries one base64-encoded chunk of an upload
+type SyncChunkRequest struct {
+	Index int    `json:"index"`
+	Data  string `json:"data"`
+}
+
+// SyncCompleteResponse is returned when all chunks of an upload are in
+type SyncCompleteResponse struct {
+	ResultID int64  `json:"result_id"`
+	Message  string `json:"message"`
+}
+
+// SyncResult describes a result file stored on the server, e.g., from the
+// platform's continuous scanning
+type SyncResult struct {
+	ID        int64     `json:"id"`
+	Domain    string    `json:"domain"`
+	Program   string    `json:"program,omitempty"`
+	Tool      string    `json:"tool"`
+	Timestamp time.Time `json:"timestamp"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+}
+
+// SyncResultListResponse contains the result files of a domain or program
+type SyncResultListResponse struct {
+	Results []SyncResult `json:"results"`
+	Total   int          `json:"total"`
+}
+
+// Event is one entry of the platform's event feed
+type Event struct {
+	ID          string                 `json:"id"`
+	Type        string                 `json:"type"` // anomaly, scan_completed, or asset
+	ProgramID   int64                  `json:"program_id,omitempty"`
+	ProgramName string                 `json:"program_name,omitempty"`
+	Domain      string                 `json:"domain,omitempty"`
+	Message     string                 `json:"message"`
+	Priority    float64                `json:"priority,omitempty"` // Anomaly priority score
+	Timestamp   time.Time              `json:"timestamp"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// EventListResponse represents the response from listing recent events
+type EventListResponse struct {
+	Events []Event `json:"events"`
+	Total  int     `json:"total"`
+}
+
+// HealthResponse is the server's health report
+type HealthResponse struct {
+	Status     string          `json:"status"`      // "ok", or "degraded" when a backend is down
+	APIVersion string          `json:"api_version"` // Version of the REST API
+	Version    string          `json:"version,omitempty"`
+	Features   map[string]bool `json:"features,omitempty"` // Feature flags enabled on the server
+}
+
+// ScheduleRequest sets how often the server scans a program
+type ScheduleRequest struct {
+	Cron     string `json:"cron"`     // Five-field cron expression (minute hour day month weekday)
+	Timezone string `json:"timezone"` // IANA time zone the cron expression is in
+	Enabled  bool   `json:"enabled"`
+}
+
+// Schedule is the continuous recon cadence of a program on the server
+type Schedule struct {
+	ProgramID   int64      `json:"program_id"`
+	ProgramName string     `json:"program_name"`
+	Cron        string     `json:"cron"`
+	Timezone    string     `json:"timezone"`
+	Enabled     bool       `json:"enabled"`
+	NextRunAt   *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ScheduleListResponse contains the scan schedules of the user's programs
+type ScheduleListResponse struct {
+	Schedules []Schedule `json:"schedules"`
+	Total     int        `json:"total"`
+}
+
+// TeamMember is a hunter on the user's team, who can be given access to
+// the team's programs and findings
+type TeamMember struct {
+	UserID    int64      `json:"user_id,omitempty"`
+	Username  string     `json:"username,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	Role      string     `json:"role"`   // owner, admin, or member
+	Status    string     `json:"status"` // active, or invited until the invite is accepted
+	InvitedAt *time.Time `json:"invited_at,omitempty"`
+	JoinedAt  *time.Time `json:"joined_at,omitempty"`
+}
+
+// TeamMemberListResponse contains the members of the user's team
+type TeamMemberListResponse struct {
+	Members []TeamMember `json:"members"`
+	Total   int          `json:"total"`
+}
+
+// TeamInviteRequest invites a user to the team, by username or email
+type TeamInviteRequest struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
+
+// ProgramShareRequest shares a program, with its findings, with a user
+type ProgramShareRequest struct {
+	User       string `json:"user"`       // Username or email
+	Permission string `json:"permission"` // read or write
+}
+
+// ProgramShare is a user's access to a shared program
+type ProgramShare struct {
+	ProgramID   int64     `json:"program_id"`
+	ProgramName string    `json:"program_name"`
+	Username    string    `json:"username"`
+	Permission  string    `json:"permission"`
+	SharedAt    time.Time `json:"shared_at"`
+}
@@ -0,0 +1,138 @@
+// Package notify posts concise recon summaries to Slack and Discord
+// incoming webhooks
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Channels supported by notify, in the order they are posted to
+var Channels = []string{"slack", "discord"}
+
+// maxListed caps the hostnames listed per section of a message
+const maxListed = 10
+
+// Summary is the news of one recon run worth posting
+type Summary struct {
+	Domain        string
+	Event         string   // What ran, e.g., "Subdomain enumeration"
+	NewHosts      []string // Subdomains not seen in the previous scan
+	NewlyAlive    []string // Hosts alive now that were not before
+	TakeoverRisks []string // Subdomains flagged as takeover risks
+	Note          string   // Extra line, e.g., totals
+}
+
+// Empty reports whether a summary has no news to post
+func (s Summary) Empty() bool {
+	return len(s.NewHosts) == 0 && len(s.NewlyAlive) == 0 && len(s.TakeoverRisks) == 0
+}
+
+// Text formats a summary as a short plain-text message
+func (s Summary) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", s.Event, s.Domain)
+	if s.Note != "" {
+		fmt.Fprintf(&b, " (%s)", s.Note)
+	}
+	b.WriteString("\n")
+
+	sections := []struct {
+		title string
+		names []string
+	}{
+		{"New hosts", s.NewHosts},
+		{"Newly alive", s.NewlyAlive},
+		{"Takeover risks", s.TakeoverRisks},
+	}
+	for _, section := range sections {
+		if len(section.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s (%d): ", section.title, len(section.names))
+		names := section.names
+		if len(names) > maxListed {
+			names = names[:maxListed]
+		}
+		b.WriteString(strings.Join(names, ", "))
+		if len(section.names) > maxListed {
+			fmt.Fprintf(&b, ", ... and %d more", len(section.names)-maxListed)
+		}
+		b.WriteString("\n")
+	}
+	if s.Empty() {
+		b.WriteString("No new hosts, newly alive hosts, or takeover risks\n")
+	}
+
+	return b.String()
+}
+
+// ValidateWebhookURL checks that a Slack or Discord webhook URL is an
+// absolute https URL
+func ValidateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL: %s (use: https://hooks.slack.com/services/... or https://discord.com/api/webhooks/...)", raw)
+	}
+	return nil
+}
+
+// Send posts a message to the webhook of each channel. It returns the
+// channels posted to and the first error; a failing channel does not stop
+// the others.
+func Send(webhooks map[string]string, message string) ([]string, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	var sent []string
+	var firstErr error
+	for _, channel := range Channels {
+		webhookURL := webhooks[channel]
+		if webhookURL == "" {
+			continue
+		}
+		if err := post(client, channel, webhookURL, message); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		sent = append(sent, channel)
+	}
+
+	return sent, firstErr
+}
+
+// post sends a message to one webhook: Slack takes {"text"} and Discord
+// {"content"}, which Discord caps at 2000 characters
+func post(client *http.Client, channel, webhookURL, message string) error {
+	payload := map[string]string{"text": message}
+	if channel == "discord" {
+		if len(message) > 2000 {
+			message = message[:1997] + "..."
+		}
+		payload = map[string]string{"content": message}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %w", channel, err)
+	}
+
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s notification failed: %w", channel, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s notification failed: HTTP %d", channel, resp.StatusCode)
+	}
+	return nil
+}
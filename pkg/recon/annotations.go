@@ -0,0 +1,310 @@
+package recon
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// annotationsFileName keeps the triage state of a domain's subdomains as
+// JSON in its results directory, apart from the scans so it survives new
+// ones; it isn't named *.json so it isn't taken for a result file
+const annotationsFileName = ".annotations"
+
+// SubdomainAnnotation is the triage state of a subdomain: tags such as
+// interesting or login-page, and free-form notes
+type SubdomainAnnotation struct {
+	Tags      []string  `json:"tags,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Annotation is the triage state of one subdomain read from a spreadsheet.
+// Tags is nil and Notes is nil when the sheet has no such column, leaving
+// the stored value unchanged; an empty cell clears it.
+type Annotation struct {
+	Subdomain string
+	Tags      []string
+	Notes     *string
+}
+
+// AnnotationSummary reports the outcome of importing annotations
+type AnnotationSummary struct {
+	Rows      int      // Annotated rows read from the sheet
+	Updated   int      // Subdomains whose tags or notes changed
+	Unchanged int      // Subdomains already annotated the same way
+	Unknown   []string // Rows naming subdomains missing from the results
+	FilePath  string   // Annotations file written, empty when nothing changed
+}
+
+// ParseAnnotationsCSV reads subdomain tags and notes from a CSV file with a
+// header row, such as a results CSV export edited in a spreadsheet. It needs
+// a Subdomain column and a Tags or Notes column; tags are split on ; or ,.
+func ParseAnnotationsCSV(path string) ([]Annotation, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	// Spreadsheets often save a byte order mark before the first header
+	column := func(name string) int {
+		return slices.IndexFunc(header, func(h string) bool {
+			return strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name)
+		})
+	}
+	nameCol, tagsCol, notesCol := column("Subdomain"), column("Tags"), column("Notes")
+	if nameCol < 0 {
+		return nil, fmt.Errorf("CSV has no Subdomain column")
+	}
+	if tagsCol < 0 && notesCol < 0 {
+		return nil, fmt.Errorf("CSV has no Tags or Notes column")
+	}
+
+	cell := func(row []string, col int) string {
+		if col < 0 || col >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[col])
+	}
+
+	var annotations []Annotation
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		name := strings.ToLower(cell(row, nameCol))
+		if name == "" {
+			continue
+		}
+		annotation := Annotation{Subdomain: name}
+		if tagsCol >= 0 {
+			annotation.Tags = parseTags(cell(row, tagsCol))
+		}
+		if notesCol >= 0 {
+			notes := cell(row, notesCol)
+			annotation.Notes = &notes
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, nil
+}
+
+// parseTags splits a tags cell on ; or , into unique lowercase tags
+func parseTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// LoadAnnotations reads the annotations of a domain's subdomains, keyed by
+// lowercase subdomain name
+func LoadAnnotations(domain string) (map[string]SubdomainAnnotation, error) {
+	path, err := annotationsPath(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]SubdomainAnnotation)
+	data, err := ReadResultFile(path)
+	if os.IsNotExist(err) {
+		return annotations, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotations: %w", err)
+	}
+	if err := json.Unmarshal(data, &annotations); err != nil {
+		return nil, fmt.Errorf("invalid annotations in %s: %w", path, err)
+	}
+	return annotations, nil
+}
+
+// saveAnnotations writes a domain's annotations, sealed when the vault is
+// enabled; the caller holds the domain's results lock. Emptied annotations are kept so they still clear
+// the tags and notes a scan stored.
+func saveAnnotations(domain string, annotations map[string]SubdomainAnnotation) (string, error) {
+	if err := EnsureDomainResultsDir(domain); err != nil {
+		return "", err
+	}
+	path, err := annotationsPath(domain)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(annotations, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal annotations: %w", err)
+	}
+	// Notes can hold credentials and other findings, so seal them like results
+	if config.VaultEnabled() {
+		if data, err = config.VaultSeal(data); err != nil {
+			return "", fmt.Errorf("failed to encrypt annotations: %w", err)
+		}
+	}
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write annotations: %w", err)
+	}
+	return path, nil
+}
+
+func annotationsPath(domain string) (string, error) {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(domainDir, annotationsFileName), nil
+}
+
+// applyAnnotations gives the subdomains of results the tags and notes kept
+// in the domain's annotations, which win over those stored in the scan
+func applyAnnotations(domain string, results *SubdomainResults) {
+	annotations, err := LoadAnnotations(domain)
+	if err != nil || len(annotations) == 0 {
+		return
+	}
+	for i := range results.Subdomains {
+		sub := &results.Subdomains[i]
+		if annotation, ok := annotations[strings.ToLower(sub.Name)]; ok {
+			sub.Tags = annotation.Tags
+			sub.Notes = annotation.Notes
+		}
+	}
+}
+
+// AnnotateSubdomain changes the tags and notes of a subdomain of the latest
+// results: tags in add are added and those in remove removed, and notes,
+// when not nil, replaces the notes ("" clears them). It returns the
+// subdomain's annotation after the change.
+func AnnotateSubdomain(domain, subdomain string, add, remove []string, notes *string) (*SubdomainAnnotation, error) {
+	unlock, err := LockDomainResults(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	results, err := GetLatestSubdomainResult(domain)
+	if err != nil {
+		return nil, fmt.Errorf("no subdomain results for %s: %w", domain, err)
+	}
+	name := strings.ToLower(strings.TrimSuffix(subdomain, "."))
+	index := slices.IndexFunc(results.Subdomains, func(sub Subdomain) bool { return strings.EqualFold(sub.Name, name) })
+	if index < 0 {
+		return nil, fmt.Errorf("%s is not in the subdomain results of %s", subdomain, domain)
+	}
+
+	annotations, err := LoadAnnotations(domain)
+	if err != nil {
+		return nil, err
+	}
+	sub := results.Subdomains[index]
+	annotation := SubdomainAnnotation{Tags: sub.Tags, Notes: sub.Notes}
+	for _, tag := range parseTags(strings.Join(add, ",")) {
+		if !contains(annotation.Tags, tag) {
+			annotation.Tags = append(annotation.Tags, tag)
+		}
+	}
+	removed := parseTags(strings.Join(remove, ","))
+	annotation.Tags = slices.DeleteFunc(slices.Clone(annotation.Tags), func(tag string) bool { return contains(removed, tag) })
+	if notes != nil {
+		annotation.Notes = strings.TrimSpace(*notes)
+	}
+	annotation.UpdatedAt = time.Now().UTC()
+
+	annotations[name] = annotation
+	if _, err := saveAnnotations(domain, annotations); err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+// ImportAnnotations applies annotations to the subdomains of the latest
+// results of a domain, saving them to its annotations when anything changed
+func ImportAnnotations(domain string, annotations []Annotation) (*AnnotationSummary, error) {
+	unlock, err := LockDomainResults(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var results SubdomainResults
+	if err := LoadLatestResult(domain, "subdomains", &results); err != nil {
+		return nil, fmt.Errorf("no subdomain results for %s: %w", domain, err)
+	}
+	stored, err := LoadAnnotations(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(results.Subdomains))
+	for i, sub := range results.Subdomains {
+		index[strings.ToLower(sub.Name)] = i
+	}
+
+	summary := &AnnotationSummary{Rows: len(annotations)}
+	for _, annotation := range annotations {
+		i, ok := index[annotation.Subdomain]
+		if !ok {
+			summary.Unknown = append(summary.Unknown, annotation.Subdomain)
+			continue
+		}
+
+		sub := &results.Subdomains[i]
+		changed := false
+		if annotation.Tags != nil && !slices.Equal(annotation.Tags, sub.Tags) {
+			sub.Tags = annotation.Tags
+			if len(sub.Tags) == 0 {
+				sub.Tags = nil
+			}
+			changed = true
+		}
+		if annotation.Notes != nil && *annotation.Notes != sub.Notes {
+			sub.Notes = *annotation.Notes
+			changed = true
+		}
+
+		if changed {
+			stored[annotation.Subdomain] = SubdomainAnnotation{Tags: sub.Tags, Notes: sub.Notes, UpdatedAt: time.Now().UTC()}
+			summary.Updated++
+		} else {
+			summary.Unchanged++
+		}
+	}
+
+	if summary.Updated == 0 {
+		return summary, nil
+	}
+
+	filePath, err := saveAnnotations(domain, stored)
+	if err != nil {
+		return nil, err
+	}
+	summary.FilePath = filePath
+
+	return summary, nil
+}
@@ -0,0 +1,103 @@
+package recon
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Finding types produced by DetectAnomalies
+const (
+	FindingLargeResponse = "large_response"
+	FindingSlowResponse  = "slow_response"
+	FindingAuthLargeBody = "auth_large_body"
+)
+
+// Anomaly thresholds. A host must exceed both the multiple of the median and
+// the absolute floor, so small result sets don't flag ordinary hosts.
+const (
+	largeResponseFactor = 10
+	largeResponseMin    = 50 * 1024
+	slowResponseFactor  = 5
+	slowResponseMinMs   = 3000
+	authLargeBodyMin    = 10 * 1024
+)
+
+// Finding flags a host worth a closer look
+type Finding struct {
+	Subdomain string `json:"subdomain"`
+	Type      string `json:"type"`
+	Detail    string `json:"detail"`
+}
+
+// DetectAnomalies flags alive hosts whose responses stand out from the rest:
+// unusually large bodies, very slow responses, and 401/403 responses with
+// bodies too large to be a plain error page
+func DetectAnomalies(subdomains []Subdomain) []Finding {
+	var sizes, times []int64
+	for _, sub := range subdomains {
+		if httpResult := aliveHTTP(sub); httpResult != nil {
+			sizes = append(sizes, httpResult.BodySize)
+			times = append(times, httpResult.ResponseTimeMs)
+		}
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	medianSize := median(sizes)
+	medianTime := median(times)
+
+	var findings []Finding
+	for _, sub := range subdomains {
+		httpResult := aliveHTTP(sub)
+		if httpResult == nil {
+			continue
+		}
+
+		if httpResult.BodySize >= largeResponseMin && httpResult.BodySize >= medianSize*largeResponseFactor {
+			findings = append(findings, Finding{
+				Subdomain: sub.Name,
+				Type:      FindingLargeResponse,
+				Detail:    fmt.Sprintf("%s response (median %s)", FormatFileSize(httpResult.BodySize), FormatFileSize(medianSize)),
+			})
+		}
+
+		if httpResult.ResponseTimeMs >= slowResponseMinMs && httpResult.ResponseTimeMs >= medianTime*slowResponseFactor {
+			findings = append(findings, Finding{
+				Subdomain: sub.Name,
+				Type:      FindingSlowResponse,
+				Detail:    fmt.Sprintf("%dms response time (median %dms)", httpResult.ResponseTimeMs, medianTime),
+			})
+		}
+
+		if (httpResult.StatusCode == 401 || httpResult.StatusCode == 403) && httpResult.BodySize >= authLargeBodyMin {
+			findings = append(findings, Finding{
+				Subdomain: sub.Name,
+				Type:      FindingAuthLargeBody,
+				Detail:    fmt.Sprintf("%d with %s body", httpResult.StatusCode, FormatFileSize(httpResult.BodySize)),
+			})
+		}
+	}
+
+	return findings
+}
+
+// aliveHTTP returns the main HTTP result of an alive subdomain, or nil
+func aliveHTTP(sub Subdomain) *HTTPResult {
+	if sub.Verified == nil || sub.Verified.Status != "alive" || sub.Verified.HTTP == nil || !sub.Verified.HTTP.Accessible {
+		return nil
+	}
+	return sub.Verified.HTTP
+}
+
+func median(values []int64) int64 {
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,319 @@
+package recon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// ArchiveVersion is the version of the archive layout written by this build
+const ArchiveVersion = 1
+
+// archiveMetadataName is the first entry of every archive
+const archiveMetadataName = "recon-archive.json"
+
+// ArchiveMetadata describes the dataset bundled in an archive
+type ArchiveMetadata struct {
+	Version   int       `json:"version"`
+	Domain    string    `json:"domain"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ArchiveSummary reports the files bundled into or restored from an archive
+type ArchiveSummary struct {
+	Domain    string
+	CreatedAt time.Time
+	Files     int   // Result and export files
+	Bytes     int64 // Their total size
+	Sealed    int   // Files sealed by the vault, readable only with its key
+}
+
+// ArchiveDomain bundles a domain's complete dataset into a gzipped tarball
+// at outputPath: every stored result file with its sidecars
+// (results/<domain>/) and the domain's exports (exports/<domain>_*). Paths
+// are relative to the config directory, so RestoreArchive can unpack them
+// on another machine.
+func ArchiveDomain(domain, outputPath string) (*ArchiveSummary, error) {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(domainDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no results found for %s", domain)
+	}
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the results lock so no scan saves into the dataset mid-archive
+	unlock, err := LockDomainResults(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var files []string
+	err = filepath.WalkDir(domainDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && !isArchiveExcluded(entry.Name()) {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results directory: %w", err)
+	}
+
+	exports, err := filepath.Glob(filepath.Join(configDir, "exports", domain+"_*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for exports: %w", err)
+	}
+	for _, export := range exports {
+		// _latest symlinks point at an export that is archived anyway
+		if info, err := os.Lstat(export); err == nil && info.Mode().IsRegular() {
+			files = append(files, export)
+		}
+	}
+
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	summary := &ArchiveSummary{Domain: domain, CreatedAt: time.Now()}
+	if err := writeArchive(file, configDir, files, summary); err != nil {
+		file.Close()
+		os.Remove(outputPath)
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(outputPath)
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return summary, nil
+}
+
+// isArchiveExcluded reports whether a results directory file is local state
+// that doesn't belong in an archive: lock files, interrupted writes, the
+// manifest, which is rebuilt from the restored files, and the sync state of
+// this machine
+func isArchiveExcluded(name string) bool {
+	switch name {
+	case lockFileName, manifestLockName, manifestFileName, syncStateFileName:
+		return true
+	}
+	return strings.Contains(name, ".tmp-")
+}
+
+func writeArchive(w io.Writer, configDir string, files []string, summary *ArchiveSummary) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	metadata, err := json.MarshalIndent(ArchiveMetadata{
+		Version:   ArchiveVersion,
+		Domain:    summary.Domain,
+		CreatedAt: summary.CreatedAt,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive metadata: %w", err)
+	}
+	header := &tar.Header{
+		Name:    archiveMetadataName,
+		Mode:    0600,
+		Size:    int64(len(metadata)),
+		ModTime: summary.CreatedAt,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := tw.Write(metadata); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	for _, filePath := range files {
+		rel, err := filepath.Rel(configDir, filePath)
+		if err != nil {
+			return err
+		}
+		if err := addArchiveFile(tw, filePath, filepath.ToSlash(rel), summary); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+func addArchiveFile(tw *tar.Writer, filePath, name string, summary *ArchiveSummary) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	summary.Files++
+	summary.Bytes += int64(len(data))
+	if config.IsVaultSealed(data) {
+		summary.Sealed++
+	}
+	return nil
+}
+
+// RestoreArchive unpacks an archive written by ArchiveDomain into the
+// config directory. Existing files are left untouched and reported as a
+// conflict unless overwrite is set; nothing is written when there are
+// conflicts.
+func RestoreArchive(archivePath string, overwrite bool) (*ArchiveSummary, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// First pass: validate every entry and find conflicts
+	metadata, err := scanArchive(archivePath, func(name string, header *tar.Header, r io.Reader) error {
+		if overwrite {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(configDir, filepath.FromSlash(name))); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := LockDomainResults(metadata.Domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	summary := &ArchiveSummary{Domain: metadata.Domain, CreatedAt: metadata.CreatedAt}
+	_, err = scanArchive(archivePath, func(name string, header *tar.Header, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from archive: %w", name, err)
+		}
+
+		target := filepath.Join(configDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := writeFileAtomic(target, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		_ = os.Chtimes(target, header.ModTime, header.ModTime)
+
+		summary.Files++
+		summary.Bytes += int64(len(data))
+		if config.IsVaultSealed(data) {
+			summary.Sealed++
+		}
+		return nil
+	})
+	return summary, err
+}
+
+// scanArchive reads an archive's metadata and calls fn for every other
+// entry, after checking the entry belongs to the archived domain's dataset
+func scanArchive(archivePath string, fn func(name string, header *tar.Header, r io.Reader) error) (*ArchiveMetadata, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("not a results archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != archiveMetadataName {
+		return nil, fmt.Errorf("not a results archive: missing %s", archiveMetadataName)
+	}
+	var metadata ArchiveMetadata
+	if err := json.NewDecoder(tr).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse archive metadata: %w", err)
+	}
+	if metadata.Version > ArchiveVersion {
+		return nil, fmt.Errorf("archive version %d is newer than this build supports (%d); upgrade recon-cli", metadata.Version, ArchiveVersion)
+	}
+	if metadata.Domain == "" || metadata.Domain != path.Base(metadata.Domain) || strings.HasPrefix(metadata.Domain, ".") {
+		return nil, fmt.Errorf("archive names an invalid domain: %q", metadata.Domain)
+	}
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unexpected archive entry: %s", header.Name)
+		}
+
+		name := path.Clean(header.Name)
+		if !archiveEntryAllowed(name, metadata.Domain) {
+			return nil, fmt.Errorf("unexpected archive entry: %s", header.Name)
+		}
+		if err := fn(name, header, tr); err != nil {
+			return nil, err
+		}
+	}
+
+	return &metadata, nil
+}
+
+// archiveEntryAllowed reports whether an entry lies in the domain's results
+// directory or is one of its exports
+func archiveEntryAllowed(name, domain string) bool {
+	if strings.HasPrefix(name, "../") || path.IsAbs(name) {
+		return false
+	}
+	if rest, ok := strings.CutPrefix(name, "results/"+domain+"/"); ok {
+		return rest != "" && !isArchiveExcluded(path.Base(rest))
+	}
+	dir, file := path.Split(name)
+	return dir == "exports/" && strings.HasPrefix(file, domain+"_")
+}
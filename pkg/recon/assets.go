@@ -0,0 +1,341 @@
+package recon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// Asset kinds
+const (
+	AssetWordlist     = "wordlist"
+	AssetFingerprints = "fingerprints"
+)
+
+// secListsVersion is the SecLists release the catalog's wordlists are
+// pinned to
+const secListsVersion = "2024.3"
+
+// Asset is a curated data file recon modules can use, e.g., a wordlist
+type Asset struct {
+	Name        string
+	Kind        string // wordlist or fingerprints
+	Purpose     string // The wordlists.<purpose> setting it suits, e.g., dirs
+	Version     string // Upstream release or branch the URL points at
+	URL         string
+	Description string
+}
+
+// InstalledAsset records a downloaded asset in ~/.recon-cli/assets/assets.json
+type InstalledAsset struct {
+	Name         string    `json:"name"`
+	Version      string    `json:"version"`
+	File         string    `json:"file"` // Relative to the assets directory
+	SHA256       string    `json:"sha256"`
+	Size         int64     `json:"size"`
+	Entries      int       `json:"entries"` // Words or fingerprints
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+// AssetStatus describes a catalog asset and its downloaded copy, if any
+type AssetStatus struct {
+	Asset
+	Installed *InstalledAsset // nil when not downloaded
+	Modified  bool            // The file no longer matches its checksum
+	Outdated  bool            // Downloaded from another version than the catalog's
+}
+
+// assetCatalog lists the assets 'recon assets download' knows about
+var assetCatalog = []Asset{
+	{
+		Name: "seclists-top5k", Kind: AssetWordlist, Purpose: "subdomains", Version: secListsVersion,
+		URL:         secListsURL("Discovery/DNS/subdomains-top1million-5000.txt"),
+		Description: "Top 5,000 subdomain labels",
+	},
+	{
+		Name: "seclists-top20k", Kind: AssetWordlist, Purpose: "subdomains", Version: secListsVersion,
+		URL:         secListsURL("Discovery/DNS/subdomains-top1million-20000.txt"),
+		Description: "Top 20,000 subdomain labels",
+	},
+	{
+		Name: "seclists-top110k", Kind: AssetWordlist, Purpose: "subdomains", Version: secListsVersion,
+		URL:         secListsURL("Discovery/DNS/subdomains-top1million-110000.txt"),
+		Description: "Top 110,000 subdomain labels",
+	},
+	{
+		Name: "seclists-common", Kind: AssetWordlist, Purpose: "dirs", Version: secListsVersion,
+		URL:         secListsURL("Discovery/Web-Content/common.txt"),
+		Description: "Common files and directories",
+	},
+	{
+		Name: "seclists-raft-medium-dirs", Kind: AssetWordlist, Purpose: "dirs", Version: secListsVersion,
+		URL:         secListsURL("Discovery/Web-Content/raft-medium-directories.txt"),
+		Description: "Directories from the RAFT project, medium size",
+	},
+	{
+		Name: "altdns-words", Kind: AssetWordlist, Purpose: "permutations", Version: "master",
+		URL:         "https://raw.githubusercontent.com/infosec-au/altdns/master/words.txt",
+		Description: "Words altdns inserts into subdomain permutations",
+	},
+	{
+		Name: "can-i-take-over-xyz", Kind: AssetFingerprints, Purpose: "takeover", Version: "master",
+		URL:         takeoverFingerprintsURL,
+		Description: "Subdomain takeover fingerprints, also used by 'recon dns'",
+	},
+}
+
+func secListsURL(path string) string {
+	return "https://raw.githubusercontent.com/danielmiessler/SecLists/" + secListsVersion + "/" + path
+}
+
+// Assets returns the catalog of assets available for download
+func Assets() []Asset {
+	return append([]Asset(nil), assetCatalog...)
+}
+
+// FindAsset returns the catalog asset with a name
+func FindAsset(name string) (Asset, bool) {
+	for _, asset := range assetCatalog {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// GetAssetsDir returns the directory downloaded assets are kept in
+func GetAssetsDir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "assets"), nil
+}
+
+// assetFile returns the file name of an asset in the assets directory;
+// wordlists are <name>.txt, which 'config validate' relies on
+func assetFile(asset Asset) string {
+	if asset.Kind == AssetFingerprints {
+		return asset.Name + ".json"
+	}
+	return asset.Name + ".txt"
+}
+
+// LoadInstalledAssets reads the downloaded assets by name
+func LoadInstalledAssets() (map[string]InstalledAsset, error) {
+	dir, err := GetAssetsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]InstalledAsset)
+	data, err := os.ReadFile(filepath.Join(dir, "assets.json"))
+	if os.IsNotExist(err) {
+		return installed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assets manifest: %w", err)
+	}
+
+	var entries []InstalledAsset
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid assets manifest: %w", err)
+	}
+	for _, entry := range entries {
+		installed[entry.Name] = entry
+	}
+	return installed, nil
+}
+
+// saveInstalledAssets writes the manifest of downloaded assets, sorted by name
+func saveInstalledAssets(dir string, installed map[string]InstalledAsset) error {
+	entries := make([]InstalledAsset, 0, len(installed))
+	for _, entry := range installed {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal assets manifest: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "assets.json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write assets manifest: %w", err)
+	}
+	return nil
+}
+
+// AssetStatuses returns every catalog asset with the state of its
+// downloaded copy, verifying the checksums of the files
+func AssetStatuses() ([]AssetStatus, error) {
+	dir, err := GetAssetsDir()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := LoadInstalledAssets()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]AssetStatus, 0, len(assetCatalog))
+	for _, asset := range assetCatalog {
+		status := AssetStatus{Asset: asset}
+		if entry, ok := installed[asset.Name]; ok {
+			status.Installed = &entry
+			status.Outdated = entry.Version != asset.Version
+			sum, _, err := fileChecksum(filepath.Join(dir, entry.File))
+			status.Modified = err != nil || sum != entry.SHA256
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// DownloadAsset downloads a catalog asset into the assets directory and
+// records its version and checksum. Downloading the takeover fingerprints
+// also replaces the fingerprint file 'recon dns' uses.
+func DownloadAsset(name string, proxy *url.URL) (*InstalledAsset, error) {
+	asset, ok := FindAsset(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown asset: %s (see 'recon-cli recon assets list')", name)
+	}
+
+	data, err := getBody(newSourceClient(proxy), asset.URL, asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries int
+	var fingerprints []TakeoverFingerprint
+	switch asset.Kind {
+	case AssetFingerprints:
+		if fingerprints, err = parseTakeoverFingerprints(data); err != nil {
+			return nil, fmt.Errorf("invalid %s fingerprints: %w", asset.Name, err)
+		}
+		entries = len(fingerprints)
+	default:
+		entries = countWords(data)
+	}
+	if entries == 0 {
+		return nil, fmt.Errorf("%s is empty", asset.Name)
+	}
+
+	dir, err := GetAssetsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create assets directory: %w", err)
+	}
+	installed, err := LoadInstalledAssets()
+	if err != nil {
+		return nil, err
+	}
+
+	file := assetFile(asset)
+	if err := writeFileAtomic(filepath.Join(dir, file), data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", asset.Name, err)
+	}
+	sum := sha256.Sum256(data)
+	entry := InstalledAsset{
+		Name:         asset.Name,
+		Version:      asset.Version,
+		File:         file,
+		SHA256:       hex.EncodeToString(sum[:]),
+		Size:         int64(len(data)),
+		Entries:      entries,
+		DownloadedAt: time.Now().UTC(),
+	}
+	installed[asset.Name] = entry
+	if err := saveInstalledAssets(dir, installed); err != nil {
+		return nil, err
+	}
+
+	if asset.Kind == AssetFingerprints {
+		path, err := GetFingerprintsPath()
+		if err != nil {
+			return nil, err
+		}
+		if err := saveTakeoverFingerprints(path, fingerprints); err != nil {
+			return nil, err
+		}
+	}
+	return &entry, nil
+}
+
+// countWords counts the non-empty, non-comment lines of a wordlist
+func countWords(data []byte) int {
+	count := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			count++
+		}
+	}
+	return count
+}
+
+// fileChecksum returns the hex SHA-256 and size of a file
+func fileChecksum(path string) (string, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), int64(len(data)), nil
+}
+
+// ResolveWordlist returns the path of a wordlist setting, which is either a
+// file or the name of a downloaded asset (e.g., seclists-top110k)
+func ResolveWordlist(configured string) (string, error) {
+	if _, err := os.Stat(configured); err == nil || strings.ContainsAny(configured, `/\`) {
+		return configured, nil
+	}
+	asset, ok := FindAsset(configured)
+	if !ok || asset.Kind != AssetWordlist {
+		return configured, nil
+	}
+
+	installed, err := LoadInstalledAssets()
+	if err != nil {
+		return "", err
+	}
+	entry, ok := installed[asset.Name]
+	if !ok {
+		return "", fmt.Errorf("wordlist %s is not downloaded (run 'recon-cli recon assets download %s')", asset.Name, asset.Name)
+	}
+	dir, err := GetAssetsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, entry.File), nil
+}
+
+// installedWordlist returns the path of the first downloaded wordlist for
+// a purpose, in catalog order, or ""
+func installedWordlist(purpose string) string {
+	dir, err := GetAssetsDir()
+	if err != nil {
+		return ""
+	}
+	installed, err := LoadInstalledAssets()
+	if err != nil {
+		return ""
+	}
+	for _, asset := range assetCatalog {
+		if entry, ok := installed[asset.Name]; ok && asset.Kind == AssetWordlist && asset.Purpose == purpose {
+			return filepath.Join(dir, entry.File)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package recon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VerificationChange describes a field that changed between a subdomain's
+// previous and current verification
+type VerificationChange struct {
+	Subdomain string `json:"subdomain"`
+	Field     string `json:"field"` // "status", "status_code", "title", or "tech"
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+// DiffVerification compares a subdomain's current verification against the
+// most recent entry in its history. Subdomains verified for the first time
+// have no changes.
+func DiffVerification(sub Subdomain) []VerificationChange {
+	if sub.Verified == nil || len(sub.History) == 0 {
+		return nil
+	}
+
+	prev := sub.History[len(sub.History)-1]
+	curr := newVerificationRecord(sub.Verified)
+
+	var changes []VerificationChange
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, VerificationChange{
+				Subdomain: sub.Name,
+				Field:     field,
+				Before:    before,
+				After:     after,
+			})
+		}
+	}
+
+	add("status", prev.Status, curr.Status)
+	add("status_code", formatStatusCode(prev.StatusCode), formatStatusCode(curr.StatusCode))
+	add("title", prev.Title, curr.Title)
+	add("tech", strings.Join(prev.Tech, ", "), strings.Join(curr.Tech, ", "))
+
+	return changes
+}
+
+// DiffVerifications returns the changes for every subdomain in the slice
+func DiffVerifications(subdomains []Subdomain) []VerificationChange {
+	var changes []VerificationChange
+	for _, sub := range subdomains {
+		changes = append(changes, DiffVerification(sub)...)
+	}
+	return changes
+}
+
+// newVerificationRecord condenses a verification result into a history entry
+func newVerificationRecord(result *VerificationResult) VerificationRecord {
+	record := VerificationRecord{
+		Timestamp: result.Timestamp,
+		Status:    result.Status,
+	}
+	if result.HTTP != nil {
+		record.StatusCode = result.HTTP.StatusCode
+		record.Title = result.HTTP.Title
+		record.Tech = TechStack(result.HTTP)
+	}
+	return record
+}
+
+// TechStack lists the technologies identified from an HTTP response: the
+// Server and X-Powered-By headers and the favicon product
+func TechStack(httpResult *HTTPResult) []string {
+	var tech []string
+	if httpResult.Headers != nil {
+		if httpResult.Headers.Server != "" {
+			tech = append(tech, httpResult.Headers.Server)
+		}
+		if httpResult.Headers.PoweredBy != "" {
+			tech = append(tech, httpResult.Headers.PoweredBy)
+		}
+	}
+	if httpResult.FaviconProduct != "" {
+		tech = append(tech, httpResult.FaviconProduct)
+	}
+	return tech
+}
+
+func formatStatusCode(code int) string {
+	if code == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", code)
+}
+
+// DNSChange describes an infrastructure change for a subdomain between two
+// DNS enumerations
+type DNSChange struct {
+	Subdomain string `json:"subdomain"`
+	Field     string `json:"field"` // "ip", "cname", "ns", or "takeover"
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+// DiffDNSResults compares current DNS results against a previous scan:
+// added and removed IPs, CNAME re-points, NS changes, and takeover risks
+// that were not present before. Subdomains missing from either scan are
+// skipped since they were not queried both times.
+func DiffDNSResults(previous, current *DNSResults) []DNSChange {
+	before := make(map[string]DNSInfo, len(previous.Records))
+	for _, info := range previous.Records {
+		before[info.Subdomain] = info
+	}
+
+	var changes []DNSChange
+	for _, curr := range current.Records {
+		prev, ok := before[curr.Subdomain]
+		if !ok || prev.Error != "" || curr.Error != "" {
+			continue
+		}
+
+		add := func(field, before, after string) {
+			changes = append(changes, DNSChange{
+				Subdomain: curr.Subdomain,
+				Field:     field,
+				Before:    before,
+				After:     after,
+			})
+		}
+
+		prevIPs := append(append([]string{}, prev.A...), prev.AAAA...)
+		currIPs := append(append([]string{}, curr.A...), curr.AAAA...)
+		for _, ip := range currIPs {
+			if !contains(prevIPs, ip) {
+				add("ip", "", ip)
+			}
+		}
+		for _, ip := range prevIPs {
+			if !contains(currIPs, ip) {
+				add("ip", ip, "")
+			}
+		}
+
+		if prevCNAME, currCNAME := strings.Join(prev.CNAME, ", "), strings.Join(curr.CNAME, ", "); prevCNAME != currCNAME {
+			add("cname", prevCNAME, currCNAME)
+		}
+
+		if prevNS, currNS := strings.Join(SortDomains(prev.NS), ", "), strings.Join(SortDomains(curr.NS), ", "); prevNS != currNS {
+			add("ns", prevNS, currNS)
+		}
+
+		if curr.TakeoverRisk && !prev.TakeoverRisk {
+			add("takeover", "", curr.TakeoverReason)
+		}
+	}
+
+	return changes
+}
+
+// ScanDiff summarizes how a domain's subdomains changed between two scans
+type ScanDiff struct {
+	Domain     string    `json:"domain"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	New        []string  `json:"new"`
+	Removed    []string  `json:"removed"`
+	NewlyAlive []string  `json:"newly_alive"` // Not alive (or unverified) before, alive now
+	NewlyDead  []string  `json:"newly_dead"`  // Alive before, verified and not alive now
+}
+
+// DiffSubdomainResults compares two subdomain scans of the same domain
+func DiffSubdomainResults(from, to *SubdomainResults) *ScanDiff {
+	diff := &ScanDiff{
+		Domain:     to.Domain,
+		From:       from.Timestamp,
+		To:         to.Timestamp,
+		New:        []string{},
+		Removed:    []string{},
+		NewlyAlive: []string{},
+		NewlyDead:  []string{},
+	}
+
+	before := make(map[string]Subdomain, len(from.Subdomains))
+	for _, sub := range from.Subdomains {
+		before[strings.ToLower(sub.Name)] = sub
+	}
+	after := make(map[string]bool, len(to.Subdomains))
+
+	for _, sub := range to.Subdomains {
+		name := strings.ToLower(sub.Name)
+		after[name] = true
+
+		prev, ok := before[name]
+		if !ok {
+			diff.New = append(diff.New, sub.Name)
+			if isAlive(sub) {
+				diff.NewlyAlive = append(diff.NewlyAlive, sub.Name)
+			}
+			continue
+		}
+
+		switch {
+		case isAlive(sub) && !isAlive(prev):
+			diff.NewlyAlive = append(diff.NewlyAlive, sub.Name)
+		case isAlive(prev) && sub.Verified != nil && !isAlive(sub):
+			diff.NewlyDead = append(diff.NewlyDead, sub.Name)
+		}
+	}
+
+	for _, sub := range from.Subdomains {
+		if !after[strings.ToLower(sub.Name)] {
+			diff.Removed = append(diff.Removed, sub.Name)
+		}
+	}
+
+	diff.New = SortDomains(diff.New)
+	diff.Removed = SortDomains(diff.Removed)
+	diff.NewlyAlive = SortDomains(diff.NewlyAlive)
+	diff.NewlyDead = SortDomains(diff.NewlyDead)
+
+	return diff
+}
+
+// isAlive reports whether a subdomain's latest verification found it alive
+func isAlive(sub Subdomain) bool {
+	return sub.Verified != nil && sub.Verified.Status == "alive"
+}
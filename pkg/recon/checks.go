@@ -0,0 +1,185 @@
+package recon
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Misconfiguration checks available through VerifyOptions.Checks
+const (
+	CheckCORS         = "cors"
+	CheckOpenRedirect = "open-redirect"
+	CheckTrace        = "trace"
+)
+
+// Finding types produced by misconfiguration checks
+const (
+	FindingCORSReflected = "cors_reflected_origin"
+	FindingCORSNull      = "cors_null_origin"
+	FindingOpenRedirect  = "open_redirect"
+	FindingTraceEnabled  = "trace_enabled"
+)
+
+var availableChecks = []string{CheckCORS, CheckOpenRedirect, CheckTrace}
+
+// Benign canary values used by the checks; they never resolve to a real site
+const (
+	canaryOrigin = "https://recontronic-origin.example"
+	canaryHost   = "recontronic-redirect.example"
+	canaryHeader = "X-Recontronic-Trace"
+)
+
+// redirectParams are common parameter names echoed into redirects
+var redirectParams = []string{"redirect", "redirect_uri", "url", "next", "return", "returnUrl", "dest", "continue"}
+
+// ParseChecks validates a list of check names
+func ParseChecks(names []string) ([]string, error) {
+	var checks []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !contains(availableChecks, name) {
+			return nil, fmt.Errorf("unknown check: %q (available: %s)", name, strings.Join(availableChecks, ", "))
+		}
+		if !contains(checks, name) {
+			checks = append(checks, name)
+		}
+	}
+	return checks, nil
+}
+
+// runChecks sends the selected benign probe requests to an alive host
+func runChecks(client *http.Client, subdomain, baseURL string, options VerifyOptions) []Finding {
+	base := strings.TrimSuffix(baseURL, "/")
+
+	var findings []Finding
+	for _, check := range options.Checks {
+		var finding *Finding
+		switch check {
+		case CheckCORS:
+			finding = checkCORS(client, base, options)
+		case CheckOpenRedirect:
+			finding = checkOpenRedirect(client, base, options)
+		case CheckTrace:
+			finding = checkTrace(client, base, options)
+		}
+		if finding != nil {
+			finding.Subdomain = subdomain
+			findings = append(findings, *finding)
+		}
+	}
+
+	return findings
+}
+
+// checkCORS reports whether the host reflects an arbitrary or null Origin in
+// Access-Control-Allow-Origin
+func checkCORS(client *http.Client, base string, options VerifyOptions) *Finding {
+	for _, origin := range []string{canaryOrigin, "null"} {
+		resp, err := sendCheck(client, "GET", base+"/", options, func(req *http.Request) {
+			req.Header.Set("Origin", origin)
+		})
+		if err != nil {
+			return nil
+		}
+
+		allowed := resp.header.Get("Access-Control-Allow-Origin")
+		credentials := strings.EqualFold(resp.header.Get("Access-Control-Allow-Credentials"), "true")
+		if allowed != origin {
+			continue
+		}
+
+		detail := fmt.Sprintf("Access-Control-Allow-Origin reflects %s", origin)
+		if credentials {
+			detail += " with credentials allowed"
+		}
+		findingType := FindingCORSReflected
+		if origin == "null" {
+			findingType = FindingCORSNull
+		}
+		return &Finding{Type: findingType, Detail: detail}
+	}
+
+	return nil
+}
+
+// checkOpenRedirect reports whether a redirect parameter is echoed into the
+// Location header
+func checkOpenRedirect(client *http.Client, base string, options VerifyOptions) *Finding {
+	query := url.Values{}
+	for _, param := range redirectParams {
+		query.Set(param, "https://"+canaryHost+"/")
+	}
+
+	// Inspect the first response rather than following the redirect
+	noFollow := *client
+	noFollow.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := sendCheck(&noFollow, "GET", base+"/?"+query.Encode(), options, nil)
+	if err != nil || resp.statusCode < 300 || resp.statusCode >= 400 {
+		return nil
+	}
+
+	location, err := url.Parse(resp.header.Get("Location"))
+	if err != nil || !strings.EqualFold(location.Hostname(), canaryHost) {
+		return nil
+	}
+
+	return &Finding{
+		Type:   FindingOpenRedirect,
+		Detail: fmt.Sprintf("%d redirect to attacker-supplied URL via query parameter", resp.statusCode),
+	}
+}
+
+// checkTrace reports whether the TRACE method echoes the request back
+func checkTrace(client *http.Client, base string, options VerifyOptions) *Finding {
+	token := fmt.Sprintf("%x", randomBytes(8))
+	resp, err := sendCheck(client, "TRACE", base+"/", options, func(req *http.Request) {
+		req.Header.Set(canaryHeader, token)
+	})
+	if err != nil || resp.statusCode != http.StatusOK || !strings.Contains(resp.body, token) {
+		return nil
+	}
+
+	return &Finding{Type: FindingTraceEnabled, Detail: "TRACE method enabled and echoes request headers"}
+}
+
+// checkResponse is the part of a check response the checks inspect
+type checkResponse struct {
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+// sendCheck sends a single check request, letting customize adjust it
+func sendCheck(client *http.Client, method, target string, options VerifyOptions, customize func(*http.Request)) (*checkResponse, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	setRequestHeaders(req, options)
+	if customize != nil {
+		customize(req)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024)) // Read max 64KB
+
+	return &checkResponse{
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       string(body),
+	}, nil
+}
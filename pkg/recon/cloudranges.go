@@ -0,0 +1,293 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// Published IP range feeds. Azure's Service Tags file is renamed weekly, so
+// its current URL is taken from the download page.
+const (
+	awsRangesURL          = "https://ip-ranges.amazonaws.com/ip-ranges.json"
+	gcpRangesURL          = "https://www.gstatic.com/ipranges/cloud.json"
+	azureRangesPageURL    = "https://www.microsoft.com/en-us/download/details.aspx?id=56519"
+	cloudflareRangesV4URL = "https://www.cloudflare.com/ips-v4"
+	cloudflareRangesV6URL = "https://www.cloudflare.com/ips-v6"
+)
+
+// azureServiceTagsPattern finds the Service Tags JSON link on the download page
+var azureServiceTagsPattern = regexp.MustCompile(`https://download\.microsoft\.com/download/[^"']+/ServiceTags_Public_\d+\.json`)
+
+// CloudRanges holds the published IP ranges of each cloud provider
+type CloudRanges struct {
+	UpdatedAt time.Time           `json:"updated_at,omitempty"` // Zero for the built-in defaults
+	Providers map[string][]string `json:"providers"`            // CIDRs keyed by provider
+
+	networks map[string][]*net.IPNet
+}
+
+// defaultCloudRanges are used until 'recon fingerprints update' downloads
+// the full published lists. Cloudflare's list is complete; the others are
+// the largest blocks each provider publishes.
+var defaultCloudRanges = map[string][]string{
+	"AWS": {
+		"3.0.0.0/8", "18.128.0.0/9", "52.0.0.0/11", "52.32.0.0/11", "52.64.0.0/12",
+		"54.0.0.0/8", "13.32.0.0/15", "13.224.0.0/14", "99.84.0.0/16", "2600:1f00::/24",
+	},
+	"GCP": {
+		"34.64.0.0/10", "35.184.0.0/13", "35.192.0.0/12", "35.208.0.0/12",
+		"104.196.0.0/14", "130.211.0.0/16", "2600:1900::/28",
+	},
+	"Azure": {
+		"13.64.0.0/11", "20.36.0.0/14", "20.40.0.0/13", "40.64.0.0/10",
+		"52.224.0.0/11", "104.40.0.0/13", "137.116.0.0/15",
+	},
+	"Cloudflare": {
+		"173.245.48.0/20", "103.21.244.0/22", "103.22.200.0/22", "103.31.4.0/22",
+		"141.101.64.0/18", "108.162.192.0/18", "190.93.240.0/20", "188.114.96.0/20",
+		"197.234.240.0/22", "198.41.128.0/17", "162.158.0.0/15", "104.16.0.0/13",
+		"104.24.0.0/14", "172.64.0.0/13", "131.0.72.0/22",
+		"2400:cb00::/32", "2606:4700::/32", "2803:f800::/32", "2405:b500::/32",
+		"2405:8100::/32", "2a06:98c0::/29", "2c0f:f248::/32",
+	},
+}
+
+// GetCloudRangesPath returns the path to the downloaded cloud range file
+func GetCloudRangesPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "fingerprints", "cloud-ranges.json"), nil
+}
+
+// LoadCloudRanges reads ~/.recon-cli/fingerprints/cloud-ranges.json,
+// falling back to the built-in ranges when it has not been downloaded
+func LoadCloudRanges() (*CloudRanges, error) {
+	path, err := GetCloudRangesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newCloudRanges(defaultCloudRanges), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cloud ranges: %w", err)
+	}
+
+	var ranges CloudRanges
+	if err := json.Unmarshal(data, &ranges); err != nil {
+		return nil, fmt.Errorf("invalid cloud ranges in %s: %w", path, err)
+	}
+	ranges.parse()
+	return &ranges, nil
+}
+
+// newCloudRanges builds ranges from CIDRs keyed by provider
+func newCloudRanges(providers map[string][]string) *CloudRanges {
+	ranges := &CloudRanges{Providers: providers}
+	ranges.parse()
+	return ranges
+}
+
+// parse converts the CIDR strings, skipping invalid ones
+func (r *CloudRanges) parse() {
+	r.networks = make(map[string][]*net.IPNet, len(r.Providers))
+	for provider, cidrs := range r.Providers {
+		for _, cidr := range cidrs {
+			if _, network, err := net.ParseCIDR(cidr); err == nil {
+				r.networks[provider] = append(r.networks[provider], network)
+			}
+		}
+	}
+}
+
+// Provider returns the cloud provider publishing a range containing ip, or
+// "" when none does
+func (r *CloudRanges) Provider(ip string) string {
+	if r == nil {
+		return ""
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+
+	// Check providers in a fixed order so overlapping ranges are stable
+	providers := make([]string, 0, len(r.networks))
+	for provider := range r.networks {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	for _, provider := range providers {
+		for _, network := range r.networks[provider] {
+			if network.Contains(parsed) {
+				return provider
+			}
+		}
+	}
+	return ""
+}
+
+// UpdateCloudRanges downloads the AWS, GCP, Azure, and Cloudflare published
+// IP ranges and saves them. Providers whose feed fails are kept from the
+// current file (or the built-in defaults) and reported in the error.
+func UpdateCloudRanges(proxy *url.URL) (*CloudRanges, error) {
+	current, err := LoadCloudRanges()
+	if err != nil {
+		current = newCloudRanges(defaultCloudRanges)
+	}
+
+	client := newSourceClient(proxy)
+	fetchers := map[string]func() ([]string, error){
+		"AWS": func() ([]string, error) {
+			body, err := getBody(client, awsRangesURL, "aws")
+			if err != nil {
+				return nil, err
+			}
+			var feed struct {
+				Prefixes []struct {
+					IPPrefix string `json:"ip_prefix"`
+				} `json:"prefixes"`
+				IPv6Prefixes []struct {
+					IPv6Prefix string `json:"ipv6_prefix"`
+				} `json:"ipv6_prefixes"`
+			}
+			if err := json.Unmarshal(body, &feed); err != nil {
+				return nil, fmt.Errorf("failed to parse aws ranges: %w", err)
+			}
+			var cidrs []string
+			for _, prefix := range feed.Prefixes {
+				cidrs = append(cidrs, prefix.IPPrefix)
+			}
+			for _, prefix := range feed.IPv6Prefixes {
+				cidrs = append(cidrs, prefix.IPv6Prefix)
+			}
+			return cidrs, nil
+		},
+		"GCP": func() ([]string, error) {
+			body, err := getBody(client, gcpRangesURL, "gcp")
+			if err != nil {
+				return nil, err
+			}
+			var feed struct {
+				Prefixes []struct {
+					IPv4Prefix string `json:"ipv4Prefix"`
+					IPv6Prefix string `json:"ipv6Prefix"`
+				} `json:"prefixes"`
+			}
+			if err := json.Unmarshal(body, &feed); err != nil {
+				return nil, fmt.Errorf("failed to parse gcp ranges: %w", err)
+			}
+			var cidrs []string
+			for _, prefix := range feed.Prefixes {
+				if prefix.IPv4Prefix != "" {
+					cidrs = append(cidrs, prefix.IPv4Prefix)
+				}
+				if prefix.IPv6Prefix != "" {
+					cidrs = append(cidrs, prefix.IPv6Prefix)
+				}
+			}
+			return cidrs, nil
+		},
+		"Azure": func() ([]string, error) {
+			page, err := getBody(client, azureRangesPageURL, "azure")
+			if err != nil {
+				return nil, err
+			}
+			link := azureServiceTagsPattern.Find(page)
+			if link == nil {
+				return nil, fmt.Errorf("azure service tags link not found")
+			}
+			body, err := getBody(client, string(link), "azure")
+			if err != nil {
+				return nil, err
+			}
+			var feed struct {
+				Values []struct {
+					Name       string `json:"name"`
+					Properties struct {
+						AddressPrefixes []string `json:"addressPrefixes"`
+					} `json:"properties"`
+				} `json:"values"`
+			}
+			if err := json.Unmarshal(body, &feed); err != nil {
+				return nil, fmt.Errorf("failed to parse azure ranges: %w", err)
+			}
+			// The AzureCloud tag covers every public Azure range
+			for _, value := range feed.Values {
+				if value.Name == "AzureCloud" {
+					return value.Properties.AddressPrefixes, nil
+				}
+			}
+			return nil, fmt.Errorf("azure AzureCloud service tag not found")
+		},
+		"Cloudflare": func() ([]string, error) {
+			var cidrs []string
+			for _, feedURL := range []string{cloudflareRangesV4URL, cloudflareRangesV6URL} {
+				body, err := getBody(client, feedURL, "cloudflare")
+				if err != nil {
+					return nil, err
+				}
+				for _, line := range strings.Split(string(body), "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						cidrs = append(cidrs, line)
+					}
+				}
+			}
+			return cidrs, nil
+		},
+	}
+
+	providers := make(map[string][]string, len(fetchers))
+	var errs []string
+	for provider, fetch := range fetchers {
+		cidrs, err := fetch()
+		if err != nil || len(cidrs) == 0 {
+			if err == nil {
+				err = fmt.Errorf("no ranges published")
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", provider, err))
+			providers[provider] = current.Providers[provider]
+			continue
+		}
+		providers[provider] = cidrs
+	}
+
+	ranges := newCloudRanges(providers)
+	ranges.UpdatedAt = time.Now()
+
+	path, err := GetCloudRangesPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create fingerprints directory: %w", err)
+	}
+	data, err := json.MarshalIndent(ranges, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cloud ranges: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write cloud ranges: %w", err)
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return ranges, fmt.Errorf("some providers failed to update: %s", strings.Join(errs, "; "))
+	}
+	return ranges, nil
+}
@@ -0,0 +1,111 @@
+package recon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ContentCluster groups alive hosts serving the same or near-identical content
+type ContentCluster struct {
+	ContentHash string      `json:"content_hash"`
+	Title       string      `json:"title,omitempty"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Identical   bool        `json:"identical"` // All members share the exact same body
+	Subdomains  []Subdomain `json:"subdomains"`
+}
+
+var (
+	// Digits cover timestamps, nonces, request IDs, and cache-busters
+	volatileDigits = regexp.MustCompile(`[0-9]+`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// hashBody returns the hex SHA-256 of a response body
+func hashBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashNormalizedBody hashes a body after removing content that typically
+// differs between copies of the same page: the hostname, digits, letter case,
+// and whitespace
+func hashNormalizedBody(body []byte, host string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	normalized := strings.ToLower(string(body))
+	if host != "" {
+		normalized = strings.ReplaceAll(normalized, strings.ToLower(host), "")
+	}
+	normalized = volatileDigits.ReplaceAllString(normalized, "")
+	normalized = whitespace.ReplaceAllString(normalized, " ")
+
+	sum := sha256.Sum256([]byte(strings.TrimSpace(normalized)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeTitle lowercases a title and strips digits and extra whitespace so
+// titles such as "Login - node 12" and "Login - node 7" compare equal
+func NormalizeTitle(title string) string {
+	title = strings.ToLower(title)
+	title = volatileDigits.ReplaceAllString(title, "")
+	title = whitespace.ReplaceAllString(title, " ")
+	return strings.TrimSpace(title)
+}
+
+// ClusterByContent groups alive subdomains by normalized body hash. Hosts
+// without a body hash fall back to grouping by status code and normalized
+// title. Clusters are sorted largest first.
+func ClusterByContent(subdomains []Subdomain) []ContentCluster {
+	clusters := make(map[string]*ContentCluster)
+	bodyHashes := make(map[string]map[string]bool)
+
+	for _, sub := range subdomains {
+		if sub.Verified == nil || sub.Verified.Status != "alive" || sub.Verified.HTTP == nil {
+			continue
+		}
+
+		httpResult := sub.Verified.HTTP
+		key := httpResult.ContentHash
+		if key == "" {
+			key = fmt.Sprintf("title:%d|%s", httpResult.StatusCode, NormalizeTitle(httpResult.Title))
+		}
+
+		cluster, ok := clusters[key]
+		if !ok {
+			cluster = &ContentCluster{
+				ContentHash: httpResult.ContentHash,
+				Title:       httpResult.Title,
+				StatusCode:  httpResult.StatusCode,
+			}
+			clusters[key] = cluster
+			bodyHashes[key] = make(map[string]bool)
+		}
+
+		cluster.Subdomains = append(cluster.Subdomains, sub)
+		bodyHashes[key][httpResult.BodyHash] = true
+	}
+
+	result := make([]ContentCluster, 0, len(clusters))
+	for key, cluster := range clusters {
+		cluster.Identical = cluster.ContentHash != "" && len(bodyHashes[key]) == 1
+		result = append(result, *cluster)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Subdomains) != len(result[j].Subdomains) {
+			return len(result[i].Subdomains) > len(result[j].Subdomains)
+		}
+		return result[i].Subdomains[0].Name < result[j].Subdomains[0].Name
+	})
+
+	return result
+}
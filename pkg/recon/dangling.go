@@ -0,0 +1,143 @@
+package recon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DanglingRecord is an A, AAAA, or NS record pointing at a resource that
+// appears to no longer exist
+type DanglingRecord struct {
+	Type     string `json:"type"`               // A, AAAA, or NS
+	Value    string `json:"value"`              // IP address or nameserver host
+	Provider string `json:"provider,omitempty"` // Cloud provider owning the IP
+	Reason   string `json:"reason"`
+}
+
+// cloudPTRSuffixes identify IPs in cloud provider address pools from their
+// default reverse names. Elastic and public IPs keep these names whether or
+// not they are assigned to a running instance.
+var cloudPTRSuffixes = map[string][]string{
+	"AWS":    {".compute.amazonaws.com", ".compute-1.amazonaws.com"},
+	"Azure":  {".cloudapp.net", ".cloudapp.azure.com"},
+	"GCP":    {".bc.googleusercontent.com"},
+	"Linode": {".ip.linodeusercontent.com", ".members.linode.com"},
+}
+
+// danglingProbePorts are checked to decide whether a cloud IP is in use
+var danglingProbePorts = []string{"80", "443"}
+
+// checkDanglingRecords looks for A/AAAA records pointing at cloud IPs with
+// nothing listening (e.g., a released Elastic IP or deleted Azure public IP
+// that someone else can allocate) and NS records delegating to nameservers
+// that do not serve the zone or whose domain is unregistered.
+func checkDanglingRecords(ctx context.Context, server string, info DNSInfo, timeout time.Duration, cache *dnsCache) []DanglingRecord {
+	var dangling []DanglingRecord
+
+	for _, ip := range append(append([]string{}, info.A...), info.AAAA...) {
+		provider := cloudProviderForIP(ctx, server, ip, info.PTR[ip], cache)
+		if provider == "" || hostResponds(ctx, ip, timeout) {
+			continue
+		}
+		recordType := "A"
+		if strings.Contains(ip, ":") {
+			recordType = "AAAA"
+		}
+		dangling = append(dangling, DanglingRecord{
+			Type:     recordType,
+			Value:    ip,
+			Provider: provider,
+			Reason:   fmt.Sprintf("%s IP does not respond on ports %s (possibly released)", provider, strings.Join(danglingProbePorts, "/")),
+		})
+	}
+
+	for _, ns := range info.NS {
+		if reason := checkDelegation(ctx, server, info.Subdomain, strings.TrimSuffix(ns, "."), cache); reason != "" {
+			dangling = append(dangling, DanglingRecord{
+				Type:   "NS",
+				Value:  ns,
+				Reason: reason,
+			})
+		}
+	}
+
+	return dangling
+}
+
+// cloudProviderForIP returns the cloud provider whose address pool contains
+// ip, judged by its reverse name. ptrs are used when already known.
+func cloudProviderForIP(ctx context.Context, server, ip string, ptrs []string, cache *dnsCache) string {
+	if len(ptrs) == 0 {
+		name, err := dns.ReverseAddr(ip)
+		if err != nil {
+			return ""
+		}
+		answers, _ := cache.lookup(ctx, server, name, dns.TypePTR)
+		for _, answer := range answers {
+			ptrs = append(ptrs, answer.Data)
+		}
+	}
+
+	for _, ptr := range ptrs {
+		ptr = strings.ToLower(strings.TrimSuffix(ptr, "."))
+		for provider, suffixes := range cloudPTRSuffixes {
+			for _, suffix := range suffixes {
+				if strings.HasSuffix(ptr, suffix) {
+					return provider
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// hostResponds reports whether ip accepts or actively refuses a TCP
+// connection on any probe port. A refusal still means a host owns the IP;
+// only timeouts on every port suggest it is unallocated.
+func hostResponds(ctx context.Context, ip string, timeout time.Duration) bool {
+	dialer := &net.Dialer{Timeout: timeout}
+	for _, port := range danglingProbePorts {
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip, port))
+		if err == nil {
+			conn.Close()
+			return true
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDelegation returns why a delegation of zone to nameserver ns is
+// dangling, or "" when ns serves the zone
+func checkDelegation(ctx context.Context, server, zone, ns string, cache *dnsCache) string {
+	// A nameserver under an unregistered domain can be registered by anyone
+	nsDomain := RegistrableDomain(ns)
+	if resp, err := cache.exchange(ctx, server, nsDomain, dns.TypeSOA); err == nil && resp.Rcode == dns.RcodeNameError {
+		return fmt.Sprintf("nameserver domain %s is not registered", nsDomain)
+	}
+
+	addresses, err := cache.lookup(ctx, server, ns, dns.TypeA)
+	if err != nil || len(addresses) == 0 {
+		return ""
+	}
+
+	// Ask the nameserver itself; a deleted hosted zone is refused or fails
+	resp, err := exchangeDNS(ctx, net.JoinHostPort(addresses[0].Data, "53"), zone, dns.TypeSOA)
+	if err != nil {
+		return ""
+	}
+	switch resp.Rcode {
+	case dns.RcodeRefused, dns.RcodeServerFailure:
+		return fmt.Sprintf("nameserver %s does not serve %s (lame delegation)", ns, zone)
+	}
+	return ""
+}
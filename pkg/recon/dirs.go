@@ -0,0 +1,527 @@
+package recon
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// DefaultDirMatchStatus mirrors ffuf's default matcher: responses worth a look
+// rather than plain 404s
+var DefaultDirMatchStatus = []int{200, 201, 202, 203, 204, 301, 302, 307, 308, 401, 403, 405, 500}
+
+// defaultDirWordlists are common install locations checked when no wordlist
+// is configured
+var defaultDirWordlists = []string{
+	"/usr/share/seclists/Discovery/Web-Content/common.txt",
+	"/usr/share/wordlists/seclists/Discovery/Web-Content/common.txt",
+	"/usr/share/wordlists/dirb/common.txt",
+	"/usr/share/dirb/wordlists/common.txt",
+}
+
+// DirEntry represents a discovered path
+type DirEntry struct {
+	URL        string `json:"url"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code"`
+	Size       int64  `json:"size"`
+	Location   string `json:"location,omitempty"` // Redirect target
+}
+
+// DirHostResult represents the paths found on a single target
+type DirHostResult struct {
+	Target   string     `json:"target"` // Base URL that was brute-forced
+	Host     string     `json:"host"`
+	Entries  []DirEntry `json:"entries"`
+	Filtered int        `json:"filtered"` // Matches dropped by status/size filters or calibration
+	Error    string     `json:"error,omitempty"`
+}
+
+// DirsResults represents the results of directory brute-forcing
+type DirsResults struct {
+	Domain    string          `json:"domain"`
+	Timestamp time.Time       `json:"timestamp"`
+	Tool      string          `json:"tool"` // ffuf, gobuster, or native
+	Wordlist  string          `json:"wordlist"`
+	Hosts     []DirHostResult `json:"hosts"`
+	Summary   map[string]int  `json:"summary"` // Entry count per status code
+}
+
+// DirsOptions configures directory brute-forcing
+type DirsOptions struct {
+	Tool         string        // ffuf, gobuster, native, or "" to pick the first installed
+	Wordlist     string        // Path to the wordlist
+	Extensions   []string      // Extensions appended to every word (e.g., .php, .bak)
+	Threads      int           // Concurrent requests per target (default: 20)
+	RateLimit    float64       // Maximum requests per second per target (0 = unlimited)
+	Timeout      time.Duration // Timeout per request (default: 10s)
+	MatchStatus  []int         // Status codes to keep (default: DefaultDirMatchStatus)
+	FilterStatus []int         // Status codes to drop
+	FilterSize   []int64       // Response sizes to drop
+	Proxy        *url.URL      // Route requests through this proxy (optional)
+}
+
+// ResolveDirWordlist returns the configured wordlist, a file or asset name,
+// falling back to common install locations and downloaded dirs wordlists
+func ResolveDirWordlist(configured string) (string, error) {
+	if configured != "" {
+		configured, err := ResolveWordlist(configured)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(configured); err != nil {
+			return "", fmt.Errorf("wordlist not found: %s", configured)
+		}
+		return configured, nil
+	}
+
+	for _, path := range defaultDirWordlists {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if path := installedWordlist("dirs"); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("no wordlist found (use --wordlist, 'recon-cli config set wordlists.dirs <path>', or 'recon-cli recon assets download seclists-common')")
+}
+
+// SelectDirTool returns the tool to use, preferring ffuf, then gobuster,
+// then the native worker pool
+func SelectDirTool(requested string) (string, error) {
+	switch requested {
+	case "":
+		for _, tool := range []string{"ffuf", "gobuster"} {
+			if IsToolAvailable(tool) {
+				return tool, nil
+			}
+		}
+		return "native", nil
+	case "ffuf", "gobuster":
+		if !IsToolAvailable(requested) {
+			return "", fmt.Errorf("%s is not installed", requested)
+		}
+		return requested, nil
+	case "native":
+		return requested, nil
+	default:
+		return "", fmt.Errorf("invalid tool: %s (must be: ffuf, gobuster, or native)", requested)
+	}
+}
+
+// BruteForceDirs brute-forces paths on each target, one target at a time
+func BruteForceDirs(domain string, targets []string, options DirsOptions) (*DirsResults, error) {
+	if options.Threads == 0 {
+		options.Threads = 20
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 10 * time.Second
+	}
+	if len(options.MatchStatus) == 0 {
+		options.MatchStatus = DefaultDirMatchStatus
+	}
+
+	tool, err := SelectDirTool(options.Tool)
+	if err != nil {
+		return nil, err
+	}
+	options.Tool = tool
+
+	results := &DirsResults{
+		Domain:    domain,
+		Timestamp: time.Now(),
+		Tool:      tool,
+		Wordlist:  options.Wordlist,
+		Hosts:     []DirHostResult{},
+		Summary:   make(map[string]int),
+	}
+
+	// ffuf and gobuster can't read a wordlist sealed by the vault
+	if tool != "native" {
+		wordlist, cleanup, err := plainWordlist(options.Wordlist)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		options.Wordlist = wordlist
+	}
+
+	client := newDirsClient(options)
+
+	for _, target := range targets {
+		target = strings.TrimSuffix(target, "/")
+		parsed, err := url.Parse(target)
+		if err != nil {
+			continue
+		}
+
+		fmt.Printf("Scanning %s... ", target)
+		startTime := time.Now()
+
+		hostResult := DirHostResult{
+			Target:  target,
+			Host:    parsed.Hostname(),
+			Entries: []DirEntry{},
+		}
+
+		var entries []DirEntry
+		switch tool {
+		case "ffuf":
+			entries, err = runFfuf(target, options)
+		case "gobuster":
+			entries, err = runGobuster(target, options)
+		default:
+			entries, err = runNativeDirs(client, target, options)
+		}
+		duration := time.Since(startTime)
+
+		if err != nil {
+			fmt.Printf("✗ failed after %s: %v\n", duration.Round(time.Second), err)
+			hostResult.Error = err.Error()
+			results.Hosts = append(results.Hosts, hostResult)
+			continue
+		}
+
+		// Drop responses matching the target's "not found" page
+		calibration := calibrateDirs(client, target)
+		for _, entry := range entries {
+			if !keepDirEntry(entry, options, calibration) {
+				hostResult.Filtered++
+				continue
+			}
+			hostResult.Entries = append(hostResult.Entries, entry)
+			results.Summary[strconv.Itoa(entry.StatusCode)]++
+		}
+		sort.Slice(hostResult.Entries, func(i, j int) bool {
+			return hostResult.Entries[i].Path < hostResult.Entries[j].Path
+		})
+
+		fmt.Printf("✓ %d paths in %s\n", len(hostResult.Entries), duration.Round(time.Second))
+		results.Hosts = append(results.Hosts, hostResult)
+	}
+
+	return results, nil
+}
+
+// dirCalibration describes the response to a path that should not exist
+type dirCalibration struct {
+	statusCode int
+	size       int64
+}
+
+// calibrateDirs requests a random path so soft-404 pages that answer every
+// request with the same status and size can be filtered out
+func calibrateDirs(client *http.Client, target string) *dirCalibration {
+	entry, err := fetchDirEntry(client, target, hex.EncodeToString(randomBytes(8)))
+	if err != nil || entry.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return &dirCalibration{statusCode: entry.StatusCode, size: entry.Size}
+}
+
+// keepDirEntry applies status and size filters to an entry
+func keepDirEntry(entry DirEntry, options DirsOptions, calibration *dirCalibration) bool {
+	if !containsInt(options.MatchStatus, entry.StatusCode) || containsInt(options.FilterStatus, entry.StatusCode) {
+		return false
+	}
+	for _, size := range options.FilterSize {
+		if entry.Size == size {
+			return false
+		}
+	}
+	if calibration != nil && entry.StatusCode == calibration.statusCode && entry.Size == calibration.size {
+		return false
+	}
+	return true
+}
+
+// plainWordlist returns a path external tools can read the wordlist from:
+// the wordlist itself, or a temporary decrypted copy when the vault sealed
+// it. cleanup removes the copy.
+func plainWordlist(path string) (plain string, cleanup func(), err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	header := make([]byte, config.VaultHeaderSize)
+	n, _ := io.ReadFull(file, header)
+	file.Close()
+	if !config.IsVaultSealed(header[:n]) {
+		return path, func() {}, nil
+	}
+
+	data, err := ReadResultFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	copyFile, err := os.CreateTemp("", "recon-wordlist-*.txt")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create wordlist copy: %w", err)
+	}
+	cleanup = func() { os.Remove(copyFile.Name()) }
+	_, err = copyFile.Write(data)
+	if closeErr := copyFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write wordlist copy: %w", err)
+	}
+	return copyFile.Name(), cleanup, nil
+}
+
+// dirWords reads the wordlist, which may be a text result sealed by the
+// vault, and expands it with extensions
+func dirWords(options DirsOptions) ([]string, error) {
+	data, err := ReadResultFile(options.Wordlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		word := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "/")
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+		for _, ext := range options.Extensions {
+			words = append(words, word+"."+strings.TrimPrefix(ext, "."))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+
+	return words, nil
+}
+
+// newDirsClient creates the HTTP client for native brute-forcing and
+// calibration. Redirects are not followed so they are reported as found.
+func newDirsClient(options DirsOptions) *http.Client {
+	return &http.Client{
+		Timeout: options.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, // Skip cert validation for recon
+			},
+			Proxy:               proxyFunc(options.Proxy),
+			MaxIdleConnsPerHost: options.Threads,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// fetchDirEntry requests target/word and records the response
+func fetchDirEntry(client *http.Client, target, word string) (DirEntry, error) {
+	entryURL := target + "/" + word
+	resp, err := client.Get(entryURL)
+	if err != nil {
+		return DirEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	size, _ := io.Copy(io.Discard, io.LimitReader(resp.Body, 10*1024*1024))
+
+	return DirEntry{
+		URL:        entryURL,
+		Path:       "/" + word,
+		StatusCode: resp.StatusCode,
+		Size:       size,
+		Location:   resp.Header.Get("Location"),
+	}, nil
+}
+
+// runNativeDirs brute-forces a target with a worker pool
+func runNativeDirs(client *http.Client, target string, options DirsOptions) ([]DirEntry, error) {
+	words, err := dirWords(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var limiter *RateLimiter
+	if options.RateLimit > 0 {
+		limiter = NewRateLimiter(options.RateLimit, 0)
+	}
+
+	jobs := make(chan string)
+	var entries []DirEntry
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < options.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for word := range jobs {
+				limiter.Wait("")
+				entry, err := fetchDirEntry(client, target, word)
+				if err != nil || entry.StatusCode == http.StatusNotFound {
+					continue
+				}
+				mu.Lock()
+				entries = append(entries, entry)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, word := range words {
+		jobs <- word
+	}
+	close(jobs)
+	wg.Wait()
+
+	return entries, nil
+}
+
+// runFfuf brute-forces a target with ffuf and parses its JSON output
+func runFfuf(target string, options DirsOptions) ([]DirEntry, error) {
+	outputFile, err := os.CreateTemp("", "recon-ffuf-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	outputFile.Close()
+	defer os.Remove(outputFile.Name())
+
+	args := []string{"-u", target + "/FUZZ", "-w", options.Wordlist, "-s",
+		"-of", "json", "-o", outputFile.Name(),
+		"-t", strconv.Itoa(options.Threads),
+		"-timeout", strconv.Itoa(int(options.Timeout.Seconds())),
+		"-mc", joinInts(options.MatchStatus)}
+	if len(options.Extensions) > 0 {
+		var exts []string
+		for _, ext := range options.Extensions {
+			exts = append(exts, "."+strings.TrimPrefix(ext, "."))
+		}
+		args = append(args, "-e", strings.Join(exts, ","))
+	}
+	if options.RateLimit > 0 {
+		args = append(args, "-rate", strconv.Itoa(int(options.RateLimit+0.5)))
+	}
+	if options.Proxy != nil {
+		args = append(args, "-x", options.Proxy.String())
+	}
+
+	if _, err := ExecuteWithTimeout("ffuf", 2*time.Hour, args...); err != nil {
+		return nil, fmt.Errorf("ffuf execution failed: %w", err)
+	}
+
+	data, err := os.ReadFile(outputFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ffuf output: %w", err)
+	}
+
+	var output struct {
+		Results []struct {
+			Input            map[string]string `json:"input"`
+			Status           int               `json:"status"`
+			Length           int64             `json:"length"`
+			URL              string            `json:"url"`
+			RedirectLocation string            `json:"redirectlocation"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse ffuf output: %w", err)
+	}
+
+	var entries []DirEntry
+	for _, result := range output.Results {
+		entries = append(entries, DirEntry{
+			URL:        result.URL,
+			Path:       "/" + strings.TrimPrefix(result.Input["FUZZ"], "/"),
+			StatusCode: result.Status,
+			Size:       result.Length,
+			Location:   result.RedirectLocation,
+		})
+	}
+
+	return entries, nil
+}
+
+// gobusterLinePattern matches gobuster dir output such as
+// "/admin                (Status: 301) [Size: 169] [--> http://example.com/admin/]"
+var gobusterLinePattern = regexp.MustCompile(`^(\S+)\s+\(Status:\s*(\d+)\)\s+\[Size:\s*(\d+)\](?:\s+\[-->\s*(\S+)\])?`)
+
+// runGobuster brute-forces a target with gobuster and parses its output
+func runGobuster(target string, options DirsOptions) ([]DirEntry, error) {
+	args := []string{"dir", "-u", target, "-w", options.Wordlist, "-q", "--no-color",
+		"-t", strconv.Itoa(options.Threads),
+		"--timeout", options.Timeout.String(),
+		"-s", joinInts(options.MatchStatus), "-b", ""}
+	if len(options.Extensions) > 0 {
+		var exts []string
+		for _, ext := range options.Extensions {
+			exts = append(exts, strings.TrimPrefix(ext, "."))
+		}
+		args = append(args, "-x", strings.Join(exts, ","))
+	}
+	if options.RateLimit > 0 {
+		// gobuster only supports a per-thread delay
+		delay := time.Duration(float64(options.Threads) / options.RateLimit * float64(time.Second))
+		args = append(args, "--delay", delay.String())
+	}
+	if options.Proxy != nil {
+		args = append(args, "--proxy", options.Proxy.String())
+	}
+
+	result, err := ExecuteWithTimeout("gobuster", 2*time.Hour, args...)
+	if err != nil && (result == nil || result.Stdout == "") {
+		return nil, fmt.Errorf("gobuster execution failed: %w", err)
+	}
+
+	var entries []DirEntry
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		match := gobusterLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		statusCode, _ := strconv.Atoi(match[2])
+		size, _ := strconv.ParseInt(match[3], 10, 64)
+		path := "/" + strings.TrimPrefix(match[1], "/")
+		entries = append(entries, DirEntry{
+			URL:        target + path,
+			Path:       path,
+			StatusCode: statusCode,
+			Size:       size,
+			Location:   match[4],
+		})
+	}
+
+	return entries, nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
@@ -3,42 +3,58 @@ package recon
 import (
 	"context"
 	"fmt"
-	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/miekg/dns"
 )
 
-// DNSRecord represents a single DNS record
+// DNSRecord represents a single DNS record as returned by a nameserver
 type DNSRecord struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
-	TTL   uint32 `json:"ttl,omitempty"`
+	Name   string `json:"name"` // Owner name, e.g. the SRV service name or reverse zone name
+	Type   string `json:"type"`
+	Class  string `json:"class"`
+	Value  string `json:"value"`
+	TTL    uint32 `json:"ttl"`
+	Server string `json:"server"` // Nameserver that answered
 }
 
 // DNSInfo represents all DNS information for a subdomain
 type DNSInfo struct {
-	Subdomain      string    `json:"subdomain"`
-	A              []string  `json:"a_records,omitempty"`
-	AAAA           []string  `json:"aaaa_records,omitempty"`
-	CNAME          []string  `json:"cname_records,omitempty"`
-	MX             []string  `json:"mx_records,omitempty"`
-	TXT            []string  `json:"txt_records,omitempty"`
-	NS             []string  `json:"ns_records,omitempty"`
-	CloudProvider  string    `json:"cloud_provider,omitempty"`
-	TakeoverRisk   bool      `json:"takeover_risk"`
-	TakeoverReason string    `json:"takeover_reason,omitempty"`
-	QueryTime      time.Time `json:"query_time"`
-	Error          string    `json:"error,omitempty"`
+	Subdomain      string              `json:"subdomain"`
+	A              []string            `json:"a_records,omitempty"`
+	AAAA           []string            `json:"aaaa_records,omitempty"`
+	CNAME          []string            `json:"cname_records,omitempty"`
+	MX             []string            `json:"mx_records,omitempty"`
+	TXT            []string            `json:"txt_records,omitempty"`
+	NS             []string            `json:"ns_records,omitempty"`
+	CAA            []string            `json:"caa_records,omitempty"`
+	SOA            []string            `json:"soa_records,omitempty"`
+	SRV            []string            `json:"srv_records,omitempty"` // "_service._proto priority weight port target"
+	PTR            map[string][]string `json:"ptr_records,omitempty"` // Reverse names keyed by resolved IP
+	Records        []DNSRecord         `json:"records,omitempty"`     // Every answer with TTL, class, and server
+	CloudProvider  string              `json:"cloud_provider,omitempty"`
+	TakeoverRisk   bool                `json:"takeover_risk"`
+	TakeoverReason string              `json:"takeover_reason,omitempty"`
+	TakeoverStatus string              `json:"takeover_status,omitempty"` // Vulnerable or Edge case, per the matched fingerprint
+	TakeoverScore  int                 `json:"takeover_score,omitempty"`  // 1-10; higher when the CNAME dangles in an unsigned zone
+	DNSSEC         *DNSSECInfo         `json:"dnssec,omitempty"`
+	Dangling       []DanglingRecord    `json:"dangling,omitempty"` // A/AAAA/NS records pointing at released resources
+	QueryTime      time.Time           `json:"query_time"`
+	Error          string              `json:"error,omitempty"`
 }
 
 // DNSResults represents the complete DNS enumeration results
 type DNSResults struct {
-	Domain       string     `json:"domain"`
-	Records      []DNSInfo  `json:"records"`
-	TotalQueried int        `json:"total_queried"`
-	Summary      DNSSummary `json:"summary"`
-	EnumeratedAt time.Time  `json:"enumerated_at"`
+	Domain       string            `json:"domain"`
+	Records      []DNSInfo         `json:"records"`
+	IPInfo       map[string]IPInfo `json:"ip_info,omitempty"`        // GeoIP enrichment keyed by IP
+	Email        []EmailSecurity   `json:"email_security,omitempty"` // Target and mail-receiving subdomains
+	TotalQueried int               `json:"total_queried"`
+	Summary      DNSSummary        `json:"summary"`
+	EnumeratedAt time.Time         `json:"enumerated_at"`
 }
 
 // DNSSummary provides statistics about DNS enumeration
@@ -49,40 +65,50 @@ type DNSSummary struct {
 	TotalTXT       int      `json:"total_txt"`
 	TotalCNAME     int      `json:"total_cname"`
 	TotalNS        int      `json:"total_ns"`
+	TotalCAA       int      `json:"total_caa"`
+	TotalSOA       int      `json:"total_soa"`
+	TotalSRV       int      `json:"total_srv"`
+	TotalPTR       int      `json:"total_ptr"`
 	TakeoverRisks  int      `json:"takeover_risks"`
+	DanglingRisks  int      `json:"dangling_risks"`  // Subdomains with dangling A/AAAA/NS records
+	DNSSECSigned   int      `json:"dnssec_signed"`   // Subdomains in signed zones (secure or signed)
+	DNSSECUnsigned int      `json:"dnssec_unsigned"` // Subdomains in unsigned zones
+	DNSSECBogus    int      `json:"dnssec_bogus"`    // Subdomains failing validation
 	CloudProviders []string `json:"cloud_providers"`
 	UniqueIPs      int      `json:"unique_ips"`
+	Queries        int      `json:"queries"`    // Lookups sent to nameservers (excluding DNSSEC and email checks)
+	CacheHits      int      `json:"cache_hits"` // Lookups answered from the in-run cache
 }
 
 // DNSEnumerationOptions configures DNS enumeration
 type DNSEnumerationOptions struct {
-	AliveOnly     bool
-	RecordTypes   []string // A, AAAA, MX, TXT, NS, CNAME
-	Concurrency   int
-	Timeout       time.Duration
-	CheckTakeover bool
+	AliveOnly       bool
+	RecordTypes     []string       // A, AAAA, CNAME, MX, TXT, NS, CAA, SOA, SRV, PTR
+	Concurrency     int            // Workers per record type, and for per-subdomain checks
+	TypeConcurrency map[string]int // Workers for specific record types, overriding Concurrency (e.g., SRV: 2)
+	Timeout         time.Duration
+	CheckTakeover   bool
+	CheckDangling   bool                  // Probe cloud IPs and NS delegations for released resources
+	CheckDNSSEC     bool                  // Check zone signing and resolver validation per subdomain
+	CheckEmail      bool                  // Grade SPF, DMARC, and DKIM for the target and mail domains
+	Resolvers       *ResolverPool         // Custom nameservers (optional, default: system resolver)
+	CloudRanges     *CloudRanges          // Provider IP ranges (optional, default: LoadCloudRanges)
+	Fingerprints    []TakeoverFingerprint // Takeover fingerprints (optional, default: LoadTakeoverFingerprints)
+	Subdomains      *SubdomainResults     // Scan to query (optional, default: the latest subdomain results)
 }
 
-// Common subdomain takeover signatures
-var takeoverSignatures = map[string][]string{
-	"herokuapp.com":     {"No such app", "There's nothing here"},
-	"github.io":         {"404", "There isn't a GitHub Pages site here"},
-	"azurewebsites.net": {"404", "Error 404"},
-	"cloudfront.net":    {"ERROR: The request could not be satisfied"},
-	"s3.amazonaws.com":  {"NoSuchBucket", "The specified bucket does not exist"},
-	"bitbucket.io":      {"Repository not found"},
-	"ghost.io":          {"The thing you were looking for is no longer here"},
-	"pantheonsite.io":   {"404 error unknown site"},
-	"zendesk.com":       {"Help Center Closed"},
-	"uservoice.com":     {"This UserVoice subdomain is currently available"},
-	"surge.sh":          {"project not found"},
-	"tumblr.com":        {"Whatever you were looking for doesn't currently exist"},
-	"wordpress.com":     {"Do you want to register"},
-	"statuspage.io":     {"You are being redirected"},
-	"hubspot.net":       {"404"},
+// DefaultDNSRecordTypes are queried when no record types are specified. SRV
+// is opt-in since it probes several service names per subdomain.
+var DefaultDNSRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "CAA", "SOA", "PTR"}
+
+// srvServices are the common service labels probed for SRV records
+var srvServices = []string{
+	"_sip._tcp", "_sip._udp", "_sips._tcp", "_xmpp-server._tcp", "_xmpp-client._tcp",
+	"_ldap._tcp", "_kerberos._tcp", "_autodiscover._tcp", "_caldavs._tcp", "_carddavs._tcp",
+	"_imaps._tcp", "_submission._tcp", "_minecraft._tcp",
 }
 
-// Cloud provider IP ranges and patterns
+// Cloud provider CNAME and NS patterns (IPs are matched by CloudRanges)
 var cloudProviders = map[string][]string{
 	"AWS":          {"amazonaws.com", "cloudfront.net", "awsglobalaccelerator.com"},
 	"Azure":        {"azurewebsites.net", "cloudapp.azure.com", "azure.com"},
@@ -94,12 +120,19 @@ var cloudProviders = map[string][]string{
 	"Heroku":       {"herokuapp.com", "herokussl.com"},
 }
 
-// EnumerateDNS performs DNS enumeration for all subdomains
+// EnumerateDNS performs DNS enumeration for all subdomains. Each record type
+// is queried by its own pool of workers, then PTR, takeover, DNSSEC, and
+// dangling checks run per subdomain. Responses are cached for the run so
+// shared CNAME targets, IPs, and nameservers are only queried once.
 func EnumerateDNS(ctx context.Context, domain string, options DNSEnumerationOptions) (*DNSResults, error) {
-	// Load latest subdomain results
-	var subdomainResults SubdomainResults
-	if err := LoadLatestResult(domain, "subdomains", &subdomainResults); err != nil {
-		return nil, fmt.Errorf("failed to load subdomain results: %w", err)
+	// Load latest subdomain results unless a scan was chosen
+	subdomainResults := options.Subdomains
+	if subdomainResults == nil {
+		latest, err := GetLatestSubdomainResult(domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load subdomain results: %w", err)
+		}
+		subdomainResults = latest
 	}
 
 	// Filter subdomains if needed
@@ -126,139 +159,337 @@ func EnumerateDNS(ctx context.Context, domain string, options DNSEnumerationOpti
 		options.Timeout = 5 * time.Second
 	}
 	if len(options.RecordTypes) == 0 {
-		options.RecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS"}
+		options.RecordTypes = DefaultDNSRecordTypes
+	}
+
+	if options.CheckTakeover && options.Fingerprints == nil {
+		fingerprints, err := LoadTakeoverFingerprints()
+		if err != nil {
+			return nil, err
+		}
+		options.Fingerprints = fingerprints
+	}
+
+	if options.CloudRanges == nil {
+		ranges, err := LoadCloudRanges()
+		if err != nil {
+			return nil, err
+		}
+		options.CloudRanges = ranges
 	}
 
 	// Create results structure
 	results := &DNSResults{
 		Domain:       domain,
-		Records:      make([]DNSInfo, 0, len(subdomainsToQuery)),
+		Records:      make([]DNSInfo, len(subdomainsToQuery)),
 		TotalQueried: len(subdomainsToQuery),
 		EnumeratedAt: time.Now(),
 	}
+	for i, sub := range subdomainsToQuery {
+		results.Records[i] = DNSInfo{
+			Subdomain: sub.Name,
+			QueryTime: time.Now(),
+		}
+	}
 
-	// Concurrent DNS enumeration
-	semaphore := make(chan struct{}, options.Concurrency)
+	cache := newDNSCache()
+
+	// Subdomains usually share a handful of zones
+	dnssecCache := newDNSSECZoneCache()
+
+	// Query each record type with its own worker pool. PTR lookups depend
+	// on the A and AAAA answers, so they run with the per-subdomain checks.
+	var recordTypes []string
+	for _, recordType := range options.RecordTypes {
+		if recordType != "PTR" && dnsTypeNames[recordType] != 0 && !contains(recordTypes, recordType) {
+			recordTypes = append(recordTypes, recordType)
+		}
+	}
+
+	answers := make([][][]DNSRecord, len(recordTypes))
+	errs := make([][]error, len(recordTypes))
 	var wg sync.WaitGroup
-	var mu sync.Mutex
 
-	for _, subdomain := range subdomainsToQuery {
+	for t, recordType := range recordTypes {
+		answers[t] = make([][]DNSRecord, len(subdomainsToQuery))
+		errs[t] = make([]error, len(subdomainsToQuery))
+
+		workers := options.Concurrency
+		if n := options.TypeConcurrency[recordType]; n > 0 {
+			workers = n
+		}
+
+		jobs := make(chan int)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(t int, recordType string) {
+				defer wg.Done()
+				for i := range jobs {
+					answers[t][i], errs[t][i] = queryRecordType(ctx, subdomainsToQuery[i].Name, recordType, options, cache)
+				}
+			}(t, recordType)
+		}
+
+		go func() {
+			for i := range subdomainsToQuery {
+				jobs <- i
+			}
+			close(jobs)
+		}()
+	}
+
+	wg.Wait()
+
+	// Assemble answers in record type order, then run per-subdomain checks
+	semaphore := make(chan struct{}, options.Concurrency)
+
+	for i := range results.Records {
+		info := &results.Records[i]
+		for t, recordType := range recordTypes {
+			applyDNSRecords(info, recordType, answers[t][i])
+		}
+
 		wg.Add(1)
-		go func(sub Subdomain) {
+		go func(info *DNSInfo) {
 			defer wg.Done()
 
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			info := queryDNSInfo(ctx, sub.Name, options)
-
-			mu.Lock()
-			results.Records = append(results.Records, info)
-			mu.Unlock()
-		}(subdomain)
+			checkDNSInfo(ctx, info, options, cache, dnssecCache)
+		}(info)
 	}
 
 	wg.Wait()
 
+	// Grade email security
+	if options.CheckEmail {
+		server := options.Resolvers.Server()
+		for _, name := range emailDomains(domain, results.Records) {
+			results.Email = append(results.Email, CheckEmailSecurity(ctx, server, name))
+		}
+	}
+
 	// Calculate summary
 	results.Summary = calculateDNSSummary(results.Records)
+	results.Summary.Queries, results.Summary.CacheHits = cache.stats()
 
 	return results, nil
 }
 
-// queryDNSInfo queries all DNS records for a single subdomain
-func queryDNSInfo(ctx context.Context, subdomain string, options DNSEnumerationOptions) DNSInfo {
-	info := DNSInfo{
-		Subdomain: subdomain,
-		QueryTime: time.Now(),
+// queryRecordType queries one record type for a subdomain. Queries are sent
+// directly to a nameserver so every record keeps its TTL, class, and the
+// server that answered. SRV records are probed under common service names.
+func queryRecordType(ctx context.Context, subdomain, recordType string, options DNSEnumerationOptions, cache *dnsCache) ([]DNSRecord, error) {
+	server := options.Resolvers.Server()
+
+	names := []string{subdomain}
+	if recordType == "SRV" {
+		names = names[:0]
+		for _, service := range srvServices {
+			names = append(names, service+"."+subdomain)
+		}
 	}
 
-	resolver := &net.Resolver{
-		PreferGo: true,
+	var records []DNSRecord
+	var lastErr error
+	for _, name := range names {
+		queryCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+		found, err := cache.lookup(queryCtx, server, name, dnsTypeNames[recordType])
+		cancel()
+		if recordType == "A" {
+			options.Resolvers.Report(server, err)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, answer := range found {
+			records = append(records, newDNSRecord(answer, recordType))
+		}
 	}
 
-	// Query A records
-	if contains(options.RecordTypes, "A") {
-		ips, err := resolver.LookupIP(ctx, "ip4", subdomain)
-		if err == nil {
-			for _, ip := range ips {
-				info.A = append(info.A, ip.String())
-			}
-		}
+	return records, lastErr
+}
+
+// newDNSRecord records an answer with its metadata
+func newDNSRecord(answer dnsAnswer, recordType string) DNSRecord {
+	return DNSRecord{
+		Name:   answer.Name,
+		Type:   recordType,
+		Class:  dns.Class(answer.Class).String(),
+		Value:  answer.Data,
+		TTL:    answer.TTL,
+		Server: answer.Server,
 	}
+}
 
-	// Query AAAA records
-	if contains(options.RecordTypes, "AAAA") {
-		ips, err := resolver.LookupIP(ctx, "ip6", subdomain)
-		if err == nil {
-			for _, ip := range ips {
-				info.AAAA = append(info.AAAA, ip.String())
+// applyDNSRecords fills the per-type fields of info from the answers to a
+// record type query
+func applyDNSRecords(info *DNSInfo, recordType string, records []DNSRecord) {
+	for _, record := range records {
+		info.Records = append(info.Records, record)
+
+		switch recordType {
+		case "A":
+			info.A = append(info.A, record.Value)
+		case "AAAA":
+			info.AAAA = append(info.AAAA, record.Value)
+		case "CNAME":
+			cname := strings.TrimSuffix(record.Value, ".")
+			if !strings.EqualFold(cname, info.Subdomain) {
+				info.CNAME = append(info.CNAME, cname)
 			}
+		case "MX":
+			// Value is "preference host"; keep just the host
+			fields := strings.Fields(record.Value)
+			if len(fields) == 2 {
+				info.MX = append(info.MX, fields[1])
+			}
+		case "TXT":
+			info.TXT = append(info.TXT, record.Value)
+		case "NS":
+			info.NS = append(info.NS, record.Value)
+		case "CAA":
+			info.CAA = append(info.CAA, record.Value)
+		case "SOA":
+			// Present only at zone apexes
+			info.SOA = append(info.SOA, record.Value)
+		case "SRV":
+			service := strings.TrimSuffix(strings.TrimSuffix(record.Name, "."), "."+info.Subdomain)
+			info.SRV = append(info.SRV, service+" "+record.Value)
 		}
 	}
+}
 
-	// Query CNAME records
-	if contains(options.RecordTypes, "CNAME") {
-		cname, err := resolver.LookupCNAME(ctx, subdomain)
-		if err == nil && cname != subdomain && cname != subdomain+"." {
-			info.CNAME = []string{strings.TrimSuffix(cname, ".")}
+// Values returns a subdomain's values of one record type (A, AAAA, CNAME,
+// MX, TXT, NS, CAA, SOA, SRV, or PTR). PTR values are "ip → name".
+func (info DNSInfo) Values(recordType string) []string {
+	switch strings.ToUpper(recordType) {
+	case "A":
+		return info.A
+	case "AAAA":
+		return info.AAAA
+	case "CNAME":
+		return info.CNAME
+	case "MX":
+		return info.MX
+	case "TXT":
+		return info.TXT
+	case "NS":
+		return info.NS
+	case "CAA":
+		return info.CAA
+	case "SOA":
+		return info.SOA
+	case "SRV":
+		return info.SRV
+	case "PTR":
+		ips := make([]string, 0, len(info.PTR))
+		for ip := range info.PTR {
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
 
-			// Check for subdomain takeover
-			if options.CheckTakeover {
-				checkSubdomainTakeover(&info, cname)
+		var values []string
+		for _, ip := range ips {
+			for _, name := range info.PTR[ip] {
+				values = append(values, ip+" → "+name)
 			}
 		}
+		return values
 	}
+	return nil
+}
 
-	// Query MX records
-	if contains(options.RecordTypes, "MX") {
-		mxRecords, err := resolver.LookupMX(ctx, subdomain)
-		if err == nil {
-			for _, mx := range mxRecords {
-				info.MX = append(info.MX, strings.TrimSuffix(mx.Host, "."))
+// checkDNSInfo runs the checks that build on a subdomain's records: PTR
+// lookups, takeover and dangling record detection, DNSSEC, and cloud
+// provider identification
+func checkDNSInfo(ctx context.Context, info *DNSInfo, options DNSEnumerationOptions, cache *dnsCache, dnssecCache *dnssecZoneCache) {
+	server := options.Resolvers.Server()
+
+	// Reverse-resolve the addresses found
+	if contains(options.RecordTypes, "PTR") {
+		for _, ip := range append(append([]string{}, info.A...), info.AAAA...) {
+			name, err := dns.ReverseAddr(ip)
+			if err != nil {
+				continue
+			}
+			found, _ := cache.lookup(ctx, server, name, dns.TypePTR)
+			for _, answer := range found {
+				info.Records = append(info.Records, newDNSRecord(answer, "PTR"))
+				if info.PTR == nil {
+					info.PTR = make(map[string][]string)
+				}
+				info.PTR[ip] = append(info.PTR[ip], answer.Data)
 			}
 		}
 	}
 
-	// Query TXT records
-	if contains(options.RecordTypes, "TXT") {
-		txtRecords, err := resolver.LookupTXT(ctx, subdomain)
-		if err == nil {
-			info.TXT = txtRecords
+	// Check for subdomain takeover
+	if options.CheckTakeover {
+		for _, cname := range info.CNAME {
+			checkSubdomainTakeover(info, cname, options.Fingerprints)
+			if info.TakeoverRisk {
+				break
+			}
 		}
 	}
 
-	// Query NS records
-	if contains(options.RecordTypes, "NS") {
-		nsRecords, err := resolver.LookupNS(ctx, subdomain)
-		if err == nil {
-			for _, ns := range nsRecords {
-				info.NS = append(info.NS, strings.TrimSuffix(ns.Host, "."))
-			}
-		}
+	// Check DNSSEC signing and validation
+	if options.CheckDNSSEC {
+		info.DNSSEC = checkDNSSEC(ctx, server, info.Subdomain, dnssecCache)
+	}
+
+	// Check for records left pointing at released IPs or zones
+	if options.CheckDangling {
+		info.Dangling = checkDanglingRecords(ctx, server, *info, options.Timeout, cache)
+	}
+
+	if info.TakeoverRisk {
+		info.TakeoverScore = scoreTakeover(ctx, server, *info, cache)
 	}
 
 	// Identify cloud provider
-	info.CloudProvider = identifyCloudProvider(info)
+	info.CloudProvider = identifyCloudProvider(*info, options.CloudRanges)
+}
 
-	return info
+// checkSubdomainTakeover checks if a CNAME points to a service that a
+// fingerprint lists as vulnerable or an edge case
+func checkSubdomainTakeover(info *DNSInfo, cname string, fingerprints []TakeoverFingerprint) {
+	fingerprint := matchTakeoverFingerprint(fingerprints, cname)
+	if fingerprint == nil || fingerprint.Status == TakeoverNotVulnerable {
+		return
+	}
+
+	info.TakeoverRisk = true
+	info.TakeoverStatus = fingerprint.Status
+	info.TakeoverReason = fmt.Sprintf("CNAME points to %s (%s)", fingerprint.Service, strings.ToLower(fingerprint.Status))
 }
 
-// checkSubdomainTakeover checks if a CNAME points to a potentially vulnerable service
-func checkSubdomainTakeover(info *DNSInfo, cname string) {
-	cname = strings.ToLower(cname)
+// scoreTakeover rates a takeover candidate from 1 to 10. A CNAME whose target
+// no longer resolves is likely claimable, and an unsigned zone offers no
+// integrity protection for the record.
+func scoreTakeover(ctx context.Context, server string, info DNSInfo, cache *dnsCache) int {
+	score := 6
 
-	for service, _ := range takeoverSignatures {
-		if strings.Contains(cname, service) {
-			info.TakeoverRisk = true
-			info.TakeoverReason = fmt.Sprintf("CNAME points to %s (potential takeover)", service)
-			return
+	if len(info.CNAME) > 0 {
+		resp, err := cache.exchange(ctx, server, info.CNAME[len(info.CNAME)-1], dns.TypeA)
+		if err == nil && resp.Rcode == dns.RcodeNameError {
+			score += 2
 		}
 	}
+
+	if info.DNSSEC != nil && info.DNSSEC.Status == DNSSECInsecure {
+		score += 2
+	}
+
+	return score
 }
 
-// identifyCloudProvider identifies the cloud provider based on DNS records
-func identifyCloudProvider(info DNSInfo) string {
+// identifyCloudProvider identifies the cloud provider from CNAME patterns,
+// then the published IP ranges containing A/AAAA records, then NS patterns
+func identifyCloudProvider(info DNSInfo, ranges *CloudRanges) string {
 	// Check CNAME records
 	for _, cname := range info.CNAME {
 		cnameLower := strings.ToLower(cname)
@@ -271,6 +502,13 @@ func identifyCloudProvider(info DNSInfo) string {
 		}
 	}
 
+	// Check A/AAAA records against published ranges
+	for _, ip := range append(append([]string{}, info.A...), info.AAAA...) {
+		if provider := ranges.Provider(ip); provider != "" {
+			return provider
+		}
+	}
+
 	// Check NS records
 	for _, ns := range info.NS {
 		nsLower := strings.ToLower(ns)
@@ -299,10 +537,30 @@ func calculateDNSSummary(records []DNSInfo) DNSSummary {
 		summary.TotalTXT += len(record.TXT)
 		summary.TotalCNAME += len(record.CNAME)
 		summary.TotalNS += len(record.NS)
+		summary.TotalCAA += len(record.CAA)
+		summary.TotalSOA += len(record.SOA)
+		summary.TotalSRV += len(record.SRV)
+		for _, names := range record.PTR {
+			summary.TotalPTR += len(names)
+		}
 
 		if record.TakeoverRisk {
 			summary.TakeoverRisks++
 		}
+		if len(record.Dangling) > 0 {
+			summary.DanglingRisks++
+		}
+
+		if record.DNSSEC != nil {
+			switch record.DNSSEC.Status {
+			case DNSSECSecure, DNSSECSigned:
+				summary.DNSSECSigned++
+			case DNSSECInsecure:
+				summary.DNSSECUnsigned++
+			case DNSSECBogus:
+				summary.DNSSECBogus++
+			}
+		}
 
 		if record.CloudProvider != "" && !cloudProvidersMap[record.CloudProvider] {
 			cloudProvidersMap[record.CloudProvider] = true
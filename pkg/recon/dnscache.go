@@ -0,0 +1,86 @@
+package recon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// dnsCache memoizes DNS responses by name and type for the length of one
+// enumeration. Subdomains often share CNAME targets, IPs, and nameservers,
+// so follow-up lookups (PTR, takeover scoring, dangling checks) repeat the
+// same queries many times. Concurrent lookups of the same key wait for the
+// first one instead of querying again. A nil cache queries directly.
+type dnsCache struct {
+	mu      sync.Mutex
+	entries map[string]*dnsCacheEntry
+	queries int
+	hits    int
+}
+
+// dnsCacheEntry holds one response, or the error from obtaining it
+type dnsCacheEntry struct {
+	done chan struct{}
+	resp *dnsMessage
+	err  error
+}
+
+func newDNSCache() *dnsCache {
+	return &dnsCache{entries: make(map[string]*dnsCacheEntry)}
+}
+
+// exchange returns the cached response for name and qtype, querying server
+// on a miss. Failed queries are not cached so later lookups retry.
+func (c *dnsCache) exchange(ctx context.Context, server, name string, qtype uint16) (*dnsMessage, error) {
+	if c == nil {
+		return exchangeDNS(ctx, server, name, qtype)
+	}
+
+	key := fmt.Sprintf("%s/%d", strings.ToLower(strings.TrimSuffix(name, ".")), qtype)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.hits++
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+			return entry.resp, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	entry := &dnsCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.queries++
+	c.mu.Unlock()
+
+	entry.resp, entry.err = exchangeDNS(ctx, server, name, qtype)
+	if entry.err != nil {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	close(entry.done)
+
+	return entry.resp, entry.err
+}
+
+// lookup is lookupRecords through the cache
+func (c *dnsCache) lookup(ctx context.Context, server, name string, qtype uint16) ([]dnsAnswer, error) {
+	resp, err := c.exchange(ctx, server, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	return answersOfType(resp, name, qtype)
+}
+
+// stats returns the number of queries sent and lookups answered from cache
+func (c *dnsCache) stats() (queries, hits int) {
+	if c == nil {
+		return 0, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queries, c.hits
+}
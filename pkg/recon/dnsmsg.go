@@ -0,0 +1,351 @@
+package recon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// systemResolverName is recorded as the answering server when queries go
+// through the platform resolver instead of a nameserver
+const systemResolverName = "system"
+
+// dnsQueryOptions sets optional header bits and EDNS0 on a query
+type dnsQueryOptions struct {
+	DNSSECOK         bool // Set the EDNS0 DO bit so RRSIGs are returned, and request AD
+	CheckingDisabled bool // Set CD so a validating resolver skips validation
+}
+
+// dnsTypeNames maps the record type names DNS enumeration supports to their
+// codes
+var dnsTypeNames = map[string]uint16{
+	"A":      dns.TypeA,
+	"NS":     dns.TypeNS,
+	"CNAME":  dns.TypeCNAME,
+	"SOA":    dns.TypeSOA,
+	"PTR":    dns.TypePTR,
+	"MX":     dns.TypeMX,
+	"TXT":    dns.TypeTXT,
+	"AAAA":   dns.TypeAAAA,
+	"SRV":    dns.TypeSRV,
+	"CAA":    dns.TypeCAA,
+	"DS":     dns.TypeDS,
+	"RRSIG":  dns.TypeRRSIG,
+	"DNSKEY": dns.TypeDNSKEY,
+}
+
+// dnsAnswer is a resource record from the answer section
+type dnsAnswer struct {
+	Name   string
+	Type   uint16
+	Class  uint16
+	TTL    uint32
+	Data   string // Presentation format of the record data
+	Server string // Nameserver that answered
+}
+
+// dnsMessage is the part of a DNS response the checks use
+type dnsMessage struct {
+	Rcode             int
+	AuthenticatedData bool // AD: the resolver validated the answer with DNSSEC
+	Server            string
+	Answers           []dnsAnswer
+}
+
+// exchangeDNS sends a query to server over UDP, retrying over TCP when the
+// answer is truncated, or over HTTPS when server is a DoH endpoint. An empty
+// server tries each nameserver in /etc/resolv.conf in turn, or the platform
+// resolver when none is listed there.
+func exchangeDNS(ctx context.Context, server, name string, qtype uint16) (*dnsMessage, error) {
+	return exchangeDNSWithOptions(ctx, server, name, qtype, dnsQueryOptions{})
+}
+
+// exchangeDNSWithOptions is exchangeDNS with EDNS0 and header options
+func exchangeDNSWithOptions(ctx context.Context, server, name string, qtype uint16, options dnsQueryOptions) (*dnsMessage, error) {
+	servers := []string{server}
+	if server == "" {
+		servers = systemNameservers()
+		if len(servers) == 0 {
+			return exchangeSystemResolver(ctx, name, qtype)
+		}
+	}
+
+	fqdn := dns.Fqdn(name)
+	if _, ok := dns.IsDomainName(fqdn); !ok {
+		return nil, fmt.Errorf("invalid DNS name: %s", name)
+	}
+	query := new(dns.Msg)
+	query.SetQuestion(fqdn, qtype)
+	if options.DNSSECOK {
+		query.SetEdns0(4096, true)
+		query.AuthenticatedData = true
+	}
+	query.CheckingDisabled = options.CheckingDisabled
+
+	// Move on to the next nameserver only when one can't be reached; an
+	// answer, even SERVFAIL, is the server's verdict
+	var lastErr error
+	for _, server := range servers {
+		resp, err := exchangeDNSMsg(ctx, server, query)
+		if err == nil {
+			return newDNSMessage(resp, server), nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// exchangeDNSMsg sends query to a single server
+func exchangeDNSMsg(ctx context.Context, server string, query *dns.Msg) (*dns.Msg, error) {
+	if isDoHServer(server) {
+		packed, err := query.Pack()
+		if err != nil {
+			return nil, err
+		}
+		answer, err := exchangeDoH(ctx, nil, server, packed)
+		if err != nil {
+			return nil, err
+		}
+		resp := new(dns.Msg)
+		if err := resp.Unpack(answer); err != nil {
+			return nil, err
+		}
+		if resp.Id != query.Id {
+			return nil, fmt.Errorf("DNS response ID mismatch from %s", server)
+		}
+		return resp, nil
+	}
+
+	client := &dns.Client{Timeout: 5 * time.Second}
+	resp, _, err := client.ExchangeContext(ctx, query, server)
+	if err == nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.ExchangeContext(ctx, query, server)
+	}
+	return resp, err
+}
+
+// newDNSMessage keeps the rcode, AD bit, and answers of a response
+func newDNSMessage(resp *dns.Msg, server string) *dnsMessage {
+	message := &dnsMessage{
+		Rcode:             resp.Rcode,
+		AuthenticatedData: resp.AuthenticatedData,
+		Server:            server,
+	}
+	for _, rr := range resp.Answer {
+		header := rr.Header()
+		message.Answers = append(message.Answers, dnsAnswer{
+			Name:   strings.TrimSuffix(header.Name, "."),
+			Type:   header.Rrtype,
+			Class:  header.Class,
+			TTL:    header.Ttl,
+			Data:   rrData(rr),
+			Server: server,
+		})
+	}
+	return message
+}
+
+// rrData renders record data in presentation format, with names written
+// without the trailing dot
+func rrData(rr dns.RR) string {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String()
+	case *dns.AAAA:
+		return rr.AAAA.String()
+	case *dns.NS:
+		return strings.TrimSuffix(rr.Ns, ".")
+	case *dns.CNAME:
+		return strings.TrimSuffix(rr.Target, ".")
+	case *dns.PTR:
+		return strings.TrimSuffix(rr.Ptr, ".")
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", rr.Preference, strings.TrimSuffix(rr.Mx, "."))
+	case *dns.TXT:
+		return strings.Join(rr.Txt, "")
+	case *dns.SOA:
+		return fmt.Sprintf("%s %s %d %d %d %d %d", strings.TrimSuffix(rr.Ns, "."), strings.TrimSuffix(rr.Mbox, "."),
+			rr.Serial, rr.Refresh, rr.Retry, rr.Expire, rr.Minttl)
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, strings.TrimSuffix(rr.Target, "."))
+	case *dns.CAA:
+		return fmt.Sprintf("%d %s %q", rr.Flag, rr.Tag, rr.Value)
+	default:
+		return strings.TrimPrefix(rr.String(), rr.Header().String())
+	}
+}
+
+// systemNameservers returns the nameservers in /etc/resolv.conf, in order
+func systemNameservers() []string {
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil
+	}
+
+	servers := make([]string, 0, len(conf.Servers))
+	for _, server := range conf.Servers {
+		servers = append(servers, net.JoinHostPort(server, conf.Port))
+	}
+	return servers
+}
+
+// exchangeSystemResolver answers a query through net.Resolver, for systems
+// such as Windows where no nameserver can be read from /etc/resolv.conf.
+// The platform resolver hides TTLs and DNSSEC data, can't tell NXDOMAIN from
+// an empty answer, and only supports the record types net.Resolver exposes.
+func exchangeSystemResolver(ctx context.Context, name string, qtype uint16) (*dnsMessage, error) {
+	name = strings.TrimSuffix(name, ".")
+	resp := &dnsMessage{Server: systemResolverName}
+	answer := func(data string) {
+		resp.Answers = append(resp.Answers, dnsAnswer{
+			Name:   name,
+			Type:   qtype,
+			Class:  dns.ClassINET,
+			Data:   strings.TrimSuffix(data, "."),
+			Server: systemResolverName,
+		})
+	}
+
+	resolver := net.DefaultResolver
+	var err error
+	switch qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		network := "ip4"
+		if qtype == dns.TypeAAAA {
+			network = "ip6"
+		}
+		var ips []net.IP
+		ips, err = resolver.LookupIP(ctx, network, name)
+		for _, ip := range ips {
+			answer(ip.String())
+		}
+
+	case dns.TypeCNAME:
+		var cname string
+		cname, err = resolver.LookupCNAME(ctx, name)
+		if err == nil && !strings.EqualFold(strings.TrimSuffix(cname, "."), name) {
+			answer(cname)
+		}
+
+	case dns.TypeMX:
+		var records []*net.MX
+		records, err = resolver.LookupMX(ctx, name)
+		for _, mx := range records {
+			answer(fmt.Sprintf("%d %s", mx.Pref, strings.TrimSuffix(mx.Host, ".")))
+		}
+
+	case dns.TypeNS:
+		var records []*net.NS
+		records, err = resolver.LookupNS(ctx, name)
+		for _, ns := range records {
+			answer(ns.Host)
+		}
+
+	case dns.TypeTXT:
+		var records []string
+		records, err = resolver.LookupTXT(ctx, name)
+		for _, txt := range records {
+			answer(txt)
+		}
+
+	case dns.TypeSRV:
+		var records []*net.SRV
+		_, records, err = resolver.LookupSRV(ctx, "", "", name)
+		for _, srv := range records {
+			answer(fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, strings.TrimSuffix(srv.Target, ".")))
+		}
+
+	case dns.TypePTR:
+		ip := ipFromReverseName(name)
+		if ip == "" {
+			return nil, fmt.Errorf("invalid reverse DNS name: %s", name)
+		}
+		var names []string
+		names, err = resolver.LookupAddr(ctx, ip)
+		for _, host := range names {
+			answer(host)
+		}
+
+	default:
+		return nil, fmt.Errorf("%s queries need a nameserver in /etc/resolv.conf or --resolvers", dns.TypeToString[qtype])
+	}
+
+	var dnsErr *net.DNSError
+	if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ipFromReverseName parses an in-addr.arpa or ip6.arpa name back into the
+// address it was made from, or returns "" when name isn't a reverse name
+func ipFromReverseName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	if rest, ok := strings.CutSuffix(name, ".in-addr.arpa"); ok {
+		octets := strings.Split(rest, ".")
+		slices.Reverse(octets)
+		if ip := net.ParseIP(strings.Join(octets, ".")); ip != nil && ip.To4() != nil {
+			return ip.String()
+		}
+		return ""
+	}
+
+	if rest, ok := strings.CutSuffix(name, ".ip6.arpa"); ok {
+		nibbles := strings.Split(rest, ".")
+		if len(nibbles) != 32 {
+			return ""
+		}
+		slices.Reverse(nibbles)
+		var b strings.Builder
+		for i, nibble := range nibbles {
+			if i > 0 && i%4 == 0 {
+				b.WriteByte(':')
+			}
+			b.WriteString(nibble)
+		}
+		if ip := net.ParseIP(b.String()); ip != nil {
+			return ip.String()
+		}
+	}
+	return ""
+}
+
+// lookupRecords queries name for qtype and returns the matching answers,
+// skipping CNAME records the resolver followed along the way. NXDOMAIN and
+// empty answers return no records and no error.
+func lookupRecords(ctx context.Context, server, name string, qtype uint16) ([]dnsAnswer, error) {
+	resp, err := exchangeDNS(ctx, server, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	return answersOfType(resp, name, qtype)
+}
+
+// answersOfType extracts the qtype answers from a response to a query for
+// name, treating any rcode other than success or NXDOMAIN as an error
+func answersOfType(resp *dnsMessage, name string, qtype uint16) ([]dnsAnswer, error) {
+	switch resp.Rcode {
+	case dns.RcodeSuccess, dns.RcodeNameError:
+	default:
+		return nil, fmt.Errorf("DNS server returned %s for %s", dns.RcodeToString[resp.Rcode], name)
+	}
+
+	var answers []dnsAnswer
+	for _, answer := range resp.Answers {
+		if answer.Type == qtype {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
@@ -0,0 +1,140 @@
+package recon
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// DNSSEC validation states
+const (
+	DNSSECSecure   = "secure"   // Signed and validated by the resolver (AD bit)
+	DNSSECSigned   = "signed"   // Signed, but the resolver did not validate it
+	DNSSECInsecure = "insecure" // Zone is not signed
+	DNSSECBogus    = "bogus"    // Signed, but validation fails
+)
+
+// DNSSECInfo describes the DNSSEC state of a subdomain's zone
+type DNSSECInfo struct {
+	Status    string `json:"status"`         // secure, signed, insecure, or bogus
+	Zone      string `json:"zone,omitempty"` // Closest enclosing zone publishing DNSKEYs
+	HasDNSKEY bool   `json:"has_dnskey"`     // Zone publishes DNSKEY records
+	HasDS     bool   `json:"has_ds"`         // Parent zone publishes a DS record (chain of trust)
+	HasRRSIG  bool   `json:"has_rrsig"`      // The subdomain's answer is signed
+	Validated bool   `json:"validated"`      // Resolver set the AD bit
+}
+
+// dnssecZone caches the key material of one zone
+type dnssecZone struct {
+	name      string
+	hasDNSKEY bool
+	hasDS     bool
+}
+
+// dnssecZoneCache avoids repeating DNSKEY and DS lookups for subdomains that
+// share a zone
+type dnssecZoneCache struct {
+	mu    sync.Mutex
+	zones map[string]*dnssecZone
+}
+
+func newDNSSECZoneCache() *dnssecZoneCache {
+	return &dnssecZoneCache{zones: make(map[string]*dnssecZone)}
+}
+
+// checkDNSSEC determines whether the zone containing subdomain is signed and
+// whether the resolver validates it. Validation results depend on the
+// resolver: only validating resolvers (e.g., 1.1.1.1, 8.8.8.8) set AD.
+func checkDNSSEC(ctx context.Context, server, subdomain string, cache *dnssecZoneCache) *DNSSECInfo {
+	info := &DNSSECInfo{Status: DNSSECInsecure}
+
+	zone := cache.lookup(ctx, server, subdomain)
+	if zone != nil {
+		info.Zone = zone.name
+		info.HasDNSKEY = zone.hasDNSKEY
+		info.HasDS = zone.hasDS
+	}
+
+	resp, err := exchangeDNSWithOptions(ctx, server, subdomain, dns.TypeA, dnsQueryOptions{DNSSECOK: true})
+	if err != nil {
+		if zone != nil {
+			info.Status = DNSSECSigned
+		}
+		return info
+	}
+
+	if resp.Rcode == dns.RcodeServerFailure {
+		// A validating resolver answers SERVFAIL for bogus data but succeeds
+		// once validation is disabled
+		cdResp, err := exchangeDNSWithOptions(ctx, server, subdomain, dns.TypeA,
+			dnsQueryOptions{DNSSECOK: true, CheckingDisabled: true})
+		if err == nil && cdResp.Rcode != dns.RcodeServerFailure {
+			info.Status = DNSSECBogus
+			return info
+		}
+	}
+
+	for _, answer := range resp.Answers {
+		if answer.Type == dns.TypeRRSIG {
+			info.HasRRSIG = true
+		}
+	}
+	info.Validated = resp.AuthenticatedData
+
+	switch {
+	case info.Validated:
+		info.Status = DNSSECSecure
+	case info.HasDNSKEY || info.HasRRSIG:
+		info.Status = DNSSECSigned
+	}
+
+	return info
+}
+
+// lookup finds the closest enclosing zone of name that publishes DNSKEY
+// records, walking up to the registrable domain. It returns nil for
+// unsigned zones.
+func (c *dnssecZoneCache) lookup(ctx context.Context, server, name string) *dnssecZone {
+	apex := RegistrableDomain(name)
+	candidate := strings.ToLower(strings.TrimSuffix(name, "."))
+
+	for {
+		c.mu.Lock()
+		zone, cached := c.zones[candidate]
+		c.mu.Unlock()
+		if cached {
+			if zone != nil {
+				return zone
+			}
+		} else {
+			zone = queryDNSSECZone(ctx, server, candidate)
+			c.mu.Lock()
+			c.zones[candidate] = zone
+			c.mu.Unlock()
+			if zone != nil {
+				return zone
+			}
+		}
+
+		if candidate == apex || !strings.Contains(candidate, ".") {
+			return nil
+		}
+		candidate = candidate[strings.Index(candidate, ".")+1:]
+	}
+}
+
+// queryDNSSECZone checks whether name is a signed zone apex
+func queryDNSSECZone(ctx context.Context, server, name string) *dnssecZone {
+	keys, err := lookupRecords(ctx, server, name, dns.TypeDNSKEY)
+	if err != nil || len(keys) == 0 {
+		return nil
+	}
+
+	zone := &dnssecZone{name: name, hasDNSKEY: true}
+	if ds, err := lookupRecords(ctx, server, name, dns.TypeDS); err == nil && len(ds) > 0 {
+		zone.hasDS = true
+	}
+	return zone
+}
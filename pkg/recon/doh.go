@@ -0,0 +1,139 @@
+package recon
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dohClients holds the HTTP client for each DoH endpoint registered by
+// NewDoHResolverPool, so direct queries reuse its proxy settings
+var dohClients sync.Map
+
+// NewDoHResolverPool creates a pool that resolves every query through a
+// DNS-over-HTTPS endpoint (RFC 8484), e.g. https://cloudflare-dns.com/dns-query.
+// HTTP requests are routed through proxyURL when set.
+func NewDoHResolverPool(endpoint string, proxyURL *url.URL) (*ResolverPool, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid DoH endpoint: %s (expected https://host/path)", endpoint)
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy:               proxyFunc(proxyURL),
+			MaxIdleConnsPerHost: 10,
+		},
+	}
+	dohClients.Store(endpoint, client)
+
+	return &ResolverPool{
+		servers:   []string{endpoint},
+		stats:     map[string]*ResolverStats{endpoint: {Server: endpoint}},
+		dohClient: client,
+	}, nil
+}
+
+// isDoHServer reports whether a resolver address is a DoH endpoint
+func isDoHServer(server string) bool {
+	return strings.HasPrefix(server, "https://")
+}
+
+// exchangeDoH posts a wire-format DNS query to a DoH endpoint and returns the
+// wire-format response
+func exchangeDoH(ctx context.Context, client *http.Client, endpoint string, query []byte) ([]byte, error) {
+	if client == nil {
+		if stored, ok := dohClients.Load(endpoint); ok {
+			client = stored.(*http.Client)
+		} else {
+			client = &http.Client{Timeout: 10 * time.Second}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("DoH query failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH query failed: HTTP %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 65535))
+}
+
+// dohConn adapts DoH to the stream (TCP-style, length-prefixed) connection
+// net.Resolver expects from a custom Dial, so the standard resolver API can
+// be used over DoH
+type dohConn struct {
+	ctx      context.Context
+	client   *http.Client
+	endpoint string
+	request  bytes.Buffer
+	response bytes.Buffer
+	deadline time.Time
+}
+
+func (c *dohConn) Write(b []byte) (int, error) {
+	return c.request.Write(b)
+}
+
+func (c *dohConn) Read(b []byte) (int, error) {
+	if c.response.Len() == 0 {
+		framed := c.request.Bytes()
+		if len(framed) < 2 {
+			return 0, io.EOF
+		}
+		length := int(binary.BigEndian.Uint16(framed))
+		if len(framed) < 2+length {
+			return 0, io.ErrUnexpectedEOF
+		}
+		query := framed[2 : 2+length]
+		c.request.Next(2 + length)
+
+		ctx := c.ctx
+		if !c.deadline.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, c.deadline)
+			defer cancel()
+		}
+
+		answer, err := exchangeDoH(ctx, c.client, c.endpoint, query)
+		if err != nil {
+			return 0, err
+		}
+		c.response.Write(binary.BigEndian.AppendUint16(nil, uint16(len(answer))))
+		c.response.Write(answer)
+	}
+	return c.response.Read(b)
+}
+
+func (c *dohConn) Close() error                       { return nil }
+func (c *dohConn) LocalAddr() net.Addr                { return dohAddr(c.endpoint) }
+func (c *dohConn) RemoteAddr() net.Addr               { return dohAddr(c.endpoint) }
+func (c *dohConn) SetDeadline(t time.Time) error      { c.deadline = t; return nil }
+func (c *dohConn) SetReadDeadline(t time.Time) error  { c.deadline = t; return nil }
+func (c *dohConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// dohAddr identifies a DoH endpoint as a net.Addr
+type dohAddr string
+
+func (a dohAddr) Network() string { return "https" }
+func (a dohAddr) String() string  { return string(a) }
@@ -0,0 +1,283 @@
+package recon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// spfLookupLimit is the RFC 7208 limit on DNS-querying SPF terms
+const spfLookupLimit = 10
+
+// dkimSelectors are common DKIM selectors probed under _domainkey, since
+// selectors cannot be enumerated
+var dkimSelectors = []string{
+	"default", "google", "selector1", "selector2", "k1", "k2", "k3", "s1", "s2",
+	"mail", "dkim", "smtp", "mx", "mandrill", "everlytickey1", "mxvault", "zoho",
+	"protonmail", "protonmail2", "protonmail3", "fm1", "fm2", "fm3", "pm", "sig1",
+}
+
+// EmailSecurity summarizes the SPF, DMARC, and DKIM posture of a domain
+type EmailSecurity struct {
+	Domain string     `json:"domain"`
+	SPF    *SPFInfo   `json:"spf,omitempty"`
+	DMARC  *DMARCInfo `json:"dmarc,omitempty"`
+	DKIM   []string   `json:"dkim_selectors,omitempty"` // Selectors with a published key
+	Score  int        `json:"score"`                    // 0-100
+	Grade  string     `json:"grade"`                    // A-F
+	Issues []string   `json:"issues,omitempty"`
+}
+
+// SPFInfo is a parsed SPF record
+type SPFInfo struct {
+	Record     string   `json:"record"`
+	Mechanisms []string `json:"mechanisms"`
+	All        string   `json:"all,omitempty"`      // Qualified "all" term, e.g. -all or ~all
+	Redirect   string   `json:"redirect,omitempty"` // redirect= modifier target
+	Lookups    int      `json:"lookups"`            // DNS lookups needed, including nested includes
+}
+
+// DMARCInfo is a parsed DMARC record
+type DMARCInfo struct {
+	Record          string   `json:"record"`
+	Policy          string   `json:"policy"`                     // none, quarantine, or reject
+	SubdomainPolicy string   `json:"subdomain_policy,omitempty"` // sp= tag
+	Percent         int      `json:"percent"`                    // pct= tag (default 100)
+	ReportURIs      []string `json:"report_uris,omitempty"`      // rua= tag
+	Inherited       bool     `json:"inherited,omitempty"`        // Taken from the organizational domain
+}
+
+// CheckEmailSecurity looks up and grades the SPF, DMARC, and DKIM records of
+// a domain
+func CheckEmailSecurity(ctx context.Context, server, domain string) EmailSecurity {
+	result := EmailSecurity{Domain: domain}
+
+	// SPF
+	txt := lookupTXT(ctx, server, domain)
+	if record := findTXTRecord(txt, "v=spf1"); record != "" {
+		result.SPF = ParseSPF(record)
+		result.SPF.Lookups = countSPFLookups(ctx, server, result.SPF, 0, map[string]bool{domain: true})
+	}
+
+	// DMARC, falling back to the organizational domain's policy
+	if record := findTXTRecord(lookupTXT(ctx, server, "_dmarc."+domain), "v=dmarc1"); record != "" {
+		result.DMARC = ParseDMARC(record)
+	} else if apex := RegistrableDomain(domain); apex != domain {
+		if record := findTXTRecord(lookupTXT(ctx, server, "_dmarc."+apex), "v=dmarc1"); record != "" {
+			result.DMARC = ParseDMARC(record)
+			result.DMARC.Inherited = true
+			if result.DMARC.SubdomainPolicy != "" {
+				result.DMARC.Policy = result.DMARC.SubdomainPolicy
+			}
+		}
+	}
+
+	// DKIM
+	for _, selector := range dkimSelectors {
+		records := lookupTXT(ctx, server, selector+"._domainkey."+domain)
+		for _, record := range records {
+			if strings.Contains(record, "p=") {
+				result.DKIM = append(result.DKIM, selector)
+				break
+			}
+		}
+	}
+
+	gradeEmailSecurity(&result)
+	return result
+}
+
+// ParseSPF parses an SPF record into its terms
+func ParseSPF(record string) *SPFInfo {
+	spf := &SPFInfo{Record: record, Mechanisms: []string{}}
+
+	for _, term := range strings.Fields(record)[1:] {
+		lower := strings.ToLower(term)
+		switch {
+		case strings.HasPrefix(lower, "redirect="):
+			spf.Redirect = term[len("redirect="):]
+		case strings.TrimLeft(lower, "+-~?") == "all":
+			spf.All = term
+			if !strings.ContainsAny(term[:1], "+-~?") {
+				spf.All = "+" + term
+			}
+		case strings.Contains(lower, "="):
+			// Other modifiers (exp=) do not affect evaluation
+		default:
+			spf.Mechanisms = append(spf.Mechanisms, term)
+		}
+	}
+
+	return spf
+}
+
+// ParseDMARC parses a DMARC record's tags
+func ParseDMARC(record string) *DMARCInfo {
+	dmarc := &DMARCInfo{Record: record, Percent: 100}
+
+	for _, tag := range strings.Split(record, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(tag), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "p":
+			dmarc.Policy = strings.ToLower(value)
+		case "sp":
+			dmarc.SubdomainPolicy = strings.ToLower(value)
+		case "pct":
+			if pct, err := strconv.Atoi(value); err == nil {
+				dmarc.Percent = pct
+			}
+		case "rua":
+			for _, uri := range strings.Split(value, ",") {
+				dmarc.ReportURIs = append(dmarc.ReportURIs, strings.TrimSpace(uri))
+			}
+		}
+	}
+
+	return dmarc
+}
+
+// countSPFLookups counts the DNS lookups an SPF evaluation needs, following
+// include and redirect targets. Counting stops once the limit is exceeded.
+func countSPFLookups(ctx context.Context, server string, spf *SPFInfo, depth int, seen map[string]bool) int {
+	lookups := 0
+
+	follow := func(target string) {
+		lookups++
+		if depth >= spfLookupLimit || seen[target] {
+			return
+		}
+		seen[target] = true
+		if record := findTXTRecord(lookupTXT(ctx, server, target), "v=spf1"); record != "" {
+			lookups += countSPFLookups(ctx, server, ParseSPF(record), depth+1, seen)
+		}
+	}
+
+	for _, mechanism := range spf.Mechanisms {
+		name := strings.ToLower(strings.TrimLeft(mechanism, "+-~?"))
+		switch {
+		case strings.HasPrefix(name, "include:"):
+			follow(mechanism[strings.Index(mechanism, ":")+1:])
+		case name == "a" || name == "mx" || name == "ptr" ||
+			strings.HasPrefix(name, "a:") || strings.HasPrefix(name, "a/") ||
+			strings.HasPrefix(name, "mx:") || strings.HasPrefix(name, "mx/") ||
+			strings.HasPrefix(name, "ptr:") || strings.HasPrefix(name, "exists:"):
+			lookups++
+		}
+		if lookups > spfLookupLimit {
+			return lookups
+		}
+	}
+
+	if spf.Redirect != "" {
+		follow(spf.Redirect)
+	}
+
+	return lookups
+}
+
+// gradeEmailSecurity scores the parsed records and lists the issues found
+func gradeEmailSecurity(result *EmailSecurity) {
+	score := 100
+	issue := func(penalty int, format string, args ...interface{}) {
+		score -= penalty
+		result.Issues = append(result.Issues, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case result.SPF == nil:
+		issue(30, "no SPF record")
+	case result.SPF.All == "+all":
+		issue(30, "SPF allows any sender (+all)")
+	case result.SPF.All == "?all":
+		issue(20, "SPF is neutral (?all)")
+	case result.SPF.All == "~all":
+		issue(5, "SPF soft-fails (~all)")
+	case result.SPF.All == "" && result.SPF.Redirect == "":
+		issue(15, "SPF has no all mechanism")
+	}
+	if result.SPF != nil && result.SPF.Lookups > spfLookupLimit {
+		issue(10, "SPF exceeds %d DNS lookups (%d), causing permerror", spfLookupLimit, result.SPF.Lookups)
+	}
+
+	switch {
+	case result.DMARC == nil:
+		issue(30, "no DMARC record")
+	case result.DMARC.Policy == "none":
+		issue(20, "DMARC policy is none (monitoring only)")
+	case result.DMARC.Policy == "quarantine":
+		issue(5, "DMARC policy is quarantine, not reject")
+	case result.DMARC.Policy != "reject":
+		issue(20, "DMARC policy %q is invalid", result.DMARC.Policy)
+	}
+	if result.DMARC != nil && result.DMARC.Percent < 100 {
+		issue(5, "DMARC applies to only %d%% of mail", result.DMARC.Percent)
+	}
+
+	if len(result.DKIM) == 0 {
+		issue(15, "no DKIM key found for common selectors")
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	result.Score = score
+
+	switch {
+	case score >= 90:
+		result.Grade = "A"
+	case score >= 75:
+		result.Grade = "B"
+	case score >= 60:
+		result.Grade = "C"
+	case score >= 40:
+		result.Grade = "D"
+	default:
+		result.Grade = "F"
+	}
+}
+
+// emailDomains returns the domains worth grading: the target itself and any
+// subdomain that receives mail
+func emailDomains(domain string, records []DNSInfo) []string {
+	domains := []string{domain}
+	for _, record := range records {
+		if len(record.MX) > 0 && !strings.EqualFold(record.Subdomain, domain) {
+			domains = append(domains, record.Subdomain)
+		}
+	}
+	sort.Strings(domains[1:])
+	return domains
+}
+
+// lookupTXT returns the TXT records of name, ignoring errors
+func lookupTXT(ctx context.Context, server, name string) []string {
+	answers, err := lookupRecords(ctx, server, name, dns.TypeTXT)
+	if err != nil {
+		return nil
+	}
+	records := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		records = append(records, answer.Data)
+	}
+	return records
+}
+
+// findTXTRecord returns the first record starting with prefix (case-insensitive)
+func findTXTRecord(records []string, prefix string) string {
+	for _, record := range records {
+		trimmed := strings.TrimSpace(record)
+		if len(trimmed) >= len(prefix) && strings.EqualFold(trimmed[:len(prefix)], prefix) {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package recon
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// whoisDateLayouts are the date formats registries commonly use
+var whoisDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006.01.02 15:04:05",
+	"2006.01.02",
+	"2006/01/02",
+	"02-Jan-2006",
+	"02-January-2006",
+	"02.01.2006",
+	"January 2 2006",
+	"Mon Jan 2 15:04:05 MST 2006",
+	"20060102",
+}
+
+// WhoisExpiry describes a stored WHOIS record and when its domain expires
+type WhoisExpiry struct {
+	Domain      string    `json:"domain"` // Domain that expires
+	Target      string    `json:"target"` // Results directory the record was found in
+	ExpiresAt   time.Time `json:"expires_at"`
+	DaysLeft    int       `json:"days_left"`    // Negative once expired
+	CNAMETarget bool      `json:"cname_target"` // Target subdomains CNAME to this domain
+}
+
+// ParseWhoisDate parses a registry date string, returning nil if the format
+// is not recognized
+func ParseWhoisDate(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	// Some registries append a timezone note, e.g. "2025-08-13 (UTC)"
+	if i := strings.Index(value, " ("); i > 0 {
+		value = value[:i]
+	}
+
+	for _, layout := range whoisDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// ExpiringWhois scans every domain's latest WHOIS and bulk WHOIS results and
+// returns the domains expiring within the given window, soonest first.
+// Records saved before dates were parsed are parsed on the fly.
+func ExpiringWhois(within time.Duration) ([]WhoisExpiry, error) {
+	resultsDir, err := GetResultsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(resultsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []WhoisExpiry{}, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	expiring := []WhoisExpiry{}
+	add := func(target string, info WhoisInfo, cnameTarget bool) {
+		expiresAt := info.ExpiresAt
+		if expiresAt == nil {
+			expiresAt = ParseWhoisDate(info.ExpiryDate)
+		}
+		if expiresAt == nil || expiresAt.Sub(now) > within {
+			return
+		}
+		expiring = append(expiring, WhoisExpiry{
+			Domain:      info.Domain,
+			Target:      target,
+			ExpiresAt:   *expiresAt,
+			DaysLeft:    int(expiresAt.Sub(now).Hours() / 24),
+			CNAMETarget: cnameTarget,
+		})
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		target := entry.Name()
+
+		var whois WhoisResults
+		if err := LoadLatestResult(target, "whois", &whois); err == nil && !IsIPQuery(whois.Info.Domain) {
+			add(target, whois.Info, false)
+		}
+
+		var bulk BulkWhoisResults
+		if err := LoadLatestResult(target, "whoisbulk", &bulk); err == nil {
+			cnameTargets := make(map[string]bool)
+			for _, domain := range bulk.CNAMETargets {
+				cnameTargets[domain] = true
+			}
+			for _, info := range bulk.Domains {
+				if info.Domain == whois.Info.Domain {
+					continue // Already reported from the direct lookup
+				}
+				add(target, info, cnameTargets[info.Domain])
+			}
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresAt.Before(expiring[j].ExpiresAt)
+	})
+
+	return expiring, nil
+}
@@ -0,0 +1,121 @@
+package recon
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"net/http"
+	"strings"
+)
+
+// knownFavicons maps Shodan-style favicon hashes to the product they identify
+var knownFavicons = map[int32]string{
+	116323821:   "Spring Boot",
+	81586312:    "Jenkins",
+	-297069493:  "Apache Tomcat",
+	516963061:   "GitLab",
+	1485257654:  "SonarQube",
+	999357577:   "Hikvision",
+	-1293291467: "Atlassian Confluence",
+	855273746:   "Atlassian JIRA",
+	-1616143106: "AVTECH IP Camera",
+	708578229:   "Google",
+}
+
+// FaviconHash computes the Shodan-compatible favicon hash: MurmurHash3
+// (32-bit, seed 0) of the base64 encoding with a newline every 76 characters
+func FaviconHash(data []byte) int32 {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for i := 0; i < len(encoded); i += 76 {
+		end := i + 76
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		b.WriteString(encoded[i:end])
+		b.WriteByte('\n')
+	}
+
+	return int32(murmur3([]byte(b.String()), 0))
+}
+
+// LookupFaviconProduct returns the product associated with a favicon hash
+func LookupFaviconProduct(hash int32) string {
+	return knownFavicons[hash]
+}
+
+// fetchFavicon downloads /favicon.ico relative to baseURL and returns its hash
+func fetchFavicon(client *http.Client, baseURL string, options VerifyOptions) (int32, bool) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(baseURL, "/")+"/favicon.ico", nil)
+	if err != nil {
+		return 0, false
+	}
+	setRequestHeaders(req, options)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024)) // Read max 512KB
+	if err != nil || len(data) == 0 {
+		return 0, false
+	}
+
+	return FaviconHash(data), true
+}
+
+// murmur3 implements the 32-bit MurmurHash3 (x86) algorithm
+func murmur3(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	h := seed
+	nblocks := len(data) / 4
+
+	for i := 0; i < nblocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	tail := data[nblocks*4:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+
+	return h
+}
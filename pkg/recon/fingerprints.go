@@ -0,0 +1,171 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// takeoverFingerprintsURL is the can-i-take-over-xyz fingerprint dataset
+const takeoverFingerprintsURL = "https://raw.githubusercontent.com/EdOverflow/can-i-take-over-xyz/master/fingerprints.json"
+
+// Takeover vulnerability statuses used by can-i-take-over-xyz
+const (
+	TakeoverVulnerable    = "Vulnerable"
+	TakeoverNotVulnerable = "Not vulnerable"
+	TakeoverEdgeCase      = "Edge case"
+)
+
+// TakeoverFingerprint describes how to recognise an unclaimed resource on a
+// third-party service. The JSON layout matches can-i-take-over-xyz.
+type TakeoverFingerprint struct {
+	Service     string   `json:"service"`
+	CNAME       []string `json:"cname"`                 // Substrings of CNAME targets on the service
+	Fingerprint string   `json:"fingerprint,omitempty"` // Response body text of an unclaimed resource
+	NXDomain    bool     `json:"nxdomain"`              // Unclaimed resources leave the CNAME target NXDOMAIN
+	Status      string   `json:"status"`                // Vulnerable, Not vulnerable, or Edge case
+	Vulnerable  bool     `json:"vulnerable"`
+	Discussion  string   `json:"discussion,omitempty"` // Link to the upstream discussion
+}
+
+// defaultTakeoverFingerprints seed the fingerprint file on first use
+var defaultTakeoverFingerprints = []TakeoverFingerprint{
+	{Service: "Heroku", CNAME: []string{"herokuapp.com", "herokudns.com"}, Fingerprint: "No such app", Status: TakeoverEdgeCase},
+	{Service: "GitHub Pages", CNAME: []string{"github.io"}, Fingerprint: "There isn't a GitHub Pages site here", Status: TakeoverEdgeCase},
+	{Service: "Microsoft Azure", CNAME: []string{"azurewebsites.net", "cloudapp.net", "cloudapp.azure.com", "trafficmanager.net", "blob.core.windows.net", "azureedge.net"}, NXDomain: true, Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Amazon CloudFront", CNAME: []string{"cloudfront.net"}, Fingerprint: "ERROR: The request could not be satisfied", Status: TakeoverNotVulnerable},
+	{Service: "AWS/S3", CNAME: []string{"s3.amazonaws.com", "s3-website"}, Fingerprint: "The specified bucket does not exist", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Bitbucket", CNAME: []string{"bitbucket.io"}, Fingerprint: "Repository not found", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Ghost", CNAME: []string{"ghost.io"}, Fingerprint: "The thing you were looking for is no longer here", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Pantheon", CNAME: []string{"pantheonsite.io"}, Fingerprint: "404 error unknown site", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Zendesk", CNAME: []string{"zendesk.com"}, Fingerprint: "Help Center Closed", Status: TakeoverNotVulnerable},
+	{Service: "UserVoice", CNAME: []string{"uservoice.com"}, Fingerprint: "This UserVoice subdomain is currently available", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Surge.sh", CNAME: []string{"surge.sh"}, Fingerprint: "project not found", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Tumblr", CNAME: []string{"tumblr.com"}, Fingerprint: "Whatever you were looking for doesn't currently exist", Status: TakeoverEdgeCase},
+	{Service: "WordPress", CNAME: []string{"wordpress.com"}, Fingerprint: "Do you want to register", Status: TakeoverVulnerable, Vulnerable: true},
+	{Service: "Statuspage", CNAME: []string{"statuspage.io"}, Fingerprint: "You are being redirected", Status: TakeoverEdgeCase},
+	{Service: "HubSpot", CNAME: []string{"hubspot.net"}, Status: TakeoverNotVulnerable},
+}
+
+// GetFingerprintsPath returns the path to the takeover fingerprint file
+func GetFingerprintsPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "fingerprints", "takeover.json"), nil
+}
+
+// LoadTakeoverFingerprints reads ~/.recon-cli/fingerprints/takeover.json,
+// writing the built-in defaults there first if it does not exist
+func LoadTakeoverFingerprints() ([]TakeoverFingerprint, error) {
+	path, err := GetFingerprintsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if err := saveTakeoverFingerprints(path, defaultTakeoverFingerprints); err != nil {
+			return nil, err
+		}
+		return defaultTakeoverFingerprints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fingerprints: %w", err)
+	}
+
+	fingerprints, err := parseTakeoverFingerprints(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fingerprints in %s: %w", path, err)
+	}
+	return fingerprints, nil
+}
+
+// UpdateTakeoverFingerprints downloads the can-i-take-over-xyz dataset and
+// replaces the local fingerprint file. It returns the fingerprints saved.
+func UpdateTakeoverFingerprints(proxy *url.URL) ([]TakeoverFingerprint, error) {
+	data, err := getBody(newSourceClient(proxy), takeoverFingerprintsURL, "can-i-take-over-xyz")
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints, err := parseTakeoverFingerprints(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fingerprint dataset: %w", err)
+	}
+	if len(fingerprints) == 0 {
+		return nil, fmt.Errorf("fingerprint dataset is empty")
+	}
+
+	path, err := GetFingerprintsPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveTakeoverFingerprints(path, fingerprints); err != nil {
+		return nil, err
+	}
+	return fingerprints, nil
+}
+
+// parseTakeoverFingerprints decodes fingerprints, dropping entries without
+// CNAME patterns since they cannot be matched against DNS records
+func parseTakeoverFingerprints(data []byte) ([]TakeoverFingerprint, error) {
+	var entries []TakeoverFingerprint
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]TakeoverFingerprint, 0, len(entries))
+	for _, entry := range entries {
+		var cnames []string
+		for _, cname := range entry.CNAME {
+			cname = strings.ToLower(strings.Trim(strings.TrimSpace(cname), "."))
+			if cname != "" {
+				cnames = append(cnames, cname)
+			}
+		}
+		if len(cnames) == 0 {
+			continue
+		}
+		entry.CNAME = cnames
+		fingerprints = append(fingerprints, entry)
+	}
+	return fingerprints, nil
+}
+
+// saveTakeoverFingerprints writes fingerprints with owner-only permissions
+func saveTakeoverFingerprints(path string, fingerprints []TakeoverFingerprint) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create fingerprints directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(fingerprints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fingerprints: %w", err)
+	}
+
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write fingerprints: %w", err)
+	}
+	return nil
+}
+
+// matchTakeoverFingerprint returns the first fingerprint with a CNAME
+// pattern contained in target, or nil
+func matchTakeoverFingerprint(fingerprints []TakeoverFingerprint, target string) *TakeoverFingerprint {
+	target = strings.ToLower(strings.TrimSuffix(target, "."))
+	for i, fingerprint := range fingerprints {
+		for _, cname := range fingerprint.CNAME {
+			if strings.Contains(target, cname) {
+				return &fingerprints[i]
+			}
+		}
+	}
+	return nil
+}
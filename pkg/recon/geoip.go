@@ -0,0 +1,203 @@
+package recon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ipAPIBatchSize is the maximum number of IPs ip-api accepts per batch request
+const ipAPIBatchSize = 100
+
+// Hosting types assigned during IP enrichment
+const (
+	HostingTypeHosting = "hosting" // Datacenter, cloud, or CDN
+	HostingTypeMobile  = "mobile"
+	HostingTypeProxy   = "proxy" // Known proxy, VPN, or Tor exit
+	HostingTypeISP     = "isp"   // Residential or business connection
+)
+
+// Group-by keys supported by GroupSubdomainsByIP
+const (
+	GroupByCountry  = "country"
+	GroupByProvider = "provider"
+)
+
+// IPInfo contains geolocation and network ownership for an IP
+type IPInfo struct {
+	IP          string `json:"ip"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	ASN         string `json:"asn,omitempty"`
+	ASNOrg      string `json:"asn_org,omitempty"`
+	HostingType string `json:"hosting_type,omitempty"`
+}
+
+// EnrichIPs looks up country, ASN, and hosting type for each IP using the
+// ip-api.com batch endpoint. The free tier only supports plain HTTP and 15
+// batch requests per minute, so requests wait when the limit is reached.
+func EnrichIPs(ips []string, proxy *url.URL) (map[string]IPInfo, error) {
+	client := newSourceClient(proxy)
+	results := make(map[string]IPInfo)
+
+	for start := 0; start < len(ips); start += ipAPIBatchSize {
+		end := start + ipAPIBatchSize
+		if end > len(ips) {
+			end = len(ips)
+		}
+
+		payload, err := json.Marshal(ips[start:end])
+		if err != nil {
+			return results, err
+		}
+
+		resp, err := client.Post("http://ip-api.com/batch?fields=status,message,query,country,countryCode,as,asname,hosting,mobile,proxy",
+			"application/json", bytes.NewReader(payload))
+		if err != nil {
+			return results, fmt.Errorf("ip-api query failed: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return results, fmt.Errorf("failed to read ip-api response: %w", err)
+		}
+		if resp.StatusCode != 200 {
+			return results, fmt.Errorf("ip-api query failed: HTTP %d", resp.StatusCode)
+		}
+
+		var entries []struct {
+			Status      string `json:"status"`
+			Query       string `json:"query"`
+			Country     string `json:"country"`
+			CountryCode string `json:"countryCode"`
+			AS          string `json:"as"`
+			ASName      string `json:"asname"`
+			Hosting     bool   `json:"hosting"`
+			Mobile      bool   `json:"mobile"`
+			Proxy       bool   `json:"proxy"`
+		}
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return results, fmt.Errorf("failed to parse ip-api response: %w", err)
+		}
+
+		for _, entry := range entries {
+			if entry.Status != "success" {
+				continue
+			}
+			results[entry.Query] = IPInfo{
+				IP:          entry.Query,
+				Country:     entry.Country,
+				CountryCode: entry.CountryCode,
+				ASN:         parseASN(entry.AS),
+				ASNOrg:      parseASNOrg(entry.AS, entry.ASName),
+				HostingType: hostingType(entry.Hosting, entry.Mobile, entry.Proxy),
+			}
+		}
+
+		// X-Rl is the number of requests left in the current window and
+		// X-Ttl the seconds until it resets
+		if end < len(ips) && resp.Header.Get("X-Rl") == "0" {
+			if ttl, err := strconv.Atoi(resp.Header.Get("X-Ttl")); err == nil {
+				time.Sleep(time.Duration(ttl+1) * time.Second)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// parseASN extracts "AS13335" from "AS13335 Cloudflare, Inc."
+func parseASN(as string) string {
+	if fields := strings.Fields(as); len(fields) > 0 && strings.HasPrefix(fields[0], "AS") {
+		return fields[0]
+	}
+	return ""
+}
+
+// parseASNOrg extracts the organization from "AS13335 Cloudflare, Inc.",
+// falling back to the short AS name
+func parseASNOrg(as, asName string) string {
+	if _, org, ok := strings.Cut(as, " "); ok && org != "" {
+		return org
+	}
+	return asName
+}
+
+func hostingType(hosting, mobile, proxy bool) string {
+	switch {
+	case proxy:
+		return HostingTypeProxy
+	case hosting:
+		return HostingTypeHosting
+	case mobile:
+		return HostingTypeMobile
+	default:
+		return HostingTypeISP
+	}
+}
+
+// UniqueIPs returns the sorted unique A and AAAA addresses in the results
+func (r *DNSResults) UniqueIPs() []string {
+	seen := make(map[string]bool)
+	var ips []string
+	for _, record := range r.Records {
+		for _, ip := range append(append([]string{}, record.A...), record.AAAA...) {
+			if !seen[ip] {
+				seen[ip] = true
+				ips = append(ips, ip)
+			}
+		}
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// GroupSubdomainsByIP groups subdomains by the country or provider of the
+// IPs they resolve to. A subdomain spanning several groups appears in each;
+// subdomains without enrichment data are grouped under "Unknown".
+func (r *DNSResults) GroupSubdomainsByIP(subdomains []Subdomain, by string) (map[string][]Subdomain, error) {
+	if by != GroupByCountry && by != GroupByProvider {
+		return nil, fmt.Errorf("invalid group-by: %q (valid: %s, %s)", by, GroupByCountry, GroupByProvider)
+	}
+
+	recordsByName := make(map[string]DNSInfo)
+	for _, record := range r.Records {
+		recordsByName[strings.ToLower(record.Subdomain)] = record
+	}
+
+	groups := make(map[string][]Subdomain)
+	for _, sub := range subdomains {
+		record := recordsByName[strings.ToLower(sub.Name)]
+
+		keys := make(map[string]bool)
+		for _, ip := range append(append([]string{}, record.A...), record.AAAA...) {
+			info, ok := r.IPInfo[ip]
+			if !ok {
+				continue
+			}
+			key := info.Country
+			if by == GroupByProvider {
+				key = info.ASNOrg
+			}
+			if key != "" {
+				keys[key] = true
+			}
+		}
+		if len(keys) == 0 {
+			keys["Unknown"] = true
+		}
+
+		for key := range keys {
+			groups[key] = append(groups[key], sub)
+		}
+	}
+
+	return groups, nil
+}
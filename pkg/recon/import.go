@@ -0,0 +1,507 @@
+package recon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported import formats
+const (
+	ImportText      = "text"      // One hostname or URL per line
+	ImportSubfinder = "subfinder" // subfinder -json (JSON lines)
+	ImportAmass     = "amass"     // amass -json (JSON lines)
+	ImportNmap      = "nmap"      // nmap -oX
+	ImportHttpx     = "httpx"     // httpx -json (JSON lines)
+	ImportBurp      = "burp"      // Burp "Save items" / target site map XML export
+)
+
+// ImportFormats lists the formats accepted by ParseImportFile
+var ImportFormats = []string{ImportText, ImportSubfinder, ImportAmass, ImportNmap, ImportHttpx, ImportBurp}
+
+// MetadataImportedIPs is the metadata key under which IPs reported by an
+// imported tool are stored when the subdomain has not been verified
+const MetadataImportedIPs = "imported_ips"
+
+// ImportRecord is one host read from an external tool's output
+type ImportRecord struct {
+	Name       string
+	IPs        []string
+	URL        string // Probed URL (httpx, Burp)
+	StatusCode int    // HTTP status from the probe (httpx, Burp)
+	Title      string
+}
+
+// ParsedImport holds the in-scope hosts read from an export
+type ParsedImport struct {
+	Format     string
+	Records    []ImportRecord
+	OutOfScope int // Hosts skipped for not belonging to the domain
+}
+
+// ImportSummary reports the outcome of merging imported records
+type ImportSummary struct {
+	Source   string
+	Parsed   int // Unique in-scope hosts read from the file
+	Added    int // Subdomains not in the previous results
+	Updated  int // Existing subdomains credited to the source
+	Probed   int // Subdomains given verification data from the import
+	FilePath string
+}
+
+// DetectImportFormat guesses the format of an export from its file
+// extension and first record
+func DetectImportFormat(path string, data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+
+	if strings.EqualFold(filepath.Ext(path), ".xml") || bytes.HasPrefix(trimmed, []byte("<")) {
+		head := trimmed
+		if len(head) > 4096 {
+			head = head[:4096]
+		}
+		if bytes.Contains(head, []byte("<nmaprun")) {
+			return ImportNmap
+		}
+		if bytes.Contains(head, []byte("<items")) {
+			return ImportBurp
+		}
+	}
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		line := trimmed
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(line, &fields); err == nil {
+			_, hasStatus := fields["status_code"]
+			_, hasOldStatus := fields["status-code"]
+			_, hasURL := fields["url"]
+			switch {
+			case hasStatus || hasOldStatus || hasURL:
+				return ImportHttpx
+			case fields["addresses"] != nil || fields["name"] != nil:
+				return ImportAmass
+			case fields["host"] != nil:
+				return ImportSubfinder
+			}
+		}
+	}
+
+	return ImportText
+}
+
+// ParseImportFile reads an external tool's output and returns the hosts in
+// scope for domain, merging duplicate entries. An empty format is detected
+// from the file.
+func ParseImportFile(path, format, domain string) (*ParsedImport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	if format == "" {
+		format = DetectImportFormat(path, data)
+	}
+
+	var records []ImportRecord
+	switch format {
+	case ImportText:
+		records = parseImportText(data)
+	case ImportSubfinder:
+		records, err = parseImportSubfinder(data)
+	case ImportAmass:
+		records, err = parseImportAmass(data)
+	case ImportNmap:
+		records, err = parseImportNmap(data)
+	case ImportHttpx:
+		records, err = parseImportHttpx(data)
+	case ImportBurp:
+		records, err = parseImportBurp(data)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s (supported: %s)", format, strings.Join(ImportFormats, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize, scope, and merge records for the same host
+	merged := make(map[string]*ImportRecord)
+	outOfScope := make(map[string]bool)
+	for _, record := range records {
+		name := normalizeImportHost(record.Name)
+		if name == "" || net.ParseIP(name) != nil {
+			continue
+		}
+		if !isInScope(name, domain) {
+			outOfScope[name] = true
+			continue
+		}
+
+		existing, ok := merged[name]
+		if !ok {
+			record.Name = name
+			merged[name] = &record
+			continue
+		}
+		for _, ip := range record.IPs {
+			if !contains(existing.IPs, ip) {
+				existing.IPs = append(existing.IPs, ip)
+			}
+		}
+		if existing.StatusCode == 0 && record.StatusCode != 0 {
+			existing.URL = record.URL
+			existing.StatusCode = record.StatusCode
+			existing.Title = record.Title
+		}
+	}
+
+	result := make([]ImportRecord, 0, len(merged))
+	for _, record := range merged {
+		sort.Strings(record.IPs)
+		result = append(result, *record)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return &ParsedImport{
+		Format:     format,
+		Records:    result,
+		OutOfScope: len(outOfScope),
+	}, nil
+}
+
+// normalizeImportHost reduces a hostname, host:port, or URL to a bare
+// lowercase hostname
+func normalizeImportHost(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasPrefix(value, "#") {
+		return ""
+	}
+
+	if strings.Contains(value, "://") {
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return ""
+		}
+		value = parsed.Hostname()
+	} else {
+		if i := strings.IndexAny(value, "/?#"); i >= 0 {
+			value = value[:i]
+		}
+		if host, _, err := net.SplitHostPort(value); err == nil {
+			value = host
+		}
+	}
+
+	value = strings.TrimPrefix(value, "*.")
+	return strings.ToLower(strings.TrimSuffix(value, "."))
+}
+
+// parseImportText reads one hostname or URL per line. IPs following the
+// host on a line (e.g., "host ip" lists) are kept; other fields are ignored.
+func parseImportText(data []byte) []ImportRecord {
+	var records []ImportRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.FieldsFunc(scanner.Text(), func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		record := ImportRecord{Name: fields[0]}
+		for _, field := range fields[1:] {
+			if net.ParseIP(field) != nil {
+				record.IPs = append(record.IPs, field)
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// forEachJSONLine decodes each non-empty line of JSON lines output
+func forEachJSONLine(data []byte, tool string, fn func(line []byte) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("invalid %s output on line %d: %w", tool, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s output: %w", tool, err)
+	}
+	return nil
+}
+
+func parseImportSubfinder(data []byte) ([]ImportRecord, error) {
+	var records []ImportRecord
+	err := forEachJSONLine(data, "subfinder", func(line []byte) error {
+		var entry struct {
+			Host string `json:"host"`
+			IP   string `json:"ip"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return err
+		}
+		record := ImportRecord{Name: entry.Host}
+		if entry.IP != "" {
+			record.IPs = []string{entry.IP}
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+func parseImportAmass(data []byte) ([]ImportRecord, error) {
+	var records []ImportRecord
+	err := forEachJSONLine(data, "amass", func(line []byte) error {
+		var entry struct {
+			Name      string `json:"name"`
+			Addresses []struct {
+				IP string `json:"ip"`
+			} `json:"addresses"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return err
+		}
+		record := ImportRecord{Name: entry.Name}
+		for _, address := range entry.Addresses {
+			if address.IP != "" {
+				record.IPs = append(record.IPs, address.IP)
+			}
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+func parseImportHttpx(data []byte) ([]ImportRecord, error) {
+	var records []ImportRecord
+	err := forEachJSONLine(data, "httpx", func(line []byte) error {
+		var entry struct {
+			URL           string   `json:"url"`
+			Input         string   `json:"input"`
+			Host          string   `json:"host"` // Hostname in older releases, IP in newer ones
+			A             []string `json:"a"`
+			AAAA          []string `json:"aaaa"`
+			StatusCode    int      `json:"status_code"`
+			OldStatusCode int      `json:"status-code"`
+			Title         string   `json:"title"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return err
+		}
+
+		name := entry.URL
+		if name == "" {
+			name = entry.Input
+		}
+		if name == "" {
+			name = entry.Host
+		}
+
+		record := ImportRecord{
+			Name:       name,
+			URL:        entry.URL,
+			StatusCode: entry.StatusCode,
+			Title:      entry.Title,
+			IPs:        append(append([]string{}, entry.A...), entry.AAAA...),
+		}
+		if record.StatusCode == 0 {
+			record.StatusCode = entry.OldStatusCode
+		}
+		if net.ParseIP(entry.Host) != nil && !contains(record.IPs, entry.Host) {
+			record.IPs = append(record.IPs, entry.Host)
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+func parseImportNmap(data []byte) ([]ImportRecord, error) {
+	var run struct {
+		Hosts []struct {
+			Status struct {
+				State string `xml:"state,attr"`
+			} `xml:"status"`
+			Addresses []struct {
+				Addr     string `xml:"addr,attr"`
+				AddrType string `xml:"addrtype,attr"`
+			} `xml:"address"`
+			Hostnames []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"hostnames>hostname"`
+		} `xml:"host"`
+	}
+	if err := xml.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("invalid nmap XML: %w", err)
+	}
+
+	var records []ImportRecord
+	for _, host := range run.Hosts {
+		if host.Status.State == "down" {
+			continue
+		}
+		var ips []string
+		for _, address := range host.Addresses {
+			if address.AddrType == "ipv4" || address.AddrType == "ipv6" {
+				ips = append(ips, address.Addr)
+			}
+		}
+		for _, hostname := range host.Hostnames {
+			records = append(records, ImportRecord{Name: hostname.Name, IPs: ips})
+		}
+	}
+	return records, nil
+}
+
+func parseImportBurp(data []byte) ([]ImportRecord, error) {
+	var export struct {
+		Items []struct {
+			URL  string `xml:"url"`
+			Host struct {
+				Name string `xml:",chardata"`
+				IP   string `xml:"ip,attr"`
+			} `xml:"host"`
+			Status string `xml:"status"`
+		} `xml:"item"`
+	}
+	if err := xml.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid Burp XML: %w", err)
+	}
+
+	var records []ImportRecord
+	for _, item := range export.Items {
+		name := item.Host.Name
+		if name == "" {
+			name = item.URL
+		}
+		record := ImportRecord{Name: name, URL: item.URL}
+		if item.Host.IP != "" {
+			record.IPs = []string{item.Host.IP}
+		}
+		// Items without a response (status) were never requested
+		record.StatusCode, _ = strconv.Atoi(strings.TrimSpace(item.Status))
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ImportSubdomains merges records into the latest subdomain results for
+// domain, crediting source for each, and saves them as a new result file.
+// Records carrying an HTTP status give unverified subdomains verification
+// data so they show as alive; IPs alone are kept in metadata.
+func ImportSubdomains(domain, source string, records []ImportRecord) (*ImportSummary, error) {
+	unlock, err := LockDomainResults(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var results SubdomainResults
+	if err := LoadLatestResult(domain, "subdomains", &results); err != nil {
+		results = SubdomainResults{
+			Domain:  domain,
+			Summary: make(map[string]int),
+		}
+	}
+	if results.Summary == nil {
+		results.Summary = make(map[string]int)
+	}
+
+	summary := &ImportSummary{Source: source, Parsed: len(records)}
+
+	index := make(map[string]int, len(results.Subdomains))
+	for i, sub := range results.Subdomains {
+		index[strings.ToLower(sub.Name)] = i
+	}
+
+	now := time.Now()
+	for _, record := range records {
+		name := strings.ToLower(record.Name)
+		i, ok := index[name]
+		if ok {
+			if !contains(results.Subdomains[i].DiscoveredBy, source) {
+				results.Subdomains[i].DiscoveredBy = append(results.Subdomains[i].DiscoveredBy, source)
+				results.Summary[source]++
+				summary.Updated++
+			}
+		} else {
+			results.Subdomains = append(results.Subdomains, Subdomain{
+				Name:         name,
+				DiscoveredBy: []string{source},
+				FirstSeen:    now,
+			})
+			i = len(results.Subdomains) - 1
+			index[name] = i
+			results.Summary[source]++
+			summary.Added++
+		}
+
+		sub := &results.Subdomains[i]
+		switch {
+		case sub.Verified == nil && record.StatusCode > 0:
+			sub.Verified = &VerificationResult{
+				Timestamp: now,
+				Status:    "alive",
+				DNS: &DNSResult{
+					Resolves: true,
+					IPs:      record.IPs,
+				},
+				HTTP: &HTTPResult{
+					Accessible: true,
+					URL:        record.URL,
+					StatusCode: record.StatusCode,
+					Title:      record.Title,
+				},
+			}
+			summary.Probed++
+		case len(record.IPs) > 0:
+			if sub.Metadata == nil {
+				sub.Metadata = make(map[string]interface{})
+			}
+			sub.Metadata[MetadataImportedIPs] = record.IPs
+		}
+	}
+
+	if !contains(results.SourcesUsed, source) {
+		results.SourcesUsed = append(results.SourcesUsed, source)
+	}
+	sort.Slice(results.Subdomains, func(i, j int) bool {
+		return strings.ToLower(results.Subdomains[i].Name) < strings.ToLower(results.Subdomains[j].Name)
+	})
+	results.TotalUnique = len(results.Subdomains)
+	results.Timestamp = now
+
+	filePath, err := SaveResults(domain, "subdomains", results, FormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	summary.FilePath = filePath
+
+	return summary, nil
+}
@@ -0,0 +1,220 @@
+package recon
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InventoryAsset is one subdomain in the consolidated inventory
+type InventoryAsset struct {
+	Domain        string   `json:"domain"`
+	Name          string   `json:"name"`
+	Status        string   `json:"status,omitempty"` // Verification status; empty when unverified
+	StatusCode    int      `json:"status_code,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	IPs           []string `json:"ips,omitempty"`
+	Tech          []string `json:"tech,omitempty"`
+	CloudProvider string   `json:"cloud_provider,omitempty"`
+	Sources       []string `json:"sources,omitempty"`
+}
+
+// DomainInventory summarizes the latest results of one domain
+type DomainInventory struct {
+	Domain         string    `json:"domain"`
+	LastScan       time.Time `json:"last_scan"`
+	Assets         int       `json:"assets"`
+	Verified       int       `json:"verified"`
+	Alive          int       `json:"alive"`
+	TakeoverRisks  int       `json:"takeover_risks"`
+	CloudProviders []string  `json:"cloud_providers,omitempty"`
+}
+
+// Inventory aggregates the latest results of every domain into one
+// portfolio-wide view
+type Inventory struct {
+	GeneratedAt    time.Time         `json:"generated_at"`
+	Domains        []DomainInventory `json:"domains"`
+	Assets         []InventoryAsset  `json:"assets"`
+	TotalAssets    int               `json:"total_assets"`
+	VerifiedAssets int               `json:"verified_assets"`
+	AliveAssets    int               `json:"alive_assets"`
+	Technologies   map[string]int    `json:"technologies"`    // Alive assets per technology
+	CloudProviders map[string]int    `json:"cloud_providers"` // Assets per cloud provider
+}
+
+// BuildInventory collects the latest subdomain and DNS results of every
+// domain with stored subdomain results. Domains are sorted by name and
+// assets by domain, then name.
+func BuildInventory() (*Inventory, error) {
+	inventory := &Inventory{
+		GeneratedAt:    time.Now(),
+		Domains:        []DomainInventory{},
+		Assets:         []InventoryAsset{},
+		Technologies:   make(map[string]int),
+		CloudProviders: make(map[string]int),
+	}
+
+	resultsDir, err := GetResultsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(resultsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return inventory, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		domain := entry.Name()
+
+		results, err := ListResultsForDomain(domain)
+		if err != nil {
+			continue
+		}
+		var latest *ResultInfo
+		for i := range results {
+			if results[i].ToolName == "subdomains" {
+				latest = &results[i]
+				break
+			}
+		}
+		if latest == nil {
+			continue // WHOIS-only targets such as IPs and netblocks
+		}
+
+		var subdomains SubdomainResults
+		if err := loadJSONFile(latest.FilePath, &subdomains); err != nil {
+			continue
+		}
+		dnsResults, _ := LoadDNSResults(domain)
+
+		summary, assets := inventoryDomain(domain, &subdomains, dnsResults)
+		summary.LastScan = latest.Timestamp
+
+		for _, asset := range assets {
+			if asset.Status == "alive" {
+				for _, tech := range asset.Tech {
+					inventory.Technologies[tech]++
+				}
+			}
+			if asset.CloudProvider != "" {
+				inventory.CloudProviders[asset.CloudProvider]++
+			}
+		}
+
+		inventory.Domains = append(inventory.Domains, summary)
+		inventory.Assets = append(inventory.Assets, assets...)
+		inventory.TotalAssets += summary.Assets
+		inventory.VerifiedAssets += summary.Verified
+		inventory.AliveAssets += summary.Alive
+	}
+
+	sort.Slice(inventory.Domains, func(i, j int) bool {
+		return inventory.Domains[i].Domain < inventory.Domains[j].Domain
+	})
+	sort.SliceStable(inventory.Assets, func(i, j int) bool {
+		if inventory.Assets[i].Domain != inventory.Assets[j].Domain {
+			return inventory.Assets[i].Domain < inventory.Assets[j].Domain
+		}
+		return inventory.Assets[i].Name < inventory.Assets[j].Name
+	})
+
+	return inventory, nil
+}
+
+// inventoryDomain summarizes one domain's subdomain results and lists its
+// assets, adding cloud providers and takeover risks from dnsResults when it
+// is not nil
+func inventoryDomain(domain string, subdomains *SubdomainResults, dnsResults *DNSResults) (DomainInventory, []InventoryAsset) {
+	dnsBySubdomain := make(map[string]DNSInfo)
+	if dnsResults != nil {
+		for _, info := range dnsResults.Records {
+			dnsBySubdomain[info.Subdomain] = info
+		}
+	}
+
+	summary := DomainInventory{
+		Domain:   domain,
+		LastScan: subdomains.Timestamp,
+		Assets:   len(subdomains.Subdomains),
+	}
+	providers := make(map[string]bool)
+	assets := make([]InventoryAsset, 0, len(subdomains.Subdomains))
+
+	for _, sub := range subdomains.Subdomains {
+		asset := InventoryAsset{
+			Domain:  domain,
+			Name:    sub.Name,
+			Sources: sub.DiscoveredBy,
+		}
+
+		if sub.Verified != nil {
+			asset.Status = sub.Verified.Status
+			if sub.Verified.DNS != nil {
+				asset.IPs = sub.Verified.DNS.IPs
+			}
+			if sub.Verified.HTTP != nil {
+				asset.StatusCode = sub.Verified.HTTP.StatusCode
+				asset.Title = sub.Verified.HTTP.Title
+				for _, tech := range TechStack(sub.Verified.HTTP) {
+					asset.Tech = appendUnique(asset.Tech, inventoryTechName(tech))
+				}
+			}
+			summary.Verified++
+		}
+
+		if info, ok := dnsBySubdomain[sub.Name]; ok {
+			asset.CloudProvider = info.CloudProvider
+			if len(asset.IPs) == 0 {
+				asset.IPs = append(append([]string{}, info.A...), info.AAAA...)
+			}
+			if info.TakeoverRisk {
+				summary.TakeoverRisks++
+			}
+		}
+
+		if asset.Status == "alive" {
+			summary.Alive++
+		}
+		if asset.CloudProvider != "" {
+			providers[asset.CloudProvider] = true
+		}
+
+		assets = append(assets, asset)
+	}
+
+	for provider := range providers {
+		summary.CloudProviders = append(summary.CloudProviders, provider)
+	}
+	sort.Strings(summary.CloudProviders)
+
+	return summary, assets
+}
+
+// inventoryTechName drops the version from a technology so counts group by
+// product, e.g. "nginx/1.18.0 (Ubuntu)" becomes "nginx"
+func inventoryTechName(tech string) string {
+	if i := strings.Index(tech, "/"); i > 0 {
+		tech = tech[:i]
+	}
+	if i := strings.Index(tech, " ("); i > 0 {
+		tech = tech[:i]
+	}
+	return strings.TrimSpace(tech)
+}
+
+// appendUnique appends item unless slice already holds it
+func appendUnique(slice []string, item string) []string {
+	if contains(slice, item) {
+		return slice
+	}
+	return append(slice, item)
+}
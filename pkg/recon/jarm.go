@@ -0,0 +1,524 @@
+package recon
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JARM fingerprints a TLS server by sending ten crafted ClientHello messages
+// and hashing how the server answers each one. This is a native port of the
+// reference implementation at https://github.com/salesforce/jarm.
+
+// knownJARM maps JARM fingerprints to the C2 framework or product they identify
+var knownJARM = map[string]string{
+	"07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1": "Cobalt Strike",
+	"07d14d16d21d21d00042d41d00041de5fb3038104f457d92ba02e9311512c2": "Metasploit",
+	"29d21b20d29d29d21c41d21b21b41d494e0df9532e75299f15ba73156cee38": "Merlin C2",
+}
+
+// emptyJARM is the fingerprint of a server that answered none of the probes
+var emptyJARM = strings.Repeat("0", 62)
+
+// jarmProbe describes one of the ten ClientHello variations
+type jarmProbe struct {
+	version        string // "TLS_1.1", "TLS_1.2", or "TLS_1.3"
+	ciphers        string // "ALL" or "NO1.3"
+	cipherOrder    string // "FORWARD", "REVERSE", "TOP_HALF", "BOTTOM_HALF", "MIDDLE_OUT"
+	grease         bool
+	rareALPN       bool
+	support        string // "1.2_SUPPORT", "1.3_SUPPORT", or "NO_SUPPORT"
+	extensionOrder string // "FORWARD" or "REVERSE"
+}
+
+var jarmProbes = []jarmProbe{
+	{"TLS_1.2", "ALL", "FORWARD", false, false, "1.2_SUPPORT", "REVERSE"},
+	{"TLS_1.2", "ALL", "REVERSE", false, false, "1.2_SUPPORT", "FORWARD"},
+	{"TLS_1.2", "ALL", "TOP_HALF", false, false, "NO_SUPPORT", "FORWARD"},
+	{"TLS_1.2", "ALL", "BOTTOM_HALF", false, true, "NO_SUPPORT", "FORWARD"},
+	{"TLS_1.2", "ALL", "MIDDLE_OUT", true, true, "NO_SUPPORT", "REVERSE"},
+	{"TLS_1.1", "ALL", "FORWARD", false, false, "NO_SUPPORT", "FORWARD"},
+	{"TLS_1.3", "ALL", "FORWARD", false, false, "1.3_SUPPORT", "REVERSE"},
+	{"TLS_1.3", "ALL", "REVERSE", false, false, "1.3_SUPPORT", "FORWARD"},
+	{"TLS_1.3", "NO1.3", "FORWARD", false, false, "1.3_SUPPORT", "FORWARD"},
+	{"TLS_1.3", "ALL", "MIDDLE_OUT", true, false, "1.3_SUPPORT", "REVERSE"},
+}
+
+// jarmCiphers is the full cipher list offered by the probes, in probe order
+var jarmCiphers = []uint16{
+	0x0016, 0x0033, 0x0067, 0xc09e, 0xc0a2, 0x009e, 0x0039, 0x006b, 0xc09f, 0xc0a3,
+	0x009f, 0x0045, 0x00be, 0x0088, 0x00c4, 0x009a, 0xc008, 0xc009, 0xc023, 0xc0ac,
+	0xc0ae, 0xc02b, 0xc00a, 0xc024, 0xc0ad, 0xc0af, 0xc02c, 0xc072, 0xc073, 0xcca9,
+	0x1302, 0x1301, 0xcc14, 0xc007, 0xc012, 0xc013, 0xc027, 0xc02f, 0xc014, 0xc028,
+	0xc030, 0xc060, 0xc061, 0xc076, 0xc077, 0xcca8, 0x1305, 0x1304, 0x1303, 0xcc13,
+	0xc011, 0x000a, 0x002f, 0x003c, 0xc09c, 0xc0a0, 0x009c, 0x0035, 0x003d, 0xc09d,
+	0xc0a1, 0x009d, 0x0041, 0x00ba, 0x0084, 0x00c0, 0x0007, 0x0004, 0x0005,
+}
+
+// jarmCipherIndex is the sorted cipher list used to encode the selected cipher
+var jarmCipherIndex = []uint16{
+	0x0004, 0x0005, 0x0007, 0x000a, 0x0016, 0x002f, 0x0033, 0x0035, 0x0039, 0x003c,
+	0x003d, 0x0041, 0x0045, 0x0067, 0x006b, 0x0084, 0x0088, 0x009a, 0x009c, 0x009d,
+	0x009e, 0x009f, 0x00ba, 0x00be, 0x00c0, 0x00c4, 0xc007, 0xc008, 0xc009, 0xc00a,
+	0xc011, 0xc012, 0xc013, 0xc014, 0xc023, 0xc024, 0xc027, 0xc028, 0xc02b, 0xc02c,
+	0xc02f, 0xc030, 0xc060, 0xc061, 0xc072, 0xc073, 0xc076, 0xc077, 0xc09c, 0xc09d,
+	0xc09e, 0xc09f, 0xc0a0, 0xc0a1, 0xc0a2, 0xc0a3, 0xc0ac, 0xc0ad, 0xc0ae, 0xc0af,
+	0xcc13, 0xcc14, 0xcca8, 0xcca9, 0x1301, 0x1302, 0x1303, 0x1304, 0x1305,
+}
+
+var (
+	jarmALPN = []string{"http/0.9", "http/1.0", "http/1.1", "spdy/1", "spdy/2", "spdy/3", "h2", "h2c", "hq"}
+	// Rare ALPN values omit h2 and http/1.1
+	jarmRareALPN = []string{"http/0.9", "http/1.0", "spdy/1", "spdy/2", "spdy/3", "h2c", "hq"}
+)
+
+// JARMFingerprint computes the JARM fingerprint of the TLS server at
+// host:port. Each of the ten probes waits for the rate limiter and connects
+// through the proxy of options.
+func JARMFingerprint(host string, port int, options VerifyOptions) (string, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	dial := proxyDialer(options.Proxy, options.Timeout)
+
+	raw := make([]string, 0, len(jarmProbes))
+	answered := false
+	for _, probe := range jarmProbes {
+		options.Limiter.Wait(host)
+		response, err := sendJARMProbe(dial, address, buildClientHello(host, probe), options.Timeout)
+		if err != nil {
+			raw = append(raw, "|||")
+			continue
+		}
+		parsed := parseServerHello(response)
+		if parsed != "|||" {
+			answered = true
+		}
+		raw = append(raw, parsed)
+	}
+
+	if !answered {
+		return emptyJARM, fmt.Errorf("no TLS response from %s", address)
+	}
+
+	return jarmHash(raw), nil
+}
+
+// LookupJARM returns the C2 framework or product associated with a fingerprint
+func LookupJARM(fingerprint string) string {
+	return knownJARM[fingerprint]
+}
+
+// JARMCluster groups alive HTTPS hosts sharing a JARM fingerprint
+type JARMCluster struct {
+	JARM       string      `json:"jarm"`
+	Match      string      `json:"match,omitempty"`
+	Subdomains []Subdomain `json:"subdomains"`
+}
+
+// ClusterByJARM groups verified hosts by JARM fingerprint, largest first
+func ClusterByJARM(subdomains []Subdomain) []JARMCluster {
+	clusters := make(map[string]*JARMCluster)
+
+	for _, sub := range subdomains {
+		if sub.Verified == nil || sub.Verified.TLS == nil || sub.Verified.TLS.JARM == "" {
+			continue
+		}
+
+		fingerprint := sub.Verified.TLS.JARM
+		cluster, ok := clusters[fingerprint]
+		if !ok {
+			cluster = &JARMCluster{JARM: fingerprint, Match: LookupJARM(fingerprint)}
+			clusters[fingerprint] = cluster
+		}
+		cluster.Subdomains = append(cluster.Subdomains, sub)
+	}
+
+	result := make([]JARMCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		result = append(result, *cluster)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Subdomains) != len(result[j].Subdomains) {
+			return len(result[i].Subdomains) > len(result[j].Subdomains)
+		}
+		return result[i].JARM < result[j].JARM
+	})
+
+	return result
+}
+
+// sendJARMProbe writes a ClientHello and reads the start of the server's reply
+func sendJARMProbe(dial dialFunc, address string, hello []byte, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	conn, err := dial(ctx, "tcp", address)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write(hello); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1484)
+	n, err := conn.Read(buf)
+	if n == 0 {
+		return nil, err
+	}
+
+	return buf[:n], nil
+}
+
+// buildClientHello assembles a TLS record containing a crafted ClientHello
+func buildClientHello(host string, probe jarmProbe) []byte {
+	var recordVersion, helloVersion []byte
+	switch probe.version {
+	case "TLS_1.1":
+		recordVersion, helloVersion = []byte{0x03, 0x02}, []byte{0x03, 0x02}
+	case "TLS_1.3":
+		recordVersion, helloVersion = []byte{0x03, 0x01}, []byte{0x03, 0x03}
+	default:
+		recordVersion, helloVersion = []byte{0x03, 0x03}, []byte{0x03, 0x03}
+	}
+
+	hello := append([]byte{}, helloVersion...)
+	hello = append(hello, randomBytes(32)...) // Random
+	hello = append(hello, 32)                 // Session ID length
+	hello = append(hello, randomBytes(32)...) // Session ID
+
+	ciphers := jarmCipherList(probe)
+	hello = appendUint16(hello, uint16(len(ciphers)))
+	hello = append(hello, ciphers...)
+	hello = append(hello, 0x01, 0x00) // Compression methods: null
+
+	hello = append(hello, jarmExtensions(host, probe)...)
+
+	handshake := []byte{0x01, 0x00}
+	handshake = appendUint16(handshake, uint16(len(hello)))
+	handshake = append(handshake, hello...)
+
+	record := []byte{0x16}
+	record = append(record, recordVersion...)
+	record = appendUint16(record, uint16(len(handshake)))
+	return append(record, handshake...)
+}
+
+// jarmCipherList returns the encoded cipher suites for a probe
+func jarmCipherList(probe jarmProbe) []byte {
+	var ciphers [][]byte
+	for _, c := range jarmCiphers {
+		if probe.ciphers == "NO1.3" && c>>8 == 0x13 {
+			continue
+		}
+		ciphers = append(ciphers, appendUint16(nil, c))
+	}
+
+	if probe.cipherOrder != "FORWARD" {
+		ciphers = jarmMung(ciphers, probe.cipherOrder)
+	}
+	if probe.grease {
+		ciphers = append([][]byte{randomGrease()}, ciphers...)
+	}
+
+	return joinBytes(ciphers)
+}
+
+// jarmExtensions builds the extensions block for a probe
+func jarmExtensions(host string, probe jarmProbe) []byte {
+	var ext []byte
+
+	if probe.grease {
+		ext = append(ext, randomGrease()...)
+		ext = append(ext, 0x00, 0x00)
+	}
+
+	// Server name
+	ext = append(ext, 0x00, 0x00)
+	ext = appendUint16(ext, uint16(len(host)+5))
+	ext = appendUint16(ext, uint16(len(host)+3))
+	ext = append(ext, 0x00)
+	ext = appendUint16(ext, uint16(len(host)))
+	ext = append(ext, host...)
+
+	ext = append(ext, 0x00, 0x17, 0x00, 0x00)                                                             // Extended master secret
+	ext = append(ext, 0x00, 0x01, 0x00, 0x01, 0x01)                                                       // Max fragment length
+	ext = append(ext, 0xff, 0x01, 0x00, 0x01, 0x00)                                                       // Renegotiation info
+	ext = append(ext, 0x00, 0x0a, 0x00, 0x0a, 0x00, 0x08, 0x00, 0x1d, 0x00, 0x17, 0x00, 0x18, 0x00, 0x19) // Supported groups
+	ext = append(ext, 0x00, 0x0b, 0x00, 0x02, 0x01, 0x00)                                                 // EC point formats
+	ext = append(ext, 0x00, 0x23, 0x00, 0x00)                                                             // Session ticket
+
+	ext = append(ext, jarmALPNExtension(probe)...)
+
+	// Signature algorithms
+	ext = append(ext, 0x00, 0x0d, 0x00, 0x14, 0x00, 0x12, 0x04, 0x03, 0x08, 0x04, 0x04, 0x01,
+		0x05, 0x03, 0x08, 0x05, 0x05, 0x01, 0x08, 0x06, 0x06, 0x01, 0x02, 0x01)
+
+	ext = append(ext, jarmKeyShare(probe.grease)...)
+	ext = append(ext, 0x00, 0x2d, 0x00, 0x02, 0x01, 0x01) // PSK key exchange modes
+
+	if probe.version == "TLS_1.3" || probe.support == "1.2_SUPPORT" {
+		ext = append(ext, jarmSupportedVersions(probe)...)
+	}
+
+	return append(appendUint16(nil, uint16(len(ext))), ext...)
+}
+
+// jarmALPNExtension builds the ALPN extension
+func jarmALPNExtension(probe jarmProbe) []byte {
+	names := jarmALPN
+	if probe.rareALPN {
+		names = jarmRareALPN
+	}
+
+	var protocols [][]byte
+	for _, name := range names {
+		protocols = append(protocols, append([]byte{byte(len(name))}, name...))
+	}
+	if probe.extensionOrder != "FORWARD" {
+		protocols = jarmMung(protocols, probe.extensionOrder)
+	}
+
+	all := joinBytes(protocols)
+	ext := []byte{0x00, 0x10}
+	ext = appendUint16(ext, uint16(len(all)+2))
+	ext = appendUint16(ext, uint16(len(all)))
+	return append(ext, all...)
+}
+
+// jarmKeyShare builds the key share extension with a random x25519 key
+func jarmKeyShare(grease bool) []byte {
+	var share []byte
+	if grease {
+		share = append(share, randomGrease()...)
+		share = append(share, 0x00, 0x01, 0x00)
+	}
+	share = append(share, 0x00, 0x1d, 0x00, 0x20)
+	share = append(share, randomBytes(32)...)
+
+	ext := []byte{0x00, 0x33}
+	ext = appendUint16(ext, uint16(len(share)+2))
+	ext = appendUint16(ext, uint16(len(share)))
+	return append(ext, share...)
+}
+
+// jarmSupportedVersions builds the supported versions extension
+func jarmSupportedVersions(probe jarmProbe) []byte {
+	versions := [][]byte{{0x03, 0x01}, {0x03, 0x02}, {0x03, 0x03}}
+	if probe.support != "1.2_SUPPORT" {
+		versions = append(versions, []byte{0x03, 0x04})
+	}
+	if probe.extensionOrder != "FORWARD" {
+		versions = jarmMung(versions, probe.extensionOrder)
+	}
+
+	var list []byte
+	if probe.grease {
+		list = append(list, randomGrease()...)
+	}
+	list = append(list, joinBytes(versions)...)
+
+	ext := []byte{0x00, 0x2b}
+	ext = appendUint16(ext, uint16(len(list)+1))
+	ext = append(ext, byte(len(list)))
+	return append(ext, list...)
+}
+
+// jarmMung reorders items as the reference implementation does
+func jarmMung(items [][]byte, order string) [][]byte {
+	n := len(items)
+	var out [][]byte
+
+	switch order {
+	case "REVERSE":
+		for i := n - 1; i >= 0; i-- {
+			out = append(out, items[i])
+		}
+	case "BOTTOM_HALF":
+		if n%2 == 1 {
+			out = append(out, items[n/2+1:]...)
+		} else {
+			out = append(out, items[n/2:]...)
+		}
+	case "TOP_HALF":
+		if n%2 == 1 {
+			out = append(out, items[n/2])
+		}
+		out = append(out, jarmMung(jarmMung(items, "REVERSE"), "BOTTOM_HALF")...)
+	case "MIDDLE_OUT":
+		middle := n / 2
+		if n%2 == 1 {
+			out = append(out, items[middle])
+			for i := 1; i <= middle; i++ {
+				out = append(out, items[middle+i], items[middle-i])
+			}
+		} else {
+			for i := 1; i <= middle; i++ {
+				out = append(out, items[middle-1+i], items[middle-i])
+			}
+		}
+	default:
+		out = items
+	}
+
+	return out
+}
+
+// parseServerHello extracts "cipher|version|alpn|extensions" from a reply
+func parseServerHello(data []byte) (result string) {
+	defer func() {
+		if recover() != nil { // Malformed replies are treated as no answer
+			result = "|||"
+		}
+	}()
+
+	if len(data) < 44 || data[0] == 21 || data[0] != 22 || data[5] != 2 {
+		return "|||"
+	}
+
+	helloLength := int(binary.BigEndian.Uint16(data[3:5]))
+	sessionIDLength := int(data[43])
+	cipher := data[sessionIDLength+44 : sessionIDLength+46]
+	version := data[9:11]
+
+	return hex.EncodeToString(cipher) + "|" + hex.EncodeToString(version) + "|" +
+		parseServerExtensions(data, sessionIDLength, helloLength)
+}
+
+// parseServerExtensions returns "alpn|type-type-..." for a ServerHello
+func parseServerExtensions(data []byte, counter, helloLength int) (result string) {
+	defer func() {
+		if recover() != nil {
+			result = "|"
+		}
+	}()
+
+	if data[counter+47] == 11 {
+		return "|"
+	}
+	if string(clampSlice(data, counter+50, counter+53)) == "\x0e\xac\x0b" || string(clampSlice(data, 82, 85)) == "\x0f\xf0\x0b" {
+		return "|"
+	}
+	if counter+42 >= helloLength {
+		return "|"
+	}
+
+	count := 49 + counter
+	length := int(binary.BigEndian.Uint16(data[counter+47 : counter+49]))
+	maximum := length + (count - 1)
+
+	var types []string
+	alpn := ""
+	for count < maximum {
+		extType := data[count : count+2]
+		extLength := int(binary.BigEndian.Uint16(data[count+2 : count+4]))
+		types = append(types, hex.EncodeToString(extType))
+
+		if extLength == 0 {
+			count += 4
+			continue
+		}
+
+		value := data[count+4 : count+4+extLength]
+		if extType[0] == 0x00 && extType[1] == 0x10 && alpn == "" && len(value) > 3 {
+			alpn = string(value[3:])
+		}
+		count += extLength + 4
+	}
+
+	return alpn + "|" + strings.Join(types, "-")
+}
+
+// jarmHash converts the ten raw probe answers into the 62 character JARM
+func jarmHash(raw []string) string {
+	var fuzzy strings.Builder
+	var alpnsAndExtensions strings.Builder
+
+	for _, answer := range raw {
+		parts := strings.Split(answer, "|")
+		for len(parts) < 4 {
+			parts = append(parts, "")
+		}
+		fuzzy.WriteString(jarmCipherByte(parts[0]))
+		fuzzy.WriteString(jarmVersionByte(parts[1]))
+		alpnsAndExtensions.WriteString(parts[2])
+		alpnsAndExtensions.WriteString(parts[3])
+	}
+
+	sum := sha256.Sum256([]byte(alpnsAndExtensions.String()))
+	return fuzzy.String() + hex.EncodeToString(sum[:])[:32]
+}
+
+// jarmCipherByte encodes a selected cipher as its 1-based index in hex
+func jarmCipherByte(cipher string) string {
+	if cipher == "" {
+		return "00"
+	}
+
+	count := 1
+	for _, c := range jarmCipherIndex {
+		if hex.EncodeToString(appendUint16(nil, c)) == cipher {
+			break
+		}
+		count++
+	}
+
+	return fmt.Sprintf("%02x", count)
+}
+
+// jarmVersionByte encodes a selected TLS version as a single letter
+func jarmVersionByte(version string) string {
+	if len(version) < 4 {
+		return "0"
+	}
+	n, err := strconv.Atoi(version[3:4])
+	if err != nil || n > 5 {
+		return "0"
+	}
+	return string("abcdef"[n])
+}
+
+// randomGrease returns a random GREASE value (0x?a?a)
+func randomGrease() []byte {
+	b := randomBytes(1)[0] & 0xf0
+	return []byte{b | 0x0a, b | 0x0a}
+}
+
+// clampSlice slices data like Python does, truncating out-of-range bounds
+func clampSlice(data []byte, start, end int) []byte {
+	if start > len(data) {
+		start = len(data)
+	}
+	if end > len(data) {
+		end = len(data)
+	}
+	return data[start:end]
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func joinBytes(parts [][]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
@@ -0,0 +1,305 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Leak represents a code search hit that mentions the target
+type Leak struct {
+	Platform   string   `json:"platform"` // "github" or "gitlab"
+	Repository string   `json:"repository"`
+	File       string   `json:"file"`
+	Line       int      `json:"line,omitempty"`
+	URL        string   `json:"url"`
+	Query      string   `json:"query"`
+	Matches    []string `json:"matches,omitempty"` // Names of secret patterns found in the context
+	Hostnames  []string `json:"hostnames,omitempty"`
+	Context    string   `json:"context"`
+}
+
+// LeakResults represents the results of a leak search
+type LeakResults struct {
+	Domain    string         `json:"domain"`
+	Timestamp time.Time      `json:"timestamp"`
+	Platforms []string       `json:"platforms"`
+	Queries   []string       `json:"queries"`
+	Leaks     []Leak         `json:"leaks"`
+	Summary   map[string]int `json:"summary"` // Leak count per platform and secret pattern
+}
+
+// LeakSearchOptions configures a leak search
+type LeakSearchOptions struct {
+	GitHubToken string
+	GitLabToken string
+	GitLabURL   string        // GitLab instance (default: https://gitlab.com)
+	Proxy       *url.URL      // Route requests through this proxy (optional)
+	Delay       time.Duration // Pause between queries to respect search rate limits
+}
+
+// leakQueryTerms are combined with the domain to find likely secrets
+var leakQueryTerms = []string{"", "password", "secret", "token", "api_key", "filename:.env"}
+
+// secretPatterns detect common credential formats in search context
+var secretPatterns = []struct {
+	Name    string
+	Pattern *regexp.Regexp
+}{
+	{"aws_access_key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github_token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"gitlab_token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"slack_token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"google_api_key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"stripe_key", regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{20,}\b`)},
+	{"private_key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`)},
+	{"credential_assignment", regexp.MustCompile(`(?i)\b(password|passwd|secret|api[_-]?key|access[_-]?token|auth[_-]?token)\b\s*[:=]\s*["']?[^\s"']{8,}`)},
+	{"env_file", regexp.MustCompile(`(?m)^[A-Z][A-Z0-9_]{2,}=\S+$`)},
+}
+
+// SearchLeaks queries GitHub and GitLab code search for the domain combined
+// with common secret terms, deduplicating hits across queries
+func SearchLeaks(domain string, options LeakSearchOptions) (*LeakResults, error) {
+	if options.GitHubToken == "" && options.GitLabToken == "" {
+		return nil, fmt.Errorf("no GitHub or GitLab token configured")
+	}
+	if options.GitLabURL == "" {
+		options.GitLabURL = "https://gitlab.com"
+	}
+
+	results := &LeakResults{
+		Domain:    domain,
+		Timestamp: time.Now(),
+		Platforms: []string{},
+		Leaks:     []Leak{},
+		Summary:   make(map[string]int),
+	}
+
+	client := newSourceClient(options.Proxy)
+	hostPattern := regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+` + regexp.QuoteMeta(domain) + `\b`)
+	seen := make(map[string]bool)
+
+	addLeaks := func(leaks []Leak) {
+		for _, leak := range leaks {
+			key := fmt.Sprintf("%s|%s|%s|%d|%s", leak.Platform, leak.Repository, leak.File, leak.Line, hashBody([]byte(leak.Context)))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			leak.Matches = detectSecrets(leak.Context)
+			leak.Hostnames = uniqueLower(hostPattern.FindAllString(leak.Context, -1))
+			results.Leaks = append(results.Leaks, leak)
+
+			results.Summary[leak.Platform]++
+			for _, match := range leak.Matches {
+				results.Summary[match]++
+			}
+		}
+	}
+
+	type searcher struct {
+		platform string
+		search   func(query string) ([]Leak, error)
+	}
+	var searchers []searcher
+	if options.GitHubToken != "" {
+		searchers = append(searchers, searcher{"github", func(query string) ([]Leak, error) {
+			return searchGitHubCode(client, options.GitHubToken, query)
+		}})
+	}
+	if options.GitLabToken != "" {
+		searchers = append(searchers, searcher{"gitlab", func(query string) ([]Leak, error) {
+			return searchGitLabBlobs(client, options.GitLabURL, options.GitLabToken, query)
+		}})
+	}
+
+	for _, s := range searchers {
+		results.Platforms = append(results.Platforms, s.platform)
+
+		for i, term := range leakQueryTerms {
+			query := strings.TrimSpace(fmt.Sprintf("\"%s\" %s", domain, term))
+			if s.platform == "gitlab" {
+				// GitLab search does not support GitHub qualifiers
+				if strings.Contains(term, ":") {
+					continue
+				}
+				query = strings.TrimSpace(domain + " " + term)
+			}
+			if !contains(results.Queries, query) {
+				results.Queries = append(results.Queries, query)
+			}
+
+			if i > 0 && options.Delay > 0 {
+				time.Sleep(options.Delay)
+			}
+
+			fmt.Printf("Searching %s: %s... ", s.platform, query)
+			leaks, err := s.search(query)
+			if err != nil {
+				fmt.Printf("✗ %v\n", err)
+				continue
+			}
+			fmt.Printf("✓ %d hits\n", len(leaks))
+			addLeaks(leaks)
+		}
+	}
+
+	// Show hits containing likely secrets first
+	sort.SliceStable(results.Leaks, func(i, j int) bool {
+		return len(results.Leaks[i].Matches) > len(results.Leaks[j].Matches)
+	})
+
+	return results, nil
+}
+
+// searchGitHubCode runs a GitHub code search, requesting text matches so the
+// surrounding code is available as context
+func searchGitHubCode(client *http.Client, token, query string) ([]Leak, error) {
+	apiURL := "https://api.github.com/search/code?per_page=100&q=" + url.QueryEscape(query)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github.text-match+json")
+
+	body, err := doSearchRequest(client, req, "github")
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Items []struct {
+			Path       string `json:"path"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+			TextMatches []struct {
+				Fragment string `json:"fragment"`
+			} `json:"text_matches"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse github response: %w", err)
+	}
+
+	var leaks []Leak
+	for _, item := range response.Items {
+		var fragments []string
+		for _, match := range item.TextMatches {
+			fragments = append(fragments, match.Fragment)
+		}
+		leaks = append(leaks, Leak{
+			Platform:   "github",
+			Repository: item.Repository.FullName,
+			File:       item.Path,
+			URL:        item.HTMLURL,
+			Query:      query,
+			Context:    strings.Join(fragments, "\n...\n"),
+		})
+	}
+
+	return leaks, nil
+}
+
+// searchGitLabBlobs runs a GitLab blob search, which reports the starting
+// line of each match
+func searchGitLabBlobs(client *http.Client, baseURL, token, query string) ([]Leak, error) {
+	base := strings.TrimSuffix(baseURL, "/")
+	apiURL := fmt.Sprintf("%s/api/v4/search?scope=blobs&per_page=100&search=%s", base, url.QueryEscape(query))
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+
+	body, err := doSearchRequest(client, req, "gitlab")
+	if err != nil {
+		return nil, err
+	}
+
+	var response []struct {
+		Path      string `json:"path"`
+		Ref       string `json:"ref"`
+		StartLine int    `json:"startline"`
+		Data      string `json:"data"`
+		ProjectID int    `json:"project_id"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse gitlab response: %w", err)
+	}
+
+	var leaks []Leak
+	for _, item := range response {
+		project := fmt.Sprintf("%d", item.ProjectID)
+		leaks = append(leaks, Leak{
+			Platform:   "gitlab",
+			Repository: project,
+			File:       item.Path,
+			Line:       item.StartLine,
+			URL:        fmt.Sprintf("%s/projects/%s", base, project), // Redirects to the project page
+			Query:      query,
+			Context:    item.Data,
+		})
+	}
+
+	return leaks, nil
+}
+
+// doSearchRequest sends a search API request and returns the 200 body
+func doSearchRequest(client *http.Client, req *http.Request, platform string) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s search failed: %w", platform, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", platform, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("%s token rejected (HTTP 401)", platform)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%s rate limit reached (HTTP %d)", platform, resp.StatusCode)
+	default:
+		return nil, fmt.Errorf("%s search failed: HTTP %d", platform, resp.StatusCode)
+	}
+}
+
+// detectSecrets returns the names of secret patterns found in text
+func detectSecrets(text string) []string {
+	var matches []string
+	for _, p := range secretPatterns {
+		if p.Pattern.MatchString(text) {
+			matches = append(matches, p.Name)
+		}
+	}
+	return matches
+}
+
+func uniqueLower(values []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		v = strings.ToLower(v)
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package recon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// lockFileName is the advisory lock file kept in each domain's results
+// directory
+const lockFileName = ".lock"
+
+// LockDomainResults takes an exclusive advisory lock on a domain's results
+// for a read-modify-write cycle (load the latest results, update them, save
+// a new file), blocking while another process holds it. Call the returned
+// function to release the lock. The lock is not reentrant.
+func LockDomainResults(domain string) (func(), error) {
+	if err := EnsureDomainResultsDir(domain); err != nil {
+		return nil, err
+	}
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(domainDir, lockFileName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results lock: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock results for %s: %w", domain, err)
+	}
+
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it into place, so readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
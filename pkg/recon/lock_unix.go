@@ -0,0 +1,23 @@
+//go:build !windows
+
+package recon
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive flock on file, waiting for other holders
+func lockFile(file *os.File) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases a lock taken by lockFile
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
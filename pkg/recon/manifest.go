@@ -0,0 +1,288 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Manifest files kept in each domain's results directory
+const (
+	manifestFileName = ".manifest"
+	manifestLockName = ".manifest.lock"
+)
+
+// manifestEntry indexes one stored result file
+type manifestEntry struct {
+	File          string    `json:"file"`
+	Tool          string    `json:"tool"`
+	Timestamp     time.Time `json:"timestamp"`
+	SchemaVersion int       `json:"schema_version"`
+	Size          int64     `json:"size"`
+	TotalCount    int       `json:"total_count,omitempty"`
+	AliveCount    int       `json:"alive_count,omitempty"`
+	DeadCount     int       `json:"dead_count,omitempty"`
+	Verified      bool      `json:"verified,omitempty"`
+	SourcesUsed   []string  `json:"sources_used,omitempty"`
+
+	pending bool // Counts could not be read; retried on the next load
+}
+
+// resultsManifest indexes the result files of one domain, so the latest
+// result of a tool is selected by recorded tool and timestamp rather than by
+// file name, and listings don't reparse every file
+type resultsManifest struct {
+	Version int             `json:"version"`
+	Entries []manifestEntry `json:"entries"`
+}
+
+// loadManifest returns the domain's manifest entries, newest first. The
+// manifest is reconciled with the directory: files written by older
+// versions or copied in by hand are indexed, and deleted files dropped.
+func loadManifest(domain string) ([]manifestEntry, error) {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(domainDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var entries []manifestEntry
+	err = withManifestLock(domainDir, func() error {
+		manifest := readManifest(domainDir)
+		changed, err := reconcileManifest(domainDir, manifest)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := writeManifest(domainDir, manifest); err != nil {
+				return err
+			}
+		}
+		entries = manifest.Entries
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].File > entries[j].File
+		}
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// recordManifestEntry adds or replaces the entry for a newly saved file
+func recordManifestEntry(domain string, entry manifestEntry) error {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return err
+	}
+
+	return withManifestLock(domainDir, func() error {
+		manifest := readManifest(domainDir)
+		if _, err := reconcileManifest(domainDir, manifest); err != nil {
+			return err
+		}
+
+		replaced := false
+		for i := range manifest.Entries {
+			if manifest.Entries[i].File == entry.File {
+				manifest.Entries[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			manifest.Entries = append(manifest.Entries, entry)
+		}
+
+		return writeManifest(domainDir, manifest)
+	})
+}
+
+// withManifestLock runs fn holding the domain's manifest lock, which is
+// separate from LockDomainResults so saves made under that lock can update
+// the manifest
+func withManifestLock(domainDir string, fn func() error) error {
+	file, err := os.OpenFile(filepath.Join(domainDir, manifestLockName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open manifest lock: %w", err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock manifest: %w", err)
+	}
+	defer unlockFile(file)
+
+	return fn()
+}
+
+// readManifest reads the manifest, returning an empty one when it is
+// missing or unreadable so it is rebuilt from the directory
+func readManifest(domainDir string) *resultsManifest {
+	manifest := &resultsManifest{Version: ResultsSchemaVersion}
+	data, err := os.ReadFile(filepath.Join(domainDir, manifestFileName))
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return &resultsManifest{Version: ResultsSchemaVersion}
+	}
+	return manifest
+}
+
+func writeManifest(domainDir string, manifest *resultsManifest) error {
+	saved := *manifest
+	saved.Entries = make([]manifestEntry, 0, len(manifest.Entries))
+	for _, entry := range manifest.Entries {
+		if !entry.pending {
+			saved.Entries = append(saved.Entries, entry)
+		}
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(domainDir, manifestFileName), data, 0600); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// reconcileManifest brings the manifest in line with the result files on
+// disk and reports whether it changed
+func reconcileManifest(domainDir string, manifest *resultsManifest) (bool, error) {
+	dirEntries, err := os.ReadDir(domainDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to read results directory: %w", err)
+	}
+
+	sizes := make(map[string]int64)
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json"+compressedSuffix)) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		sizes[name] = info.Size()
+	}
+
+	changed := false
+	known := make(map[string]bool, len(manifest.Entries))
+	kept := manifest.Entries[:0]
+	for _, entry := range manifest.Entries {
+		size, ok := sizes[entry.File]
+		if !ok {
+			changed = true // Deleted, compacted, or renamed
+			continue
+		}
+		if entry.Size != size {
+			entry.Size = size // Rewritten, e.g., sealed by the vault
+			changed = true
+		}
+		known[entry.File] = true
+		kept = append(kept, entry)
+	}
+	manifest.Entries = kept
+
+	for name, size := range sizes {
+		if known[name] {
+			continue
+		}
+		entry, ok := indexResultFile(domainDir, name, size)
+		if !ok {
+			continue
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+		if !entry.pending {
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// indexResultFile builds the manifest entry of a result file found on disk
+// from its name (<tool>_<YYYYMMDD_HHMMSS>.json[.gz]) and contents
+func indexResultFile(domainDir, name string, size int64) (manifestEntry, bool) {
+	tool, timestampStr, ok := strings.Cut(trimResultExt(name), "_")
+	if !ok || tool == "" {
+		return manifestEntry{}, false
+	}
+	timestamp, err := time.ParseInLocation("20060102_150405", timestampStr, time.Local)
+	if err != nil {
+		return manifestEntry{}, false
+	}
+
+	entry := manifestEntry{
+		File:      name,
+		Tool:      tool,
+		Timestamp: timestamp,
+		Size:      size,
+	}
+
+	data, err := ReadResultFile(filepath.Join(domainDir, name))
+	if err != nil {
+		entry.pending = true
+		return entry, true
+	}
+	entry.SchemaVersion = resultSchemaVersion(data)
+
+	if tool == "subdomains" {
+		var results SubdomainResults
+		if err := unmarshalResult(data, &results); err != nil {
+			entry.pending = true
+		} else {
+			summarizeSubdomainResults(&entry, &results)
+		}
+	}
+
+	return entry, true
+}
+
+// summarizeSubdomainResults records the counts of a subdomain scan
+func summarizeSubdomainResults(entry *manifestEntry, results *SubdomainResults) {
+	entry.TotalCount = results.TotalUnique
+	entry.SourcesUsed = results.SourcesUsed
+	for _, sub := range results.Subdomains {
+		if sub.Verified == nil {
+			continue
+		}
+		entry.Verified = true
+		if sub.Verified.Status == "alive" {
+			entry.AliveCount++
+		} else {
+			entry.DeadCount++
+		}
+	}
+}
+
+// resultInfo converts a manifest entry for callers of the results API
+func (e manifestEntry) resultInfo(domain, domainDir string) ResultInfo {
+	return ResultInfo{
+		Domain:      domain,
+		ToolName:    e.Tool,
+		Timestamp:   e.Timestamp,
+		FilePath:    filepath.Join(domainDir, e.File),
+		FileSize:    e.Size,
+		TotalCount:  e.TotalCount,
+		AliveCount:  e.AliveCount,
+		DeadCount:   e.DeadCount,
+		Verified:    e.Verified,
+		SourcesUsed: e.SourcesUsed,
+	}
+}
@@ -0,0 +1,213 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NucleiSeverities lists nuclei severities from most to least severe
+var NucleiSeverities = []string{"critical", "high", "medium", "low", "info", "unknown"}
+
+// nucleiExcludedTags keeps scans low-impact by skipping templates that send
+// attack payloads or could disrupt the target
+var nucleiExcludedTags = []string{"dos", "intrusive", "fuzz", "bruteforce"}
+
+// NucleiOptions configures a nuclei scan
+type NucleiOptions struct {
+	Severities []string      // Template severities to run (optional, default: all)
+	Tags       []string      // Template tags to run (optional, default: all)
+	RateLimit  int           // Maximum requests per second (0 = nuclei default)
+	Timeout    time.Duration // Overall scan timeout
+	Proxy      string        // Proxy URL passed to nuclei (optional)
+}
+
+// NucleiFinding represents a single nuclei template match
+type NucleiFinding struct {
+	TemplateID       string    `json:"template_id"`
+	Name             string    `json:"name"`
+	Severity         string    `json:"severity"`
+	Tags             []string  `json:"tags,omitempty"`
+	Type             string    `json:"type"`
+	Host             string    `json:"host"`
+	MatchedAt        string    `json:"matched_at"`
+	ExtractedResults []string  `json:"extracted_results,omitempty"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// NucleiResults represents the findings of a nuclei scan
+type NucleiResults struct {
+	Domain     string          `json:"domain"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Targets    int             `json:"targets"`
+	Severities []string        `json:"severities,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+	Findings   []NucleiFinding `json:"findings"`
+	Summary    map[string]int  `json:"summary"` // Finding count per severity
+}
+
+// ParseNucleiSeverities validates a list of severities
+func ParseNucleiSeverities(severities []string) ([]string, error) {
+	var result []string
+	for _, severity := range severities {
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if severity == "" {
+			continue
+		}
+		if !contains(NucleiSeverities, severity) {
+			return nil, fmt.Errorf("invalid severity: %q (valid: %s)", severity, strings.Join(NucleiSeverities, ", "))
+		}
+		result = append(result, severity)
+	}
+	return result, nil
+}
+
+// RunNuclei runs nuclei against the target URLs and parses its JSONL output
+func RunNuclei(domain string, targets []string, options NucleiOptions) (*NucleiResults, error) {
+	if !IsToolAvailable("nuclei") {
+		return nil, fmt.Errorf("nuclei is not installed")
+	}
+
+	// nuclei reads the target list from a file
+	targetsFile, err := os.CreateTemp("", "recon-nuclei-*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create targets file: %w", err)
+	}
+	defer os.Remove(targetsFile.Name())
+
+	if _, err := targetsFile.WriteString(strings.Join(targets, "\n") + "\n"); err != nil {
+		targetsFile.Close()
+		return nil, fmt.Errorf("failed to write targets file: %w", err)
+	}
+	targetsFile.Close()
+
+	args := []string{"-l", targetsFile.Name(), "-jsonl", "-silent", "-no-color",
+		"-exclude-tags", strings.Join(nucleiExcludedTags, ",")}
+	if len(options.Severities) > 0 {
+		args = append(args, "-severity", strings.Join(options.Severities, ","))
+	}
+	if len(options.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(options.Tags, ","))
+	}
+	if options.RateLimit > 0 {
+		args = append(args, "-rate-limit", strconv.Itoa(options.RateLimit))
+	}
+	if options.Proxy != "" {
+		args = append(args, "-proxy", options.Proxy)
+	}
+
+	result, err := ExecuteWithTimeout("nuclei", options.Timeout, args...)
+	if err != nil && (result == nil || result.Stdout == "") {
+		return nil, fmt.Errorf("nuclei execution failed: %w", err)
+	}
+
+	results := &NucleiResults{
+		Domain:     domain,
+		Timestamp:  time.Now(),
+		Targets:    len(targets),
+		Severities: options.Severities,
+		Tags:       options.Tags,
+		Findings:   ParseNucleiOutput(result.Stdout),
+		Summary:    make(map[string]int),
+	}
+	for _, finding := range results.Findings {
+		results.Summary[finding.Severity]++
+	}
+
+	return results, nil
+}
+
+// ParseNucleiOutput parses nuclei JSONL output, skipping malformed lines
+func ParseNucleiOutput(output string) []NucleiFinding {
+	findings := []NucleiFinding{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var entry struct {
+			TemplateID string `json:"template-id"`
+			Info       struct {
+				Name     string          `json:"name"`
+				Severity string          `json:"severity"`
+				Tags     json.RawMessage `json:"tags"`
+			} `json:"info"`
+			Type             string    `json:"type"`
+			Host             string    `json:"host"`
+			MatchedAt        string    `json:"matched-at"`
+			ExtractedResults []string  `json:"extracted-results"`
+			Timestamp        time.Time `json:"timestamp"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.TemplateID == "" {
+			continue
+		}
+
+		severity := strings.ToLower(entry.Info.Severity)
+		if severity == "" {
+			severity = "unknown"
+		}
+
+		findings = append(findings, NucleiFinding{
+			TemplateID:       entry.TemplateID,
+			Name:             entry.Info.Name,
+			Severity:         severity,
+			Tags:             parseNucleiTags(entry.Info.Tags),
+			Type:             entry.Type,
+			Host:             entry.Host,
+			MatchedAt:        entry.MatchedAt,
+			ExtractedResults: entry.ExtractedResults,
+			Timestamp:        entry.Timestamp,
+		})
+	}
+
+	return findings
+}
+
+// parseNucleiTags handles tags encoded either as a list or a comma-separated
+// string, which differs between nuclei versions
+func parseNucleiTags(raw json.RawMessage) []string {
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err == nil {
+		return tags
+	}
+
+	var joined string
+	if err := json.Unmarshal(raw, &joined); err == nil && joined != "" {
+		for _, tag := range strings.Split(joined, ",") {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
+	}
+	return tags
+}
+
+// AliveURLs returns the base URL of every alive subdomain and extra port
+func AliveURLs(subdomains []Subdomain) []string {
+	var urls []string
+	seen := make(map[string]bool)
+
+	add := func(u string) {
+		if u != "" && !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+
+	for _, sub := range subdomains {
+		if sub.Verified == nil || sub.Verified.Status != "alive" {
+			continue
+		}
+		if sub.Verified.HTTP != nil && sub.Verified.HTTP.Accessible {
+			add(sub.Verified.HTTP.URL)
+		}
+		for _, result := range sub.Verified.HTTPResults {
+			add(result.URL)
+		}
+	}
+
+	return urls
+}
@@ -0,0 +1,186 @@
+package recon
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxJSFileSize caps how much of each JavaScript file is scanned
+const maxJSFileSize = 5 * 1024 * 1024
+
+// ParamResults represents query parameter names mined for a domain
+type ParamResults struct {
+	Domain         string              `json:"domain"`
+	Timestamp      time.Time           `json:"timestamp"`
+	URLsScanned    int                 `json:"urls_scanned"`
+	JSFilesScanned int                 `json:"js_files_scanned"`
+	JSFilesFailed  int                 `json:"js_files_failed"`
+	Parameters     map[string]int      `json:"parameters"` // Occurrences of each parameter name across all hosts
+	Hosts          map[string][]string `json:"hosts"`      // Parameter names per host, most frequent first
+}
+
+// ParamMiningOptions configures parameter mining
+type ParamMiningOptions struct {
+	ScanJS      bool     // Fetch collected JavaScript files and extract parameters from them
+	MaxJSFiles  int      // Maximum JavaScript files to fetch (default: 50)
+	Concurrency int      // Concurrent JavaScript fetches (default: 5)
+	Proxy       *url.URL // Route JavaScript fetches through this proxy (optional)
+}
+
+var (
+	// paramNamePattern limits extracted names to plausible parameter names
+	paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-\[\]]{0,39}$`)
+
+	// jsQueryParamPattern finds parameter names in query strings embedded in JS
+	jsQueryParamPattern = regexp.MustCompile(`[?&]([A-Za-z_][A-Za-z0-9_.\-\[\]]{0,39})=`)
+
+	// jsSearchParamsPattern finds names passed to URLSearchParams accessors
+	jsSearchParamsPattern = regexp.MustCompile(`\.(?:get|getAll|set|append|has|delete)\(\s*["']([A-Za-z_][A-Za-z0-9_.\-\[\]]{0,39})["']`)
+)
+
+// MineParameters aggregates query parameter names per host from collected
+// URLs and, optionally, the JavaScript files among them
+func MineParameters(urlResults *URLResults, options ParamMiningOptions) (*ParamResults, error) {
+	if options.MaxJSFiles == 0 {
+		options.MaxJSFiles = 50
+	}
+	if options.Concurrency == 0 {
+		options.Concurrency = 5
+	}
+
+	results := &ParamResults{
+		Domain:      urlResults.Domain,
+		Timestamp:   time.Now(),
+		URLsScanned: len(urlResults.URLs),
+		Parameters:  make(map[string]int),
+		Hosts:       make(map[string][]string),
+	}
+
+	hostCounts := make(map[string]map[string]int)
+	add := func(host, name string) {
+		if !paramNamePattern.MatchString(name) {
+			return
+		}
+		host = strings.ToLower(host)
+		if hostCounts[host] == nil {
+			hostCounts[host] = make(map[string]int)
+		}
+		hostCounts[host][name]++
+		results.Parameters[name]++
+	}
+
+	var jsFiles []*url.URL
+	for _, raw := range urlResults.URLs {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		for name := range parsed.Query() {
+			add(parsed.Hostname(), name)
+		}
+		if strings.EqualFold(path.Ext(parsed.Path), ".js") {
+			jsFiles = append(jsFiles, parsed)
+		}
+	}
+
+	if options.ScanJS && len(jsFiles) > 0 {
+		if len(jsFiles) > options.MaxJSFiles {
+			jsFiles = jsFiles[:options.MaxJSFiles]
+		}
+
+		client := newSourceClient(options.Proxy)
+		client.Timeout = 30 * time.Second
+
+		semaphore := make(chan struct{}, options.Concurrency)
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+
+		for _, jsURL := range jsFiles {
+			wg.Add(1)
+			go func(jsURL *url.URL) {
+				defer wg.Done()
+
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				names, err := extractJSParameters(client, jsURL.String())
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					results.JSFilesFailed++
+					return
+				}
+				results.JSFilesScanned++
+				for _, name := range names {
+					add(jsURL.Hostname(), name)
+				}
+			}(jsURL)
+		}
+
+		wg.Wait()
+	}
+
+	for host, counts := range hostCounts {
+		results.Hosts[host] = TopCounts(counts, 0)
+	}
+
+	return results, nil
+}
+
+// extractJSParameters fetches a JavaScript file and returns the parameter
+// names referenced by embedded endpoints and URLSearchParams calls
+func extractJSParameters(client *http.Client, jsURL string) ([]string, error) {
+	resp, err := client.Get(jsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxJSFileSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, pattern := range []*regexp.Regexp{jsQueryParamPattern, jsSearchParamsPattern} {
+		for _, match := range pattern.FindAllSubmatch(body, -1) {
+			name := string(match[1])
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// Wordlist returns parameter names one per line, most frequent first, in
+// the format accepted by Arjun (-w) and ffuf (-w file:FUZZ). An empty host
+// returns names from every host.
+func (r *ParamResults) Wordlist(host string) string {
+	var names []string
+	if host == "" {
+		names = TopCounts(r.Parameters, 0)
+	} else {
+		names = r.Hosts[strings.ToLower(host)]
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	return strings.Join(names, "\n") + "\n"
+}
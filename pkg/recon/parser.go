@@ -21,6 +21,44 @@ func ValidateDomain(domain string) error {
 	return nil
 }
 
+// multiLabelSuffixes are common public suffixes with more than one label, so
+// registrable domains under them keep three labels (e.g., example.co.uk)
+var multiLabelSuffixes = map[string]bool{
+	"co.uk": true, "org.uk": true, "ac.uk": true, "gov.uk": true, "me.uk": true,
+	"com.au": true, "net.au": true, "org.au": true, "gov.au": true, "edu.au": true,
+	"co.nz": true, "org.nz": true, "co.jp": true, "ne.jp": true, "or.jp": true,
+	"co.kr": true, "co.in": true, "co.za": true, "co.il": true, "com.br": true,
+	"com.cn": true, "com.mx": true, "com.tr": true, "com.sg": true, "com.hk": true,
+	"com.tw": true, "com.ar": true, "com.my": true, "com.ph": true, "com.ua": true,
+}
+
+// RegistrableDomain returns the domain a registrant would own for a hostname
+// (e.g., app.example.co.uk → example.co.uk)
+func RegistrableDomain(host string) string {
+	parts := strings.Split(strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(host, "*."), ".")), ".")
+	if len(parts) <= 2 {
+		return strings.Join(parts, ".")
+	}
+	labels := 2
+	if multiLabelSuffixes[strings.Join(parts[len(parts)-2:], ".")] {
+		labels = 3
+	}
+	return strings.Join(parts[len(parts)-labels:], ".")
+}
+
+// RegistrableDomains returns the sorted unique registrable domains of hosts,
+// skipping anything that is not a valid domain
+func RegistrableDomains(hosts []string) []string {
+	var domains []string
+	for _, host := range hosts {
+		domain := RegistrableDomain(strings.TrimSpace(host))
+		if ValidateDomain(domain) == nil {
+			domains = append(domains, domain)
+		}
+	}
+	return SortDomains(Deduplicate(domains))
+}
+
 // CleanDomains removes duplicates, wildcards, and sorts domains
 func CleanDomains(domains []string) []string {
 	// Remove wildcards first
@@ -0,0 +1,226 @@
+package recon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Metadata keys under which collected paths are stored on a subdomain
+const (
+	MetadataRobotsDisallow = "robots_disallow"
+	MetadataSitemapURLs    = "sitemap_urls"
+	MetadataSecurityTxt    = "security_txt"
+)
+
+// maxSitemapURLs caps how many sitemap URLs are stored per host
+const maxSitemapURLs = 500
+
+// PathsResult holds paths collected from robots.txt, sitemap.xml, and
+// security.txt on an alive host
+type PathsResult struct {
+	RobotsDisallow []string // Disallowed paths from robots.txt
+	SitemapURLs    []string // URLs listed in sitemap.xml
+	SecurityTxt    []string // "Field: value" directives from security.txt
+}
+
+// collectPaths fetches the well-known discovery files relative to baseURL
+func collectPaths(client *http.Client, baseURL string, options VerifyOptions) *PathsResult {
+	base := strings.TrimSuffix(baseURL, "/")
+	result := &PathsResult{}
+
+	var sitemaps []string
+	if body, ok := fetchText(client, base+"/robots.txt", options); ok {
+		result.RobotsDisallow, sitemaps = parseRobots(body)
+	}
+
+	// robots.txt may point at sitemaps other than the default location
+	sitemaps = append([]string{base + "/sitemap.xml"}, sitemaps...)
+	seen := make(map[string]bool)
+	for _, sitemap := range sitemaps {
+		if seen[sitemap] || len(seen) >= 3 {
+			continue
+		}
+		seen[sitemap] = true
+
+		if body, ok := fetchText(client, sitemap, options); ok {
+			result.SitemapURLs = append(result.SitemapURLs, parseSitemap(body)...)
+		}
+		if len(result.SitemapURLs) >= maxSitemapURLs {
+			result.SitemapURLs = result.SitemapURLs[:maxSitemapURLs]
+			break
+		}
+	}
+
+	if body, ok := fetchText(client, base+"/.well-known/security.txt", options); ok {
+		result.SecurityTxt = parseSecurityTxt(body)
+	}
+
+	return result
+}
+
+// fetchText downloads a small text file, rejecting HTML soft-404 pages
+func fetchText(client *http.Client, url string, options VerifyOptions) ([]byte, bool) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, false
+	}
+	setRequestHeaders(req, options)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024)) // Read max 512KB
+	if err != nil || len(body) == 0 {
+		return nil, false
+	}
+
+	trimmed := bytes.ToLower(bytes.TrimSpace(body))
+	if bytes.HasPrefix(trimmed, []byte("<!doctype html")) || bytes.HasPrefix(trimmed, []byte("<html")) {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// parseRobots extracts Disallow paths and Sitemap URLs from robots.txt
+func parseRobots(body []byte) (disallow, sitemaps []string) {
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "disallow":
+			if value != "/" && !seen[value] {
+				seen[value] = true
+				disallow = append(disallow, value)
+			}
+		case "sitemap":
+			sitemaps = append(sitemaps, value)
+		}
+	}
+
+	return disallow, sitemaps
+}
+
+// parseSitemap extracts <loc> URLs from a sitemap or sitemap index
+func parseSitemap(body []byte) []string {
+	var sitemap struct {
+		URLs []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	if err := xml.Unmarshal(body, &sitemap); err != nil {
+		return nil
+	}
+
+	var urls []string
+	for _, u := range sitemap.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			urls = append(urls, loc)
+		}
+	}
+	for _, s := range sitemap.Sitemaps {
+		if loc := strings.TrimSpace(s.Loc); loc != "" {
+			urls = append(urls, loc)
+		}
+	}
+
+	return urls
+}
+
+// parseSecurityTxt extracts directives from security.txt (RFC 9116). A file
+// without a Contact field is treated as not present.
+func parseSecurityTxt(body []byte) []string {
+	var directives []string
+	hasContact := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-----") {
+			continue
+		}
+
+		field, value, ok := strings.Cut(line, ":")
+		if !ok || strings.ContainsAny(field, " \t") {
+			continue
+		}
+		if strings.EqualFold(field, "Contact") {
+			hasContact = true
+		}
+		directives = append(directives, field+": "+strings.TrimSpace(value))
+	}
+
+	if !hasContact {
+		return nil
+	}
+	return directives
+}
+
+// MetadataStrings returns a string list stored in the subdomain's metadata,
+// handling values decoded from JSON as []interface{}
+func (s Subdomain) MetadataStrings(key string) []string {
+	switch values := s.Metadata[key].(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, v := range values {
+			if str, ok := v.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// applyPaths stores collected paths in the subdomain's metadata, replacing
+// paths collected by earlier verifications
+func applyPaths(sub *Subdomain, paths *PathsResult) {
+	if paths == nil {
+		return
+	}
+	if sub.Metadata == nil {
+		sub.Metadata = make(map[string]interface{})
+	}
+
+	set := func(key string, values []string) {
+		if len(values) > 0 {
+			sub.Metadata[key] = values
+		} else {
+			delete(sub.Metadata, key)
+		}
+	}
+	set(MetadataRobotsDisallow, paths.RobotsDisallow)
+	set(MetadataSitemapURLs, paths.SitemapURLs)
+	set(MetadataSecurityTxt, paths.SecurityTxt)
+}
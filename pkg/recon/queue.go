@@ -0,0 +1,134 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// queueFileName is the journal of server operations waiting for the server
+// to be reachable again, kept in the config directory
+const queueFileName = "queue.json"
+
+// Kinds of queued operations
+const (
+	QueueSyncPush = "sync-push" // Push a domain's results (Target: domain)
+)
+
+// QueuedOperation is a mutating server interaction saved while the server
+// was unreachable, replayed by 'recon sync flush'
+type QueuedOperation struct {
+	ID        string            `json:"id"`
+	Kind      string            `json:"kind"`
+	Target    string            `json:"target"`
+	Params    map[string]string `json:"params,omitempty"`
+	QueuedAt  time.Time         `json:"queued_at"`
+	Attempts  int               `json:"attempts,omitempty"`
+	LastError string            `json:"last_error,omitempty"`
+}
+
+// LoadQueue returns the queued operations, oldest first
+func LoadQueue() ([]QueuedOperation, error) {
+	var ops []QueuedOperation
+	err := withQueueLock(func(path string) error {
+		var err error
+		ops, err = readQueue(path)
+		return err
+	})
+	return ops, err
+}
+
+// EnqueueOperation adds an operation to the queue. It returns false when an
+// operation of the same kind, target, and parameters is already queued,
+// since replaying it once does the work of both.
+func EnqueueOperation(op QueuedOperation) (bool, error) {
+	added := false
+	err := UpdateQueue(func(ops []QueuedOperation) []QueuedOperation {
+		for _, queued := range ops {
+			if queued.Kind == op.Kind && queued.Target == op.Target && maps.Equal(queued.Params, op.Params) {
+				return ops
+			}
+		}
+		op.QueuedAt = time.Now()
+		op.ID = strconv.FormatInt(op.QueuedAt.UnixNano(), 36)
+		added = true
+		return append(ops, op)
+	})
+	return added, err
+}
+
+// UpdateQueue replaces the queued operations with what update returns,
+// holding the queue lock, so operations queued by other processes meanwhile
+// aren't lost
+func UpdateQueue(update func([]QueuedOperation) []QueuedOperation) error {
+	return withQueueLock(func(path string) error {
+		ops, err := readQueue(path)
+		if err != nil {
+			return err
+		}
+		ops = update(ops)
+		if len(ops) == 0 {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to clear queue: %w", err)
+			}
+			return nil
+		}
+
+		data, err := json.MarshalIndent(ops, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal queue: %w", err)
+		}
+		if err := writeFileAtomic(path, data, 0600); err != nil {
+			return fmt.Errorf("failed to write queue: %w", err)
+		}
+		return nil
+	})
+}
+
+// withQueueLock runs fn with the path of the queue file while holding an
+// exclusive lock on it
+func withQueueLock(fn func(path string) error) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(configDir, queueFileName)
+
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open queue lock: %w", err)
+	}
+	defer file.Close()
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock queue: %w", err)
+	}
+	defer unlockFile(file)
+
+	return fn(path)
+}
+
+// readQueue reads the queue file, empty when it doesn't exist
+func readQueue(path string) ([]QueuedOperation, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	var ops []QueuedOperation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse queue: %w", err)
+	}
+	return ops, nil
+}
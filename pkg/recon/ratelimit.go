@@ -0,0 +1,121 @@
+package recon
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared across all probe goroutines, with an
+// optional minimum delay between consecutive requests to the same host
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // Tokens added per second (0 = unlimited)
+	burst     float64
+	tokens    float64
+	last      time.Time
+	hostDelay time.Duration
+	hostNext  map[string]time.Time
+}
+
+// NewRateLimiter creates a limiter allowing perSecond requests per second
+// overall and waiting at least hostDelay between requests to the same host
+func NewRateLimiter(perSecond float64, hostDelay time.Duration) *RateLimiter {
+	burst := perSecond
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:      perSecond,
+		burst:     burst,
+		tokens:    burst,
+		last:      time.Now(),
+		hostDelay: hostDelay,
+		hostNext:  make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until a request to host is allowed
+func (r *RateLimiter) Wait(host string) {
+	if r == nil {
+		return
+	}
+	time.Sleep(r.reserve(host))
+}
+
+// reserve claims a token and a host slot, returning how long to wait
+func (r *RateLimiter) reserve(host string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+
+	if r.rate > 0 {
+		// Refill tokens for elapsed time
+		r.tokens += now.Sub(r.last).Seconds() * r.rate
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+		r.last = now
+
+		r.tokens--
+		if r.tokens < 0 {
+			wait = time.Duration(-r.tokens / r.rate * float64(time.Second))
+		}
+	}
+
+	if r.hostDelay > 0 {
+		start := now.Add(wait)
+		if next, ok := r.hostNext[host]; ok && next.After(start) {
+			wait = next.Sub(now)
+			start = next
+		}
+		r.hostNext[host] = start.Add(r.hostDelay)
+	}
+
+	return wait
+}
+
+// ParseRateLimit parses a rate such as "5/s", "100/m", or "5" (per second)
+// into requests per second
+func ParseRateLimit(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	count, unit, found := strings.Cut(value, "/")
+	rate, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil || rate < 0 {
+		return 0, fmt.Errorf("invalid rate limit: %s (use e.g. 5/s or 100/m)", value)
+	}
+	if !found {
+		return rate, nil
+	}
+
+	switch strings.TrimSpace(unit) {
+	case "s", "sec", "second":
+		return rate, nil
+	case "m", "min", "minute":
+		return rate / 60, nil
+	case "h", "hour":
+		return rate / 3600, nil
+	default:
+		return 0, fmt.Errorf("invalid rate limit unit: %s (use s, m, or h)", unit)
+	}
+}
+
+// limitedTransport applies a RateLimiter to every request, including redirects
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.limiter.Wait(req.URL.Hostname())
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,106 @@
+package recon
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testHostDelay = 200 * time.Millisecond
+
+// minHostGap is the smallest gap accepted between requests to one host; the
+// server timestamps requests after the client reserved its slot, so allow a
+// little jitter
+const minHostGap = testHostDelay - 20*time.Millisecond
+
+// requestLog records when a test server received each request
+type requestLog struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (l *requestLog) record() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.times = append(l.times, time.Now())
+}
+
+func (l *requestLog) snapshot() []time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]time.Time(nil), l.times...)
+}
+
+func TestLimiterDelaysRedirects(t *testing.T) {
+	var log requestLog
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.record()
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/next", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	options := DefaultVerifyOptions()
+	options.Limiter = NewRateLimiter(0, testHostDelay)
+
+	resp, err := newProbeClient(options).Get(server.URL)
+	if err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	resp.Body.Close()
+
+	times := log.snapshot()
+	if len(times) != 2 {
+		t.Fatalf("server saw %d requests, want 2 (request and redirect)", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap < minHostGap {
+		t.Errorf("redirect followed after %v, want at least %v", gap, testHostDelay)
+	}
+}
+
+func TestLimiterDelaysIPv6Fallback(t *testing.T) {
+	listener, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 loopback unavailable: %v", err)
+	}
+
+	var log requestLog
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.record()
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	const host = "v6only.example.test"
+
+	options := DefaultVerifyOptions()
+	options.Limiter = NewRateLimiter(0, testHostDelay)
+
+	// The hostname probe that failed before the fallback took the host's slot
+	start := time.Now()
+	options.Limiter.Wait(host)
+
+	resp, err := newPinnedProbeClient(options, "::1").Get("http://" + net.JoinHostPort(host, port) + "/")
+	if err != nil {
+		t.Fatalf("pinned probe failed: %v", err)
+	}
+	resp.Body.Close()
+
+	times := log.snapshot()
+	if len(times) != 1 {
+		t.Fatalf("server saw %d requests, want 1", len(times))
+	}
+	if gap := times[0].Sub(start); gap < minHostGap {
+		t.Errorf("IPv6 fallback sent after %v, want at least %v", gap, testHostDelay)
+	}
+}
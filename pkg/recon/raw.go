@@ -0,0 +1,178 @@
+package recon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// rawSidecarSuffix names the plain-text sidecar holding the raw outputs of a
+// result file: whois_20250131_140512.json keeps them in
+// whois_20250131_140512_raw.txt
+const rawSidecarSuffix = "_raw.txt"
+
+// RawRef locates a raw output stored in a result file's sidecar instead of
+// inline, keeping structured result files small and diffable
+type RawRef struct {
+	File   string `json:"file"`   // Sidecar file name, in the result file's directory
+	Offset int    `json:"offset"` // Byte offset of the output in the sidecar
+	Length int    `json:"length"` // Byte length of the output
+}
+
+// rawField pairs a raw output with the reference that replaces it on disk
+type rawField struct {
+	output *string
+	ref    **RawRef
+}
+
+// rawOutputCarrier is implemented by results holding raw tool output, which
+// SaveResults moves to a sidecar and loading reads back in
+type rawOutputCarrier interface {
+	rawFields() []rawField
+}
+
+func (w *WhoisInfo) rawField() rawField {
+	return rawField{output: &w.RawOutput, ref: &w.RawRef}
+}
+
+func (r *WhoisResults) rawFields() []rawField {
+	return []rawField{r.Info.rawField()}
+}
+
+func (r *IPWhoisResults) rawFields() []rawField {
+	fields := make([]rawField, len(r.IPs))
+	for i := range r.IPs {
+		fields[i] = r.IPs[i].rawField()
+	}
+	return fields
+}
+
+func (r *BulkWhoisResults) rawFields() []rawField {
+	fields := make([]rawField, len(r.Domains))
+	for i := range r.Domains {
+		fields[i] = r.Domains[i].rawField()
+	}
+	return fields
+}
+
+// rawSidecarName returns the name of the sidecar of a result file, which is
+// the same for its plain and compressed forms
+func rawSidecarName(resultFile string) string {
+	return trimResultExt(filepath.Base(resultFile)) + rawSidecarSuffix
+}
+
+// isRawSidecar reports whether a file name is a raw output sidecar
+func isRawSidecar(name string) bool {
+	return strings.HasSuffix(name, rawSidecarSuffix)
+}
+
+// externalizeRawOutputs replaces the raw outputs of a result with references
+// into sidecar contents, storing identical outputs once. It returns the
+// contents, nil when there are no raw outputs, and a func that restores the
+// result in memory.
+func externalizeRawOutputs(carrier rawOutputCarrier, sidecar string) ([]byte, func()) {
+	var contents bytes.Buffer
+	offsets := make(map[string]int)
+	var restores []func()
+
+	for _, field := range carrier.rawFields() {
+		output, ref := *field.output, *field.ref
+		if output == "" {
+			continue
+		}
+
+		offset, ok := offsets[output]
+		if !ok {
+			offset = contents.Len()
+			offsets[output] = offset
+			contents.WriteString(output)
+			contents.WriteString("\n")
+		}
+
+		field := field
+		restores = append(restores, func() {
+			*field.output = output
+			*field.ref = ref
+		})
+		*field.output = ""
+		*field.ref = &RawRef{File: sidecar, Offset: offset, Length: len(output)}
+	}
+
+	restore := func() {
+		for _, fn := range restores {
+			fn()
+		}
+	}
+	if contents.Len() == 0 {
+		return nil, restore
+	}
+	return contents.Bytes(), restore
+}
+
+// writeRawSidecar writes sidecar contents, sealed when the vault is enabled
+func writeRawSidecar(filePath string, contents []byte) error {
+	if config.VaultEnabled() {
+		var err error
+		if contents, err = config.VaultSeal(contents); err != nil {
+			return fmt.Errorf("failed to encrypt raw output: %w", err)
+		}
+	}
+	if err := writeFileAtomic(filePath, contents, 0600); err != nil {
+		return fmt.Errorf("failed to write raw output: %w", err)
+	}
+	return nil
+}
+
+// resolveRawOutputs reads the raw outputs a result loaded from filePath
+// references back from its sidecar. Results saved before sidecars existed
+// keep their outputs inline; a missing sidecar leaves the outputs empty.
+func resolveRawOutputs(filePath string, v interface{}) error {
+	carrier, ok := v.(rawOutputCarrier)
+	if !ok {
+		return nil
+	}
+
+	sidecars := make(map[string][]byte)
+	for _, field := range carrier.rawFields() {
+		ref := *field.ref
+		if ref == nil || *field.output != "" {
+			continue
+		}
+
+		contents, ok := sidecars[ref.File]
+		if !ok {
+			data, err := ReadResultFile(filepath.Join(filepath.Dir(filePath), filepath.Base(ref.File)))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read raw output: %w", err)
+			}
+			contents = data
+			sidecars[ref.File] = contents
+		}
+		if contents == nil {
+			continue
+		}
+
+		if ref.Offset < 0 || ref.Length < 0 || ref.Offset+ref.Length > len(contents) {
+			return fmt.Errorf("raw output reference outside %s", ref.File)
+		}
+		*field.output = string(contents[ref.Offset : ref.Offset+ref.Length])
+	}
+	return nil
+}
+
+// StripRawOutputs clears the raw outputs and sidecar references of a result,
+// e.g., for exports that leave raw output out
+func StripRawOutputs(v interface{}) {
+	carrier, ok := v.(rawOutputCarrier)
+	if !ok {
+		return
+	}
+	for _, field := range carrier.rawFields() {
+		*field.output = ""
+		*field.ref = nil
+	}
+}
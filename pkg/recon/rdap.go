@@ -0,0 +1,255 @@
+package recon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// rdapBootstrapURL redirects queries to the authoritative registry or RIR
+// RDAP server using the IANA bootstrap registries
+const rdapBootstrapURL = "https://rdap.org"
+
+// rdapResponse is the subset of an RDAP domain or IP network object (RFC 9083)
+// mapped into WhoisInfo
+type rdapResponse struct {
+	LDHName      string       `json:"ldhName"`
+	Name         string       `json:"name"` // IP network name
+	Country      string       `json:"country"`
+	StartAddress string       `json:"startAddress"`
+	EndAddress   string       `json:"endAddress"`
+	Port43       string       `json:"port43"`
+	Status       []string     `json:"status"`
+	Events       []rdapEvent  `json:"events"`
+	Entities     []rdapEntity `json:"entities"`
+	Links        []rdapLink   `json:"links"`
+	Nameservers  []struct {
+		LDHName string `json:"ldhName"`
+	} `json:"nameservers"`
+	CIDRs []struct {
+		V4Prefix string `json:"v4prefix"`
+		V6Prefix string `json:"v6prefix"`
+		Length   int    `json:"length"`
+	} `json:"cidr0_cidrs"`
+}
+
+type rdapEvent struct {
+	EventAction string `json:"eventAction"`
+	EventDate   string `json:"eventDate"`
+}
+
+type rdapLink struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+	Type string `json:"type"`
+}
+
+// rdapEntity is a contact (registrar, registrant, abuse) with a jCard
+type rdapEntity struct {
+	Roles      []string          `json:"roles"`
+	VCardArray []json.RawMessage `json:"vcardArray"`
+	Entities   []rdapEntity      `json:"entities"`
+	Links      []rdapLink        `json:"links"`
+}
+
+// LookupRDAP queries the authoritative RDAP server for a domain, IP, or CIDR
+// and normalizes the response into WhoisInfo. For domains, the registrar's
+// RDAP server is also queried when the registry links to it, since it holds
+// the registrant contact.
+func LookupRDAP(ctx context.Context, query string) (*WhoisInfo, error) {
+	client := newSourceClient(nil)
+
+	objectType := "domain"
+	if IsIPQuery(query) {
+		objectType = "ip"
+	}
+
+	registry, raw, err := fetchRDAP(ctx, client, fmt.Sprintf("%s/%s/%s", rdapBootstrapURL, objectType, query))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &WhoisInfo{
+		Domain:      query,
+		NameServers: []string{},
+		Status:      []string{},
+		Protocol:    "rdap",
+		RawOutput:   raw,
+	}
+	applyRDAP(info, registry, objectType)
+
+	if objectType == "domain" {
+		for _, link := range registry.Links {
+			if link.Rel != "related" || !strings.Contains(link.Type, "rdap+json") {
+				continue
+			}
+			// Registrar data is best-effort; the registry response already has
+			// dates, status, and nameservers
+			if registrar, raw, err := fetchRDAP(ctx, client, link.Href); err == nil {
+				applyRDAP(info, registrar, objectType)
+				info.RawOutput += "\n" + raw
+			}
+			break
+		}
+	}
+
+	return info, nil
+}
+
+// fetchRDAP retrieves and decodes an RDAP object, returning the raw JSON too
+func fetchRDAP(ctx context.Context, client *http.Client, rdapURL string) (*rdapResponse, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rdapURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("RDAP query failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("RDAP query failed: HTTP %d from %s", resp.StatusCode, resp.Request.URL.Host)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 5*1024*1024))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read RDAP response: %w", err)
+	}
+
+	var result rdapResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to parse RDAP response: %w", err)
+	}
+
+	return &result, string(body), nil
+}
+
+// applyRDAP fills empty WhoisInfo fields from an RDAP response, so the
+// first (registry) response takes precedence
+func applyRDAP(info *WhoisInfo, resp *rdapResponse, objectType string) {
+	setIfEmpty := func(field *string, value string) {
+		if *field == "" {
+			*field = strings.TrimSpace(value)
+		}
+	}
+
+	for _, event := range resp.Events {
+		switch event.EventAction {
+		case "registration":
+			setIfEmpty(&info.CreatedDate, event.EventDate)
+		case "last changed":
+			setIfEmpty(&info.UpdatedDate, event.EventDate)
+		case "expiration":
+			setIfEmpty(&info.ExpiryDate, event.EventDate)
+		}
+	}
+
+	if len(info.NameServers) == 0 {
+		for _, ns := range resp.Nameservers {
+			info.NameServers = append(info.NameServers, strings.ToLower(strings.TrimSuffix(ns.LDHName, ".")))
+		}
+	}
+	if len(info.Status) == 0 {
+		info.Status = append(info.Status, resp.Status...)
+	}
+	setIfEmpty(&info.WhoisServer, resp.Port43)
+
+	for _, entity := range resp.Entities {
+		for _, role := range entity.Roles {
+			switch role {
+			case "registrar":
+				setIfEmpty(&info.Registrar, entity.vcard("fn"))
+				for _, link := range entity.Links {
+					if link.Rel == "about" {
+						setIfEmpty(&info.RegistrarURL, link.Href)
+					}
+				}
+			case "registrant":
+				if objectType == "ip" {
+					setIfEmpty(&info.Organization, entity.vcard("org", "fn"))
+				} else {
+					setIfEmpty(&info.RegistrantOrg, entity.vcard("org", "fn"))
+					setIfEmpty(&info.RegistrantEmail, entity.vcard("email"))
+				}
+			case "abuse":
+				setIfEmpty(&info.AbuseEmail, entity.vcard("email"))
+			}
+		}
+
+		// Abuse contacts are usually nested under the registrar or registrant
+		for _, nested := range entity.Entities {
+			for _, role := range nested.Roles {
+				if role == "abuse" {
+					setIfEmpty(&info.AbuseEmail, nested.vcard("email"))
+				}
+			}
+		}
+	}
+
+	if objectType == "ip" {
+		setIfEmpty(&info.NetName, resp.Name)
+		setIfEmpty(&info.Country, strings.ToUpper(resp.Country))
+		if len(resp.CIDRs) > 0 {
+			cidr := resp.CIDRs[0]
+			prefix := cidr.V4Prefix
+			if prefix == "" {
+				prefix = cidr.V6Prefix
+			}
+			setIfEmpty(&info.Netblock, prefix+"/"+strconv.Itoa(cidr.Length))
+		} else if resp.StartAddress != "" {
+			setIfEmpty(&info.Netblock, resp.StartAddress+" - "+resp.EndAddress)
+		}
+		setIfEmpty(&info.RIR, detectRIR(resp.Port43+" "+rdapSelfHost(resp)))
+	}
+}
+
+// rdapSelfHost returns the host of the response's self link, which identifies
+// the answering registry when port43 is missing
+func rdapSelfHost(resp *rdapResponse) string {
+	for _, link := range resp.Links {
+		if link.Rel == "self" {
+			if parsed, err := url.Parse(link.Href); err == nil {
+				return parsed.Host
+			}
+		}
+	}
+	return ""
+}
+
+// vcard returns the first non-empty text value among the given jCard
+// properties (RFC 7095), e.g. vcard("org", "fn")
+func (e rdapEntity) vcard(names ...string) string {
+	if len(e.VCardArray) < 2 {
+		return ""
+	}
+
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(e.VCardArray[1], &properties); err != nil {
+		return ""
+	}
+
+	for _, name := range names {
+		for _, property := range properties {
+			if len(property) < 4 {
+				continue
+			}
+			var propertyName, value string
+			if json.Unmarshal(property[0], &propertyName) != nil || propertyName != name {
+				continue
+			}
+			// Structured values (e.g., adr) are arrays; only text values are used
+			if json.Unmarshal(property[3], &value) == nil && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package recon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReportSeverities lists report finding severities, most severe first
+var ReportSeverities = []string{"critical", "high", "medium", "low", "info"}
+
+// Report merges the latest results of every module for one domain into the
+// data of a narrative report. Modules that have not been run are nil or
+// empty.
+type Report struct {
+	Domain      string
+	GeneratedAt time.Time
+	Summary     ReportSummary
+	Methodology []ReportStep
+	Assets      []InventoryAsset
+	Ports       []PortResult
+	Findings    []ReportFinding // Most severe first
+	Subdomains  *SubdomainResults
+	DNS         *DNSResults
+	Whois       *WhoisResults
+	Nuclei      *NucleiResults
+}
+
+// ReportSummary holds the headline numbers of the executive summary
+type ReportSummary struct {
+	Subdomains     int
+	Verified       int
+	Alive          int
+	WebPorts       int // Accessible web ports across alive hosts
+	TakeoverRisks  int
+	DanglingRisks  int
+	CloudProviders []string
+	Technologies   []string // Most common first
+	Registrar      string
+	ExpiryDate     string
+	Severities     map[string]int // Findings per severity
+}
+
+// ReportStep describes one module run that fed the report
+type ReportStep struct {
+	Module  string
+	RanAt   time.Time
+	Details string
+}
+
+// ReportFinding is a notable finding from any module
+type ReportFinding struct {
+	Severity  string // critical, high, medium, low, or info
+	Category  string // Takeover, Dangling DNS, Misconfiguration, Nuclei, or Anomaly
+	Subdomain string
+	Title     string
+	Detail    string
+}
+
+// BuildReport loads the latest subdomain, verification, DNS, WHOIS, and
+// nuclei results of a domain. Subdomain results are required; every other
+// module is optional.
+func BuildReport(domain string) (*Report, error) {
+	subdomains, err := GetLatestSubdomainResult(domain)
+	if err != nil {
+		return nil, fmt.Errorf("no subdomain results for %s: %w", domain, err)
+	}
+
+	report := &Report{
+		Domain:      domain,
+		GeneratedAt: time.Now(),
+		Subdomains:  subdomains,
+		Findings:    []ReportFinding{},
+	}
+	if dnsResults, err := LoadDNSResults(domain); err == nil {
+		report.DNS = dnsResults
+	}
+	if whois, err := LoadWhoisResults(domain); err == nil {
+		report.Whois = whois
+	}
+	var nuclei NucleiResults
+	if err := LoadLatestResult(domain, "nuclei", &nuclei); err == nil {
+		report.Nuclei = &nuclei
+	}
+
+	inventory, assets := inventoryDomain(domain, subdomains, report.DNS)
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].Name < assets[j].Name
+	})
+	report.Assets = assets
+	report.Ports = QueryPorts(subdomains, PortQueryOptions{AccessibleOnly: true})
+
+	report.Summary = ReportSummary{
+		Subdomains:     inventory.Assets,
+		Verified:       inventory.Verified,
+		Alive:          inventory.Alive,
+		WebPorts:       len(report.Ports),
+		TakeoverRisks:  inventory.TakeoverRisks,
+		CloudProviders: inventory.CloudProviders,
+		Severities:     make(map[string]int),
+	}
+	techs := make(map[string]int)
+	for _, asset := range assets {
+		if asset.Status == "alive" {
+			for _, tech := range asset.Tech {
+				techs[tech]++
+			}
+		}
+	}
+	report.Summary.Technologies = TopCounts(techs, 0)
+	if report.DNS != nil {
+		report.Summary.DanglingRisks = report.DNS.Summary.DanglingRisks
+	}
+	if report.Whois != nil {
+		report.Summary.Registrar = report.Whois.Info.Registrar
+		report.Summary.ExpiryDate = report.Whois.Info.ExpiryDate
+	}
+
+	report.Methodology = reportMethodology(report)
+	report.Findings = reportFindings(report)
+	for _, finding := range report.Findings {
+		report.Summary.Severities[finding.Severity]++
+	}
+
+	return report, nil
+}
+
+// reportMethodology describes the module runs behind a report
+func reportMethodology(report *Report) []ReportStep {
+	subdomains := report.Subdomains
+	steps := []ReportStep{{
+		Module:  "Subdomain enumeration",
+		RanAt:   subdomains.Timestamp,
+		Details: fmt.Sprintf("%d unique subdomains from passive sources (%s)", subdomains.TotalUnique, strings.Join(subdomains.SourcesUsed, ", ")),
+	}}
+
+	if report.Summary.Verified > 0 {
+		var verifiedAt time.Time
+		for _, sub := range subdomains.Subdomains {
+			if sub.Verified != nil && sub.Verified.Timestamp.After(verifiedAt) {
+				verifiedAt = sub.Verified.Timestamp
+			}
+		}
+		steps = append(steps, ReportStep{
+			Module:  "Verification",
+			RanAt:   verifiedAt,
+			Details: fmt.Sprintf("DNS resolution and HTTP probing (%d subdomains verified, %d alive)", report.Summary.Verified, report.Summary.Alive),
+		})
+	}
+	if report.DNS != nil {
+		steps = append(steps, ReportStep{
+			Module:  "DNS enumeration",
+			RanAt:   report.DNS.EnumeratedAt,
+			Details: fmt.Sprintf("Record lookups with takeover and dangling record checks (%d subdomains)", report.DNS.TotalQueried),
+		})
+	}
+	if report.Whois != nil {
+		steps = append(steps, ReportStep{
+			Module:  "WHOIS",
+			RanAt:   report.Whois.LookedUpAt,
+			Details: "Registration lookup for " + report.Whois.Info.Domain,
+		})
+	}
+	if report.Nuclei != nil {
+		steps = append(steps, ReportStep{
+			Module:  "Nuclei",
+			RanAt:   report.Nuclei.Timestamp,
+			Details: fmt.Sprintf("Low-impact template scan of %d alive hosts", report.Nuclei.Targets),
+		})
+	}
+
+	return steps
+}
+
+// reportFindings collects the notable findings of every module, most severe
+// first
+func reportFindings(report *Report) []ReportFinding {
+	findings := []ReportFinding{}
+
+	if report.DNS != nil {
+		for _, info := range report.DNS.Records {
+			if info.TakeoverRisk {
+				severity := "high"
+				if info.TakeoverScore >= 8 {
+					severity = "critical"
+				}
+				findings = append(findings, ReportFinding{
+					Severity:  severity,
+					Category:  "Takeover",
+					Subdomain: info.Subdomain,
+					Title:     "Possible subdomain takeover",
+					Detail:    strings.TrimSpace(info.TakeoverReason + " " + strings.Join(info.CNAME, ", ")),
+				})
+			}
+			for _, dangling := range info.Dangling {
+				findings = append(findings, ReportFinding{
+					Severity:  "high",
+					Category:  "Dangling DNS",
+					Subdomain: info.Subdomain,
+					Title:     fmt.Sprintf("Dangling %s record: %s", dangling.Type, dangling.Value),
+					Detail:    dangling.Reason,
+				})
+			}
+		}
+	}
+
+	for _, sub := range report.Subdomains.Subdomains {
+		if sub.Verified == nil {
+			continue
+		}
+		for _, finding := range sub.Verified.Findings {
+			findings = append(findings, ReportFinding{
+				Severity:  "medium",
+				Category:  "Misconfiguration",
+				Subdomain: sub.Name,
+				Title:     strings.ReplaceAll(finding.Type, "_", " "),
+				Detail:    finding.Detail,
+			})
+		}
+	}
+
+	if report.Nuclei != nil {
+		for _, finding := range report.Nuclei.Findings {
+			severity := finding.Severity
+			if !contains(ReportSeverities, severity) {
+				severity = "info"
+			}
+			findings = append(findings, ReportFinding{
+				Severity:  severity,
+				Category:  "Nuclei",
+				Subdomain: finding.Host,
+				Title:     finding.Name,
+				Detail:    finding.MatchedAt,
+			})
+		}
+	}
+
+	for _, finding := range DetectAnomalies(report.Subdomains.Subdomains) {
+		findings = append(findings, ReportFinding{
+			Severity:  "info",
+			Category:  "Anomaly",
+			Subdomain: finding.Subdomain,
+			Title:     strings.ReplaceAll(finding.Type, "_", " "),
+			Detail:    finding.Detail,
+		})
+	}
+
+	rank := make(map[string]int, len(ReportSeverities))
+	for i, severity := range ReportSeverities {
+		rank[severity] = i
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return rank[findings[i].Severity] < rank[findings[j].Severity]
+	})
+	return findings
+}
@@ -0,0 +1,65 @@
+package recon
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ParseHeaders parses "Name: value" strings into request headers, such as
+// the collaborator header a bug bounty program requires
+func ParseHeaders(raw []string) (http.Header, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	headers := make(http.Header)
+	for _, h := range raw {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q (expected 'Name: value')", h)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+
+	return headers, nil
+}
+
+// ParseBasicAuth parses "user:pass" credentials. An empty string means no
+// authentication.
+func ParseBasicAuth(raw string) (*url.Userinfo, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	user, pass, ok := strings.Cut(raw, ":")
+	if !ok || user == "" {
+		return nil, fmt.Errorf("invalid basic auth %q (expected user:pass)", raw)
+	}
+
+	return url.UserPassword(user, pass), nil
+}
+
+// setRequestHeaders applies the user agent, custom headers, and basic auth
+// from options to an outgoing probe request
+func setRequestHeaders(req *http.Request, options VerifyOptions) {
+	req.Header.Set("User-Agent", options.UserAgent)
+
+	for name, values := range options.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = values[0]
+			continue
+		}
+		req.Header.Del(name)
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if options.BasicAuth != nil {
+		pass, _ := options.BasicAuth.Password()
+		req.SetBasicAuth(options.BasicAuth.Username(), pass)
+	}
+}
@@ -0,0 +1,223 @@
+package recon
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResolverFailures is the number of consecutive failures after which a
+// resolver is skipped while healthier resolvers remain
+const maxResolverFailures = 5
+
+// ResolverPool rotates DNS queries across a set of nameservers, tracking
+// failures per resolver so unreliable servers are avoided
+type ResolverPool struct {
+	mu      sync.Mutex
+	servers []string
+	next    int
+	stats   map[string]*ResolverStats
+
+	dohClient *http.Client // Set when the pool resolves over DNS-over-HTTPS
+}
+
+// ResolverStats tracks query outcomes for a single resolver
+type ResolverStats struct {
+	Server              string `json:"server"`
+	Queries             int    `json:"queries"`
+	Failures            int    `json:"failures"`
+	ConsecutiveFailures int    `json:"-"`
+}
+
+// NewResolverPool creates a pool from nameserver addresses. Addresses without
+// a port default to port 53. Hostnames (such as a target's authoritative
+// nameserver, for split-horizon checks) are resolved once here.
+func NewResolverPool(servers []string) (*ResolverPool, error) {
+	pool := &ResolverPool{
+		stats: make(map[string]*ResolverStats),
+	}
+
+	for _, server := range servers {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			continue
+		}
+
+		host, port, err := net.SplitHostPort(server)
+		if err != nil {
+			host, port = server, "53"
+		}
+		if net.ParseIP(host) == nil {
+			addrs, err := net.LookupHost(host)
+			if err != nil || len(addrs) == 0 {
+				return nil, fmt.Errorf("invalid resolver address: %s", server)
+			}
+			host = addrs[0]
+		}
+		server = net.JoinHostPort(host, port)
+
+		if _, exists := pool.stats[server]; exists {
+			continue
+		}
+		pool.servers = append(pool.servers, server)
+		pool.stats[server] = &ResolverStats{Server: server}
+	}
+
+	if len(pool.servers) == 0 {
+		return nil, fmt.Errorf("no resolvers specified")
+	}
+
+	return pool, nil
+}
+
+// LoadResolversFile reads nameserver addresses from a file, one per line.
+// Blank lines and lines starting with # are ignored.
+func LoadResolversFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open resolvers file: %w", err)
+	}
+	defer file.Close()
+
+	var servers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		servers = append(servers, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read resolvers file: %w", err)
+	}
+
+	return servers, nil
+}
+
+// Resolver returns a resolver bound to the next healthy nameserver in the
+// pool, along with the nameserver address for failure reporting. A nil pool
+// returns the system resolver.
+func (p *ResolverPool) Resolver() (*net.Resolver, string) {
+	if p == nil {
+		return &net.Resolver{PreferGo: true}, ""
+	}
+
+	server := p.pick()
+
+	if p.dohClient != nil {
+		return &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return &dohConn{ctx: ctx, client: p.dohClient, endpoint: server}, nil
+			},
+		}, server
+	}
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, server)
+		},
+	}, server
+}
+
+// Server returns the next healthy nameserver address in the pool for direct
+// queries. A nil pool returns "" so queries go to the system nameservers.
+func (p *ResolverPool) Server() string {
+	if p == nil {
+		return ""
+	}
+	return p.pick()
+}
+
+// pick selects the next nameserver round-robin, skipping failing ones
+func (p *ResolverPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := 0; i < len(p.servers); i++ {
+		server := p.servers[p.next]
+		p.next = (p.next + 1) % len(p.servers)
+
+		if p.stats[server].ConsecutiveFailures < maxResolverFailures {
+			p.stats[server].Queries++
+			return server
+		}
+	}
+
+	// Every resolver is failing; keep rotating rather than giving up
+	server := p.servers[p.next]
+	p.next = (p.next + 1) % len(p.servers)
+	p.stats[server].Queries++
+	return server
+}
+
+// Report records the outcome of a query made against server. NXDOMAIN and
+// similar "not found" answers count as successes since the resolver answered.
+func (p *ResolverPool) Report(server string, err error) {
+	if p == nil || server == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats, ok := p.stats[server]
+	if !ok {
+		return
+	}
+
+	if isResolverFailure(err) {
+		stats.Failures++
+		stats.ConsecutiveFailures++
+	} else {
+		stats.ConsecutiveFailures = 0
+	}
+}
+
+// Stats returns a snapshot of per-resolver statistics sorted by address
+func (p *ResolverPool) Stats() []ResolverStats {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]ResolverStats, 0, len(p.stats))
+	for _, s := range p.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Server < stats[j].Server
+	})
+
+	return stats
+}
+
+// isResolverFailure reports whether err indicates the resolver itself failed
+// (timeout, refused, server failure) rather than the name not existing
+func isResolverFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+
+	return true
+}
@@ -1,35 +1,50 @@
 package recon
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // ResultInfo represents metadata about a stored result file
 type ResultInfo struct {
-	Domain      string
-	ToolName    string
-	Timestamp   time.Time
-	FilePath    string
-	FileSize    int64
-	TotalCount  int
-	AliveCount  int
-	DeadCount   int
-	Verified    bool
-	SourcesUsed []string
+	Domain      string    `json:"domain"`
+	ToolName    string    `json:"tool"`
+	Timestamp   time.Time `json:"timestamp"`
+	FilePath    string    `json:"file"`
+	FileSize    int64     `json:"size"`
+	TotalCount  int       `json:"total"`
+	AliveCount  int       `json:"alive"`
+	DeadCount   int       `json:"dead"`
+	Verified    bool      `json:"verified"`
+	SourcesUsed []string  `json:"sources,omitempty"`
 }
 
 // QueryOptions configures result filtering
 type QueryOptions struct {
-	AliveOnly  bool
-	DeadOnly   bool
-	StatusCode int
-	Source     string
+	AliveOnly     bool
+	DeadOnly      bool
+	StatusCode    int
+	Source        string
+	MissingHeader string      // Only accessible hosts lacking this header (e.g. "hsts")
+	FaviconHash   *int32      // Only hosts whose favicon matches this hash
+	FinalURL      string      // Only hosts whose final URL contains this substring
+	CrossDomain   bool        // Only hosts that redirect off their base domain
+	Tech          string      // Only hosts with a technology containing this text
+	TitleContains string      // Only hosts whose page title contains this text
+	Cloud         string      // Only subdomains hosted by this cloud provider (needs DNS)
+	TakeoverRisk  bool        // Only subdomains flagged as takeover risks (needs DNS)
+	IPCIDR        *net.IPNet  // Only subdomains with an IP in this range
+	DNS           *DNSResults // DNS results for the Cloud and TakeoverRisk filters
+	Tag           string      // Only subdomains tagged with this tag
 }
 
 // ListResults lists all stored results grouped by domain
@@ -71,205 +86,607 @@ func ListResults() (map[string][]ResultInfo, error) {
 	return resultsByDomain, nil
 }
 
-// ListResultsForDomain lists all results for a specific domain
+// ListResultsForDomain lists all results for a specific domain, newest
+// first, from the domain's results manifest
 func ListResultsForDomain(domain string) ([]ResultInfo, error) {
 	domainDir, err := GetDomainResultsDir(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if directory exists
-	if _, err := os.Stat(domainDir); os.IsNotExist(err) {
-		return []ResultInfo{}, nil
+	entries, err := loadManifest(domain)
+	if err != nil {
+		return nil, err
 	}
 
-	// Find all JSON files
-	pattern := filepath.Join(domainDir, "*.json")
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search for results: %w", err)
+	results := make([]ResultInfo, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, entry.resultInfo(domain, domainDir))
 	}
 
-	var results []ResultInfo
+	return results, nil
+}
 
-	for _, filePath := range matches {
-		// Parse filename to extract tool name and timestamp
-		filename := filepath.Base(filePath)
-		parts := strings.Split(strings.TrimSuffix(filename, ".json"), "_")
+// LoadSubdomainResult loads a specific subdomain result by timestamp
+func LoadSubdomainResult(domain string, timestamp time.Time) (*SubdomainResults, error) {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return nil, err
+	}
 
-		if len(parts) < 3 {
+	entries, err := loadManifest(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Tool != "subdomains" || !entry.Timestamp.Equal(timestamp) {
 			continue
 		}
 
-		toolName := parts[0]
-		timestampStr := strings.Join(parts[1:], "_")
+		var result SubdomainResults
+		if err := loadJSONFile(filepath.Join(domainDir, entry.File), &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
 
-		// Parse timestamp
-		timestamp, err := time.Parse("20060102_150405", timestampStr)
-		if err != nil {
+	return nil, fmt.Errorf("no subdomain results from %s found for %s", timestamp.Format("2006-01-02 15:04:05"), domain)
+}
+
+// GetLatestSubdomainResult loads the most recent subdomain scan for a domain
+func GetLatestSubdomainResult(domain string) (*SubdomainResults, error) {
+	return GetSubdomainResult(domain, ResultSelection{})
+}
+
+// GetSubdomainResult loads the subdomain scan chosen by selection
+func GetSubdomainResult(domain string, selection ResultSelection) (*SubdomainResults, error) {
+	var result SubdomainResults
+	if _, err := LoadSelectedResult(domain, "subdomains", selection, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MergePreviousVerification copies verification data and history from older
+// subdomain scans into subdomains of results that have not been verified yet,
+// so a fresh enumeration keeps what earlier verifications learned. It returns
+// the number of subdomains that received carried-over data.
+func MergePreviousVerification(domain string, results *SubdomainResults) (int, error) {
+	resultInfos, err := ListResultsForDomain(domain)
+	if err != nil {
+		return 0, err
+	}
+
+	// Collect the newest verification for each subdomain
+	previous := make(map[string]Subdomain)
+	for _, info := range resultInfos {
+		if info.ToolName != "subdomains" || !info.Verified {
 			continue
 		}
 
-		// Get file size
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
+		var older SubdomainResults
+		if err := loadJSONFile(info.FilePath, &older); err != nil {
 			continue
 		}
 
-		// Load file to get counts
-		resultInfo := ResultInfo{
-			Domain:    domain,
-			ToolName:  toolName,
-			Timestamp: timestamp,
-			FilePath:  filePath,
-			FileSize:  fileInfo.Size(),
-		}
-
-		// Parse file to get statistics
-		if toolName == "subdomains" {
-			var subResult SubdomainResults
-			if err := loadJSONFile(filePath, &subResult); err == nil {
-				resultInfo.TotalCount = subResult.TotalUnique
-				resultInfo.SourcesUsed = subResult.SourcesUsed
-
-				// Count alive and dead
-				aliveCount := 0
-				deadCount := 0
-				hasVerified := false
-
-				for _, sub := range subResult.Subdomains {
-					if sub.Verified != nil {
-						hasVerified = true
-						if sub.Verified.Status == "alive" {
-							aliveCount++
-						} else if sub.Verified.Status == "dead" {
-							deadCount++
-						}
-					}
-				}
-
-				resultInfo.AliveCount = aliveCount
-				resultInfo.DeadCount = deadCount
-				resultInfo.Verified = hasVerified
+		for _, sub := range older.Subdomains {
+			if sub.Verified == nil {
+				continue
+			}
+			key := strings.ToLower(sub.Name)
+			if _, seen := previous[key]; !seen {
+				previous[key] = sub
 			}
 		}
-
-		results = append(results, resultInfo)
 	}
 
-	// Sort by timestamp (newest first)
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Timestamp.After(results[j].Timestamp)
-	})
+	merged := 0
+	for i := range results.Subdomains {
+		sub := &results.Subdomains[i]
+		if sub.Verified != nil {
+			continue
+		}
+		if prev, ok := previous[strings.ToLower(sub.Name)]; ok {
+			sub.Verified = prev.Verified
+			sub.History = prev.History
+			merged++
+		}
+	}
 
-	return results, nil
+	return merged, nil
 }
 
-// LoadSubdomainResult loads a specific subdomain result by timestamp
-func LoadSubdomainResult(domain string, timestamp time.Time) (*SubdomainResults, error) {
-	domainDir, err := GetDomainResultsDir(domain)
+// QuerySubdomains filters the latest subdomains based on query options
+func QuerySubdomains(domain string, options QueryOptions) ([]Subdomain, error) {
+	result, err := GetLatestSubdomainResult(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build expected filename
-	timestampStr := timestamp.Format("20060102_150405")
-	filename := fmt.Sprintf("subdomains_%s.json", timestampStr)
-	filePath := filepath.Join(domainDir, filename)
+	return FilterSubdomains(result.Subdomains, options)
+}
 
-	var result SubdomainResults
-	if err := loadJSONFile(filePath, &result); err != nil {
+// FilterSubdomains validates query options and filters subdomains with them
+func FilterSubdomains(subdomains []Subdomain, options QueryOptions) ([]Subdomain, error) {
+	if err := options.Validate(); err != nil {
 		return nil, err
 	}
+	return MatchSubdomains(subdomains, options), nil
+}
 
-	return &result, nil
+// Validate checks query options that can be invalid
+func (o QueryOptions) Validate() error {
+	if o.MissingHeader != "" {
+		if _, err := CanonicalHeaderName(o.MissingHeader); err != nil {
+			return err
+		}
+	}
+	if (o.Cloud != "" || o.TakeoverRisk) && o.DNS == nil {
+		return fmt.Errorf("cloud and takeover filters need DNS results")
+	}
+	return nil
 }
 
-// GetLatestSubdomainResult loads the most recent subdomain scan for a domain
-func GetLatestSubdomainResult(domain string) (*SubdomainResults, error) {
-	results, err := ListResultsForDomain(domain)
-	if err != nil {
-		return nil, err
+// MatchSubdomains returns the subdomains matching every set query option.
+// It is the filtering engine shared by views and exports; options are
+// expected to be valid.
+func MatchSubdomains(subdomains []Subdomain, options QueryOptions) []Subdomain {
+	dnsBySubdomain := make(map[string]*DNSInfo)
+	if options.DNS != nil {
+		for i := range options.DNS.Records {
+			dnsBySubdomain[options.DNS.Records[i].Subdomain] = &options.DNS.Records[i]
+		}
 	}
 
-	// Filter to only subdomain results
-	var subdomainResults []ResultInfo
-	for _, r := range results {
-		if r.ToolName == "subdomains" {
-			subdomainResults = append(subdomainResults, r)
+	var filtered []Subdomain
+	for _, sub := range subdomains {
+		if matchSubdomain(sub, dnsBySubdomain[sub.Name], options) {
+			filtered = append(filtered, sub)
 		}
 	}
 
-	if len(subdomainResults) == 0 {
-		return nil, fmt.Errorf("no subdomain results found for %s", domain)
+	return filtered
+}
+
+// Group-by keys supported by GroupSubdomains
+const (
+	GroupByStatus = "status"
+	GroupByTech   = "tech"
+	GroupBySource = "source"
+	GroupByCloud  = "cloud"
+)
+
+// GroupSubdomains groups subdomains by verification status, technology,
+// discovery source, or cloud provider, which comes from dns. A subdomain
+// with several technologies or sources appears in each of their groups;
+// subdomains without the data are grouped under "unverified" (status) or
+// "Unknown".
+func GroupSubdomains(subdomains []Subdomain, by string, dns *DNSResults) (map[string][]Subdomain, error) {
+	var cloudBySubdomain map[string]string
+	switch by {
+	case GroupByStatus, GroupByTech, GroupBySource:
+	case GroupByCloud:
+		if dns == nil {
+			return nil, fmt.Errorf("grouping by cloud needs DNS results")
+		}
+		cloudBySubdomain = make(map[string]string)
+		for _, record := range dns.Records {
+			cloudBySubdomain[record.Subdomain] = record.CloudProvider
+		}
+	default:
+		return nil, fmt.Errorf("invalid group-by: %q (valid: %s, %s, %s, %s)", by, GroupByStatus, GroupByTech, GroupBySource, GroupByCloud)
 	}
 
-	// Load the latest (results are already sorted by timestamp descending)
-	latest := subdomainResults[0]
-	var result SubdomainResults
-	if err := loadJSONFile(latest.FilePath, &result); err != nil {
-		return nil, err
+	groups := make(map[string][]Subdomain)
+	for _, sub := range subdomains {
+		var keys []string
+		switch by {
+		case GroupByStatus:
+			keys = []string{"unverified"}
+			if sub.Verified != nil {
+				keys = []string{sub.Verified.Status}
+			}
+		case GroupByTech:
+			if sub.Verified != nil && sub.Verified.HTTP != nil {
+				keys = TechStack(sub.Verified.HTTP)
+			}
+		case GroupBySource:
+			keys = sub.DiscoveredBy
+		case GroupByCloud:
+			if provider := cloudBySubdomain[sub.Name]; provider != "" {
+				keys = []string{provider}
+			}
+		}
+		if len(keys) == 0 {
+			keys = []string{"Unknown"}
+		}
+
+		seen := make(map[string]bool)
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				groups[key] = append(groups[key], sub)
+			}
+		}
 	}
 
-	return &result, nil
+	return groups, nil
 }
 
-// QuerySubdomains filters subdomains based on query options
-func QuerySubdomains(domain string, options QueryOptions) ([]Subdomain, error) {
-	result, err := GetLatestSubdomainResult(domain)
-	if err != nil {
-		return nil, err
+// matchSubdomain reports whether a subdomain, with its DNS record when known,
+// matches every set query option
+func matchSubdomain(sub Subdomain, dns *DNSInfo, options QueryOptions) bool {
+	var http *HTTPResult
+	if sub.Verified != nil {
+		http = sub.Verified.HTTP
 	}
 
-	var filtered []Subdomain
+	if options.AliveOnly && (sub.Verified == nil || sub.Verified.Status != "alive") {
+		return false
+	}
 
-	for _, sub := range result.Subdomains {
-		// Apply filters
-		if options.AliveOnly && (sub.Verified == nil || sub.Verified.Status != "alive") {
+	if options.DeadOnly && (sub.Verified == nil || sub.Verified.Status == "alive") {
+		return false
+	}
+
+	if options.StatusCode != 0 && (http == nil || http.StatusCode != options.StatusCode) {
+		return false
+	}
+
+	if options.MissingHeader != "" {
+		if http == nil || !http.Accessible || http.Headers.Has(options.MissingHeader) {
+			return false
+		}
+	}
+
+	if options.FaviconHash != nil && (http == nil || http.FaviconHash != *options.FaviconHash) {
+		return false
+	}
+
+	if options.FinalURL != "" {
+		if http == nil || !strings.Contains(strings.ToLower(http.FinalURL), strings.ToLower(options.FinalURL)) {
+			return false
+		}
+	}
+
+	if options.CrossDomain && (http == nil || !http.CrossDomain) {
+		return false
+	}
+
+	if options.Source != "" && !contains(sub.DiscoveredBy, options.Source) {
+		return false
+	}
+
+	if options.Tag != "" && !contains(sub.Tags, strings.ToLower(options.Tag)) {
+		return false
+	}
+
+	if options.Tech != "" {
+		if http == nil {
+			return false
+		}
+		found := false
+		for _, tech := range TechStack(http) {
+			if strings.Contains(strings.ToLower(tech), strings.ToLower(options.Tech)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if options.TitleContains != "" {
+		if http == nil || !strings.Contains(strings.ToLower(http.Title), strings.ToLower(options.TitleContains)) {
+			return false
+		}
+	}
+
+	if options.Cloud != "" && (dns == nil || !strings.EqualFold(dns.CloudProvider, options.Cloud)) {
+		return false
+	}
+
+	if options.TakeoverRisk && (dns == nil || !dns.TakeoverRisk) {
+		return false
+	}
+
+	if options.IPCIDR != nil {
+		var ips []string
+		if sub.Verified != nil && sub.Verified.DNS != nil {
+			ips = sub.Verified.DNS.IPs
+		}
+		if len(ips) == 0 && dns != nil {
+			ips = append(append([]string{}, dns.A...), dns.AAAA...)
+		}
+		found := false
+		for _, ip := range ips {
+			if parsed := net.ParseIP(ip); parsed != nil && options.IPCIDR.Contains(parsed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DNSQueryOptions configures DNS result filtering
+type DNSQueryOptions struct {
+	RecordType    string // Only subdomains with records of this type (A, CNAME, MX, ...)
+	TakeoverOnly  bool   // Only subdomains flagged as takeover risks
+	DanglingOnly  bool   // Only subdomains with dangling A/AAAA/NS records
+	CloudProvider string // Only subdomains hosted by this provider (case-insensitive)
+}
+
+// QueryDNSRecords filters the per-subdomain records of DNS results
+func QueryDNSRecords(results *DNSResults, options DNSQueryOptions) []DNSInfo {
+	var filtered []DNSInfo
+
+	for _, info := range results.Records {
+		if options.RecordType != "" && len(info.Values(options.RecordType)) == 0 {
+			continue
+		}
+
+		if options.TakeoverOnly && !info.TakeoverRisk {
 			continue
 		}
 
-		if options.DeadOnly && (sub.Verified == nil || sub.Verified.Status != "dead") {
+		if options.DanglingOnly && len(info.Dangling) == 0 {
 			continue
 		}
 
-		if options.StatusCode != 0 {
-			if sub.Verified == nil || sub.Verified.HTTP == nil || sub.Verified.HTTP.StatusCode != options.StatusCode {
+		if options.CloudProvider != "" && !strings.EqualFold(info.CloudProvider, options.CloudProvider) {
+			continue
+		}
+
+		filtered = append(filtered, info)
+	}
+
+	return filtered
+}
+
+// PortResult is one web port of a subdomain probed during verification
+type PortResult struct {
+	Subdomain string     `json:"subdomain"`
+	Port      int        `json:"port"`
+	HTTP      HTTPResult `json:"http"`
+}
+
+// PortQueryOptions configures port result filtering
+type PortQueryOptions struct {
+	Port           int  // Only this port
+	AccessibleOnly bool // Only ports that answered over HTTP
+	StatusCode     int  // Only responses with this status code
+}
+
+// QueryPorts lists the web ports probed on each subdomain of a scan: the
+// default port plus any probed with verify --ports
+func QueryPorts(result *SubdomainResults, options PortQueryOptions) []PortResult {
+	var ports []PortResult
+
+	for _, sub := range result.Subdomains {
+		if sub.Verified == nil {
+			continue
+		}
+
+		probes := sub.Verified.HTTPResults
+		if sub.Verified.HTTP != nil {
+			probes = append([]HTTPResult{*sub.Verified.HTTP}, probes...)
+		}
+
+		for _, probe := range probes {
+			port := HTTPResultPort(probe)
+
+			if options.Port != 0 && port != options.Port {
 				continue
 			}
-		}
 
-		if options.Source != "" {
-			found := false
-			for _, source := range sub.DiscoveredBy {
-				if source == options.Source {
-					found = true
-					break
-				}
+			if options.AccessibleOnly && !probe.Accessible {
+				continue
 			}
-			if !found {
+
+			if options.StatusCode != 0 && probe.StatusCode != options.StatusCode {
 				continue
 			}
+
+			ports = append(ports, PortResult{
+				Subdomain: sub.Name,
+				Port:      port,
+				HTTP:      probe,
+			})
+		}
+	}
+
+	return ports
+}
+
+// HTTPResultPort returns the port a probe was sent to, taken from its URL
+// when not recorded
+func HTTPResultPort(result HTTPResult) int {
+	if result.Port != 0 {
+		return result.Port
+	}
+
+	parsed, err := url.Parse(result.URL)
+	if err != nil {
+		return 0
+	}
+	if port, err := strconv.Atoi(parsed.Port()); err == nil {
+		return port
+	}
+	if parsed.Scheme == "https" {
+		return 443
+	}
+	return 80
+}
+
+// URLQueryOptions configures URL result filtering
+type URLQueryOptions struct {
+	Host      string // Only URLs on this host
+	Extension string // Only URLs whose path has this extension (e.g. ".js" or "js")
+	Param     string // Only URLs carrying this query parameter
+	Contains  string // Only URLs containing this substring (case-insensitive)
+}
+
+// QueryURLs filters the URLs of URL results
+func QueryURLs(results *URLResults, options URLQueryOptions) []string {
+	extension := strings.ToLower(options.Extension)
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	var filtered []string
+
+	for _, raw := range results.URLs {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			continue
 		}
 
-		filtered = append(filtered, sub)
+		if options.Host != "" && !strings.EqualFold(parsed.Hostname(), options.Host) {
+			continue
+		}
+
+		if extension != "" && strings.ToLower(path.Ext(parsed.Path)) != extension {
+			continue
+		}
+
+		if options.Param != "" && !parsed.Query().Has(options.Param) {
+			continue
+		}
+
+		if options.Contains != "" && !strings.Contains(strings.ToLower(raw), strings.ToLower(options.Contains)) {
+			continue
+		}
+
+		filtered = append(filtered, raw)
 	}
 
-	return filtered, nil
+	return filtered
 }
 
 // loadJSONFile is a helper to load and unmarshal a JSON file
 func loadJSONFile(filePath string, v interface{}) error {
-	data, err := os.ReadFile(filePath)
+	data, err := ReadResultFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	if err := json.Unmarshal(data, v); err != nil {
+	if err := unmarshalResult(data, v); err != nil {
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return nil
+	return resolveRawOutputs(filePath, v)
+}
+
+// ResultsSchemaVersion is the version of the result format written by this
+// build. Bump it, and register a migration from the previous version in
+// resultMigrations, whenever a change to a result type would stop older
+// files from unmarshaling.
+const ResultsSchemaVersion = 1
+
+// resultMigrations upgrade a decoded result document from the version it is
+// keyed by to the next one
+var resultMigrations = map[int]func(doc map[string]interface{}){
+	// Files written before versioning held redirect chains as plain URLs
+	0: migrateRedirectChains,
+}
+
+// stampSchemaVersion adds schema_version as the first field of a marshaled
+// result document
+func stampSchemaVersion(data []byte) []byte {
+	if len(data) < 3 || data[0] != '{' {
+		return data // Not an object, or an empty one
+	}
+
+	stamped := []byte(fmt.Sprintf("{\n  \"schema_version\": %d,", ResultsSchemaVersion))
+	return append(stamped, data[1:]...)
+}
+
+// resultSchemaVersion returns the schema version of a result document, 0
+// for files written before versioning
+func resultSchemaVersion(data []byte) int {
+	var versioned struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &versioned); err != nil {
+		return 0
+	}
+	return versioned.SchemaVersion
+}
+
+// unmarshalResult unmarshals a result document into v, upgrading it to the
+// current schema first
+func unmarshalResult(data []byte, v interface{}) error {
+	data, err := migrateResult(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// migrateResult upgrades a result document written by an older version to
+// the current schema. Files on disk are left as they are; the next save of
+// the tool writes the current version.
+func migrateResult(data []byte) ([]byte, error) {
+	version := resultSchemaVersion(data)
+	if version == ResultsSchemaVersion {
+		return data, nil
+	}
+	if version > ResultsSchemaVersion {
+		return nil, fmt.Errorf("results use schema version %d, newer than this version of recon-cli supports (%d); please upgrade", version, ResultsSchemaVersion)
+	}
+
+	// Numbers are kept as written so large integers survive the round trip
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data, nil // Not an object; leave errors to the caller's unmarshal
+	}
+
+	for ; version < ResultsSchemaVersion; version++ {
+		if migrate, ok := resultMigrations[version]; ok {
+			migrate(doc)
+		}
+	}
+	doc["schema_version"] = ResultsSchemaVersion
+
+	return json.Marshal(doc)
+}
+
+// migrateRedirectChains converts redirect chains stored as URL strings into
+// hops, wherever they appear in the document. The status codes of those
+// hops were not recorded and are left at zero.
+func migrateRedirectChains(doc map[string]interface{}) {
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				if chain, ok := child.([]interface{}); ok && key == "redirect_chain" {
+					for i, hop := range chain {
+						if url, ok := hop.(string); ok {
+							chain[i] = map[string]interface{}{"url": url}
+						}
+					}
+					continue
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
 }
 
 // FormatFileSize formats a file size in human-readable format
@@ -0,0 +1,118 @@
+package recon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy limits how many stored results are kept per domain and
+// tool. A zero policy keeps everything.
+type RetentionPolicy struct {
+	MaxAge   time.Duration // Delete results older than this (0 = no age limit)
+	KeepLast int           // Always keep this many of the newest results (0 = no minimum)
+}
+
+// IsZero reports whether the policy keeps every result
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxAge <= 0 && p.KeepLast <= 0
+}
+
+// retentionPolicy is applied by SaveResults after each scan
+var retentionPolicy RetentionPolicy
+
+// SetRetentionPolicy sets the policy applied automatically after results
+// are saved, normally from the 'retention' config setting
+func SetRetentionPolicy(policy RetentionPolicy) {
+	retentionPolicy = policy
+}
+
+// ExpiredResults returns the results the policy would delete. Each tool is
+// handled separately so a burst of one scan type never evicts another: the
+// newest KeepLast results of a tool are kept, and of the rest those older
+// than MaxAge expire (all of them when MaxAge is unset).
+func ExpiredResults(results []ResultInfo, policy RetentionPolicy, now time.Time) []ResultInfo {
+	if policy.IsZero() {
+		return nil
+	}
+
+	byTool := make(map[string][]ResultInfo)
+	for _, result := range results {
+		byTool[result.ToolName] = append(byTool[result.ToolName], result)
+	}
+
+	var expired []ResultInfo
+	for _, toolResults := range byTool {
+		sort.Slice(toolResults, func(i, j int) bool {
+			return toolResults[i].Timestamp.After(toolResults[j].Timestamp)
+		})
+		for i, result := range toolResults {
+			if i < policy.KeepLast {
+				continue
+			}
+			if policy.MaxAge > 0 && now.Sub(result.Timestamp) <= policy.MaxAge {
+				continue
+			}
+			expired = append(expired, result)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].Timestamp.Before(expired[j].Timestamp)
+	})
+	return expired
+}
+
+// DeleteResults removes the given result files, returning how many were
+// deleted
+func DeleteResults(results []ResultInfo) (int, error) {
+	deleted := 0
+	for _, result := range results {
+		if err := os.Remove(result.FilePath); err != nil && !os.IsNotExist(err) {
+			return deleted, fmt.Errorf("failed to delete %s: %w", result.FilePath, err)
+		}
+		sidecar := filepath.Join(filepath.Dir(result.FilePath), rawSidecarName(result.FilePath))
+		if err := os.Remove(sidecar); err != nil && !os.IsNotExist(err) {
+			return deleted, fmt.Errorf("failed to delete %s: %w", sidecar, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// DeleteDomainResults removes every stored result for a domain
+func DeleteDomainResults(domain string) error {
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(domainDir); err != nil {
+		return fmt.Errorf("failed to delete results for %s: %w", domain, err)
+	}
+	return nil
+}
+
+// applyRetention enforces the configured policy on one tool's results for
+// a domain
+func applyRetention(domain, toolName string) error {
+	if retentionPolicy.IsZero() {
+		return nil
+	}
+
+	results, err := ListResultsForDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	var toolResults []ResultInfo
+	for _, result := range results {
+		if result.ToolName == toolName {
+			toolResults = append(toolResults, result)
+		}
+	}
+
+	_, err = DeleteResults(ExpiredResults(toolResults, retentionPolicy, time.Now()))
+	return err
+}
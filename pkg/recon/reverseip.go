@@ -0,0 +1,210 @@
+package recon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReverseIPEntry represents the names found to be hosted on a single IP
+type ReverseIPEntry struct {
+	IP            string   `json:"ip"`
+	Subdomains    []string `json:"subdomains"` // Known subdomains resolving to this IP
+	PTR           []string `json:"ptr,omitempty"`
+	InScope       []string `json:"in_scope,omitempty"`  // Co-hosted names under the target domain
+	Neighbors     []string `json:"neighbors,omitempty"` // Co-hosted names outside the target domain
+	SharedHosting bool     `json:"shared_hosting"`
+	Sources       []string `json:"sources,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// ReverseIPSummary contains reverse IP lookup statistics
+type ReverseIPSummary struct {
+	UniqueIPs        int `json:"unique_ips"`
+	PTRRecords       int `json:"ptr_records"`
+	InScopeHits      int `json:"in_scope_hits"`
+	NewSubdomains    int `json:"new_subdomains"` // In-scope hits missing from the DNS results
+	Neighbors        int `json:"neighbors"`
+	SharedHostingIPs int `json:"shared_hosting_ips"`
+}
+
+// ReverseIPResults represents the results of a reverse IP lookup
+type ReverseIPResults struct {
+	Domain        string           `json:"domain"`
+	Timestamp     time.Time        `json:"timestamp"`
+	Entries       []ReverseIPEntry `json:"entries"`
+	NewSubdomains []string         `json:"new_subdomains,omitempty"`
+	Summary       ReverseIPSummary `json:"summary"`
+}
+
+// ReverseIPOptions configures a reverse IP lookup
+type ReverseIPOptions struct {
+	Concurrency     int           // Number of IPs looked up at once (default: 5)
+	Timeout         time.Duration // Timeout per PTR lookup (default: 5s)
+	Passive         bool          // Query passive DNS in addition to PTR records
+	SharedThreshold int           // Neighbor count at which an IP is flagged as shared hosting (default: 10)
+	Proxy           *url.URL      // Route passive DNS requests through this proxy (optional)
+	Resolvers       *ResolverPool // Nameservers to rotate through for PTR lookups (optional)
+}
+
+// ReverseIPLookup finds names co-hosted on the unique IPs from DNS results
+// using PTR records and, optionally, passive DNS
+func ReverseIPLookup(ctx context.Context, dnsResults *DNSResults, options ReverseIPOptions) (*ReverseIPResults, error) {
+	if options.Concurrency == 0 {
+		options.Concurrency = 5
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 5 * time.Second
+	}
+	if options.SharedThreshold == 0 {
+		options.SharedThreshold = 10
+	}
+
+	// Map every IP back to the subdomains resolving to it
+	ipSubdomains := make(map[string][]string)
+	known := make(map[string]bool)
+	for _, record := range dnsResults.Records {
+		known[strings.ToLower(record.Subdomain)] = true
+		for _, ip := range append(append([]string{}, record.A...), record.AAAA...) {
+			ipSubdomains[ip] = append(ipSubdomains[ip], record.Subdomain)
+		}
+	}
+
+	if len(ipSubdomains) == 0 {
+		return nil, fmt.Errorf("no IP addresses found in DNS results")
+	}
+
+	ips := make([]string, 0, len(ipSubdomains))
+	for ip := range ipSubdomains {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	results := &ReverseIPResults{
+		Domain:    dnsResults.Domain,
+		Timestamp: time.Now(),
+		Entries:   make([]ReverseIPEntry, len(ips)),
+	}
+
+	client := newSourceClient(options.Proxy)
+	semaphore := make(chan struct{}, options.Concurrency)
+	var wg sync.WaitGroup
+
+	for i, ip := range ips {
+		wg.Add(1)
+		go func(i int, ip string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			entry := ReverseIPEntry{
+				IP:         ip,
+				Subdomains: ipSubdomains[ip],
+			}
+			names := make(map[string]bool)
+			var errs []string
+
+			// PTR records
+			lookupCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+			resolver, server := options.Resolvers.Resolver()
+			ptrs, err := resolver.LookupAddr(lookupCtx, ip)
+			options.Resolvers.Report(server, err)
+			cancel()
+			if err == nil {
+				for _, ptr := range ptrs {
+					ptr = strings.ToLower(strings.TrimSuffix(ptr, "."))
+					entry.PTR = append(entry.PTR, ptr)
+					names[ptr] = true
+				}
+				if len(entry.PTR) > 0 {
+					entry.Sources = append(entry.Sources, "ptr")
+				}
+			}
+
+			// Passive DNS
+			if options.Passive {
+				hosts, err := hackerTargetReverseIP(client, ip)
+				if err != nil {
+					errs = append(errs, err.Error())
+				} else {
+					for _, host := range hosts {
+						names[host] = true
+					}
+					entry.Sources = append(entry.Sources, "hackertarget")
+				}
+			}
+			entry.Error = strings.Join(errs, "; ")
+
+			for name := range names {
+				if isInScope(name, dnsResults.Domain) {
+					entry.InScope = append(entry.InScope, name)
+				} else {
+					entry.Neighbors = append(entry.Neighbors, name)
+				}
+			}
+			sort.Strings(entry.InScope)
+			sort.Strings(entry.Neighbors)
+			entry.SharedHosting = len(entry.Neighbors) >= options.SharedThreshold
+
+			results.Entries[i] = entry
+		}(i, ip)
+	}
+
+	wg.Wait()
+
+	// Calculate summary
+	newSubdomains := make(map[string]bool)
+	results.Summary.UniqueIPs = len(results.Entries)
+	for _, entry := range results.Entries {
+		results.Summary.PTRRecords += len(entry.PTR)
+		results.Summary.InScopeHits += len(entry.InScope)
+		results.Summary.Neighbors += len(entry.Neighbors)
+		if entry.SharedHosting {
+			results.Summary.SharedHostingIPs++
+		}
+		for _, name := range entry.InScope {
+			if !known[name] {
+				newSubdomains[name] = true
+			}
+		}
+	}
+	for name := range newSubdomains {
+		results.NewSubdomains = append(results.NewSubdomains, name)
+	}
+	sort.Strings(results.NewSubdomains)
+	results.Summary.NewSubdomains = len(results.NewSubdomains)
+
+	return results, nil
+}
+
+// hackerTargetReverseIP queries the HackerTarget reverse IP API, which
+// returns one hostname per line or a plain-text error message
+func hackerTargetReverseIP(client *http.Client, ip string) ([]string, error) {
+	body, err := getBody(client, "https://api.hackertarget.com/reverseiplookup/?q="+url.QueryEscape(ip), "hackertarget")
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(string(body))
+	if strings.HasPrefix(text, "No DNS A records found") {
+		return nil, nil
+	}
+	if strings.HasPrefix(text, "error") || strings.Contains(text, "API count exceeded") {
+		return nil, fmt.Errorf("hackertarget query failed: %s", text)
+	}
+
+	var hosts []string
+	for _, line := range strings.Split(text, "\n") {
+		host := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(line), "."))
+		if host != "" && host != ip {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
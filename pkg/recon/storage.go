@@ -1,10 +1,15 @@
 package recon
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
@@ -18,6 +23,27 @@ const (
 	FormatText
 )
 
+// compressedSuffix is appended to JSON result files written with gzip
+const compressedSuffix = ".gz"
+
+// compressResults makes SaveResults gzip JSON result files
+var compressResults bool
+
+// SetCompressResults controls whether JSON results are saved gzip-compressed
+// (.json.gz), normally from the 'compress_results' config setting
+func SetCompressResults(compress bool) {
+	compressResults = compress
+}
+
+// saveHook is called by SaveResults after each JSON result is saved
+var saveHook func(domain, toolName, filePath string)
+
+// SetSaveHook installs a func called after each JSON result is saved, e.g.,
+// to push it to the server with the 'auto_sync' config setting
+func SetSaveHook(hook func(domain, toolName, filePath string)) {
+	saveHook = hook
+}
+
 // GetResultsDir returns the base results directory
 func GetResultsDir() (string, error) {
 	configDir, err := config.GetConfigDir()
@@ -63,12 +89,16 @@ func SaveResults(domain, toolName string, data interface{}, format StorageFormat
 	}
 
 	// Generate filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
+	savedAt := time.Now().Truncate(time.Second)
+	timestamp := savedAt.Format("20060102_150405")
 	var filename string
 
 	switch format {
 	case FormatJSON:
 		filename = fmt.Sprintf("%s_%s.json", toolName, timestamp)
+		if compressResults {
+			filename += compressedSuffix
+		}
 	case FormatText:
 		filename = fmt.Sprintf("%s_%s.txt", toolName, timestamp)
 	default:
@@ -81,10 +111,29 @@ func SaveResults(domain, toolName string, data interface{}, format StorageFormat
 	var fileData []byte
 	switch format {
 	case FormatJSON:
+		// Raw tool output goes to a plain-text sidecar, written first so
+		// the result file never references a missing one
+		if carrier, ok := data.(rawOutputCarrier); ok {
+			sidecar := rawSidecarName(filename)
+			contents, restore := externalizeRawOutputs(carrier, sidecar)
+			defer restore()
+			if contents != nil {
+				if err := writeRawSidecar(filepath.Join(domainDir, sidecar), contents); err != nil {
+					return "", err
+				}
+			}
+		}
+
 		fileData, err = json.MarshalIndent(data, "", "  ")
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal JSON: %w", err)
 		}
+		fileData = stampSchemaVersion(fileData)
+		if compressResults {
+			if fileData, err = gzipData(fileData); err != nil {
+				return "", err
+			}
+		}
 	case FormatText:
 		// Assume data is already a string or []byte
 		switch v := data.(type) {
@@ -96,45 +145,438 @@ func SaveResults(domain, toolName string, data interface{}, format StorageFormat
 			return "", fmt.Errorf("text format requires string or []byte data")
 		}
 	}
+	if config.VaultEnabled() {
+		if fileData, err = config.VaultSeal(fileData); err != nil {
+			return "", fmt.Errorf("failed to encrypt results: %w", err)
+		}
+	}
 
 	// Write file with secure permissions
-	if err := os.WriteFile(filePath, fileData, 0600); err != nil {
+	if err := writeFileAtomic(filePath, fileData, 0600); err != nil {
 		return "", fmt.Errorf("failed to write results file: %w", err)
 	}
 
+	// Index the file and prune older results of this tool; failures here
+	// shouldn't fail the scan, and the manifest is rebuilt on the next load
+	if format == FormatJSON {
+		entry := manifestEntry{
+			File:          filename,
+			Tool:          toolName,
+			Timestamp:     savedAt,
+			SchemaVersion: ResultsSchemaVersion,
+			Size:          int64(len(fileData)),
+		}
+		switch v := data.(type) {
+		case SubdomainResults:
+			summarizeSubdomainResults(&entry, &v)
+		case *SubdomainResults:
+			summarizeSubdomainResults(&entry, v)
+		}
+		_ = recordManifestEntry(domain, entry)
+		_ = applyRetention(domain, toolName)
+		if saveHook != nil {
+			saveHook(domain, toolName, filePath)
+		}
+	}
+
 	return filePath, nil
 }
 
 // LoadLatestResult loads the most recent result file for a tool
 func LoadLatestResult(domain, toolName string, result interface{}) error {
+	_, err := LoadSelectedResult(domain, toolName, ResultSelection{}, result)
+	return err
+}
+
+// ResultSelection picks a stored result other than the latest: a file path
+// (or a file name in the domain's results directory), or a timestamp such as
+// "2025-01-31", "2025-01-31 14:05", or "20250131_140512" that selects the
+// newest result within that day, minute, or second. The zero value selects
+// the latest result.
+type ResultSelection struct {
+	File      string
+	Timestamp string
+}
+
+// IsLatest reports whether the selection picks the latest result
+func (s ResultSelection) IsLatest() bool {
+	return s.File == "" && s.Timestamp == ""
+}
+
+// LoadSelectedResult loads the result of a tool chosen by selection and
+// returns the path of the file it was loaded from
+func LoadSelectedResult(domain, toolName string, selection ResultSelection, result interface{}) (string, error) {
+	filePath, err := ResolveResultFile(domain, toolName, selection)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ReadResultFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read results file: %w", err)
+	}
+
+	if err := unmarshalResult(data, result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal results: %w", err)
+	}
+	if results, ok := result.(*SubdomainResults); ok {
+		applyAnnotations(domain, results)
+	}
+
+	if err := resolveRawOutputs(filePath, result); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// ResolveResultFile returns the path of the result file of a tool chosen by
+// selection
+func ResolveResultFile(domain, toolName string, selection ResultSelection) (string, error) {
 	domainDir, err := GetDomainResultsDir(domain)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Find latest file matching pattern
-	pattern := filepath.Join(domainDir, fmt.Sprintf("%s_*.json", toolName))
-	matches, err := filepath.Glob(pattern)
+	if selection.File != "" {
+		filePath := selection.File
+		if _, err := os.Stat(filePath); os.IsNotExist(err) && !strings.ContainsRune(filePath, os.PathSeparator) {
+			filePath = filepath.Join(domainDir, filePath)
+		}
+		if _, err := os.Stat(filePath); err != nil {
+			return "", fmt.Errorf("result file not found: %s", selection.File)
+		}
+
+		// Stored results are named <tool>_<timestamp>.json[.gz]
+		if tool, _, ok := strings.Cut(filepath.Base(filePath), "_"); ok && filepath.Dir(filePath) == domainDir && tool != toolName {
+			return "", fmt.Errorf("%s holds %s results, not %s", filepath.Base(filePath), tool, toolName)
+		}
+		return filePath, nil
+	}
+
+	prefix, err := timestampDigits(selection.Timestamp)
 	if err != nil {
-		return fmt.Errorf("failed to search for results: %w", err)
+		return "", err
+	}
+
+	entries, err := loadManifest(domain)
+	if err != nil {
+		return "", err
+	}
+
+	// Entries are sorted newest first by recorded timestamp
+	for _, entry := range entries {
+		if entry.Tool != toolName {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(entry.Timestamp.Local().Format("20060102150405"), prefix) {
+			continue
+		}
+		return filepath.Join(domainDir, entry.File), nil
+	}
+
+	if prefix != "" {
+		return "", fmt.Errorf("no %s results from %s found for %s", toolName, selection.Timestamp, domain)
+	}
+	return "", fmt.Errorf("no results found for %s on %s", toolName, domain)
+}
+
+// timestampDigits reduces a timestamp selection to its digits, which prefix
+// the YYYYMMDDHHMMSS form of matching result timestamps
+func timestampDigits(timestamp string) (string, error) {
+	if timestamp == "" {
+		return "", nil
+	}
+
+	var digits strings.Builder
+	for _, r := range timestamp {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		} else if !strings.ContainsRune(" -_:T", r) {
+			digits.Reset()
+			break
+		}
 	}
 
-	if len(matches) == 0 {
-		return fmt.Errorf("no results found for %s on %s", toolName, domain)
+	if digits.Len() < 8 || digits.Len() > 14 {
+		return "", fmt.Errorf("invalid timestamp: %s (use: 2025-01-31, 2025-01-31 14:05, or 20250131_140512)", timestamp)
 	}
+	return digits.String(), nil
+}
 
-	// Get the latest file (files are timestamped, so last alphabetically is latest)
-	latestFile := matches[len(matches)-1]
+// globResultFiles returns the JSON result files, plain and gzip-compressed,
+// matching pattern (without extension), sorted by name
+func globResultFiles(pattern string) ([]string, error) {
+	plain, err := filepath.Glob(pattern + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for results: %w", err)
+	}
+	compressed, err := filepath.Glob(pattern + ".json" + compressedSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for results: %w", err)
+	}
+
+	matches := append(plain, compressed...)
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// trimResultExt strips .json or .json.gz from a result file name
+func trimResultExt(filename string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filename, compressedSuffix), ".json")
+}
 
-	// Read and unmarshal
-	data, err := os.ReadFile(latestFile)
+// ReadResultFile reads a result file, decrypting it when the vault sealed
+// it and decompressing it when it is gzipped
+func ReadResultFile(filePath string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read results file: %w", err)
+		return nil, err
 	}
 
-	if err := json.Unmarshal(data, result); err != nil {
-		return fmt.Errorf("failed to unmarshal results: %w", err)
+	if config.IsVaultSealed(data) {
+		if data, err = config.VaultOpen(data); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	// Detect gzip by its magic bytes so renamed files still load
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", filepath.Base(filePath), err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", filepath.Base(filePath), err)
+	}
+	return decompressed, nil
+}
+
+// gzipData compresses data
+func gzipData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress results: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress results: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// CompactSummary reports the outcome of compressing stored results
+type CompactSummary struct {
+	Files       int   // Files compressed
+	BytesBefore int64 // Size of those files before compression
+	BytesAfter  int64 // Size after compression
+}
+
+// CompactResults gzip-compresses every uncompressed JSON result file for a
+// domain, or for all domains when domain is empty, replacing the originals
+func CompactResults(domain string) (*CompactSummary, error) {
+	var dirs []string
+	if domain != "" {
+		domainDir, err := GetDomainResultsDir(domain)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, domainDir)
+	} else {
+		resultsDir, err := GetResultsDir()
+		if err != nil {
+			return nil, err
+		}
+		entries, err := os.ReadDir(resultsDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read results directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirs = append(dirs, filepath.Join(resultsDir, entry.Name()))
+			}
+		}
+	}
+
+	summary := &CompactSummary{}
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return summary, fmt.Errorf("failed to search for results: %w", err)
+		}
+		for _, filePath := range matches {
+			before, after, err := compactResultFile(filePath)
+			if err != nil {
+				return summary, err
+			}
+			summary.Files++
+			summary.BytesBefore += before
+			summary.BytesAfter += after
+		}
+	}
+
+	return summary, nil
+}
+
+// compactResultFile replaces a JSON result file with a gzipped copy,
+// keeping its modification time, and returns the sizes before and after
+func compactResultFile(filePath string) (int64, int64, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+	data, err := ReadResultFile(filePath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	compressed, err := gzipData(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	if config.VaultEnabled() {
+		if compressed, err = config.VaultSeal(compressed); err != nil {
+			return 0, 0, fmt.Errorf("failed to encrypt %s: %w", filePath, err)
+		}
+	}
+
+	target := filePath + compressedSuffix
+	if err := writeFileAtomic(target, compressed, 0600); err != nil {
+		return 0, 0, fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	_ = os.Chtimes(target, info.ModTime(), info.ModTime())
+
+	if err := os.Remove(filePath); err != nil {
+		return 0, 0, fmt.Errorf("failed to remove %s: %w", filePath, err)
+	}
+
+	return info.Size(), int64(len(compressed)), nil
+}
+
+// resultFilesInAllDomains returns every JSON result file, compressed or not,
+// every text result and raw output sidecar, and the annotations across all
+// domains
+func resultFilesInAllDomains() ([]string, error) {
+	resultsDir, err := GetResultsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(resultsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		matches, err := globResultFiles(filepath.Join(resultsDir, entry.Name(), "*"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+
+		// Text results and raw output sidecars
+		texts, err := filepath.Glob(filepath.Join(resultsDir, entry.Name(), "*.txt"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for results: %w", err)
+		}
+		files = append(files, texts...)
+
+		annotations := filepath.Join(resultsDir, entry.Name(), annotationsFileName)
+		if _, err := os.Stat(annotations); err == nil {
+			files = append(files, annotations)
+		}
+	}
+	return files, nil
+}
+
+// SealResults encrypts every stored result file that the vault has not
+// sealed yet, returning how many were encrypted
+func SealResults() (int, error) {
+	files, err := resultFilesInAllDomains()
+	if err != nil {
+		return 0, err
+	}
+
+	sealed := 0
+	for _, filePath := range files {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return sealed, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		if config.IsVaultSealed(data) {
+			continue
+		}
+		if data, err = config.VaultSeal(data); err != nil {
+			return sealed, fmt.Errorf("failed to encrypt %s: %w", filePath, err)
+		}
+		if err := writeFileAtomic(filePath, data, 0600); err != nil {
+			return sealed, fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+		sealed++
+	}
+	return sealed, nil
+}
+
+// UnsealResults decrypts every stored result file sealed by the vault,
+// returning how many were decrypted
+func UnsealResults() (int, error) {
+	files, err := resultFilesInAllDomains()
+	if err != nil {
+		return 0, err
+	}
+
+	opened := 0
+	for _, filePath := range files {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return opened, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		if !config.IsVaultSealed(data) {
+			continue
+		}
+		if data, err = config.VaultOpen(data); err != nil {
+			return opened, fmt.Errorf("failed to decrypt %s: %w", filePath, err)
+		}
+		if err := writeFileAtomic(filePath, data, 0600); err != nil {
+			return opened, fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+		opened++
+	}
+	return opened, nil
+}
+
+// CountSealedResults returns how many stored result files are sealed by the
+// vault and how many are not
+func CountSealedResults() (sealed, plain int, err error) {
+	files, err := resultFilesInAllDomains()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, filePath := range files {
+		file, err := os.Open(filePath)
+		if err != nil {
+			continue
+		}
+		header := make([]byte, config.VaultHeaderSize)
+		n, _ := io.ReadFull(file, header)
+		file.Close()
+		if config.IsVaultSealed(header[:n]) {
+			sealed++
+		} else {
+			plain++
+		}
+	}
+	return sealed, plain, nil
 }
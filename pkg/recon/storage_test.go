@@ -0,0 +1,57 @@
+package recon
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+func TestSaveResultsSealsTextWithVault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := config.InitVault("correct horse battery staple"); err != nil {
+		t.Fatalf("InitVault: %v", err)
+	}
+
+	wordlist := "id\nsession_token\nredirect_uri\n"
+	filePath, err := SaveResults("example.com", "params", wordlist, FormatText)
+	if err != nil {
+		t.Fatalf("SaveResults: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("reading %s: %v", filePath, err)
+	}
+	if !config.IsVaultSealed(onDisk) {
+		t.Errorf("text result is not sealed: %q", onDisk)
+	}
+	if bytes.Contains(onDisk, []byte("session_token")) {
+		t.Errorf("text result contains plaintext: %q", onDisk)
+	}
+
+	opened, err := ReadResultFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadResultFile: %v", err)
+	}
+	if string(opened) != wordlist {
+		t.Errorf("ReadResultFile = %q, want %q", opened, wordlist)
+	}
+
+	sealed, plain, err := CountSealedResults()
+	if err != nil {
+		t.Fatalf("CountSealedResults: %v", err)
+	}
+	if sealed != 1 || plain != 0 {
+		t.Errorf("CountSealedResults = %d sealed, %d plain, want 1 and 0", sealed, plain)
+	}
+
+	words, err := dirWords(DirsOptions{Wordlist: filePath})
+	if err != nil {
+		t.Fatalf("dirWords: %v", err)
+	}
+	if len(words) != 3 || words[1] != "session_token" {
+		t.Errorf("dirWords = %q, want the three saved words", words)
+	}
+}
@@ -3,6 +3,9 @@ package recon
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -15,6 +18,7 @@ type SubdomainResults struct {
 	TotalUnique int            `json:"total_unique"`
 	Subdomains  []Subdomain    `json:"subdomains"`
 	Summary     map[string]int `json:"summary"`
+	Findings    []Finding      `json:"findings,omitempty"`
 }
 
 // Subdomain represents a single subdomain entry
@@ -23,7 +27,10 @@ type Subdomain struct {
 	DiscoveredBy []string               `json:"discovered_by"`
 	FirstSeen    time.Time              `json:"first_seen"`
 	Verified     *VerificationResult    `json:"verified,omitempty"`
+	History      []VerificationRecord   `json:"verification_history,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`  // Triage tags, e.g., reviewed or interesting
+	Notes        string                 `json:"notes,omitempty"` // Triage notes
 }
 
 // SubdomainSource interface for enumeration tools
@@ -117,7 +124,9 @@ func EnumerateSubdomains(domain string, sources []SubdomainSource) (*SubdomainRe
 }
 
 // CrtShSource implements SubdomainSource for crt.sh certificate transparency
-type CrtShSource struct{}
+type CrtShSource struct {
+	Proxy *url.URL // Route requests through this proxy (optional)
+}
 
 func (s *CrtShSource) Name() string {
 	return "crt.sh"
@@ -129,18 +138,35 @@ func (s *CrtShSource) IsAvailable() bool {
 
 func (s *CrtShSource) Enumerate(domain string) ([]string, error) {
 	// Query crt.sh API
-	url := fmt.Sprintf("https://crt.sh/?q=%%.%s&output=json", domain)
-	result, err := ExecuteWithTimeout("curl", 2*time.Minute, "-s", url)
+	client := &http.Client{
+		Timeout: 2 * time.Minute,
+		Transport: &http.Transport{
+			Proxy: proxyFunc(s.Proxy),
+		},
+	}
+
+	apiURL := fmt.Sprintf("https://crt.sh/?q=%%.%s&output=json", url.QueryEscape(domain))
+	resp, err := client.Get(apiURL)
 	if err != nil {
 		return nil, fmt.Errorf("crt.sh query failed: %w", err)
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crt.sh query failed: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crt.sh response: %w", err)
+	}
 
 	// Parse JSON response
 	var crtResults []struct {
 		NameValue string `json:"name_value"`
 	}
 
-	if err := json.Unmarshal([]byte(result.Stdout), &crtResults); err != nil {
+	if err := json.Unmarshal(body, &crtResults); err != nil {
 		return nil, fmt.Errorf("failed to parse crt.sh response: %w", err)
 	}
 
@@ -161,7 +187,9 @@ func (s *CrtShSource) Enumerate(domain string) ([]string, error) {
 }
 
 // SubfinderSource implements SubdomainSource for subfinder
-type SubfinderSource struct{}
+type SubfinderSource struct {
+	Proxy *url.URL // Passed to subfinder's -proxy flag (optional)
+}
 
 func (s *SubfinderSource) Name() string {
 	return "subfinder"
@@ -173,7 +201,11 @@ func (s *SubfinderSource) IsAvailable() bool {
 
 func (s *SubfinderSource) Enumerate(domain string) ([]string, error) {
 	// Run subfinder with JSON output
-	result, err := ExecuteWithTimeout("subfinder", 5*time.Minute, "-d", domain, "-silent", "-json")
+	args := []string{"-d", domain, "-silent", "-json"}
+	if s.Proxy != nil {
+		args = append(args, "-proxy", s.Proxy.String())
+	}
+	result, err := ExecuteWithTimeout("subfinder", 5*time.Minute, args...)
 	if err != nil {
 		return nil, fmt.Errorf("subfinder execution failed: %w", err)
 	}
@@ -0,0 +1,229 @@
+package recon
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// maxSweepAddresses caps the size of a single swept block (a /16)
+const maxSweepAddresses = 1 << 16
+
+// SweepHost is an IP in a swept block with at least one PTR record
+type SweepHost struct {
+	IP    string   `json:"ip"`
+	Names []string `json:"names"`
+}
+
+// SweepResults represents the results of a reverse DNS sweep
+type SweepResults struct {
+	Domain        string      `json:"domain"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Blocks        []string    `json:"blocks"`
+	ScannedIPs    int         `json:"scanned_ips"`
+	Hosts         []SweepHost `json:"hosts"`
+	InScope       []string    `json:"in_scope,omitempty"`       // PTR names under the target domain
+	NewSubdomains []string    `json:"new_subdomains,omitempty"` // In-scope names missing from the subdomain results
+	Errors        int         `json:"errors"`
+}
+
+// SweepOptions configures a reverse DNS sweep
+type SweepOptions struct {
+	Concurrency int           // Number of PTR lookups at once (default: 20)
+	Timeout     time.Duration // Timeout per PTR lookup (default: 3s)
+	Resolvers   *ResolverPool // Nameservers to rotate through (optional)
+}
+
+// SweepCandidateBlocks returns the IPv4 /24 blocks holding at least minIPs
+// of the addresses in DNS results, most populated first
+func SweepCandidateBlocks(dnsResults *DNSResults, minIPs int) []string {
+	counts := make(map[string]int)
+	for _, ip := range dnsResults.UniqueIPs() {
+		parsed := net.ParseIP(ip).To4()
+		if parsed == nil {
+			continue
+		}
+		block := &net.IPNet{IP: parsed.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+		counts[block.String()]++
+	}
+
+	var blocks []string
+	for _, block := range TopCounts(counts, 0) {
+		if counts[block] >= minIPs {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// SweepBlocks PTR-scans every address in blocks and reports names under
+// domain, flagging those missing from the latest subdomain results
+func SweepBlocks(ctx context.Context, domain string, blocks []string, options SweepOptions) (*SweepResults, error) {
+	if options.Concurrency == 0 {
+		options.Concurrency = 20
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 3 * time.Second
+	}
+
+	var ips []net.IP
+	for _, block := range blocks {
+		blockIPs, err := expandBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, blockIPs...)
+	}
+
+	results := &SweepResults{
+		Domain:     domain,
+		Timestamp:  time.Now(),
+		Blocks:     blocks,
+		ScannedIPs: len(ips),
+	}
+
+	semaphore := make(chan struct{}, options.Concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, ip := range ips {
+		wg.Add(1)
+		go func(ip string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			server := options.Resolvers.Server()
+			name, _ := dns.ReverseAddr(ip)
+
+			lookupCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+			answers, err := lookupRecords(lookupCtx, server, name, dns.TypePTR)
+			cancel()
+			options.Resolvers.Report(server, err)
+
+			host := SweepHost{IP: ip}
+			for _, answer := range answers {
+				host.Names = append(host.Names, strings.ToLower(strings.TrimSuffix(answer.Data, ".")))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results.Errors++
+			}
+			if len(host.Names) > 0 {
+				results.Hosts = append(results.Hosts, host)
+			}
+		}(ip.String())
+	}
+
+	wg.Wait()
+
+	sort.Slice(results.Hosts, func(i, j int) bool {
+		return ipLess(results.Hosts[i].IP, results.Hosts[j].IP)
+	})
+
+	// Compare in-scope names against the known subdomains
+	known := make(map[string]bool)
+	var subdomainResults SubdomainResults
+	if err := LoadLatestResult(domain, "subdomains", &subdomainResults); err == nil {
+		for _, sub := range subdomainResults.Subdomains {
+			known[strings.ToLower(sub.Name)] = true
+		}
+	}
+
+	inScope := make(map[string]bool)
+	for _, host := range results.Hosts {
+		for _, name := range host.Names {
+			if isInScope(name, domain) {
+				inScope[name] = true
+			}
+		}
+	}
+	for name := range inScope {
+		results.InScope = append(results.InScope, name)
+		if !known[name] {
+			results.NewSubdomains = append(results.NewSubdomains, name)
+		}
+	}
+	results.InScope = SortDomains(results.InScope)
+	results.NewSubdomains = SortDomains(results.NewSubdomains)
+
+	return results, nil
+}
+
+// MergeSubdomains adds names to the latest subdomain results for domain,
+// crediting source for each, and saves them as a new result file. Existing
+// subdomains gain the source; new ones are added. It returns the number of
+// subdomains added.
+func MergeSubdomains(domain, source string, names []string) (int, error) {
+	records := make([]ImportRecord, 0, len(names))
+	for _, name := range names {
+		records = append(records, ImportRecord{Name: name})
+	}
+
+	summary, err := ImportSubdomains(domain, source, records)
+	if err != nil {
+		return 0, err
+	}
+	return summary.Added, nil
+}
+
+// SaveSweepResults saves reverse DNS sweep results to a JSON file
+func SaveSweepResults(domain string, results *SweepResults) error {
+	_, err := SaveResults(domain, "rdns", results, FormatJSON)
+	return err
+}
+
+// expandBlock lists every address in a CIDR block, or the single address
+// when block is a plain IP
+func expandBlock(block string) ([]net.IP, error) {
+	if ip := net.ParseIP(block); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	_, network, err := net.ParseCIDR(block)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", block, err)
+	}
+	ones, bits := network.Mask.Size()
+	if bits-ones > 16 {
+		return nil, fmt.Errorf("block %s is too large to sweep (maximum %d addresses)", block, maxSweepAddresses)
+	}
+
+	var ips []net.IP
+	for ip := network.IP; network.Contains(ip); ip = nextIP(ip) {
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// nextIP returns the address following ip
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// ipLess orders IPv4 addresses numerically, falling back to string order
+func ipLess(a, b string) bool {
+	ipA, ipB := net.ParseIP(a).To4(), net.ParseIP(b).To4()
+	if ipA == nil || ipB == nil {
+		return a < b
+	}
+	return binary.BigEndian.Uint32(ipA) < binary.BigEndian.Uint32(ipB)
+}
@@ -0,0 +1,167 @@
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/presstronic/recontronic-cli-client/pkg/config"
+)
+
+// syncStateFileName is the file in each domain's results directory recording
+// which result files have been pushed to which server
+const syncStateFileName = ".sync-state"
+
+// SyncTools are the tools whose results 'recon sync push' uploads; subdomain
+// results carry the verification data
+var SyncTools = []string{"subdomains", "dns"}
+
+// SyncRecord describes one pushed result file
+type SyncRecord struct {
+	SHA256   string    `json:"sha256"`
+	PushedAt time.Time `json:"pushed_at"`
+	ResultID int64     `json:"result_id,omitempty"`
+}
+
+// SyncState tracks the result files of a domain pushed to each server, so
+// a push only uploads new or changed files
+type SyncState struct {
+	domain  string
+	Servers map[string]map[string]SyncRecord `json:"servers"` // Server URL → result name → record
+}
+
+// LoadSyncState loads the sync state of a domain, empty when nothing has
+// been pushed yet
+func LoadSyncState(domain string) (*SyncState, error) {
+	state := &SyncState{domain: domain, Servers: make(map[string]map[string]SyncRecord)}
+
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(domainDir, syncStateFileName))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state: %w", err)
+	}
+	if state.Servers == nil {
+		state.Servers = make(map[string]map[string]SyncRecord)
+	}
+	return state, nil
+}
+
+// Pushed reports whether a result file with this checksum has been pushed to
+// a server. Files are tracked by name without extension, so compacting a
+// result doesn't push it again.
+func (s *SyncState) Pushed(server, file, sha256 string) bool {
+	record, ok := s.Servers[server][trimResultExt(filepath.Base(file))]
+	return ok && record.SHA256 == sha256
+}
+
+// Record marks a result file as pushed to a server
+func (s *SyncState) Record(server, file string, record SyncRecord) {
+	if s.Servers[server] == nil {
+		s.Servers[server] = make(map[string]SyncRecord)
+	}
+	s.Servers[server][trimResultExt(filepath.Base(file))] = record
+}
+
+// Save writes the sync state
+func (s *SyncState) Save() error {
+	domainDir, err := GetDomainResultsDir(s.domain)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(domainDir, syncStateFileName), data, 0600); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
+
+// SyncCandidates lists the stored results of a domain's SyncTools, oldest
+// first, so the server receives scans in the order they ran
+func SyncCandidates(domain string) ([]ResultInfo, error) {
+	results, err := ListResultsForDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []ResultInfo
+	for i := len(results) - 1; i >= 0; i-- {
+		if contains(SyncTools, results[i].ToolName) {
+			candidates = append(candidates, results[i])
+		}
+	}
+	return candidates, nil
+}
+
+// syncToolPattern matches tool names that are safe in result file names,
+// which split tool and timestamp at the first underscore
+var syncToolPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// StoreSyncedResult stores a result document downloaded from the server as
+// <tool>_<timestamp>.json in the domain's results directory, compressed and
+// sealed like a local save, so every command reads it like a local result.
+// It returns the file path and false when a result of the tool with that
+// timestamp is already stored.
+func StoreSyncedResult(domain, toolName string, timestamp time.Time, data []byte) (string, bool, error) {
+	if err := ValidateDomain(domain); err != nil {
+		return "", false, err
+	}
+	if !syncToolPattern.MatchString(toolName) {
+		return "", false, fmt.Errorf("invalid tool name: %q", toolName)
+	}
+	if !json.Valid(data) {
+		return "", false, fmt.Errorf("%s result is not valid JSON", toolName)
+	}
+
+	if err := EnsureDomainResultsDir(domain); err != nil {
+		return "", false, err
+	}
+	domainDir, err := GetDomainResultsDir(domain)
+	if err != nil {
+		return "", false, err
+	}
+
+	name := fmt.Sprintf("%s_%s.json", toolName, timestamp.In(time.Local).Format("20060102_150405"))
+	for _, existing := range []string{name, name + compressedSuffix} {
+		if _, err := os.Stat(filepath.Join(domainDir, existing)); err == nil {
+			return filepath.Join(domainDir, existing), false, nil
+		}
+	}
+
+	fileData := data
+	if compressResults {
+		name += compressedSuffix
+		if fileData, err = gzipData(fileData); err != nil {
+			return "", false, err
+		}
+	}
+	if config.VaultEnabled() {
+		if fileData, err = config.VaultSeal(fileData); err != nil {
+			return "", false, fmt.Errorf("failed to encrypt results: %w", err)
+		}
+	}
+
+	filePath := filepath.Join(domainDir, name)
+	if err := writeFileAtomic(filePath, fileData, 0600); err != nil {
+		return "", false, fmt.Errorf("failed to write results file: %w", err)
+	}
+	if entry, ok := indexResultFile(domainDir, name, int64(len(fileData))); ok {
+		_ = recordManifestEntry(domain, entry)
+	}
+
+	return filePath, true, nil
+}
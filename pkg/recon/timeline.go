@@ -0,0 +1,143 @@
+package recon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AssetObservation is what one stored scan recorded about a subdomain
+type AssetObservation struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Tool       string    `json:"tool"`  // "subdomains" or "dns"
+	Found      bool      `json:"found"` // Listed in this scan
+	Sources    []string  `json:"sources,omitempty"`
+	Status     string    `json:"status,omitempty"` // Verification status, when verified
+	StatusCode int       `json:"status_code,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	IPs        []string  `json:"ips,omitempty"`
+	CNAME      []string  `json:"cname,omitempty"`
+}
+
+// AssetTimeline is every observation of a single subdomain across the
+// stored scans of its domain, oldest first
+type AssetTimeline struct {
+	Domain       string             `json:"domain"`
+	Subdomain    string             `json:"subdomain"`
+	FirstSeen    time.Time          `json:"first_seen"`
+	LastSeen     time.Time          `json:"last_seen"`
+	Sources      []string           `json:"sources"` // Every source that has reported the subdomain
+	Observations []AssetObservation `json:"observations"`
+}
+
+// AssetHistory assembles the timeline of a subdomain from the domain's
+// stored subdomain and DNS results. Subdomain scans that do not list it are
+// included as not found so disappearances show up.
+func AssetHistory(domain, subdomain string) (*AssetTimeline, error) {
+	results, err := ListResultsForDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(subdomain, "."))
+	timeline := &AssetTimeline{
+		Domain:    domain,
+		Subdomain: name,
+	}
+	sources := make(map[string]bool)
+
+	for _, result := range results {
+		var observation *AssetObservation
+		switch result.ToolName {
+		case "subdomains":
+			var scan SubdomainResults
+			if err := loadJSONFile(result.FilePath, &scan); err != nil {
+				continue
+			}
+			observation = observeSubdomain(scan, name)
+		case "dns":
+			var scan DNSResults
+			if err := loadJSONFile(result.FilePath, &scan); err != nil {
+				continue
+			}
+			observation = observeDNS(scan, name)
+			if !observation.Found {
+				// DNS scans only query some subdomains (e.g., alive ones)
+				continue
+			}
+		default:
+			continue
+		}
+
+		observation.Timestamp = result.Timestamp
+		observation.Tool = result.ToolName
+		for _, source := range observation.Sources {
+			sources[source] = true
+		}
+		timeline.Observations = append(timeline.Observations, *observation)
+	}
+
+	sort.Slice(timeline.Observations, func(i, j int) bool {
+		return timeline.Observations[i].Timestamp.Before(timeline.Observations[j].Timestamp)
+	})
+
+	for _, observation := range timeline.Observations {
+		if !observation.Found {
+			continue
+		}
+		if timeline.FirstSeen.IsZero() {
+			timeline.FirstSeen = observation.Timestamp
+		}
+		timeline.LastSeen = observation.Timestamp
+	}
+	if timeline.FirstSeen.IsZero() {
+		return nil, fmt.Errorf("%s not found in any stored results for %s", name, domain)
+	}
+
+	for source := range sources {
+		timeline.Sources = append(timeline.Sources, source)
+	}
+	sort.Strings(timeline.Sources)
+
+	return timeline, nil
+}
+
+// observeSubdomain extracts a subdomain's entry from a subdomain scan
+func observeSubdomain(scan SubdomainResults, name string) *AssetObservation {
+	observation := &AssetObservation{}
+	for _, sub := range scan.Subdomains {
+		if strings.ToLower(sub.Name) != name {
+			continue
+		}
+		observation.Found = true
+		observation.Sources = sub.DiscoveredBy
+		if sub.Verified != nil {
+			observation.Status = sub.Verified.Status
+			if sub.Verified.DNS != nil {
+				observation.IPs = sub.Verified.DNS.IPs
+			}
+			if sub.Verified.HTTP != nil {
+				observation.StatusCode = sub.Verified.HTTP.StatusCode
+				observation.Title = sub.Verified.HTTP.Title
+			}
+		}
+		break
+	}
+	return observation
+}
+
+// observeDNS extracts a subdomain's records from a DNS scan
+func observeDNS(scan DNSResults, name string) *AssetObservation {
+	observation := &AssetObservation{}
+	for _, info := range scan.Records {
+		if strings.ToLower(info.Subdomain) != name {
+			continue
+		}
+		observation.Found = true
+		observation.IPs = append(append([]string{}, info.A...), info.AAAA...)
+		observation.CNAME = info.CNAME
+		break
+	}
+	return observation
+}
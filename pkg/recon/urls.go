@@ -0,0 +1,380 @@
+package recon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// URLResults represents historical URLs collected for a domain
+type URLResults struct {
+	Domain      string         `json:"domain"`
+	Timestamp   time.Time      `json:"timestamp"`
+	SourcesUsed []string       `json:"sources_used"`
+	TotalUnique int            `json:"total_unique"`
+	URLs        []string       `json:"urls"`
+	Hosts       map[string]int `json:"hosts"`      // URL count per host
+	Extensions  map[string]int `json:"extensions"` // URL count per file extension
+	Parameters  map[string]int `json:"parameters"` // Occurrences of each query parameter name
+	Summary     map[string]int `json:"summary"`    // Raw URL count per source
+}
+
+// URLSource interface for historical URL providers
+type URLSource interface {
+	Name() string
+	IsAvailable() bool
+	Collect(domain string) ([]string, error)
+}
+
+// URLCollectionOptions configures historical URL collection
+type URLCollectionOptions struct {
+	Hosts []string // Only keep URLs on these hosts (optional, default: every host under the domain)
+}
+
+// CollectURLs runs all available sources, then normalizes, dedupes, and
+// inventories the URLs they return
+func CollectURLs(domain string, sources []URLSource, options URLCollectionOptions) (*URLResults, error) {
+	results := &URLResults{
+		Domain:      domain,
+		Timestamp:   time.Now(),
+		SourcesUsed: []string{},
+		URLs:        []string{},
+		Hosts:       make(map[string]int),
+		Extensions:  make(map[string]int),
+		Parameters:  make(map[string]int),
+		Summary:     make(map[string]int),
+	}
+
+	allowedHosts := make(map[string]bool)
+	for _, host := range options.Hosts {
+		allowedHosts[strings.ToLower(host)] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		if !source.IsAvailable() {
+			continue
+		}
+
+		sourceName := source.Name()
+		results.SourcesUsed = append(results.SourcesUsed, sourceName)
+
+		fmt.Printf("Running %s... ", sourceName)
+		startTime := time.Now()
+
+		urls, err := source.Collect(domain)
+		duration := time.Since(startTime)
+
+		if err != nil {
+			// Log error but continue with other sources
+			fmt.Printf("✗ failed after %s: %v\n", duration.Round(time.Second), err)
+			continue
+		}
+
+		fmt.Printf("✓ %d URLs in %s\n", len(urls), duration.Round(time.Second))
+		results.Summary[sourceName] = len(urls)
+
+		for _, raw := range urls {
+			normalized, parsed, ok := NormalizeURL(raw)
+			if !ok || seen[normalized] {
+				continue
+			}
+
+			host := parsed.Hostname()
+			if !isInScope(host, domain) {
+				continue
+			}
+			if len(allowedHosts) > 0 && !allowedHosts[host] {
+				continue
+			}
+
+			seen[normalized] = true
+			results.URLs = append(results.URLs, normalized)
+			results.Hosts[host]++
+
+			if ext := path.Ext(parsed.Path); ext != "" && len(ext) <= 10 {
+				results.Extensions[strings.ToLower(ext)]++
+			}
+			for name := range parsed.Query() {
+				results.Parameters[name]++
+			}
+		}
+	}
+
+	sort.Strings(results.URLs)
+	results.TotalUnique = len(results.URLs)
+
+	return results, nil
+}
+
+// NormalizeURL canonicalizes a URL for deduplication: lowercase scheme and
+// host, default ports and fragments removed, and query parameters sorted
+func NormalizeURL(raw string) (string, *url.URL, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil, false
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return "", nil, false
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", nil, false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+	if (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	parsed.Host = host
+	if port != "" {
+		parsed.Host = host + ":" + port
+	}
+
+	parsed.Fragment = ""
+	parsed.User = nil
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+	parsed.RawQuery = parsed.Query().Encode() // Encode sorts by key
+
+	return parsed.String(), parsed, true
+}
+
+// isInScope reports whether host is the domain or one of its subdomains
+func isInScope(host, domain string) bool {
+	host = strings.ToLower(host)
+	domain = strings.ToLower(domain)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// newSourceClient creates the HTTP client used by API-based URL sources
+func newSourceClient(proxyURL *url.URL) *http.Client {
+	return &http.Client{
+		Timeout: 2 * time.Minute,
+		Transport: &http.Transport{
+			Proxy: proxyFunc(proxyURL),
+		},
+	}
+}
+
+// getBody performs a GET request and returns the body of a 200 response
+func getBody(client *http.Client, apiURL, sourceName string) ([]byte, error) {
+	resp, err := client.Get(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s query failed: %w", sourceName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s query failed: HTTP %d", sourceName, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", sourceName, err)
+	}
+
+	return body, nil
+}
+
+// WaybackSource implements URLSource for the Wayback Machine CDX API
+type WaybackSource struct {
+	Proxy *url.URL // Route requests through this proxy (optional)
+}
+
+func (s *WaybackSource) Name() string {
+	return "wayback"
+}
+
+func (s *WaybackSource) IsAvailable() bool {
+	return true // Always available (API-based)
+}
+
+func (s *WaybackSource) Collect(domain string) ([]string, error) {
+	apiURL := fmt.Sprintf("https://web.archive.org/cdx/search/cdx?url=*.%s/*&output=json&fl=original&collapse=urlkey", url.QueryEscape(domain))
+	body, err := getBody(newSourceClient(s.Proxy), apiURL, "wayback")
+	if err != nil {
+		return nil, err
+	}
+
+	// The first row is the field header
+	var rows [][]string
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, fmt.Errorf("failed to parse wayback response: %w", err)
+	}
+
+	var urls []string
+	for i, row := range rows {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		urls = append(urls, row[0])
+	}
+
+	return urls, nil
+}
+
+// CommonCrawlSource implements URLSource for the Common Crawl index API
+type CommonCrawlSource struct {
+	Proxy *url.URL // Route requests through this proxy (optional)
+}
+
+func (s *CommonCrawlSource) Name() string {
+	return "commoncrawl"
+}
+
+func (s *CommonCrawlSource) IsAvailable() bool {
+	return true // Always available (API-based)
+}
+
+func (s *CommonCrawlSource) Collect(domain string) ([]string, error) {
+	client := newSourceClient(s.Proxy)
+
+	// Find the most recent crawl index
+	body, err := getBody(client, "https://index.commoncrawl.org/collinfo.json", "commoncrawl")
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []struct {
+		CDXAPI string `json:"cdx-api"`
+	}
+	if err := json.Unmarshal(body, &indexes); err != nil || len(indexes) == 0 {
+		return nil, fmt.Errorf("failed to parse commoncrawl index list")
+	}
+
+	apiURL := fmt.Sprintf("%s?url=*.%s&output=json&fl=url", indexes[0].CDXAPI, url.QueryEscape(domain))
+	body, err = getBody(client, apiURL, "commoncrawl")
+	if err != nil {
+		return nil, err
+	}
+
+	// Response is JSON lines
+	var urls []string
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.URL != "" {
+			urls = append(urls, entry.URL)
+		}
+	}
+
+	return urls, nil
+}
+
+// URLScanSource implements URLSource for the urlscan.io search API
+type URLScanSource struct {
+	Proxy *url.URL // Route requests through this proxy (optional)
+}
+
+func (s *URLScanSource) Name() string {
+	return "urlscan"
+}
+
+func (s *URLScanSource) IsAvailable() bool {
+	return true // Always available (API-based)
+}
+
+func (s *URLScanSource) Collect(domain string) ([]string, error) {
+	apiURL := fmt.Sprintf("https://urlscan.io/api/v1/search/?q=domain:%s&size=10000", url.QueryEscape(domain))
+	body, err := getBody(newSourceClient(s.Proxy), apiURL, "urlscan")
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Results []struct {
+			Page struct {
+				URL string `json:"url"`
+			} `json:"page"`
+			Task struct {
+				URL string `json:"url"`
+			} `json:"task"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse urlscan response: %w", err)
+	}
+
+	var urls []string
+	for _, result := range response.Results {
+		if result.Page.URL != "" {
+			urls = append(urls, result.Page.URL)
+		}
+		if result.Task.URL != "" && result.Task.URL != result.Page.URL {
+			urls = append(urls, result.Task.URL)
+		}
+	}
+
+	return urls, nil
+}
+
+// GauSource implements URLSource for gau (getallurls)
+type GauSource struct {
+	Proxy *url.URL // Passed to gau's --proxy flag (optional)
+}
+
+func (s *GauSource) Name() string {
+	return "gau"
+}
+
+func (s *GauSource) IsAvailable() bool {
+	return IsToolAvailable("gau")
+}
+
+func (s *GauSource) Collect(domain string) ([]string, error) {
+	args := []string{"--subs", domain}
+	if s.Proxy != nil {
+		args = append(args, "--proxy", s.Proxy.String())
+	}
+	result, err := ExecuteWithTimeout("gau", 10*time.Minute, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gau execution failed: %w", err)
+	}
+
+	var urls []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			urls = append(urls, line)
+		}
+	}
+
+	return urls, nil
+}
+
+// TopCounts returns the keys of counts sorted by descending count, then name
+func TopCounts(counts map[string]int, limit int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
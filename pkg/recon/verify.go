@@ -3,22 +3,55 @@ package recon
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
 // VerificationResult represents the verification status of a subdomain
 type VerificationResult struct {
-	Timestamp time.Time   `json:"timestamp"`
-	Status    string      `json:"status"` // "alive", "dead", "error"
-	DNS       *DNSResult  `json:"dns,omitempty"`
-	HTTP      *HTTPResult `json:"http,omitempty"`
+	Timestamp   time.Time    `json:"timestamp"`
+	Status      string       `json:"status"` // "alive", "dead", "timeout", "connection_refused", "tls_error"
+	DNS         *DNSResult   `json:"dns,omitempty"`
+	HTTP        *HTTPResult  `json:"http,omitempty"`
+	HTTPResults []HTTPResult `json:"http_results,omitempty"` // Additional ports probed via --ports
+	TLS         *TLSResult   `json:"tls,omitempty"`
+	IPProbes    []IPProbe    `json:"ip_probes,omitempty"` // Direct-IP probes from --ip-mode
+	Findings    []Finding    `json:"findings,omitempty"`  // Misconfigurations from --checks
+
+	paths *PathsResult // Collected discovery paths, moved into subdomain metadata
+}
+
+// IPProbe represents a probe sent directly to one of a subdomain's IPs
+type IPProbe struct {
+	IP   string      `json:"ip"`
+	Mode string      `json:"mode"` // "sni" (Host/SNI set to the subdomain) or "bare" (IP only)
+	HTTP *HTTPResult `json:"http"`
+	// Differs is set when the response differs from the one served via the
+	// hostname, hinting at an origin server reachable outside the CDN
+	Differs bool   `json:"differs"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// VerificationRecord is a compact entry in a subdomain's verification history
+type VerificationRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Status     string    `json:"status"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Tech       []string  `json:"tech,omitempty"`
 }
 
 // DNSResult represents DNS resolution results
@@ -30,21 +63,148 @@ type DNSResult struct {
 
 // HTTPResult represents HTTP probe results
 type HTTPResult struct {
-	Accessible     bool     `json:"accessible"`
-	URL            string   `json:"url"`
-	StatusCode     int      `json:"status_code,omitempty"`
-	Title          string   `json:"title,omitempty"`
-	RedirectChain  []string `json:"redirect_chain,omitempty"`
-	FinalURL       string   `json:"final_url,omitempty"`
-	ContentLength  int64    `json:"content_length,omitempty"`
-	ResponseTimeMs int64    `json:"response_time_ms,omitempty"`
+	Accessible     bool             `json:"accessible"`
+	URL            string           `json:"url"`
+	Port           int              `json:"port,omitempty"`
+	StatusCode     int              `json:"status_code,omitempty"`
+	Title          string           `json:"title,omitempty"`
+	RedirectChain  []RedirectHop    `json:"redirect_chain,omitempty"`
+	FinalURL       string           `json:"final_url,omitempty"`
+	CrossDomain    bool             `json:"cross_domain_redirect,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	ContentLength  int64            `json:"content_length,omitempty"`
+	BodySize       int64            `json:"body_size,omitempty"` // Bytes read from the body (capped at 1MB)
+	ResponseTimeMs int64            `json:"response_time_ms,omitempty"`
+	Headers        *ResponseHeaders `json:"headers,omitempty"`
+	FaviconHash    int32            `json:"favicon_hash,omitempty"`
+	FaviconProduct string           `json:"favicon_product,omitempty"`
+	BodyHash       string           `json:"body_hash,omitempty"`    // SHA-256 of the raw body
+	ContentHash    string           `json:"content_hash,omitempty"` // SHA-256 of the normalized body
+	RemoteIP       string           `json:"remote_ip,omitempty"`
+	AddressFamily  string           `json:"address_family,omitempty"` // "ipv4" or "ipv6", unset when proxied
+}
+
+// RedirectHop represents a single response in a redirect chain
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+// maxRedirects is the number of redirects followed before giving up
+const maxRedirects = 10
+
+// ResponseHeaders holds the security-relevant headers captured from a response
+type ResponseHeaders struct {
+	Server                  string        `json:"server,omitempty"`
+	PoweredBy               string        `json:"x_powered_by,omitempty"`
+	ContentSecurityPolicy   string        `json:"content_security_policy,omitempty"`
+	StrictTransportSecurity string        `json:"strict_transport_security,omitempty"`
+	XFrameOptions           string        `json:"x_frame_options,omitempty"`
+	XContentTypeOptions     string        `json:"x_content_type_options,omitempty"`
+	Cookies                 []CookieFlags `json:"cookies,omitempty"`
+}
+
+// CookieFlags records the security attributes of a Set-Cookie header
+type CookieFlags struct {
+	Name     string `json:"name"`
+	Secure   bool   `json:"secure"`
+	HTTPOnly bool   `json:"http_only"`
+	SameSite string `json:"same_site,omitempty"`
+}
+
+// headerAliases maps short header names accepted by filters to canonical names
+var headerAliases = map[string]string{
+	"server":                    "server",
+	"x-powered-by":              "x-powered-by",
+	"powered-by":                "x-powered-by",
+	"csp":                       "content-security-policy",
+	"content-security-policy":   "content-security-policy",
+	"hsts":                      "strict-transport-security",
+	"strict-transport-security": "strict-transport-security",
+	"x-frame-options":           "x-frame-options",
+	"xfo":                       "x-frame-options",
+	"x-content-type-options":    "x-content-type-options",
+	"nosniff":                   "x-content-type-options",
 }
 
+// CanonicalHeaderName resolves a header name or alias (e.g. "hsts") to the
+// lowercase header name it refers to
+func CanonicalHeaderName(name string) (string, error) {
+	canonical, ok := headerAliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unsupported header: %s (supported: server, x-powered-by, csp, hsts, x-frame-options, x-content-type-options)", name)
+	}
+	return canonical, nil
+}
+
+// Has reports whether the named header (or alias) was present in the response
+func (h *ResponseHeaders) Has(name string) bool {
+	if h == nil {
+		return false
+	}
+
+	canonical, err := CanonicalHeaderName(name)
+	if err != nil {
+		return false
+	}
+
+	switch canonical {
+	case "server":
+		return h.Server != ""
+	case "x-powered-by":
+		return h.PoweredBy != ""
+	case "content-security-policy":
+		return h.ContentSecurityPolicy != ""
+	case "strict-transport-security":
+		return h.StrictTransportSecurity != ""
+	case "x-frame-options":
+		return h.XFrameOptions != ""
+	case "x-content-type-options":
+		return h.XContentTypeOptions != ""
+	}
+
+	return false
+}
+
+// TLSResult represents the certificate presented by an HTTPS endpoint
+type TLSResult struct {
+	Subject         string    `json:"subject"`
+	Issuer          string    `json:"issuer"`
+	SANs            []string  `json:"sans,omitempty"`
+	NotBefore       time.Time `json:"not_before"`
+	NotAfter        time.Time `json:"not_after"`
+	SelfSigned      bool      `json:"self_signed"`
+	Expired         bool      `json:"expired"`
+	ExpiresSoon     bool      `json:"expires_soon"`
+	DaysUntilExpiry int       `json:"days_until_expiry"`
+	JARM            string    `json:"jarm,omitempty"`
+	JARMMatch       string    `json:"jarm_match,omitempty"` // Known C2/product for the JARM fingerprint
+
+	host string // Host and port that served the certificate, after redirects
+	port int
+}
+
+// certExpiryWarning is how close to expiry a certificate must be to be flagged
+const certExpiryWarning = 30 * 24 * time.Hour
+
 // VerifyOptions configures verification behavior
 type VerifyOptions struct {
 	Concurrency int           // Parallel probes (default: 10)
 	Timeout     time.Duration // Per-probe timeout (default: 10s)
 	UserAgent   string        // Custom user agent
+	Ports       []int         // Additional ports to probe beyond 80/443
+	Retries     int           // Retries after a failed probe (default: 2)
+	RetryDelay  time.Duration // Base delay for exponential backoff (default: 500ms)
+	Limiter     *RateLimiter  // Shared rate limiter applied to every request (optional)
+	Proxy       *url.URL      // Route probes through this proxy (optional)
+	Resolvers   *ResolverPool // Custom nameservers (optional, default: system resolver)
+	IPMode      bool          // Also probe resolved IPs directly, with and without SNI
+	JARM        bool          // Compute JARM fingerprints for HTTPS hosts
+	Headers     http.Header   // Extra headers sent with every probe (optional)
+	BasicAuth   *url.Userinfo // Basic auth credentials sent with every probe (optional)
+	Checks      []string      // Misconfiguration checks to run on alive hosts (cors, open-redirect, trace)
+	Quick       bool          // Send HEAD requests only, skipping body reads, titles, and favicons
+	Paths       bool          // Fetch robots.txt, sitemap.xml, and security.txt from alive hosts (default: true)
 }
 
 // DefaultVerifyOptions returns default verification options
@@ -53,6 +213,9 @@ func DefaultVerifyOptions() VerifyOptions {
 		Concurrency: 10,
 		Timeout:     10 * time.Second,
 		UserAgent:   "Mozilla/5.0 (compatible; Recontronic/1.0)",
+		Retries:     2,
+		RetryDelay:  500 * time.Millisecond,
+		Paths:       true,
 	}
 }
 
@@ -64,7 +227,7 @@ func VerifySubdomain(subdomain string, options VerifyOptions) (*VerificationResu
 	}
 
 	// Step 1: DNS Resolution
-	dnsResult := resolveDNS(subdomain)
+	dnsResult := resolveDNS(subdomain, options.Resolvers)
 	result.DNS = dnsResult
 
 	if !dnsResult.Resolves {
@@ -72,27 +235,77 @@ func VerifySubdomain(subdomain string, options VerifyOptions) (*VerificationResu
 		return result, nil
 	}
 
-	// Step 2: HTTP Probe
-	httpResult := probeHTTP(subdomain, dnsResult.IPs, options)
+	// Step 2: HTTP Probe, retrying transient failures with backoff
+	var httpResult *HTTPResult
+	var tlsResult *TLSResult
+	var probeErr error
+	for attempt := 0; attempt <= options.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBackoff(options.RetryDelay, attempt))
+		}
+
+		httpResult, tlsResult, probeErr = probeHTTP(subdomain, dnsResult.IPs, options)
+		if httpResult.Accessible || !isRetryable(probeErr) {
+			break
+		}
+	}
 	result.HTTP = httpResult
+	result.TLS = tlsResult
+
+	if httpResult.Accessible {
+		result.Status = "alive"
+	} else {
+		result.Status = classifyProbeError(probeErr)
+		httpResult.Error = errorString(probeErr)
+	}
 
-	if httpResult != nil && httpResult.Accessible {
+	// Collect robots.txt, sitemap.xml, and security.txt paths
+	if options.Paths && httpResult.Accessible {
+		result.paths = collectPaths(newProbeClient(options), httpResult.URL, options)
+	}
+
+	// Misconfiguration checks
+	if len(options.Checks) > 0 && httpResult.Accessible {
+		result.Findings = runChecks(newProbeClient(options), subdomain, httpResult.URL, options)
+	}
+
+	// JARM fingerprint of the HTTPS endpoint
+	if options.JARM && tlsResult != nil {
+		if fingerprint, err := JARMFingerprint(tlsResult.host, tlsResult.port, options); err == nil {
+			tlsResult.JARM = fingerprint
+			tlsResult.JARMMatch = LookupJARM(fingerprint)
+		}
+	}
+
+	// Step 3: Direct-IP probes
+	if options.IPMode {
+		result.IPProbes = probeIPs(subdomain, dnsResult.IPs, httpResult, options)
+	}
+
+	// Step 4: Additional ports
+	result.HTTPResults = probePorts(subdomain, options)
+	if len(result.HTTPResults) > 0 && result.Status != "alive" {
 		result.Status = "alive"
 	}
 
 	return result, nil
 }
 
-// VerifySubdomains verifies multiple subdomains concurrently
-func VerifySubdomains(subdomains []Subdomain, options VerifyOptions) ([]Subdomain, error) {
-	var wg sync.WaitGroup
+// VerifyEvent reports that verification of a single subdomain has finished
+type VerifyEvent struct {
+	Index     int       // Position of the subdomain in the input slice
+	Subdomain Subdomain // Subdomain with its verification result applied
+	Err       error     // Set when the subdomain could not be verified
+}
+
+// VerifySubdomainsStream verifies subdomains concurrently and sends one event
+// per host as soon as it completes, so callers can report progress. The
+// channel is closed once every subdomain has been processed.
+func VerifySubdomainsStream(subdomains []Subdomain, options VerifyOptions) <-chan VerifyEvent {
+	events := make(chan VerifyEvent, options.Concurrency)
 	semaphore := make(chan struct{}, options.Concurrency)
-	resultsChan := make(chan struct {
-		index  int
-		result *VerificationResult
-	}, len(subdomains))
+	var wg sync.WaitGroup
 
-	// Verify each subdomain concurrently
 	for i, sub := range subdomains {
 		wg.Add(1)
 		go func(index int, subdomain Subdomain) {
@@ -104,52 +317,64 @@ func VerifySubdomains(subdomains []Subdomain, options VerifyOptions) ([]Subdomai
 
 			// Verify subdomain
 			result, err := VerifySubdomain(subdomain.Name, options)
-			if err != nil {
-				// Log error but don't fail
-				fmt.Printf("Warning: failed to verify %s: %v\n", subdomain.Name, err)
-				return
+			if err == nil {
+				applyVerification(&subdomain, result)
 			}
 
-			// Send result
-			resultsChan <- struct {
-				index  int
-				result *VerificationResult
-			}{index: index, result: result}
+			events <- VerifyEvent{Index: index, Subdomain: subdomain, Err: err}
 		}(i, sub)
 	}
 
-	// Close results channel when all done
+	// Close events channel when all done
 	go func() {
 		wg.Wait()
-		close(resultsChan)
+		close(events)
 	}()
 
-	// Update subdomains with results
+	return events
+}
+
+// VerifySubdomains verifies multiple subdomains concurrently
+func VerifySubdomains(subdomains []Subdomain, options VerifyOptions) ([]Subdomain, error) {
 	verified := make([]Subdomain, len(subdomains))
 	copy(verified, subdomains)
 
-	for res := range resultsChan {
-		verified[res.index].Verified = res.result
+	for event := range VerifySubdomainsStream(subdomains, options) {
+		if event.Err != nil {
+			// Log error but don't fail
+			fmt.Printf("Warning: failed to verify %s: %v\n", event.Subdomain.Name, event.Err)
+			continue
+		}
+		verified[event.Index] = event.Subdomain
 	}
 
 	return verified, nil
 }
 
-// resolveDNS checks if a subdomain resolves
-func resolveDNS(subdomain string) *DNSResult {
+// applyVerification replaces a subdomain's verification result, moving the
+// previous result into its history
+func applyVerification(sub *Subdomain, result *VerificationResult) {
+	if prev := sub.Verified; prev != nil {
+		sub.History = append(sub.History, newVerificationRecord(prev))
+	}
+	sub.Verified = result
+	applyPaths(sub, result.paths)
+}
+
+// resolveDNS checks if a subdomain resolves, using the resolver pool when set
+func resolveDNS(subdomain string, pool *ResolverPool) *DNSResult {
 	result := &DNSResult{
 		Resolves: false,
 	}
 
 	// Resolve with timeout
-	resolver := &net.Resolver{
-		PreferGo: true,
-	}
+	resolver, server := pool.Resolver()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	ips, err := resolver.LookupIP(ctx, "ip", subdomain)
+	pool.Report(server, err)
 	if err != nil {
 		result.Error = err.Error()
 		return result
@@ -168,47 +393,231 @@ func resolveDNS(subdomain string) *DNSResult {
 	return result
 }
 
-// probeHTTP attempts to connect via HTTP/HTTPS
-func probeHTTP(subdomain string, ips []string, options VerifyOptions) *HTTPResult {
-	result := &HTTPResult{
-		Accessible: false,
+// probeHTTP attempts to connect via HTTP/HTTPS, returning certificate details
+// when the endpoint was reached over TLS
+func probeHTTP(subdomain string, ips []string, options VerifyOptions) (*HTTPResult, *TLSResult, error) {
+	result, tlsResult, err := probeTarget(newProbeClient(options), subdomain, options)
+	if result.Accessible || options.Proxy != nil {
+		return result, tlsResult, err
 	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: options.Timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, // Skip cert validation for recon
-			},
-			DisableKeepAlives: true,
+	// The default dialer may give up after trying only IPv4 addresses, so
+	// retry IPv6-only and dual-stack hosts against their AAAA records directly
+	tried := 0
+	for _, ip := range ips {
+		if tried >= maxIPv6Fallbacks {
+			break
+		}
+		if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() != nil {
+			continue
+		}
+		tried++
+
+		v6Result, v6TLS, _ := probeTarget(newPinnedProbeClient(options, ip), subdomain, options)
+		if v6Result.Accessible {
+			return v6Result, v6TLS, nil
+		}
+	}
+
+	return result, tlsResult, err
+}
+
+// maxIPv6Fallbacks limits how many AAAA addresses are tried when the
+// hostname probe fails
+const maxIPv6Fallbacks = 2
+
+// addressFamily returns "ipv4" or "ipv6" for an IP address string
+func addressFamily(ip string) string {
+	parsed := net.ParseIP(ip)
+	switch {
+	case parsed == nil:
+		return ""
+	case parsed.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// maxIPProbes limits how many resolved IPs are probed per subdomain
+const maxIPProbes = 4
+
+// probeIPs probes each resolved IP twice: once with the Host header and SNI
+// set to the subdomain, and once as a bare IP without SNI
+func probeIPs(subdomain string, ips []string, hostResult *HTTPResult, options VerifyOptions) []IPProbe {
+	var probes []IPProbe
+
+	for i, ip := range ips {
+		if i >= maxIPProbes {
+			break
+		}
+
+		// Hostname routed to this IP
+		sniResult, _, _ := probeTarget(newPinnedProbeClient(options, ip), subdomain, options)
+		probes = append(probes, newIPProbe(ip, "sni", sniResult, hostResult))
+
+		// Bare IP
+		target := ip
+		if strings.Contains(ip, ":") {
+			target = "[" + ip + "]"
+		}
+		bareResult, _, _ := probeTarget(newProbeClient(options), target, options)
+		probes = append(probes, newIPProbe(ip, "bare", bareResult, hostResult))
+	}
+
+	return probes
+}
+
+// newIPProbe compares a direct-IP response against the hostname response
+func newIPProbe(ip, mode string, result, hostResult *HTTPResult) IPProbe {
+	probe := IPProbe{IP: ip, Mode: mode, HTTP: result}
+	if !result.Accessible {
+		return probe
+	}
+
+	switch {
+	case hostResult == nil || !hostResult.Accessible:
+		probe.Differs = true
+		probe.Reason = "responds directly while hostname does not"
+	case result.StatusCode != hostResult.StatusCode:
+		probe.Differs = true
+		probe.Reason = fmt.Sprintf("status %d vs %d via hostname", result.StatusCode, hostResult.StatusCode)
+	case result.ContentHash != "" && hostResult.ContentHash != "" && result.ContentHash != hostResult.ContentHash:
+		probe.Differs = true
+		probe.Reason = "different content than via hostname"
+	}
+
+	return probe
+}
+
+// probePorts probes each additional port in options.Ports, returning one
+// result per port that answered over HTTP or HTTPS
+func probePorts(subdomain string, options VerifyOptions) []HTTPResult {
+	ports := additionalPorts(options.Ports)
+	if len(ports) == 0 {
+		return nil
+	}
+
+	client := newProbeClient(options)
+
+	var results []HTTPResult
+	for _, port := range ports {
+		target := net.JoinHostPort(subdomain, strconv.Itoa(port))
+		result, _, _ := probeTarget(client, target, options)
+		if result.Accessible {
+			result.Port = port
+			results = append(results, *result)
+		}
+	}
+
+	return results
+}
+
+// additionalPorts returns ports without duplicates and without 80 and 443,
+// which the default probes already cover
+func additionalPorts(ports []int) []int {
+	seen := map[int]bool{80: true, 443: true}
+	var unique []int
+	for _, port := range ports {
+		if seen[port] {
+			continue
+		}
+		seen[port] = true
+		unique = append(unique, port)
+	}
+	return unique
+}
+
+// newProbeClient creates the HTTP client used for probing
+func newProbeClient(options VerifyOptions) *http.Client {
+	return newPinnedProbeClient(options, "")
+}
+
+// newPinnedProbeClient creates a probe client that connects to pinnedIP
+// regardless of the hostname in the URL, so the Host header and SNI still
+// carry the hostname. An empty pinnedIP behaves like newProbeClient.
+func newPinnedProbeClient(options VerifyOptions, pinnedIP string) *http.Client {
+	baseTransport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // Skip cert validation for recon
 		},
+		Proxy:             proxyFunc(options.Proxy),
+		DisableKeepAlives: true,
+	}
+	if pinnedIP != "" {
+		dialer := &net.Dialer{Timeout: options.Timeout}
+		baseTransport.Proxy = nil
+		baseTransport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			return dialer.DialContext(ctx, network, net.JoinHostPort(pinnedIP, port))
+		}
+	}
+
+	var transport http.RoundTripper = baseTransport
+	if options.Limiter != nil {
+		transport = &limitedTransport{base: transport, limiter: options.Limiter}
+	}
+
+	return &http.Client{
+		Timeout:   options.Timeout,
+		Transport: transport,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 3 {
+			if len(via) >= maxRedirects {
 				return http.ErrUseLastResponse
 			}
 			return nil
 		},
 	}
+}
+
+// probeTarget tries HTTPS then HTTP against a host or host:port. When neither
+// responds, the error from the HTTPS attempt is returned since it is usually
+// the more informative of the two.
+func probeTarget(client *http.Client, target string, options VerifyOptions) (*HTTPResult, *TLSResult, error) {
+	result := &HTTPResult{
+		Accessible: false,
+	}
+	var firstErr error
 
 	// Try HTTPS first, then HTTP
 	protocols := []string{"https", "http"}
 
+	method := "GET"
+	if options.Quick {
+		method = "HEAD"
+	}
+
 	for _, protocol := range protocols {
-		url := fmt.Sprintf("%s://%s", protocol, subdomain)
+		url := fmt.Sprintf("%s://%s", protocol, target)
 
 		startTime := time.Now()
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequest(method, url, nil)
 		if err != nil {
 			continue
 		}
 
-		req.Header.Set("User-Agent", options.UserAgent)
+		setRequestHeaders(req, options)
+
+		// Record which address answered the first request
+		var remoteIP string
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			GotConn: func(info httptrace.GotConnInfo) {
+				if addr, ok := info.Conn.RemoteAddr().(*net.TCPAddr); ok && remoteIP == "" {
+					remoteIP = addr.IP.String()
+				}
+			},
+		}))
 
 		resp, err := client.Do(req)
 		responseTime := time.Since(startTime)
 
 		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
 			continue
 		}
 		defer resp.Body.Close()
@@ -219,28 +628,204 @@ func probeHTTP(subdomain string, ips []string, options VerifyOptions) *HTTPResul
 		result.StatusCode = resp.StatusCode
 		result.ResponseTimeMs = responseTime.Milliseconds()
 		result.ContentLength = resp.ContentLength
+		result.Headers = captureHeaders(resp)
+		if options.Proxy == nil {
+			result.RemoteIP = remoteIP
+			result.AddressFamily = addressFamily(remoteIP)
+		}
 
-		// Extract title from HTML
-		if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		if !options.Quick {
+			// Hash body and extract title from HTML
 			body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024)) // Read max 1MB
 			if err == nil {
-				result.Title = extractTitle(string(body))
+				if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+					result.Title = extractTitle(string(body))
+				}
+				result.BodySize = int64(len(body))
+				result.BodyHash = hashBody(body)
+				result.ContentHash = hashNormalizedBody(body, target)
+			}
+
+			// Fingerprint favicon
+			if hash, ok := fetchFavicon(client, url, options); ok {
+				result.FaviconHash = hash
+				result.FaviconProduct = LookupFaviconProduct(hash)
 			}
 		}
 
 		// Track redirects
-		if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		result.RedirectChain = buildRedirectChain(resp)
+		if len(result.RedirectChain) > 0 {
+			result.FinalURL = resp.Request.URL.String()
+			result.CrossDomain = !sameSite(req.URL.Hostname(), resp.Request.URL.Hostname())
+		} else if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			// Redirect limit reached or Location unreachable
 			if location := resp.Header.Get("Location"); location != "" {
 				result.FinalURL = location
 			}
 		}
 
-		return result
+		tlsResult := inspectTLS(resp.TLS)
+		if tlsResult != nil {
+			tlsResult.host = resp.Request.URL.Hostname()
+			tlsResult.port = 443
+			if port, err := strconv.Atoi(resp.Request.URL.Port()); err == nil {
+				tlsResult.port = port
+			}
+		}
+
+		return result, tlsResult, nil
+	}
+
+	return result, nil, firstErr
+}
+
+// classifyProbeError maps a probe failure to a verification status
+func classifyProbeError(err error) string {
+	if err == nil {
+		return "dead"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "connection_refused"
+	}
+
+	var recordErr tls.RecordHeaderError
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &recordErr) || errors.As(err, &certErr) || strings.Contains(err.Error(), "tls:") {
+		return "tls_error"
+	}
+
+	return "dead"
+}
+
+// isRetryable reports whether a probe failure is worth retrying
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	status := classifyProbeError(err)
+	return status == "timeout" || status == "dead"
+}
+
+// retryBackoff returns the delay before the given retry attempt: exponential
+// backoff from base plus up to base of random jitter
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	backoff := base * time.Duration(1<<(attempt-1))
+	return backoff + time.Duration(rand.Int63n(int64(base)))
+}
+
+// errorString returns err's message, or "" for a nil error
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// buildRedirectChain reconstructs every hop that led to resp, in order. Each
+// hop records the URL requested and the redirect status it returned.
+func buildRedirectChain(resp *http.Response) []RedirectHop {
+	var chain []RedirectHop
+	for prev := resp.Request.Response; prev != nil; prev = prev.Request.Response {
+		chain = append([]RedirectHop{{
+			URL:        prev.Request.URL.String(),
+			StatusCode: prev.StatusCode,
+		}}, chain...)
+	}
+	return chain
+}
+
+// sameSite reports whether two hostnames share the same base domain
+func sameSite(a, b string) bool {
+	return baseDomain(a) == baseDomain(b)
+}
+
+// baseDomain returns the last two labels of a hostname
+func baseDomain(host string) string {
+	parts := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")
+	if len(parts) <= 2 {
+		return strings.Join(parts, ".")
+	}
+	return strings.Join(parts[len(parts)-2:], ".")
+}
+
+// captureHeaders extracts security-relevant headers and cookie flags
+func captureHeaders(resp *http.Response) *ResponseHeaders {
+	headers := &ResponseHeaders{
+		Server:                  resp.Header.Get("Server"),
+		PoweredBy:               resp.Header.Get("X-Powered-By"),
+		ContentSecurityPolicy:   resp.Header.Get("Content-Security-Policy"),
+		StrictTransportSecurity: resp.Header.Get("Strict-Transport-Security"),
+		XFrameOptions:           resp.Header.Get("X-Frame-Options"),
+		XContentTypeOptions:     resp.Header.Get("X-Content-Type-Options"),
+	}
+
+	for _, cookie := range resp.Cookies() {
+		flags := CookieFlags{
+			Name:     cookie.Name,
+			Secure:   cookie.Secure,
+			HTTPOnly: cookie.HttpOnly,
+		}
+		switch cookie.SameSite {
+		case http.SameSiteLaxMode:
+			flags.SameSite = "Lax"
+		case http.SameSiteStrictMode:
+			flags.SameSite = "Strict"
+		case http.SameSiteNoneMode:
+			flags.SameSite = "None"
+		}
+		headers.Cookies = append(headers.Cookies, flags)
+	}
+
+	return headers
+}
+
+// inspectTLS extracts details of the leaf certificate from a TLS connection
+func inspectTLS(state *tls.ConnectionState) *TLSResult {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	cert := state.PeerCertificates[0]
+	now := time.Now()
+
+	result := &TLSResult{
+		Subject:    cert.Subject.String(),
+		Issuer:     cert.Issuer.String(),
+		SANs:       cert.DNSNames,
+		NotBefore:  cert.NotBefore,
+		NotAfter:   cert.NotAfter,
+		SelfSigned: isSelfSigned(cert),
+		Expired:    now.After(cert.NotAfter),
+	}
+
+	remaining := cert.NotAfter.Sub(now)
+	result.DaysUntilExpiry = int(remaining.Hours() / 24)
+	result.ExpiresSoon = !result.Expired && remaining < certExpiryWarning
+
+	for _, ip := range cert.IPAddresses {
+		result.SANs = append(result.SANs, ip.String())
 	}
 
 	return result
 }
 
+// isSelfSigned reports whether a certificate was signed by its own key
+func isSelfSigned(cert *x509.Certificate) bool {
+	if cert.Subject.String() != cert.Issuer.String() {
+		return false
+	}
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
 // extractTitle extracts the <title> tag from HTML
 func extractTitle(html string) string {
 	// Simple regex to extract title
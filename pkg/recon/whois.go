@@ -3,24 +3,40 @@ package recon
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 )
 
-// WhoisInfo represents parsed WHOIS information for a domain
+// WhoisInfo represents parsed WHOIS information for a domain, IP, or netblock
 type WhoisInfo struct {
-	Domain       string    `json:"domain"`
-	Registrar    string    `json:"registrar,omitempty"`
-	CreatedDate  string    `json:"created_date,omitempty"`
-	UpdatedDate  string    `json:"updated_date,omitempty"`
-	ExpiryDate   string    `json:"expiry_date,omitempty"`
-	NameServers  []string  `json:"name_servers,omitempty"`
-	Status       []string  `json:"status,omitempty"`
-	RegistrarURL string    `json:"registrar_url,omitempty"`
-	WhoisServer  string    `json:"whois_server,omitempty"`
-	RawOutput    string    `json:"raw_output"`
-	LookedUpAt   time.Time `json:"looked_up_at"`
+	Domain          string     `json:"domain"` // Queried domain, IP, or CIDR
+	Registrar       string     `json:"registrar,omitempty"`
+	CreatedDate     string     `json:"created_date,omitempty"`
+	UpdatedDate     string     `json:"updated_date,omitempty"`
+	ExpiryDate      string     `json:"expiry_date,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"` // Parsed from CreatedDate
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"` // Parsed from UpdatedDate
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"` // Parsed from ExpiryDate
+	NameServers     []string   `json:"name_servers,omitempty"`
+	Status          []string   `json:"status,omitempty"`
+	RegistrarURL    string     `json:"registrar_url,omitempty"`
+	WhoisServer     string     `json:"whois_server,omitempty"`
+	RegistrantOrg   string     `json:"registrant_org,omitempty"`
+	RegistrantEmail string     `json:"registrant_email,omitempty"`
+	Netblock        string     `json:"netblock,omitempty"` // IP queries: CIDR or range containing the address
+	NetName         string     `json:"net_name,omitempty"`
+	Organization    string     `json:"organization,omitempty"`
+	Country         string     `json:"country,omitempty"`
+	AbuseEmail      string     `json:"abuse_email,omitempty"`
+	RIR             string     `json:"rir,omitempty"`      // ARIN, RIPE, APNIC, LACNIC, or AFRINIC
+	Protocol        string     `json:"protocol,omitempty"` // rdap or whois
+	RawOutput       string     `json:"raw_output,omitempty"`
+	RawRef          *RawRef    `json:"raw_ref,omitempty"` // Where RawOutput is stored when saved to a sidecar
+	LookedUpAt      time.Time  `json:"looked_up_at"`
 }
 
 // WhoisResults represents the complete WHOIS lookup results
@@ -31,28 +47,213 @@ type WhoisResults struct {
 	Error      string    `json:"error,omitempty"`
 }
 
-// LookupWhois performs a WHOIS lookup for the given domain
+// IPWhoisResults represents WHOIS lookups for every IP resolved for a domain
+type IPWhoisResults struct {
+	Domain     string      `json:"domain"`
+	IPs        []WhoisInfo `json:"ips"`
+	Failed     []string    `json:"failed,omitempty"` // IPs whose lookup failed
+	LookedUpAt time.Time   `json:"looked_up_at"`
+}
+
+// BulkWhoisResults represents WHOIS lookups for a set of registrable domains
+type BulkWhoisResults struct {
+	Source       string            `json:"source"` // Domain or file the domains were collected from
+	Domains      []WhoisInfo       `json:"domains"`
+	Failed       []string          `json:"failed,omitempty"`        // Domains whose lookup failed
+	Registrants  []RegistrantGroup `json:"registrants"`             // Domains grouped by shared registrant
+	CNAMETargets []string          `json:"cname_targets,omitempty"` // Looked-up domains that subdomains CNAME to
+	LookedUpAt   time.Time         `json:"looked_up_at"`
+}
+
+// RegistrantGroup lists domains registered to the same owner
+type RegistrantGroup struct {
+	Registrant string   `json:"registrant"`
+	Domains    []string `json:"domains"`
+}
+
+// IsIPQuery reports whether a WHOIS query is an IP address or CIDR netblock
+func IsIPQuery(query string) bool {
+	if net.ParseIP(query) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(query)
+	return err == nil
+}
+
+// ianaWhoisServer is the root of the WHOIS referral chain
+const ianaWhoisServer = "whois.iana.org"
+
+// maxWhoisReferrals bounds referral chains (IANA → registry → registrar)
+const maxWhoisReferrals = 3
+
+// LookupWhois looks up registration data for the given domain, IP, or CIDR.
+// RDAP is tried first since it returns structured JSON; WHOIS (native client,
+// then the system whois binary if installed) is the fallback.
 func LookupWhois(ctx context.Context, domain string, timeout time.Duration) (*WhoisInfo, error) {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Execute whois command
-	cmd := exec.CommandContext(ctx, "whois", domain)
-	output, err := cmd.CombinedOutput()
+	// Leave WHOIS half the budget if RDAP stalls
+	rdapCtx, rdapCancel := context.WithTimeout(ctx, timeout/2)
+	info, err := LookupRDAP(rdapCtx, domain)
+	rdapCancel()
+
 	if err != nil {
-		return nil, fmt.Errorf("whois command failed: %w", err)
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		var whoisErr error
+		info, whoisErr = lookupWhoisProtocol(ctx, domain)
+		if whoisErr != nil {
+			return nil, fmt.Errorf("%v; WHOIS fallback: %w", err, whoisErr)
+		}
 	}
 
-	rawOutput := string(output)
+	info.LookedUpAt = time.Now()
+	info.CreatedAt = ParseWhoisDate(info.CreatedDate)
+	info.UpdatedAt = ParseWhoisDate(info.UpdatedDate)
+	info.ExpiresAt = ParseWhoisDate(info.ExpiryDate)
+
+	return info, nil
+}
+
+// lookupWhoisProtocol performs a WHOIS lookup using the native client,
+// falling back to the system whois binary if installed
+func lookupWhoisProtocol(ctx context.Context, domain string) (*WhoisInfo, error) {
+	rawOutput, server, err := nativeWhois(ctx, domain)
+	if err != nil {
+		if !IsToolAvailable("whois") || ctx.Err() != nil {
+			return nil, err
+		}
+
+		// Execute whois command
+		cmd := exec.CommandContext(ctx, "whois", domain)
+		output, cmdErr := cmd.CombinedOutput()
+		if cmdErr != nil {
+			return nil, fmt.Errorf("%v (whois command fallback: %w)", err, cmdErr)
+		}
+		rawOutput, server = string(output), ""
+	}
 
 	// Parse the WHOIS output
-	info := parseWhoisOutput(domain, rawOutput)
-	info.LookedUpAt = time.Now()
+	var info WhoisInfo
+	if IsIPQuery(domain) {
+		info = parseIPWhoisOutput(domain, rawOutput)
+	} else {
+		info = parseWhoisOutput(domain, rawOutput)
+	}
+	info.Protocol = "whois"
+	if info.WhoisServer == "" {
+		info.WhoisServer = server
+	}
 
 	return &info, nil
 }
 
+// nativeWhois queries IANA and follows referrals to the registry and then the
+// registrar (or RIR for IPs), per RFC 3912. It returns the responses after the
+// IANA hop, most authoritative last, and the final server queried.
+func nativeWhois(ctx context.Context, query string) (string, string, error) {
+	// IANA and ARIN do not understand CIDR queries; look up the network address
+	address := query
+	if ip, _, err := net.ParseCIDR(query); err == nil {
+		address = ip.String()
+	}
+
+	ianaOutput, err := queryWhoisServer(ctx, ianaWhoisServer, address)
+	if err != nil {
+		return "", "", err
+	}
+
+	server := whoisReferral(ianaOutput)
+	if server == "" {
+		// IANA is authoritative for TLDs and unallocated space
+		return ianaOutput, ianaWhoisServer, nil
+	}
+
+	var responses []string
+	var lastServer string
+	seen := map[string]bool{ianaWhoisServer: true}
+	for hop := 0; hop < maxWhoisReferrals && server != "" && !seen[server]; hop++ {
+		seen[server] = true
+
+		serverQuery := query
+		if server == "whois.arin.net" && IsIPQuery(query) {
+			// ARIN returns a summary unless asked for full network details
+			serverQuery = "n + " + address
+		}
+
+		output, err := queryWhoisServer(ctx, server, serverQuery)
+		if err != nil {
+			if len(responses) > 0 {
+				// Keep the registry data when a registrar server is unreachable
+				break
+			}
+			return "", "", err
+		}
+		responses = append(responses, output)
+		lastServer = server
+		server = whoisReferral(output)
+	}
+
+	return strings.Join(responses, "\n"), lastServer, nil
+}
+
+// queryWhoisServer sends a query to a WHOIS server on TCP port 43 and reads
+// the response until the server closes the connection
+func queryWhoisServer(ctx context.Context, server, query string) (string, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server, "43"))
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte(query + "\r\n")); err != nil {
+		return "", fmt.Errorf("failed to query %s: %w", server, err)
+	}
+
+	output, err := io.ReadAll(io.LimitReader(conn, 1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s: %w", server, err)
+	}
+
+	return string(output), nil
+}
+
+// whoisReferral extracts the next WHOIS server from a response, if any
+func whoisReferral(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(parts[0])) {
+		case "refer", "whois", "registrar whois server", "referralserver":
+			server := strings.TrimSpace(parts[1])
+			if strings.HasPrefix(server, "rwhois://") {
+				// RWhois uses a different protocol
+				continue
+			}
+			server = strings.TrimPrefix(server, "whois://")
+			server = strings.TrimPrefix(server, "http://")
+			server = strings.TrimPrefix(server, "https://")
+			server = strings.TrimSuffix(strings.TrimSuffix(server, "/"), ":43")
+			if server != "" {
+				return strings.ToLower(server)
+			}
+		}
+	}
+	return ""
+}
+
 // parseWhoisOutput parses raw WHOIS output into structured data
 func parseWhoisOutput(domain, rawOutput string) WhoisInfo {
 	info := WhoisInfo{
@@ -87,6 +288,17 @@ func parseWhoisOutput(domain, rawOutput string) WhoisInfo {
 		keyLower := strings.ToLower(key)
 
 		switch {
+		case keyLower == "registrant organization" || keyLower == "registrant organisation" ||
+			keyLower == "registrant":
+			if info.RegistrantOrg == "" {
+				info.RegistrantOrg = value
+			}
+
+		case keyLower == "registrant email":
+			if info.RegistrantEmail == "" {
+				info.RegistrantEmail = value
+			}
+
 		case strings.Contains(keyLower, "registrar") && !strings.Contains(keyLower, "whois") &&
 			!strings.Contains(keyLower, "url") && !strings.Contains(keyLower, "iana") &&
 			!strings.Contains(keyLower, "abuse") && info.Registrar == "":
@@ -146,6 +358,237 @@ func parseWhoisOutput(domain, rawOutput string) WhoisInfo {
 	return info
 }
 
+// parseIPWhoisOutput parses raw RIR WHOIS output (ARIN, RIPE, APNIC, LACNIC,
+// AFRINIC) into netblock, organization, and abuse contact fields. Registries
+// may return several objects, most specific last, so later network objects
+// override earlier ones.
+func parseIPWhoisOutput(query, rawOutput string) WhoisInfo {
+	info := WhoisInfo{
+		Domain:    query,
+		RawOutput: rawOutput,
+		RIR:       detectRIR(rawOutput),
+	}
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "%") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "cidr", "inetnum", "inet6num", "netrange":
+			// ARIN prints both NetRange and CIDR; prefer CIDR
+			if key != "netrange" || info.Netblock == "" {
+				info.Netblock = value
+			}
+
+		case "netname":
+			info.NetName = value
+
+		case "orgname", "org-name", "owner":
+			info.Organization = value
+
+		case "descr":
+			// RIPE/APNIC objects often lack org-name; fall back to the description
+			if info.Organization == "" {
+				info.Organization = value
+			}
+
+		case "country":
+			info.Country = strings.ToUpper(value)
+
+		case "orgabuseemail", "abuse-mailbox", "rabuseemail":
+			if info.AbuseEmail == "" {
+				info.AbuseEmail = value
+			}
+
+		case "e-mail":
+			// LACNIC lists contacts without a dedicated abuse field
+			if info.AbuseEmail == "" && strings.Contains(strings.ToLower(value), "abuse") {
+				info.AbuseEmail = value
+			}
+
+		case "regdate", "created":
+			if info.CreatedDate == "" {
+				info.CreatedDate = value
+			}
+
+		case "updated", "last-modified", "changed":
+			info.UpdatedDate = value
+		}
+	}
+
+	return info
+}
+
+// detectRIR identifies the regional internet registry that answered a query
+func detectRIR(rawOutput string) string {
+	lower := strings.ToLower(rawOutput)
+	switch {
+	case strings.Contains(lower, "whois.arin.net") || strings.Contains(lower, "rdap.arin.net") || strings.Contains(lower, "american registry for internet numbers"):
+		return "ARIN"
+	case strings.Contains(lower, "source:         ripe") || strings.Contains(lower, "ripe network coordination centre") ||
+		strings.Contains(lower, "whois.ripe.net") || strings.Contains(lower, "rdap.db.ripe.net"):
+		return "RIPE"
+	case strings.Contains(lower, "source:         apnic") || strings.Contains(lower, "whois.apnic.net") || strings.Contains(lower, "rdap.apnic.net"):
+		return "APNIC"
+	case strings.Contains(lower, "lacnic"):
+		return "LACNIC"
+	case strings.Contains(lower, "afrinic"):
+		return "AFRINIC"
+	default:
+		return ""
+	}
+}
+
+// LookupIPsWhois performs a WHOIS lookup for each IP
+func LookupIPsWhois(ctx context.Context, domain string, ips []string, timeout, delay time.Duration) *IPWhoisResults {
+	infos, failed := lookupWhoisBatch(ctx, ips, timeout, delay)
+	return &IPWhoisResults{
+		Domain:     domain,
+		IPs:        infos,
+		Failed:     failed,
+		LookedUpAt: time.Now(),
+	}
+}
+
+// LookupBulkWhois performs a WHOIS lookup for each registrable domain and
+// groups domains that share a registrant
+func LookupBulkWhois(ctx context.Context, source string, domains []string, timeout, delay time.Duration) *BulkWhoisResults {
+	infos, failed := lookupWhoisBatch(ctx, domains, timeout, delay)
+
+	byRegistrant := make(map[string][]string)
+	for _, info := range infos {
+		if key := registrantKey(info); key != "" {
+			byRegistrant[key] = append(byRegistrant[key], info.Domain)
+		}
+	}
+
+	results := &BulkWhoisResults{
+		Source:      source,
+		Domains:     infos,
+		Failed:      failed,
+		Registrants: []RegistrantGroup{},
+		LookedUpAt:  time.Now(),
+	}
+	for registrant, members := range byRegistrant {
+		results.Registrants = append(results.Registrants, RegistrantGroup{
+			Registrant: registrant,
+			Domains:    members,
+		})
+	}
+	sort.Slice(results.Registrants, func(i, j int) bool {
+		a, b := results.Registrants[i], results.Registrants[j]
+		if len(a.Domains) != len(b.Domains) {
+			return len(a.Domains) > len(b.Domains)
+		}
+		return a.Registrant < b.Registrant
+	})
+
+	return results
+}
+
+// lookupWhoisBatch performs a WHOIS lookup for each query in turn, pausing
+// between queries since registries rate-limit aggressive clients
+func lookupWhoisBatch(ctx context.Context, queries []string, timeout, delay time.Duration) ([]WhoisInfo, []string) {
+	infos := []WhoisInfo{}
+	var failed []string
+
+	for i, query := range queries {
+		if i > 0 && delay > 0 {
+			time.Sleep(delay)
+		}
+
+		fmt.Printf("\rLooking up %d/%d: %s\033[K", i+1, len(queries), query)
+		info, err := LookupWhois(ctx, query, timeout)
+		if err != nil {
+			failed = append(failed, query)
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	fmt.Printf("\r\033[K")
+
+	return infos, failed
+}
+
+// registrantKey returns the identity used to match domains to the same
+// owner, ignoring privacy-redacted values
+func registrantKey(info WhoisInfo) string {
+	for _, value := range []string{info.RegistrantOrg, info.RegistrantEmail} {
+		lower := strings.ToLower(value)
+		if value == "" || strings.Contains(lower, "redacted") || strings.Contains(lower, "privacy") ||
+			strings.Contains(lower, "not disclosed") || strings.Contains(lower, "proxy") {
+			continue
+		}
+		return value
+	}
+	return ""
+}
+
+// CollectRegistrableDomains extracts the unique registrable domains from
+// stored subdomain and DNS results, including sibling apexes surfaced by
+// certificate transparency and CNAME, MX, and NS targets
+func CollectRegistrableDomains(domain string) ([]string, error) {
+	var hosts []string
+
+	subdomains, err := GetLatestSubdomainResult(domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subdomain results: %w", err)
+	}
+	for _, sub := range subdomains.Subdomains {
+		hosts = append(hosts, sub.Name)
+	}
+
+	// DNS results are optional
+	if dnsResults, err := LoadDNSResults(domain); err == nil {
+		for _, record := range dnsResults.Records {
+			hosts = append(hosts, record.CNAME...)
+			hosts = append(hosts, record.NS...)
+			for _, mx := range record.MX {
+				// MX records may carry a preference ("10 mail.example.com")
+				fields := strings.Fields(mx)
+				if len(fields) > 0 {
+					hosts = append(hosts, fields[len(fields)-1])
+				}
+			}
+		}
+	}
+
+	return RegistrableDomains(append(hosts, domain)), nil
+}
+
+// CNAMETargetDomains returns the registrable domains that a domain's
+// subdomains CNAME to outside the domain itself. If one of these lapses,
+// anyone can register it and take over the aliased subdomains.
+func CNAMETargetDomains(domain string) []string {
+	dnsResults, err := LoadDNSResults(domain)
+	if err != nil {
+		return nil
+	}
+
+	var targets []string
+	for _, record := range dnsResults.Records {
+		for _, cname := range record.CNAME {
+			if target := RegistrableDomain(cname); target != RegistrableDomain(domain) {
+				targets = append(targets, target)
+			}
+		}
+	}
+	return RegistrableDomains(targets)
+}
+
 // SaveWhoisResults saves WHOIS results to a JSON file
 func SaveWhoisResults(domain string, info *WhoisInfo) error {
 	results := WhoisResults{
@@ -154,7 +597,7 @@ func SaveWhoisResults(domain string, info *WhoisInfo) error {
 		LookedUpAt: time.Now(),
 	}
 
-	_, err := SaveResults(domain, "whois", results, FormatJSON)
+	_, err := SaveResults(domain, "whois", &results, FormatJSON)
 	return err
 }
 
@@ -167,6 +610,16 @@ func LoadWhoisResults(domain string) (*WhoisResults, error) {
 	return &results, nil
 }
 
+// SaveIPWhoisResults saves IP WHOIS results to a JSON file
+func SaveIPWhoisResults(domain string, results *IPWhoisResults) (string, error) {
+	return SaveResults(domain, "ipwhois", results, FormatJSON)
+}
+
+// SaveBulkWhoisResults saves bulk WHOIS results to a JSON file
+func SaveBulkWhoisResults(domain string, results *BulkWhoisResults) (string, error) {
+	return SaveResults(domain, "whoisbulk", results, FormatJSON)
+}
+
 // FormatWhoisInfo returns a human-readable string representation of WHOIS info
 func FormatWhoisInfo(info *WhoisInfo) string {
 	var b strings.Builder
@@ -177,6 +630,10 @@ func FormatWhoisInfo(info *WhoisInfo) string {
 		b.WriteString(fmt.Sprintf("Registrar: %s\n", info.Registrar))
 	}
 
+	if key := registrantKey(*info); key != "" {
+		b.WriteString(fmt.Sprintf("Registrant: %s\n", key))
+	}
+
 	if info.CreatedDate != "" {
 		b.WriteString(fmt.Sprintf("Created: %s\n", info.CreatedDate))
 	}
@@ -207,5 +664,29 @@ func FormatWhoisInfo(info *WhoisInfo) string {
 		b.WriteString(fmt.Sprintf("\nRegistrar URL: %s\n", info.RegistrarURL))
 	}
 
+	if info.Netblock != "" {
+		b.WriteString(fmt.Sprintf("Netblock: %s\n", info.Netblock))
+	}
+
+	if info.NetName != "" {
+		b.WriteString(fmt.Sprintf("Net Name: %s\n", info.NetName))
+	}
+
+	if info.Organization != "" {
+		b.WriteString(fmt.Sprintf("Organization: %s\n", info.Organization))
+	}
+
+	if info.Country != "" {
+		b.WriteString(fmt.Sprintf("Country: %s\n", info.Country))
+	}
+
+	if info.AbuseEmail != "" {
+		b.WriteString(fmt.Sprintf("Abuse Contact: %s\n", info.AbuseEmail))
+	}
+
+	if info.RIR != "" {
+		b.WriteString(fmt.Sprintf("Registry: %s\n", info.RIR))
+	}
+
 	return b.String()
 }
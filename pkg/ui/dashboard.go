@@ -7,24 +7,25 @@ import (
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
 )
 
-// DisplayDashboard shows the main dashboard
-func DisplayDashboard(cfg *config.Config) error {
+// DisplayDashboard shows the main dashboard, with the server's status as
+// probe reports it
+func DisplayDashboard(cfg *config.Config, probe ServerProbe) error {
 	// Try to display rich dashboard, fallback to simple if it fails
-	if err := displaySimpleDashboard(cfg); err != nil {
+	if err := displaySimpleDashboard(cfg, probe); err != nil {
 		return err
 	}
 	return nil
 }
 
 // displaySimpleDashboard shows a simple text-based dashboard
-func displaySimpleDashboard(cfg *config.Config) error {
+func displaySimpleDashboard(cfg *config.Config, probe ServerProbe) error {
 	// Gather all data
 	stats, err := GatherStats()
 	if err != nil {
 		stats = &DashboardStats{} // Use empty stats on error
 	}
 
-	systemStatus, err := GetSystemStatus(cfg)
+	systemStatus, err := GetSystemStatus(cfg, probe)
 	if err != nil {
 		return fmt.Errorf("failed to get system status: %w", err)
 	}
@@ -67,9 +68,10 @@ func printHeader(cfg *config.Config, status *SystemStatus) {
 	serverInfo := ""
 	if cfg != nil && cfg.Server != "" {
 		serverInfo = fmt.Sprintf(" Server: %s", cfg.Server)
-		if status.ServerStatus == "connected" {
-			serverInfo += " [Connected]"
-		} else {
+		switch status.ServerStatus {
+		case "connected":
+			serverInfo += fmt.Sprintf(" [Connected, %dms]", status.ServerLatency.Milliseconds())
+		case "disconnected":
 			serverInfo += " [Offline]"
 		}
 	}
@@ -77,6 +79,9 @@ func printHeader(cfg *config.Config, status *SystemStatus) {
 	authInfo := ""
 	if status.AuthStatus == "authenticated" {
 		authInfo = " | Authenticated"
+		if cfg != nil && cfg.Profile != "" {
+			authInfo += fmt.Sprintf(" (%s)", cfg.Profile)
+		}
 	}
 
 	toolsInfo := fmt.Sprintf(" | Tools: %d/%d available", status.ToolsAvailable, status.ToolsTotal)
@@ -100,10 +105,24 @@ func printQuickStats(stats *DashboardStats) {
 	fmt.Printf("║ │ Alive Targets:    %-60d │\n", stats.TotalAlive)
 	fmt.Printf("║ │ Last 24h Scans:   %-60d │\n", stats.ScansLast24h)
 	fmt.Printf("║ │ Storage Used:     %-60s │\n", FormatBytes(stats.StorageUsed))
+	if findings := formatNucleiFindings(stats.NucleiFindings); findings != "" {
+		fmt.Printf("║ │ Nuclei Findings:  %-60s │\n", findings)
+	}
 
 	fmt.Println("║ └────────────────────────────────────────────────────────────────────────────┘")
 }
 
+// formatNucleiFindings summarizes nuclei findings by severity, most severe first
+func formatNucleiFindings(findings map[string]int) string {
+	var parts []string
+	for _, severity := range []string{"critical", "high", "medium", "low", "info", "unknown"} {
+		if count := findings[severity]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, severity))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func printRecentActivity(activities []ActivityEntry) {
 	fmt.Println("║ 🔍 RECENT ACTIVITY")
 	fmt.Println("║ ┌────────────────────────────────────────────────────────────────────────────┐")
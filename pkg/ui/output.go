@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats of the --output flag and the output_format setting
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// ValidOutputFormat reports whether format is table, json, or yaml
+func ValidOutputFormat(format string) bool {
+	return format == OutputTable || IsStructured(format)
+}
+
+// IsStructured reports whether format is meant for scripts (json or yaml)
+// rather than people
+func IsStructured(format string) bool {
+	return format == OutputJSON || format == OutputYAML
+}
+
+// Render writes data as indented JSON or as YAML. YAML uses the keys of
+// the JSON encoding, in the same order, so both follow the json struct tags.
+func Render(w io.Writer, format string, data interface{}) error {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	switch format {
+	case OutputJSON:
+		_, err = fmt.Fprintf(w, "%s\n", encoded)
+		return err
+	case OutputYAML:
+		// JSON is YAML, so decoding it into a node keeps the key order;
+		// clearing the styles turns its flow style into block style
+		var node yaml.Node
+		if err := yaml.Unmarshal(encoded, &node); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		clearYAMLStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("invalid output format: %s (must be: json or yaml)", format)
+	}
+}
+
+// clearYAMLStyle resets the style of a node and its children, so the encoder
+// picks block style and quotes scalars only where needed
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
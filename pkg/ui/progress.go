@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProgressBar renders a single-line progress bar with ETA on the terminal
+type ProgressBar struct {
+	total      int
+	width      int
+	start      time.Time
+	lastRender time.Time
+	interval   time.Duration
+}
+
+// NewProgressBar creates a progress bar for total units of work
+func NewProgressBar(total int) *ProgressBar {
+	return &ProgressBar{
+		total:    total,
+		width:    30,
+		start:    time.Now(),
+		interval: 200 * time.Millisecond,
+	}
+}
+
+// Update redraws the bar for done completed units. Redraws are throttled,
+// except for the final update. The status text is appended after the ETA.
+func (p *ProgressBar) Update(done int, status string) {
+	now := time.Now()
+	if done < p.total && now.Sub(p.lastRender) < p.interval {
+		return
+	}
+	p.lastRender = now
+
+	fmt.Printf("\r\033[K%s", p.render(done, status, now))
+}
+
+// Clear erases the progress line
+func (p *ProgressBar) Clear() {
+	fmt.Print("\r\033[K")
+}
+
+// render builds the progress line
+func (p *ProgressBar) render(done int, status string, now time.Time) string {
+	pct := 0.0
+	if p.total > 0 {
+		pct = float64(done) / float64(p.total)
+	}
+
+	filled := int(pct * float64(p.width))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", p.width-filled)
+
+	eta := "--"
+	if done > 0 && done < p.total {
+		elapsed := now.Sub(p.start)
+		remaining := time.Duration(float64(elapsed) / float64(done) * float64(p.total-done))
+		eta = remaining.Round(time.Second).String()
+	} else if done >= p.total {
+		eta = "0s"
+	}
+
+	line := fmt.Sprintf("[%s] %d/%d (%.1f%%) | ETA %s", bar, done, p.total, pct*100, eta)
+	if status != "" {
+		line += " | " + status
+	}
+	return line
+}
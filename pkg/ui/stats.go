@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
@@ -19,6 +20,7 @@ type DashboardStats struct {
 	ScansLast7d     int
 	StorageUsed     int64
 	LastUpdated     time.Time
+	NucleiFindings  map[string]int // Findings per severity from each domain's latest nuclei scan
 }
 
 // SubdomainResult represents the structure of subdomain JSON files
@@ -39,7 +41,12 @@ type Subdomain struct {
 
 // VerificationResult represents verification data
 type VerificationResult struct {
-	Status string `json:"status"` // "alive", "dead", "error"
+	Status string `json:"status"` // "alive", "dead", "timeout", "connection_refused", "tls_error"
+}
+
+// NucleiResult represents the structure of nuclei JSON files
+type NucleiResult struct {
+	Summary map[string]int `json:"summary"`
 }
 
 // GatherStats collects statistics from the results directory
@@ -52,7 +59,8 @@ func GatherStats() (*DashboardStats, error) {
 	resultsDir := filepath.Join(configDir, "results")
 
 	stats := &DashboardStats{
-		LastUpdated: time.Now(),
+		LastUpdated:    time.Now(),
+		NucleiFindings: make(map[string]int),
 	}
 
 	// Check if results directory exists
@@ -80,6 +88,9 @@ func GatherStats() (*DashboardStats, error) {
 			continue
 		}
 
+		// Files are sorted by name, so the last nuclei file is the latest
+		latestNuclei := ""
+
 		for _, file := range files {
 			if file.IsDir() {
 				continue
@@ -93,12 +104,16 @@ func GatherStats() (*DashboardStats, error) {
 				stats.StorageUsed += info.Size()
 			}
 
+			if isJSONResult(file.Name()) && strings.HasPrefix(file.Name(), "nuclei_") {
+				latestNuclei = filePath
+			}
+
 			// Parse subdomain JSON files
-			if filepath.Ext(file.Name()) == ".json" &&
+			if isJSONResult(file.Name()) &&
 				len(file.Name()) > 11 &&
 				file.Name()[:11] == "subdomains_" {
 
-				data, err := os.ReadFile(filePath)
+				data, err := readResultFile(filePath)
 				if err != nil {
 					continue
 				}
@@ -127,6 +142,22 @@ func GatherStats() (*DashboardStats, error) {
 				}
 			}
 		}
+
+		if latestNuclei != "" {
+			data, err := readResultFile(latestNuclei)
+			if err != nil {
+				continue
+			}
+
+			var result NucleiResult
+			if err := json.Unmarshal(data, &result); err != nil {
+				continue
+			}
+
+			for severity, count := range result.Summary {
+				stats.NucleiFindings[severity] += count
+			}
+		}
 	}
 
 	return stats, nil
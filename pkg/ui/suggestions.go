@@ -1,15 +1,40 @@
 package ui
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
 )
 
+// whoisExpiryWindow is how far ahead domain expiry is flagged
+const whoisExpiryWindow = 60 * 24 * time.Hour
+
+// WhoisDates represents the parsed dates of a WHOIS record
+type WhoisDates struct {
+	Domain    string     `json:"domain"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// WhoisResult represents the structure of whois JSON files
+type WhoisResult struct {
+	Info WhoisDates `json:"info"`
+}
+
+// BulkWhoisResult represents the structure of whoisbulk JSON files
+type BulkWhoisResult struct {
+	Domains      []WhoisDates `json:"domains"`
+	CNAMETargets []string     `json:"cname_targets,omitempty"`
+}
+
 // Suggestion represents an actionable suggestion for the user
 type Suggestion struct {
 	Message  string
@@ -56,6 +81,9 @@ func GenerateSuggestions() ([]Suggestion, error) {
 			continue
 		}
 
+		// Files are sorted by name, so the last whois files are the latest
+		var latestWhois, latestBulkWhois string
+
 		var latestSubdomainFile string
 		var latestSubdomainTime time.Time
 		hasUnverified := false
@@ -68,8 +96,14 @@ func GenerateSuggestions() ([]Suggestion, error) {
 
 			filePath := filepath.Join(domainPath, file.Name())
 
+			if strings.HasPrefix(file.Name(), "whois_") {
+				latestWhois = filePath
+			} else if strings.HasPrefix(file.Name(), "whoisbulk_") {
+				latestBulkWhois = filePath
+			}
+
 			// Find subdomain files
-			if filepath.Ext(file.Name()) == ".json" &&
+			if isJSONResult(file.Name()) &&
 				len(file.Name()) > 11 &&
 				file.Name()[:11] == "subdomains_" {
 
@@ -84,7 +118,7 @@ func GenerateSuggestions() ([]Suggestion, error) {
 				}
 
 				// Check if it has unverified subdomains
-				data, err := os.ReadFile(filePath)
+				data, err := readResultFile(filePath)
 				if err != nil {
 					continue
 				}
@@ -118,6 +152,8 @@ func GenerateSuggestions() ([]Suggestion, error) {
 			}
 		}
 
+		suggestions = append(suggestions, expirySuggestions(domainName, latestWhois, latestBulkWhois)...)
+
 		// Suggest verification if unverified results exist
 		if hasUnverified {
 			suggestions = append(suggestions, Suggestion{
@@ -129,7 +165,7 @@ func GenerateSuggestions() ([]Suggestion, error) {
 	}
 
 	// Suggest installing missing tools
-	systemStatus, err := GetSystemStatus(nil)
+	systemStatus, err := GetSystemStatus(nil, nil)
 	if err == nil {
 		missingTools := []string{}
 		for _, tool := range systemStatus.Tools {
@@ -154,6 +190,11 @@ func GenerateSuggestions() ([]Suggestion, error) {
 		}
 	}
 
+	// Keep the most urgent suggestions when trimming
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Priority < suggestions[j].Priority
+	})
+
 	// Limit to top 5 suggestions
 	if len(suggestions) > 5 {
 		suggestions = suggestions[:5]
@@ -161,3 +202,86 @@ func GenerateSuggestions() ([]Suggestion, error) {
 
 	return suggestions, nil
 }
+
+// expirySuggestions flags a target domain, or a domain its subdomains CNAME
+// to, that expires soon. A lapsed CNAME target can be registered by anyone.
+func expirySuggestions(domainName, whoisFile, bulkWhoisFile string) []Suggestion {
+	var suggestions []Suggestion
+	now := time.Now()
+
+	var whois WhoisResult
+	if whoisFile != "" && readJSON(whoisFile, &whois) == nil && whois.Info.ExpiresAt != nil &&
+		whois.Info.ExpiresAt.Sub(now) < whoisExpiryWindow {
+		suggestions = append(suggestions, Suggestion{
+			Message:  fmt.Sprintf("%s %s", domainName, describeExpiry(*whois.Info.ExpiresAt)),
+			Action:   "recon whois expiring",
+			Priority: 2,
+		})
+	}
+
+	var bulk BulkWhoisResult
+	if bulkWhoisFile != "" && readJSON(bulkWhoisFile, &bulk) == nil {
+		cnameTargets := make(map[string]bool)
+		for _, target := range bulk.CNAMETargets {
+			cnameTargets[target] = true
+		}
+		for _, info := range bulk.Domains {
+			if !cnameTargets[info.Domain] || info.ExpiresAt == nil || info.ExpiresAt.Sub(now) >= whoisExpiryWindow {
+				continue
+			}
+			suggestions = append(suggestions, Suggestion{
+				Message:  fmt.Sprintf("%s CNAME target %s %s - takeover risk", domainName, info.Domain, describeExpiry(*info.ExpiresAt)),
+				Action:   "recon whois expiring",
+				Priority: 1,
+			})
+		}
+	}
+
+	return suggestions
+}
+
+// describeExpiry phrases an expiry time relative to now
+func describeExpiry(expiresAt time.Time) string {
+	days := int(time.Until(expiresAt).Hours() / 24)
+	if days < 0 {
+		return fmt.Sprintf("expired %dd ago", -days)
+	}
+	return fmt.Sprintf("expires in %dd", days)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := readResultFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// readResultFile reads a stored result file, decrypting results sealed by
+// the vault and decompressing gzipped (.json.gz) results
+func readResultFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if config.IsVaultSealed(data) {
+		if data, err = config.VaultOpen(data); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// isJSONResult reports whether a file name is a JSON result, compressed or not
+func isJSONResult(name string) bool {
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/presstronic/recontronic-cli-client/pkg/config"
 )
@@ -19,15 +20,26 @@ type ToolStatus struct {
 // SystemStatus represents overall system health
 type SystemStatus struct {
 	Tools          []ToolStatus
-	ServerStatus   string // "connected", "disconnected", "unknown"
-	AuthStatus     string // "authenticated", "not_authenticated"
+	ServerStatus   string        // "connected", "disconnected", "unknown", "not_configured"
+	ServerLatency  time.Duration // Round trip of the health check when connected
+	AuthStatus     string        // "authenticated", "not_authenticated"
 	StorageUsed    int64
 	ToolsAvailable int
 	ToolsTotal     int
 }
 
-// GetSystemStatus checks tool availability and system health
-func GetSystemStatus(cfg *config.Config) (*SystemStatus, error) {
+// ServerCheck is the outcome of probing the server
+type ServerCheck struct {
+	Connected bool
+	Latency   time.Duration
+}
+
+// ServerProbe checks whether the configured server is reachable
+type ServerProbe func() ServerCheck
+
+// GetSystemStatus checks tool availability and system health; probe checks
+// the server, which is "unknown" without one
+func GetSystemStatus(cfg *config.Config, probe ServerProbe) (*SystemStatus, error) {
 	status := &SystemStatus{
 		Tools: []ToolStatus{
 			{Name: "crt.sh", Installed: true, Version: "built-in", Type: "built-in"},
@@ -49,7 +61,15 @@ func GetSystemStatus(cfg *config.Config) (*SystemStatus, error) {
 
 	// Check server status
 	if cfg != nil && cfg.Server != "" {
-		status.ServerStatus = "disconnected" // Would need actual check
+		status.ServerStatus = "unknown"
+		if probe != nil {
+			check := probe()
+			status.ServerStatus = "disconnected"
+			if check.Connected {
+				status.ServerStatus = "connected"
+				status.ServerLatency = check.Latency
+			}
+		}
 	} else {
 		status.ServerStatus = "not_configured"
 	}
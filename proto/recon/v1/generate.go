@@ -0,0 +1,5 @@
+// Package reconv1 holds the code generated from stream.proto with
+// protoc-gen-go v1.36.12 and protoc-gen-go-grpc v1.5.1
+package reconv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative recon/v1/stream.proto
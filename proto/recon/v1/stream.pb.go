@@ -0,0 +1,223 @@
+// Streaming API of the Recontronic server, served over gRPC next to the REST
+// API. stream.pb.go and stream_grpc.pb.go are generated from this file; run
+// 'make generate' after changing it. Fields are only ever added, never
+// renumbered.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: recon/v1/stream.proto
+
+package reconv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        int64                  `protobuf:"varint,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchScanRequest) Reset() {
+	*x = WatchScanRequest{}
+	mi := &file_recon_v1_stream_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchScanRequest) ProtoMessage() {}
+
+func (x *WatchScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_v1_stream_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchScanRequest.ProtoReflect.Descriptor instead.
+func (*WatchScanRequest) Descriptor() ([]byte, []int) {
+	return file_recon_v1_stream_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchScanRequest) GetScanId() int64 {
+	if x != nil {
+		return x.ScanId
+	}
+	return 0
+}
+
+type ScanProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        int64                  `protobuf:"varint,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Progress      int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"` // Percent complete
+	CurrentStep   string                 `protobuf:"bytes,4,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	AssetsFound   int32                  `protobuf:"varint,5,opt,name=assets_found,json=assetsFound,proto3" json:"assets_found,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanProgress) Reset() {
+	*x = ScanProgress{}
+	mi := &file_recon_v1_stream_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanProgress) ProtoMessage() {}
+
+func (x *ScanProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_v1_stream_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanProgress.ProtoReflect.Descriptor instead.
+func (*ScanProgress) Descriptor() ([]byte, []int) {
+	return file_recon_v1_stream_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScanProgress) GetScanId() int64 {
+	if x != nil {
+		return x.ScanId
+	}
+	return 0
+}
+
+func (x *ScanProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScanProgress) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *ScanProgress) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+func (x *ScanProgress) GetAssetsFound() int32 {
+	if x != nil {
+		return x.AssetsFound
+	}
+	return 0
+}
+
+func (x *ScanProgress) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_recon_v1_stream_proto protoreflect.FileDescriptor
+
+const file_recon_v1_stream_proto_rawDesc = "" +
+	"\n" +
+	"\x15recon/v1/stream.proto\x12\brecon.v1\"+\n" +
+	"\x10WatchScanRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\x03R\x06scanId\"\xbf\x01\n" +
+	"\fScanProgress\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\x03R\x06scanId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12!\n" +
+	"\fcurrent_step\x18\x04 \x01(\tR\vcurrentStep\x12!\n" +
+	"\fassets_found\x18\x05 \x01(\x05R\vassetsFound\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp2R\n" +
+	"\rStreamService\x12A\n" +
+	"\tWatchScan\x12\x1a.recon.v1.WatchScanRequest\x1a\x16.recon.v1.ScanProgress0\x01BFZDgithub.com/presstronic/recontronic-cli-client/proto/recon/v1;reconv1b\x06proto3"
+
+var (
+	file_recon_v1_stream_proto_rawDescOnce sync.Once
+	file_recon_v1_stream_proto_rawDescData []byte
+)
+
+func file_recon_v1_stream_proto_rawDescGZIP() []byte {
+	file_recon_v1_stream_proto_rawDescOnce.Do(func() {
+		file_recon_v1_stream_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recon_v1_stream_proto_rawDesc), len(file_recon_v1_stream_proto_rawDesc)))
+	})
+	return file_recon_v1_stream_proto_rawDescData
+}
+
+var file_recon_v1_stream_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_recon_v1_stream_proto_goTypes = []any{
+	(*WatchScanRequest)(nil), // 0: recon.v1.WatchScanRequest
+	(*ScanProgress)(nil),     // 1: recon.v1.ScanProgress
+}
+var file_recon_v1_stream_proto_depIdxs = []int32{
+	0, // 0: recon.v1.StreamService.WatchScan:input_type -> recon.v1.WatchScanRequest
+	1, // 1: recon.v1.StreamService.WatchScan:output_type -> recon.v1.ScanProgress
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_recon_v1_stream_proto_init() }
+func file_recon_v1_stream_proto_init() {
+	if File_recon_v1_stream_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recon_v1_stream_proto_rawDesc), len(file_recon_v1_stream_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recon_v1_stream_proto_goTypes,
+		DependencyIndexes: file_recon_v1_stream_proto_depIdxs,
+		MessageInfos:      file_recon_v1_stream_proto_msgTypes,
+	}.Build()
+	File_recon_v1_stream_proto = out.File
+	file_recon_v1_stream_proto_goTypes = nil
+	file_recon_v1_stream_proto_depIdxs = nil
+}